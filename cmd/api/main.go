@@ -10,24 +10,45 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"errors"
 	"log/slog"
 	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
 
 	_ "github.com/zabaletac3/go-vet-api/docs"
 
 	_ "github.com/zabaletac3/go-vet-api/internal/validators"
 
+	"github.com/zabaletac3/go-vet-api/internal/auth"
+	"github.com/zabaletac3/go-vet-api/internal/blob"
 	"github.com/zabaletac3/go-vet-api/internal/config"
 	"github.com/zabaletac3/go-vet-api/internal/database"
+	"github.com/zabaletac3/go-vet-api/internal/health"
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/pagination"
+	"github.com/zabaletac3/go-vet-api/internal/palette"
+	"github.com/zabaletac3/go-vet-api/internal/ratelimit"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/storage/memory"
+	"github.com/zabaletac3/go-vet-api/internal/tenant"
+	"github.com/zabaletac3/go-vet-api/internal/tracing"
 	customhttp "github.com/zabaletac3/go-vet-api/internal/transport/http"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/requestid"
 )
 
 func main() {
 
 	// 1. Creamos el logger primero.
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	//    Los registros hechos con el ctx de una petición incluyen request_id y,
+	//    si lleva un span, trace_id.
+	logger := slog.New(requestid.LogHandler(tracing.LogHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
-	}))
+	}))))
 	slog.SetDefault(logger)
 
 
@@ -35,22 +56,154 @@ func main() {
 	cfg := config.Load()
 	logger.Info("Configuración cargada exitosamente", "entorno", cfg.Env)
 
-	// 3. Conectamos a la base de datos, pasando el logger.
-	mongoClient, cleanup, err := database.Connect(cfg.MongoURI, logger)
+	if err := auth.SetCost(cfg.BcryptCost); err != nil {
+		logger.Error("Configuración de bcrypt inválida", "error", err)
+		os.Exit(1)
+	}
+
+	if cfg.CursorSecret != "" {
+		if err := pagination.SetSecret([]byte(cfg.CursorSecret)); err != nil {
+			logger.Error("Configuración de cursores inválida", "error", err)
+			os.Exit(1)
+		}
+	} else {
+		logger.Warn("CURSOR_SECRET no definido: se usa un secreto aleatorio y los cursores caducan al reiniciar")
+	}
+
+	if err := i18n.SetDefault(cfg.DefaultLocale); err != nil {
+		logger.Error("Configuración de idioma inválida", "error", err)
+		os.Exit(1)
+	}
+
+	if err := palette.SetPolicy(cfg.PaletteContrast, cfg.PaletteContrastLevel); err != nil {
+		logger.Error("Configuración de contraste de paletas inválida", "error", err)
+		os.Exit(1)
+	}
+
+	if err := tenant.SetDir(cfg.TransferDir); err != nil {
+		logger.Error("Directorio de traspasos inválido", "error", err)
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter:    cfg.TracingExporter,
+		Endpoint:    cfg.TracingEndpoint,
+		ServiceName: cfg.TracingServiceName,
+		SampleRatio: cfg.TracingSampleRatio,
+	})
 	if err != nil {
-		logger.Error("Fallo al conectar a MongoDB", "error", err)
-		os.Exit(1) // Salimos si la conexión falla.
+		logger.Error("Configuración de trazas inválida", "error", err)
+		os.Exit(1)
 	}
-	defer cleanup()
-	
-	logger.Info("✅ Conexión a MongoDB establecida exitosamente.")
+	// Al salir se envían los spans pendientes
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error("Fallo al enviar las trazas pendientes", "error", err)
+		}
+	}()
+	logger.Info("Trazas configuradas", "exporter", cfg.TracingExporter)
+
+	// Checks de la readiness: cada dependencia se registra al prepararla
+	checks := health.NewRegistry(cfg.HealthCheckTimeout)
+	checks.Register("disk", health.DiskSpace(tenant.Dir(), cfg.HealthMinFreeDiskMB<<20))
 
-	db := mongoClient.Database(cfg.DBName)
-	logger.Info("✅ Base de datos seleccionada.", "database", db.Name())
+	// 3. Preparamos el almacenamiento según STORAGE_DRIVER.
+	var db *mongo.Database
+	var stores *storage.Stores
 
+	if cfg.StorageDriver == "memory" {
+		stores = memory.NewStores()
+		logger.Warn("⚠️ STORAGE_DRIVER=memory: los datos viven en memoria y se pierden al reiniciar.")
+	} else {
+		// Conectamos a la base de datos, pasando el logger.
+		mongoClient, cleanup, err := database.Connect(cfg.MongoURI, logger)
+		if err != nil {
+			logger.Error("Fallo al conectar a MongoDB", "error", err)
+			os.Exit(1) // Salimos si la conexión falla.
+		}
+		defer cleanup()
 
-	// 4. Creamos e iniciamos el servidor.
-	server := customhttp.NewServer(cfg.Port, logger) // Pasamos el logger al servidor también.
+		logger.Info("✅ Conexión a MongoDB establecida exitosamente.")
+
+		db = mongoClient.Database(cfg.DBName)
+		logger.Info("✅ Base de datos seleccionada.", "database", db.Name())
+		checks.Register("mongo", health.Mongo(mongoClient))
+
+		// 4. Aplicamos las migraciones pendientes (índices, etc.). Sin
+		//    AUTO_MIGRATE la instancia no está lista hasta que se apliquen.
+		migrator := database.NewMigrator(db, logger, database.Migrations)
+		checks.Register("migrations", health.Migrations(migrator.Pending))
+		if cfg.AutoMigrate {
+			applied, err := migrator.Up(context.Background())
+			switch {
+			case errors.Is(err, database.ErrMigrationLocked):
+				logger.Warn("Otra instancia está aplicando migraciones; se continúa sin migrar")
+			case err != nil:
+				logger.Error("Fallo al aplicar migraciones", "error", err)
+				os.Exit(1)
+			default:
+				logger.Info("✅ Migraciones aplicadas.", "applied", applied)
+			}
+		}
+
+		stores = storage.NewMongoStores(db)
+	}
+
+	// Ficheros adjuntos según BLOB_DRIVER
+	blobs, err := newBlobStore(cfg, checks, logger)
+	if err != nil {
+		logger.Error("Configuración de ficheros adjuntos inválida", "error", err)
+		os.Exit(1)
+	}
+	stores.Blobs = blobs
+	logger.Info("Almacén de ficheros configurado", "driver", cfg.BlobDriver)
+
+	// Idioma por defecto de cada clínica para los mensajes de error
+	i18n.SetClinicLocales(func(ctx context.Context, clinicID string) string {
+		clinic, err := stores.Clinics.GetByID(ctx, clinicID)
+		if err != nil || clinic == nil {
+			return ""
+		}
+		return clinic.Locale
+	})
+
+	// 5. Tareas en segundo plano: se detienen al apagar el servidor.
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	clinicSvc := services.NewClinicService(stores, logger)
+	purger := services.NewClinicPurger(clinicSvc, cfg.ClinicRetentionDays, cfg.ClinicPurgeInterval, logger)
+	go purger.Run(jobsCtx)
+	if cfg.ClinicRetentionDays > 0 && cfg.ClinicPurgeInterval > 0 {
+		checks.Register("worker:clinic_purger", purger.HealthCheck())
+	}
+
+	importRunner := services.NewImportRunner(stores, cfg.ImportPollInterval, logger)
+	go importRunner.Run(jobsCtx)
+	if cfg.ImportPollInterval > 0 {
+		checks.Register("worker:import_runner", importRunner.HealthCheck())
+	}
+
+	transferRunner := services.NewTransferRunner(stores, cfg.TransferPollInterval, logger)
+	go transferRunner.Run(jobsCtx)
+	if cfg.TransferPollInterval > 0 {
+		checks.Register("worker:transfer_runner", transferRunner.HealthCheck())
+	}
+
+	// 6. Creamos e iniciamos el servidor.
+	var middlewares []customhttp.Middleware
+	if cfg.RateLimitEnabled {
+		limiter, err := newRateLimiter(cfg, stores, logger)
+		if err != nil {
+			logger.Error("Configuración de límites de peticiones inválida", "error", err)
+			os.Exit(1)
+		}
+		middlewares = append(middlewares, limiter.Middleware)
+		logger.Info("Límites de peticiones activados", "store", cfg.RateLimitStore)
+	}
+	server := customhttp.NewServer(cfg.Port, checks, cfg.ShutdownDrainDelay, logger, middlewares...) // Pasamos el logger al servidor también.
 
 	// sigChan := make(chan os.Signal, 1)
 	// signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -58,7 +211,75 @@ func main() {
 	// <-sigChan
 	// logger.Info("Cerrando servidor...")
 
-	customhttp.SetupAllRoutes(server.Mux, db, logger,)
+	customhttp.SetupAllRoutes(server.Mux, stores, db, checks, logger)
 
 	server.Start()
-}
\ No newline at end of file
+}
+
+// newRateLimiter crea el limitador de peticiones con los límites de cfg.
+func newRateLimiter(cfg *config.Config, stores *storage.Stores, logger *slog.Logger) (*ratelimit.Limiter, error) {
+	principal, err := ratelimit.ParseLimit(ratelimit.ScopePrincipal, cfg.RateLimitPrincipal)
+	if err != nil {
+		return nil, err
+	}
+	clinic, err := ratelimit.ParseLimit(ratelimit.ScopeClinic, cfg.RateLimitClinic)
+	if err != nil {
+		return nil, err
+	}
+	login, err := ratelimit.ParseLimit(ratelimit.ScopeIP, cfg.RateLimitLogin)
+	if err != nil {
+		return nil, err
+	}
+
+	// Con varias réplicas los cupos deben estar en Mongo
+	store := stores.RateLimits
+	if cfg.RateLimitStore == "memory" {
+		store = memory.NewRateLimitStore()
+	}
+
+	limiter := ratelimit.New(store, []ratelimit.Limit{principal, clinic}, cfg.RateLimitTrustProxy, logger)
+	// Contra el relleno de credenciales: cupo propio y estricto por IP (el
+	// endpoint de login aún no existe; el límite ya queda preparado)
+	limiter.Route("POST /api/v1/users/login", login)
+	// Las sondas y las métricas no se limitan
+	limiter.Route("GET /healthz/live")
+	limiter.Route("GET /healthz/ready")
+	limiter.Route("GET /health")
+	limiter.Route("GET /metrics")
+	return limiter, nil
+}
+
+// newBlobStore crea el almacén de ficheros de cfg y registra su check de la
+// readiness.
+func newBlobStore(cfg *config.Config, checks *health.Registry, logger *slog.Logger) (blob.Store, error) {
+	if cfg.BlobDriver == "s3" {
+		store, err := blob.NewS3Store(blob.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PathStyle: cfg.S3PathStyle,
+		})
+		if err != nil {
+			return nil, err
+		}
+		checks.Register("blobs", store.Ping)
+		return store, nil
+	}
+
+	secret := []byte(cfg.BlobSigningSecret)
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		logger.Warn("BLOB_SIGNING_SECRET no definido: se usa un secreto aleatorio y las URLs de descarga caducan al reiniciar")
+	}
+	store, err := blob.NewLocalStore(cfg.BlobDir, secret, cfg.BlobPublicURL)
+	if err != nil {
+		return nil, err
+	}
+	checks.Register("disk:blobs", health.DiskSpace(store.Dir(), cfg.HealthMinFreeDiskMB<<20))
+	return store, nil
+}
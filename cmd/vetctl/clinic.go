@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+func runClinic(ctx context.Context, a *app, args []string) error {
+	sub, rest, err := subcommand(args, "clinic create")
+	if err != nil {
+		return err
+	}
+	if sub != "create" {
+		fmt.Fprintf(os.Stderr, "subcomando desconocido: clinic %s\n", sub)
+		return errUsage
+	}
+
+	fs := newFlagSet("clinic create")
+	name := fs.String("name", "", "nombre interno único (obligatorio)")
+	displayName := fs.String("display-name", "", "nombre para mostrar (obligatorio)")
+	address := fs.String("address", "", "dirección")
+	phone := fs.String("phone", "", "teléfono")
+	email := fs.String("email", "", "email de contacto")
+	website := fs.String("website", "", "sitio web")
+	description := fs.String("description", "", "descripción")
+	plan := fs.String("plan", models.DefaultPlan, "plan de suscripción: free, standard o premium")
+	if err := parseFlags(fs, rest); err != nil {
+		return err
+	}
+	if *name == "" || *displayName == "" {
+		fmt.Fprintln(os.Stderr, "--name y --display-name son obligatorios")
+		fs.Usage()
+		return errUsage
+	}
+
+	svc := newClinicService(a)
+	clinic, err := svc.Create(ctx, services.CreateClinicParams{
+		Name:        *name,
+		DisplayName: *displayName,
+		Address:     *address,
+		Phone:       *phone,
+		Email:       *email,
+		Website:     *website,
+		Description: *description,
+		Palette:     models.GetDefaultPalette(),
+		Plan:        *plan,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Clínica creada: %s (%s)\n", clinic.ID.Hex(), clinic.Name)
+	return nil
+}
+
+func newClinicService(a *app) services.ClinicService {
+	return services.NewClinicService(storage.NewMongoStores(a.db), a.logger)
+}
+
+func newPlanService(a *app) services.PlanService {
+	return services.NewPlanService(storage.NewMongoStores(a.db), a.logger)
+}
@@ -0,0 +1,145 @@
+// Command vetctl es la herramienta de administración de la API veterinaria.
+// Reutiliza la misma configuración (.env / variables de entorno) que cmd/api.
+//
+// Uso:
+//
+//	vetctl migrate up|down|status
+//	vetctl seed --demo
+//	vetctl clinic create --name NAME --display-name "DISPLAY NAME"
+//	vetctl user create-admin --clinic ID --email EMAIL --name "FULL NAME"
+//	vetctl users rehash-passwords
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/zabaletac3/go-vet-api/internal/auth"
+	"github.com/zabaletac3/go-vet-api/internal/config"
+	"github.com/zabaletac3/go-vet-api/internal/database"
+)
+
+// app agrupa las dependencias compartidas por todos los subcomandos.
+type app struct {
+	cfg    *config.Config
+	logger *slog.Logger
+	db     *mongo.Database
+}
+
+// command es un subcomando de vetctl; args no incluye el nombre del comando.
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, a *app, args []string) error
+}
+
+var commands = []command{
+	{name: "migrate", usage: "migrate up|down|status    aplica, revierte o lista las migraciones", run: runMigrate},
+	{name: "seed", usage: "seed --demo               genera clínicas, usuarios, tutores y pacientes de demostración", run: runSeed},
+	{name: "clinic", usage: "clinic create             crea una clínica", run: runClinic},
+	{name: "user", usage: "user create-admin         crea un usuario administrador en una clínica", run: runUser},
+	{name: "users", usage: "users rehash-passwords    marca los hashes con coste de bcrypt antiguo", run: runUsers},
+}
+
+// errUsage indica que los argumentos son inválidos; ya se mostró la ayuda.
+var errUsage = errors.New("uso incorrecto")
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	name := os.Args[1]
+	if name == "help" || name == "-h" || name == "--help" {
+		printUsage()
+		return
+	}
+
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == name {
+			cmd = &commands[i]
+			break
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "comando desconocido: %s\n\n", name)
+		printUsage()
+		os.Exit(2)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	slog.SetDefault(logger)
+
+	cfg := config.Load()
+	if cfg.StorageDriver != "mongo" {
+		logger.Error("vetctl trabaja directamente sobre MongoDB; use STORAGE_DRIVER=mongo", "storage_driver", cfg.StorageDriver)
+		os.Exit(1)
+	}
+	if err := auth.SetCost(cfg.BcryptCost); err != nil {
+		logger.Error("Configuración de bcrypt inválida", "error", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	mongoClient, cleanup, err := database.Connect(cfg.MongoURI, logger)
+	if err != nil {
+		logger.Error("Fallo al conectar a MongoDB", "error", err)
+		os.Exit(1)
+	}
+
+	a := &app{cfg: cfg, logger: logger, db: mongoClient.Database(cfg.DBName)}
+	err = cmd.run(ctx, a, os.Args[2:])
+	cleanup()
+
+	if err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Uso: vetctl <comando> [argumentos]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Comandos:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %s\n", cmd.usage)
+	}
+}
+
+// newFlagSet crea un FlagSet que devuelve errores en lugar de terminar el proceso.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("vetctl "+name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+// parseFlags parsea los flags y convierte los errores de parseo en errUsage.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage // El FlagSet ya mostró el error y la ayuda.
+	}
+	return nil
+}
+
+// subcommand separa el nombre del subcomando del resto de argumentos.
+func subcommand(args []string, usage string) (string, []string, error) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Uso: vetctl "+usage)
+		return "", nil, errUsage
+	}
+	return args[0], args[1:], nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/database"
+)
+
+func runMigrate(ctx context.Context, a *app, args []string) error {
+	sub, rest, err := subcommand(args, "migrate up|down|status")
+	if err != nil {
+		return err
+	}
+
+	migrator := database.NewMigrator(a.db, a.logger, database.Migrations)
+
+	switch sub {
+	case "up":
+		fs := newFlagSet("migrate up")
+		if err := parseFlags(fs, rest); err != nil {
+			return err
+		}
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migraciones aplicadas\n", applied)
+		return nil
+
+	case "down":
+		fs := newFlagSet("migrate down")
+		steps := fs.Int("steps", 1, "número de migraciones a revertir")
+		if err := parseFlags(fs, rest); err != nil {
+			return err
+		}
+		if *steps < 1 {
+			return fmt.Errorf("--steps debe ser mayor que 0")
+		}
+		reverted, err := migrator.Down(ctx, *steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migraciones revertidas\n", reverted)
+		return nil
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "VERSIÓN\tESTADO\tAPLICADA\tDESCRIPCIÓN")
+		for _, status := range statuses {
+			state, appliedAt := "pendiente", "-"
+			if status.Applied {
+				state, appliedAt = "aplicada", status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", status.Version, state, appliedAt, status.Description)
+		}
+		return tw.Flush()
+
+	default:
+		fmt.Fprintf(os.Stderr, "subcomando desconocido: migrate %s\n", sub)
+		return errUsage
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+// demoPassword es la contraseña de todos los usuarios de demostración.
+const demoPassword = "Demo1234!"
+
+// demoLocale agrupa los datos de ejemplo de cada país donde operan las clínicas.
+type demoLocale struct {
+	city       string
+	phone      func(r *rand.Rand) string
+	firstNames []string
+	lastNames  []string
+	clinics    []string
+}
+
+var demoLocales = []demoLocale{
+	{
+		city: "Bogotá",
+		phone: func(r *rand.Rand) string {
+			return fmt.Sprintf("+57 3%02d %03d %04d", r.IntN(30)+10, r.IntN(1000), r.IntN(10000))
+		},
+		firstNames: []string{"Camila", "Santiago", "Valentina", "Mateo", "Daniela", "Sebastián", "Mariana", "Andrés", "Laura", "Juan Pablo"},
+		lastNames:  []string{"Gómez", "Rodríguez", "Martínez", "López", "García", "Hernández", "Zabaleta", "Restrepo", "Ospina", "Cárdenas"},
+		clinics:    []string{"Huellitas", "Patitas Felices", "San Francisco de Asís", "Mundo Animal"},
+	},
+	{
+		city: "São Paulo",
+		phone: func(r *rand.Rand) string {
+			return fmt.Sprintf("+55 11 9%04d-%04d", r.IntN(10000), r.IntN(10000))
+		},
+		firstNames: []string{"Ana", "João", "Beatriz", "Lucas", "Fernanda", "Gabriel", "Juliana", "Pedro", "Larissa", "Rafael"},
+		lastNames:  []string{"Silva", "Santos", "Oliveira", "Souza", "Lima", "Pereira", "Costa", "Almeida", "Ferreira", "Ribeiro"},
+		clinics:    []string{"Amigo Fiel", "Bicho Saudável", "Pet Vida", "Quatro Patas"},
+	},
+	{
+		city: "Miami",
+		phone: func(r *rand.Rand) string {
+			return fmt.Sprintf("+1 (305) %03d-%04d", r.IntN(800)+200, r.IntN(10000))
+		},
+		firstNames: []string{"Emily", "Michael", "Sarah", "David", "Jessica", "James", "Ashley", "Daniel", "Olivia", "Chris"},
+		lastNames:  []string{"Smith", "Johnson", "Williams", "Brown", "Miller", "Davis", "Wilson", "Anderson", "Taylor", "Moore"},
+		clinics:    []string{"Paws & Claws", "Happy Tails", "Bayside Animal Hospital", "Sunshine Pet Care"},
+	},
+}
+
+// demoSpecies define especies con peso relativo, razas y rango de peso en kg.
+var demoSpecies = []struct {
+	species   string
+	weight    int
+	breeds    []string
+	minWeight float64
+	maxWeight float64
+}{
+	{"dog", 50, []string{"Labrador Retriever", "French Bulldog", "Golden Retriever", "Criollo", "Beagle", "Shih Tzu", "German Shepherd"}, 3, 40},
+	{"cat", 35, []string{"Criollo", "Siamese", "Persian", "Maine Coon", "Bengal"}, 2.5, 7},
+	{"rabbit", 5, []string{"Holland Lop", "Mini Rex"}, 1, 3},
+	{"bird", 4, []string{"Cockatiel", "Budgerigar", "Lovebird"}, 0.03, 0.12},
+	{"hamster", 3, []string{"Syrian", "Roborovski"}, 0.03, 0.15},
+	{"guinea_pig", 3, []string{"American", "Abyssinian"}, 0.7, 1.2},
+}
+
+var demoPetNames = []string{
+	"Luna", "Max", "Rocky", "Kiara", "Simón", "Mía", "Toby", "Nala", "Bruno", "Lola",
+	"Thor", "Canela", "Zeus", "Frida", "Milo", "Bella", "Coco", "Paçoca", "Bolinha", "Charlie",
+}
+
+var demoStaff = []struct {
+	role  string
+	count int
+}{
+	{"admin", 1},
+	{"veterinarian", 2},
+	{"assistant", 1},
+}
+
+// runSeed implementa `vetctl seed --demo`.
+func runSeed(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("seed")
+	demo := fs.Bool("demo", false, "genera datos de demostración (obligatorio, evita sembrar por accidente)")
+	clinicCount := fs.Int("clinics", 3, "número de clínicas")
+	ownersPerClinic := fs.Int("owners", 15, "tutores por clínica")
+	maxPets := fs.Int("max-pets", 3, "máximo de pacientes por tutor")
+	seed := fs.Uint64("seed", uint64(time.Now().UnixNano()), "semilla para datos reproducibles")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if !*demo {
+		fmt.Fprintln(os.Stderr, "por ahora solo se admite `vetctl seed --demo`")
+		fs.Usage()
+		return errUsage
+	}
+	if *clinicCount < 1 || *ownersPerClinic < 0 || *maxPets < 1 {
+		return fmt.Errorf("--clinics y --max-pets deben ser mayores que 0 y --owners no puede ser negativo")
+	}
+
+	r := rand.New(rand.NewPCG(*seed, *seed>>1|1))
+	s := &seeder{
+		rand:     r,
+		clinics:  newClinicService(a),
+		users:    services.NewUserService(storage.NewUserRepository(a.db), newPlanService(a), a.logger),
+		owners:   storage.NewOwnerRepository(a.db),
+		patients: storage.NewPatientRepository(a.db),
+		usage:    storage.NewUsageRepository(a.db),
+	}
+
+	var totals seedTotals
+	for i := 0; i < *clinicCount; i++ {
+		locale := demoLocales[i%len(demoLocales)]
+		base := locale.clinics[(i/len(demoLocales))%len(locale.clinics)]
+
+		clinic, err := s.createClinic(ctx, locale, base, i)
+		if errors.Is(err, services.ErrClinicNameExists) || errors.Is(err, services.ErrDisplayNameExists) {
+			fmt.Printf("Clínica %q ya existe, se omite\n", base)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		totals.clinics++
+
+		if err := s.seedClinic(ctx, clinic, locale, *ownersPerClinic, *maxPets, &totals); err != nil {
+			return fmt.Errorf("clínica %s: %w", clinic.Name, err)
+		}
+		fmt.Printf("Clínica %s (%s) sembrada\n", clinic.DisplayName, clinic.ID.Hex())
+	}
+
+	fmt.Printf("Listo (semilla %d): %d clínicas, %d usuarios, %d tutores, %d pacientes. Contraseña de los usuarios: %s\n",
+		*seed, totals.clinics, totals.users, totals.owners, totals.patients, demoPassword)
+	return nil
+}
+
+type seedTotals struct {
+	clinics, users, owners, patients int
+}
+
+type seeder struct {
+	rand     *rand.Rand
+	clinics  services.ClinicService
+	users    services.UserService
+	owners   storage.OwnerStorer
+	patients storage.PatientStorer
+	usage    storage.UsageStorer
+}
+
+func (s *seeder) createClinic(ctx context.Context, locale demoLocale, base string, index int) (*models.Clinic, error) {
+	displayName := fmt.Sprintf("Veterinaria %s %s", base, locale.city)
+	if index >= len(demoLocales)*len(locale.clinics) {
+		displayName = fmt.Sprintf("%s %d", displayName, index+1)
+	}
+
+	return s.clinics.Create(ctx, services.CreateClinicParams{
+		Name:        "demo-" + slugify(displayName),
+		DisplayName: displayName,
+		Address:     fmt.Sprintf("Calle %d # %d-%d, %s", s.rand.IntN(150)+1, s.rand.IntN(100)+1, s.rand.IntN(99)+1, locale.city),
+		Phone:       locale.phone(s.rand),
+		Email:       "contacto@" + slugify(base) + ".demo",
+		Description: "Clínica de demostración generada por vetctl seed",
+		Palette:     models.GetDefaultPalette(),
+		Plan:        models.PlanPremium, // Sin límites: el volumen lo deciden los flags
+	})
+}
+
+func (s *seeder) seedClinic(ctx context.Context, clinic *models.Clinic, locale demoLocale, owners, maxPets int, totals *seedTotals) error {
+	clinicID := clinic.ID.Hex()
+	domain := strings.TrimPrefix(clinic.Name, "demo-") + ".demo"
+
+	for _, staff := range demoStaff {
+		for i := 0; i < staff.count; i++ {
+			first, last := s.pick(locale.firstNames), s.pick(locale.lastNames)
+			_, err := s.users.Register(ctx, services.CreateUserParams{
+				ClinicID: clinicID,
+				FullName: first + " " + last,
+				Email:    fmt.Sprintf("%s.%s%d@%s", slugify(first), slugify(last), totals.users, domain),
+				Password: demoPassword,
+				Role:     staff.role,
+			})
+			if errors.Is(err, services.ErrUserAlreadyExists) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			totals.users++
+		}
+	}
+
+	for i := 0; i < owners; i++ {
+		first, last := s.pick(locale.firstNames), s.pick(locale.lastNames)
+		owner := &models.Owner{
+			ClinicID: clinic.ID,
+			FullName: first + " " + last,
+			Email:    fmt.Sprintf("%s.%s%d@example.com", slugify(first), slugify(last), s.rand.IntN(1000)),
+			Phone:    locale.phone(s.rand),
+			Address:  fmt.Sprintf("Carrera %d # %d-%d, %s", s.rand.IntN(120)+1, s.rand.IntN(100)+1, s.rand.IntN(99)+1, locale.city),
+		}
+		if err := s.owners.Create(ctx, owner); err != nil {
+			return err
+		}
+		totals.owners++
+
+		for p := 0; p < s.rand.IntN(maxPets)+1; p++ {
+			if err := s.patients.Create(ctx, s.newPatient(clinic, owner)); err != nil {
+				return err
+			}
+			if _, err := s.usage.Add(ctx, clinicID, models.UsagePatients, 1, 0); err != nil {
+				return err
+			}
+			totals.patients++
+		}
+	}
+
+	return nil
+}
+
+func (s *seeder) newPatient(clinic *models.Clinic, owner *models.Owner) *models.Patient {
+	total := 0
+	for _, sp := range demoSpecies {
+		total += sp.weight
+	}
+	n := s.rand.IntN(total)
+	species := demoSpecies[0]
+	for _, sp := range demoSpecies {
+		if n < sp.weight {
+			species = sp
+			break
+		}
+		n -= sp.weight
+	}
+
+	birthDate := time.Now().UTC().AddDate(0, -s.rand.IntN(15*12)-2, -s.rand.IntN(28)).Truncate(24 * time.Hour)
+	weight := species.minWeight + s.rand.Float64()*(species.maxWeight-species.minWeight)
+	sex := "male"
+	if s.rand.IntN(2) == 0 {
+		sex = "female"
+	}
+
+	return &models.Patient{
+		ClinicID:  clinic.ID,
+		OwnerID:   owner.ID,
+		Name:      s.pick(demoPetNames),
+		Species:   species.species,
+		Breed:     s.pick(species.breeds),
+		Sex:       sex,
+		BirthDate: &birthDate,
+		WeightKg:  math.Round(weight*100) / 100,
+	}
+}
+
+func (s *seeder) pick(values []string) string {
+	return values[s.rand.IntN(len(values))]
+}
+
+// slugify convierte un texto en un identificador ASCII en minúsculas separado por guiones.
+func slugify(value string) string {
+	replacer := strings.NewReplacer(
+		"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ñ", "n",
+		"ã", "a", "õ", "o", "ç", "c", "â", "a", "ê", "e", "ô", "o",
+	)
+	value = replacer.Replace(strings.ToLower(value))
+
+	var b strings.Builder
+	dash := false
+	for _, r := range value {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+			dash = false
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
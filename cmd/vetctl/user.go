@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/zabaletac3/go-vet-api/internal/auth"
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+// runUser implementa `vetctl user create-admin`.
+func runUser(ctx context.Context, a *app, args []string) error {
+	sub, rest, err := subcommand(args, "user create-admin")
+	if err != nil {
+		return err
+	}
+	if sub != "create-admin" {
+		fmt.Fprintf(os.Stderr, "subcomando desconocido: user %s\n", sub)
+		return errUsage
+	}
+
+	fs := newFlagSet("user create-admin")
+	clinicID := fs.String("clinic", "", "ID de la clínica (obligatorio)")
+	email := fs.String("email", "", "email del administrador (obligatorio)")
+	fullName := fs.String("name", "", "nombre completo (obligatorio)")
+	password := fs.String("password", "", "contraseña; si se omite se lee de VETCTL_PASSWORD o de la entrada estándar")
+	if err := parseFlags(fs, rest); err != nil {
+		return err
+	}
+	if *clinicID == "" || *email == "" || *fullName == "" {
+		fmt.Fprintln(os.Stderr, "--clinic, --email y --name son obligatorios")
+		fs.Usage()
+		return errUsage
+	}
+
+	// La clínica debe existir y no estar eliminada.
+	if _, err := newClinicService(a).GetByID(ctx, *clinicID); err != nil {
+		return fmt.Errorf("clínica %s: %w", *clinicID, err)
+	}
+
+	pass, err := readPassword(*password)
+	if err != nil {
+		return err
+	}
+
+	svc := services.NewUserService(storage.NewUserRepository(a.db), newPlanService(a), a.logger)
+	user, err := svc.Register(ctx, services.CreateUserParams{
+		ClinicID: *clinicID,
+		FullName: *fullName,
+		Email:    *email,
+		Password: pass,
+		Role:     "admin",
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Administrador creado: %s (%s)\n", user.ID.Hex(), user.Email)
+	return nil
+}
+
+// runUsers implementa `vetctl users rehash-passwords`.
+//
+// bcrypt no permite subir el coste de un hash sin la contraseña en claro, así
+// que el comando marca los usuarios afectados (passwordNeedsRehash) para que el
+// hash se regenere con el coste actual en su siguiente inicio de sesión.
+func runUsers(ctx context.Context, a *app, args []string) error {
+	sub, rest, err := subcommand(args, "users rehash-passwords")
+	if err != nil {
+		return err
+	}
+	if sub != "rehash-passwords" {
+		fmt.Fprintf(os.Stderr, "subcomando desconocido: users %s\n", sub)
+		return errUsage
+	}
+
+	fs := newFlagSet("users rehash-passwords")
+	dryRun := fs.Bool("dry-run", false, "solo informa, no marca usuarios")
+	if err := parseFlags(fs, rest); err != nil {
+		return err
+	}
+
+	repo := storage.NewUserRepository(a.db)
+
+	var scanned int
+	var outdated []primitive.ObjectID
+	err = repo.ForEach(ctx, func(user *models.User) error {
+		scanned++
+		if !user.PasswordNeedsRehash && auth.NeedsRehash(user.HashedPassword) {
+			outdated = append(outdated, user.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d usuarios revisados, %d con coste de bcrypt menor a %d\n", scanned, len(outdated), auth.Cost())
+	if *dryRun {
+		return nil
+	}
+
+	marked, err := repo.MarkPasswordNeedsRehash(ctx, outdated)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d usuarios marcados para regenerar su hash en el próximo inicio de sesión\n", marked)
+	return nil
+}
+
+// readPassword obtiene la contraseña del flag, de VETCTL_PASSWORD o de stdin.
+func readPassword(flagValue string) (string, error) {
+	if flagValue != "" {
+		return flagValue, nil
+	}
+	if env := os.Getenv("VETCTL_PASSWORD"); env != "" {
+		return env, nil
+	}
+
+	fmt.Fprint(os.Stderr, "Contraseña: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("no se pudo leer la contraseña: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
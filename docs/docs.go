@@ -18,17 +18,191 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/clinics": {
+        "/api/v1/admin/clinics/{id}/features/{key}": {
+            "put": {
+                "description": "Sets the clinic's own value for a module or feature flag, replacing the plan default. Use rollout to enable it for a stable percentage of users.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Features"
+                ],
+                "summary": "Override a clinic feature",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Flag key, e.g. inventory",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Flag value",
+                        "name": "flag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/features.SetFeatureRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/features.FeatureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, key or rollout",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Clinic not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the clinic's own value for the flag, so the plan default applies again.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Features"
+                ],
+                "summary": "Reset a clinic feature to its plan default",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Flag key, e.g. inventory",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/features.FeatureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or key",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Clinic not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/clinics/{id}/usage": {
             "get": {
-                "description": "Retrieve a paginated list of all clinics",
+                "description": "Returns the clinic's plan and, for users, patients and storage (in bytes), how much is used, the plan limit and what remains. A null limit means unlimited. Counters are kept up to date on every write; a clinic that moved to a smaller plan may be above its limits until it frees up resources.",
                 "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Plans"
+                ],
+                "summary": "Get clinic usage against its plan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/plans.UsageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Clinic not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics": {
+            "get": {
+                "description": "Retrieve a paginated list of all clinics. With Accept: text/csv, application/x-ndjson or the XLSX media type the whole filtered list is downloaded instead (page, limit and cursor are ignored; fields selects the columns)",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
                 "tags": [
                     "Clinics"
                 ],
                 "summary": "Get all clinics",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "application/json (default), text/csv, application/x-ndjson or application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                        "name": "Accept",
+                        "in": "header"
+                    },
                     {
                         "type": "integer",
                         "description": "Page number (default: 1)",
@@ -43,27 +217,57 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Search term",
+                        "description": "Full-text search on name, display name, address and description (whole words, ignores case and accents). Results are ranked by relevance unless sort is given",
                         "name": "search",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "filter[field][op]=value; op: eq (default), ne, in (comma-separated), gte, lte, exists. Fields: id, name, displayName, email, phone, website, address, isActive, version, createdAt, updatedAt",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Up to 3 comma-separated fields, '-' for descending (name, displayName, createdAt, updatedAt). Default: relevance with search, otherwise createdAt",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated response fields (id is always included)",
+                        "name": "fields",
+                        "in": "query"
+                    },
                     {
                         "type": "boolean",
-                        "description": "Filter by active status",
+                        "description": "Deprecated: use filter[isActive]",
                         "name": "is_active",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Sort field (name, display_name, created_at, updated_at)",
+                        "description": "Deprecated: use sort",
                         "name": "sort_by",
                         "in": "query"
                     },
                     {
                         "type": "boolean",
-                        "description": "Sort descending",
+                        "description": "Deprecated: use sort",
                         "name": "sort_desc",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor/prevCursor from a previous response (keyset mode, page is ignored)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the total in keyset mode (default: true)",
+                        "name": "count",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -74,21 +278,21 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid parameters",
+                        "description": "Invalid parameters or cursor",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Register a new clinic (tenant) in the system with color palette",
+                "description": "Register a new clinic (tenant) in the system with color palette.\nPrimary and secondary must contrast with the background (WCAG 2.1, level set by PALETTE_CONTRAST_LEVEL): depending on PALETTE_CONTRAST the clinic is saved with paletteWarnings or rejected with a 400 per colour.",
                 "consumes": [
                     "application/json"
                 ],
@@ -108,6 +312,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/clinics.CreateClinicRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key per creation attempt; retries with the same key replay the first response (24h)",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -115,84 +325,148 @@ const docTemplate = `{
                         "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/clinics.ClinicResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Clinic version"
+                            }
                         }
                     },
                     "400": {
                         "description": "Invalid data",
                         "schema": {
-                            "$ref": "#/definitions/response.ValidationErrorResponse"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "409": {
-                        "description": "Name already exists",
+                        "description": "Name already exists, or Idempotency-Key reused with another body or still in progress",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/clinics/{id}": {
+        "/api/v1/clinics/trash": {
             "get": {
-                "description": "Retrieve a specific clinic using its ID",
+                "description": "Retrieve a paginated list of soft-deleted clinics (trash). They are purged after the retention period. Supports the same CSV/NDJSON/XLSX downloads as GET /clinics.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
                     "Clinics"
                 ],
-                "summary": "Get clinic by ID",
+                "summary": "List deleted clinics",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Clinic ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "application/json (default), text/csv, application/x-ndjson or application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                        "name": "Accept",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search, as in GET /clinics",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter[field][op]=value, as in GET /clinics, plus deletedAt (gte, lte)",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Up to 3 comma-separated fields, '-' for descending (deletedAt, name, displayName, createdAt, updatedAt). Default: relevance with search, otherwise deletedAt",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated response fields (id is always included)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated: use sort",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Deprecated: use sort",
+                        "name": "sort_desc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor/prevCursor from a previous response (keyset mode, page is ignored)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the total in keyset mode (default: true)",
+                        "name": "count",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/clinics.ClinicResponse"
+                            "$ref": "#/definitions/clinics.ListClinicsResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid ID",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Clinic not found",
+                        "description": "Invalid parameters or cursor",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a clinic from the system (soft delete - marks as inactive)",
+            }
+        },
+        "/api/v1/clinics/{id}": {
+            "get": {
+                "description": "Retrieve a specific clinic using its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Clinics"
                 ],
-                "summary": "Delete clinic",
+                "summary": "Get clinic by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -200,47 +474,59 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous response; 304 if unchanged",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Clinic deleted successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.SuccessResponse"
+                            "$ref": "#/definitions/clinics.ClinicResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Clinic version"
+                            }
                         }
                     },
+                    "304": {
+                        "description": "Not modified"
+                    },
                     "400": {
                         "description": "Invalid ID",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "404": {
                         "description": "Clinic not found",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
             },
-            "patch": {
-                "description": "Partially update an existing clinic's data (only provided fields)",
-                "consumes": [
-                    "application/json"
-                ],
+            "delete": {
+                "description": "Delete a clinic from the system (soft delete - moves it to the trash).\nA clinic with users can only be deleted with cascade=true, which also deletes its users.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Clinics"
                 ],
-                "summary": "Update clinic (partial)",
+                "summary": "Delete clinic",
                 "parameters": [
                     {
                         "type": "string",
@@ -250,12 +536,98 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Fields to update (partial)",
-                        "name": "clinic",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/clinics.UpdateClinicRequest"
+                        "type": "string",
+                        "description": "ETag of the version being deleted",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also delete the clinic's users",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Clinic deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Clinic not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Clinic has dependencies",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Clinic was modified (ETag mismatch)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially update an existing clinic's data (only provided fields). A new palette is checked for contrast like on creation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clinics"
+                ],
+                "summary": "Update clinic (partial)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being edited",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update (partial)",
+                        "name": "clinic",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/clinics.UpdateClinicRequest"
                         }
                     }
                 ],
@@ -264,377 +636,3166 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/clinics.ClinicResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New clinic version"
+                            }
                         }
                     },
                     "400": {
                         "description": "Invalid data",
                         "schema": {
-                            "$ref": "#/definitions/response.ValidationErrorResponse"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "404": {
                         "description": "Clinic not found",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "409": {
                         "description": "Name already exists",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Clinic was modified (ETag mismatch)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/users/register": {
+        "/api/v1/clinics/{id}/attachments": {
+            "get": {
+                "description": "Lists the clinic's attachments, newest first, optionally only those of a patient or a category.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "List attachments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only this patient's attachments",
+                        "name": "patientId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "medicalRecord",
+                            "labResult",
+                            "imaging",
+                            "document"
+                        ],
+                        "type": "string",
+                        "description": "Only this category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max results (default: 100, max: 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/attachments.AttachmentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Clinic not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Crea un nuevo usuario (empleado) asociado a una clínica.",
+                "description": "Stores a file (medical record, lab result, imaging or any other clinic document) of up to 25 MiB. The content type is detected from the content and the file is scanned by the antivirus when one is configured; infected files are rejected. If the clinic already has a file with the same content (SHA-256) the new attachment shares it and does not count again towards the plan's storage.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Attachments"
                 ],
-                "summary": "Registra un nuevo usuario",
+                "summary": "Upload an attachment",
                 "parameters": [
                     {
-                        "description": "Datos para el registro del usuario",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/users.registerUserRequest"
-                        }
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "medicalRecord",
+                            "labResult",
+                            "imaging",
+                            "document"
+                        ],
+                        "type": "string",
+                        "description": "Category",
+                        "name": "category",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Patient the file belongs to",
+                        "name": "patientId",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/attachments.AttachmentResponse"
                         }
                     },
                     "400": {
-                        "description": "Error: Petición inválida",
+                        "description": "Invalid ID or category, missing or empty file",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
-                    "409": {
-                        "description": "Error: El email ya existe",
+                    "402": {
+                        "description": "Plan storage limit reached",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Clinic or patient not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Infected file",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
-                        "description": "Error: Error interno del servidor",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Antivirus not available",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics/{id}/attachments/{attachmentId}": {
+            "get": {
+                "description": "Returns the attachment's metadata and a signed download URL valid for 15 minutes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Get an attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/attachments.AttachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes the attachment. The file is deleted, and its storage freed, when no other attachment of the clinic shares its content.",
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Delete an attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Deleted"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
                         }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics/{id}/attachments/{attachmentId}/download": {
+            "get": {
+                "description": "Redirects to a signed download URL valid for 15 minutes (the storage service with S3, or GET /api/v1/files/{key} with local storage).",
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Download an attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Redirect to the signed URL"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics/{id}/branding/{kind}": {
+            "put": {
+                "description": "Uploads the clinic's logo, favicon or email header and resizes it into variants: logo sm/md/lg (fit in 128, 256 and 512 px), favicon 16/32/180/192/512 (square, center-cropped) and emailHeader 1x/2x (fit in 600x300 and 1200x600). The type is detected from the content: PNG, JPEG, GIF or WebP (SVG is not accepted), at most 4096 px per side and 2 MiB (favicons 1 MiB). Variants count towards the plan's storage; uploading the same image again changes nothing.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Branding"
+                ],
+                "summary": "Upload a branding image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "logo",
+                            "favicon",
+                            "emailHeader"
+                        ],
+                        "type": "string",
+                        "description": "Image kind",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/themes.AssetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or kind, missing file, unreadable image or dimensions out of range",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "402": {
+                        "description": "Plan storage limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Clinic not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Image too large",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Not a PNG, JPEG, GIF or WebP image",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes all variants of the clinic's logo, favicon or email header and frees their storage.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Branding"
+                ],
+                "summary": "Delete a branding image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "logo",
+                            "favicon",
+                            "emailHeader"
+                        ],
+                        "type": "string",
+                        "description": "Image kind",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Deleted"
+                    },
+                    "400": {
+                        "description": "Invalid ID or kind",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Clinic or image not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics/{id}/export": {
+            "post": {
+                "description": "Queues an export of the clinic with its users, owners and patients. The result is a versioned zip archive (one NDJSON file per collection and a manifest.json with the format version and a SHA-256 checksum per file). Poll the transfer and download the archive when it is completed. The archive includes password hashes: store it safely.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Export a whole clinic",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/transfers.TransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Clinic not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics/{id}/features": {
+            "get": {
+                "description": "Returns every module and feature flag of the clinic evaluated for the caller, so the frontend can hide menu items. Modules are enabled by the clinic's plan unless the clinic overrides them; a disabled module answers 404 (MODULE_DISABLED) on its routes. Percentage rollouts are stable per user (or per clinic for anonymous requests).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Features"
+                ],
+                "summary": "List clinic features",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/features.FeaturesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Clinic not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics/{id}/imports": {
+            "post": {
+                "description": "Reads a CSV (comma, semicolon or tab separated) or XLSX file (first sheet) of up to 10 MiB and 10000 rows. The first row is the header. Nothing is imported yet: the response proposes a column mapping to review with preview and confirm with commit.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Upload a patients and owners file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV or XLSX file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/imports.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or unreadable file",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Clinic not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large or too many rows",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Not a CSV or XLSX file",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics/{id}/imports/{importId}": {
+            "get": {
+                "description": "Returns the import with its mapping, status and progress.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Get an import",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Import ID",
+                        "name": "importId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/imports.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Import not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics/{id}/imports/{importId}/commit": {
+            "post": {
+                "description": "Saves the mapping and imports the rows in the background. Owners are deduplicated against existing ones by email or phone. Poll the import to follow its progress; invalid rows are skipped and listed in /errors.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Commit an import",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Import ID",
+                        "name": "importId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Column mapping",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/imports.MappingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/imports.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid mapping",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Import not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Import already committed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics/{id}/imports/{importId}/errors": {
+            "get": {
+                "description": "Rows that failed validation during the import, with their errors, in file order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "List rows that were not imported",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Import ID",
+                        "name": "importId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "nextAfter from the previous page",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rows per page (default: 50, max: 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/imports.InvalidRowsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Import not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics/{id}/imports/{importId}/preview": {
+            "post": {
+                "description": "Validates every row with the given mapping (or the saved one) and returns the errors per row, without importing anything. Species must be one of the allowed species (Spanish and Portuguese names are accepted) and dates must be ISO 8601.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Preview an import (dry-run)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Import ID",
+                        "name": "importId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Column mapping",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/imports.MappingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/imports.PreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid mapping",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Import not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics/{id}/imports/{importId}/resume": {
+            "post": {
+                "description": "Queues a failed import again. Rows already processed are not imported twice. Imports interrupted by a restart resume on their own.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Resume a failed import",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Import ID",
+                        "name": "importId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/imports.ImportResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Import not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Import has not failed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics/{id}/restore": {
+            "post": {
+                "description": "Restore a soft-deleted clinic (and the users deleted with it). Fails if its name or display name was taken meanwhile.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clinics"
+                ],
+                "summary": "Restore clinic",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/clinics.ClinicResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Clinic version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Clinic not found in trash",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics/{name}/branding/{kind}/{variant}": {
+            "get": {
+                "description": "Public endpoint serving one variant of a clinic's branding image. With the v parameter of the theme URLs the response is cacheable forever. Supports conditional requests, and Range requests with local storage.",
+                "produces": [
+                    "image/png",
+                    "image/jpeg"
+                ],
+                "tags": [
+                    "Branding"
+                ],
+                "summary": "Get a branding image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "logo",
+                            "favicon",
+                            "emailHeader"
+                        ],
+                        "type": "string",
+                        "description": "Image kind",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variant name, e.g. md",
+                        "name": "variant",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image version from the theme URLs",
+                        "name": "v",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Clinic, image or variant not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics/{name}/theme": {
+            "get": {
+                "description": "Public endpoint for the white-label frontend: the clinic's palette (missing colours take the defaults), the most readable text colour on each palette colour (#111827 or #FFFFFF, by WCAG 2.1 contrast) a dark-mode variant of the palette and the URLs of its branding images. Inactive clinics are not found.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Branding"
+                ],
+                "summary": "Get a clinic's theme",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clinic name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous response; 304 if unchanged",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/themes.ThemeResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Theme version"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "404": {
+                        "description": "Clinic not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics:batch": {
+            "post": {
+                "description": "Create up to 200 clinics. Each item is validated like POST /api/v1/clinics. In atomic mode (default) the batch runs in a transaction and nothing is created if any item fails; in best_effort mode each item is created independently. The response has one result per item, in request order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clinics"
+                ],
+                "summary": "Create clinics in batch",
+                "parameters": [
+                    {
+                        "description": "Clinics to create",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/clinics.CreateClinicsBatchRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key per batch attempt; retries with the same key replay the first response (24h)",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "All clinics created",
+                        "schema": {
+                            "$ref": "#/definitions/batch.Response"
+                        }
+                    },
+                    "207": {
+                        "description": "Some items failed (see items[].status)",
+                        "schema": {
+                            "$ref": "#/definitions/batch.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed body, invalid mode or item count",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key reused with another body or still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "501": {
+                        "description": "Atomic mode needs a MongoDB replica set",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/clinics:import": {
+            "post": {
+                "description": "Verifies an archive produced by the export (manifest, checksums and references) and recreates the clinic in the background with new IDs; references between records are kept. Use name and displayName when the original clinic still exists here. Poll the transfer to follow its progress.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Import a whole clinic",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Archive (.zip) of up to 512 MiB",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the new clinic (default: the archived one)",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Display name of the new clinic (default: the archived one)",
+                        "name": "displayName",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/transfers.TransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing file or invalid archive",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Clinic name or display name taken",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Archive too large",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unsupported format version or checksum mismatch",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/files/{key}": {
+            "get": {
+                "description": "Serves a stored file when the signature and expiry of the URL are valid. Only with local storage (BLOB_DRIVER=local); the URLs come from the attachment endpoints. Always served as a download. Supports Range requests.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Download a file with a signed URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Expiry (Unix seconds)",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signature",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid signature or expired URL",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "File not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/palettes/generate": {
+            "post": {
+                "description": "Derives a harmonious five-colour palette from a brand colour (JSON body) or from the dominant colours of a logo (multipart, field logo: PNG, JPEG, GIF or WebP up to 2 MiB). Primary is the brand colour, secondary an analogous colour (or the logo's second colour), tertiary a dark neutral tinted with the brand hue, quaternary the complementary accent and background a tinted white. Primary, secondary and quaternary are darkened just enough to reach the configured WCAG 2.1 contrast with the background; the dark variant lightens them against a near-black background. Nothing is saved: send the colours to PATCH /api/v1/clinics/{id}.",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Palettes"
+                ],
+                "summary": "Generate a palette",
+                "parameters": [
+                    {
+                        "description": "Brand colour (JSON)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/palettes.GenerateRequest"
+                        }
+                    },
+                    {
+                        "type": "file",
+                        "description": "Logo (multipart)",
+                        "name": "logo",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/palettes.GenerateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid colour, missing or unreadable logo",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Logo too large",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Logo is not a PNG, JPEG, GIF or WebP image",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/plans": {
+            "get": {
+                "description": "Returns the plans a clinic can be on, from smallest to largest, with their limits and enabled modules. A null limit means unlimited.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plans"
+                ],
+                "summary": "List subscription plans",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/plans.PlanResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search": {
+            "get": {
+                "description": "Typo-tolerant search ranked by similarity. Matches whole words, prefixes and words with up to 1 typo (4-7 letters) or 2 typos (8+ letters), ignoring case and accents. Owners and pets are searched within clinicId.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search clinics, owners and pets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text (max 200 characters)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clinic whose owners and pets are searched",
+                        "name": "clinicId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated: clinics, owners, pets (default: clinics, plus owners and pets when clinicId is given)",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max results (default: 20, max: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/search.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Clinic not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transfers/{id}": {
+            "get": {
+                "description": "Returns the export or import with its status and the number of records processed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Get a transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transfers.TransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transfers/{id}/archive": {
+            "get": {
+                "description": "Returns the zip archive of a completed export until its expiresAt. Supports Range requests.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Download an exported archive",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Not a completed export",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transfers/{id}/retry": {
+            "post": {
+                "description": "Queues a failed export or import again. An import does not create twice what it already created. Transfers interrupted by a restart resume on their own.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Retry a failed transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/transfers.TransferResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer has not failed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/register": {
+            "post": {
+                "description": "Crea un nuevo usuario (empleado) asociado a una clínica. Cada usuario ocupa un puesto del plan de la clínica.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Registra un nuevo usuario",
+                "parameters": [
+                    {
+                        "description": "Datos para el registro del usuario",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/users.registerUserRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única por intento; los reintentos con la misma clave repiten la primera respuesta (24h)",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Petición inválida",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "402": {
+                        "description": "La clínica alcanzó el límite de usuarios de su plan (PLAN_LIMIT_EXCEEDED)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Clínica no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "El email ya existe, o Idempotency-Key reutilizada o en curso",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users:batch": {
+            "post": {
+                "description": "Registra hasta 200 usuarios, validando cada uno con las reglas de registro. En modo atomic (por defecto) el lote va en una transacción y no se crea ninguno si alguno falla; en best_effort cada usuario se crea por separado. La respuesta trae un resultado por usuario, en el orden de la petición.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Registra usuarios en lote",
+                "parameters": [
+                    {
+                        "description": "Usuarios a registrar",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/users.registerUsersBatchRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única por intento; los reintentos con la misma clave repiten la primera respuesta (24h)",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Todos los usuarios creados",
+                        "schema": {
+                            "$ref": "#/definitions/batch.Response"
+                        }
+                    },
+                    "207": {
+                        "description": "Algunos fallaron (ver items[].status)",
+                        "schema": {
+                            "$ref": "#/definitions/batch.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Cuerpo malformado, modo o número de elementos inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key reutilizada o en curso",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "501": {
+                        "description": "El modo atomic requiere un replica set de MongoDB",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz/live": {
+            "get": {
+                "description": "Returns 200 while the process is able to serve requests. It does not check dependencies: a failing database must not restart the process.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/healthz.LivenessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz/ready": {
+            "get": {
+                "description": "Runs the dependency checks (MongoDB ping, pending migrations, background worker heartbeats, free disk space for uploads) and reports the status and latency of each one. Returns 503 when any check fails and while the instance is shutting down (status draining), so load balancers stop sending it traffic. GET /health is an alias.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/healthz.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "A check failed or the instance is draining",
+                        "schema": {
+                            "$ref": "#/definitions/healthz.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "attachments.AttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "labResult"
+                },
+                "clinicId": {
+                    "type": "string"
+                },
+                "contentType": {
+                    "description": "Detectado a partir del contenido",
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "downloadExpiresAt": {
+                    "type": "string"
+                },
+                "downloadUrl": {
+                    "description": "URL firmada de descarga; solo al pedir un adjunto",
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string",
+                    "example": "hemograma.pdf"
+                },
+                "hash": {
+                    "description": "SHA-256 del contenido",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "scan": {
+                    "$ref": "#/definitions/attachments.ScanResponse"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 48213
+                }
+            }
+        },
+        "attachments.AttachmentsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/attachments.AttachmentResponse"
+                    }
+                }
+            }
+        },
+        "attachments.ScanResponse": {
+            "type": "object",
+            "properties": {
+                "engine": {
+                    "type": "string"
+                },
+                "scannedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "clean o unscanned",
+                    "type": "string",
+                    "example": "clean"
+                }
+            }
+        },
+        "batch.ItemResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Código estable del error (como en problem+json)",
+                    "type": "string"
+                },
+                "data": {
+                    "description": "Recurso creado (status created)"
+                },
+                "detail": {
+                    "description": "Traducido al idioma de la petición",
+                    "type": "string"
+                },
+                "errors": {
+                    "description": "Errores por campo (status invalid)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ValidationError"
+                    }
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "invalid",
+                        "conflict",
+                        "error",
+                        "skipped",
+                        "rolled_back"
+                    ]
+                }
+            }
+        },
+        "batch.Response": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "description": "atomic: si se creó todo el lote; best_effort: siempre true",
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/batch.ItemResult"
+                    }
+                },
+                "mode": {
+                    "$ref": "#/definitions/services.BatchMode"
+                },
+                "summary": {
+                    "$ref": "#/definitions/batch.Summary"
+                }
+            }
+        },
+        "batch.Summary": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "clinics.ClinicResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "palette": {
+                    "$ref": "#/definitions/clinics.ColorPaletteResponse"
+                },
+                "paletteWarnings": {
+                    "description": "Solo al crear o cambiar la paleta",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/clinics.PaletteWarning"
+                    }
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "plan": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "También en la cabecera ETag",
+                    "type": "integer"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "clinics.ColorPaletteDTO": {
+            "type": "object",
+            "properties": {
+                "background": {
+                    "type": "string"
+                },
+                "primary": {
+                    "type": "string"
+                },
+                "quaternary": {
+                    "type": "string"
+                },
+                "secondary": {
+                    "type": "string"
+                },
+                "tertiary": {
+                    "type": "string"
+                }
+            }
+        },
+        "clinics.ColorPaletteResponse": {
+            "type": "object",
+            "properties": {
+                "background": {
+                    "type": "string"
+                },
+                "primary": {
+                    "type": "string"
+                },
+                "quaternary": {
+                    "type": "string"
+                },
+                "secondary": {
+                    "type": "string"
+                },
+                "tertiary": {
+                    "type": "string"
+                }
+            }
+        },
+        "clinics.CreateClinicRequest": {
+            "type": "object",
+            "required": [
+                "displayName",
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 5
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "displayName": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "minLength": 2
+                },
+                "email": {
+                    "type": "string"
+                },
+                "locale": {
+                    "description": "Idioma de los mensajes si el cliente no envía Accept-Language",
+                    "type": "string",
+                    "enum": [
+                        "es",
+                        "en",
+                        "pt"
+                    ],
+                    "example": "es"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "palette": {
+                    "$ref": "#/definitions/clinics.ColorPaletteDTO"
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 7
+                },
+                "plan": {
+                    "description": "Plan de suscripción; por defecto free",
+                    "type": "string",
+                    "enum": [
+                        "free",
+                        "standard",
+                        "premium"
+                    ],
+                    "example": "free"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "clinics.CreateClinicsBatchRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/clinics.CreateClinicRequest"
+                    }
+                },
+                "mode": {
+                    "description": "Por defecto atomic",
+                    "enum": [
+                        "atomic",
+                        "best_effort"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/services.BatchMode"
+                        }
+                    ],
+                    "example": "atomic"
+                }
+            }
+        },
+        "clinics.ListClinicsResponse": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "description": "nextCursor/prevCursor",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.CursorPaginationResponse"
+                        }
+                    ]
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/clinics.ClinicResponse"
+                    }
+                },
+                "pagination": {
+                    "description": "Solo en modo página",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PaginationResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "clinics.PaletteWarning": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "palette.primary"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "ratio": {
+                    "type": "number",
+                    "example": 2.15
+                },
+                "required": {
+                    "type": "number",
+                    "example": 3
+                }
+            }
+        },
+        "clinics.UpdateClinicRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 5
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "displayName": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "minLength": 2
+                },
+                "email": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "locale": {
+                    "type": "string",
+                    "enum": [
+                        "es",
+                        "en",
+                        "pt"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "palette": {
+                    "$ref": "#/definitions/clinics.ColorPaletteDTO"
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 7
+                },
+                "plan": {
+                    "type": "string",
+                    "enum": [
+                        "free",
+                        "standard",
+                        "premium"
+                    ]
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CursorPaginationResponse": {
+            "type": "object",
+            "properties": {
+                "hasNext": {
+                    "type": "boolean"
+                },
+                "hasPrev": {
+                    "type": "boolean"
+                },
+                "nextCursor": {
+                    "type": "string"
+                },
+                "perPage": {
+                    "type": "integer"
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "description": "Omitido con count=false",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PaginationResponse": {
+            "type": "object",
+            "properties": {
+                "currentPage": {
+                    "type": "integer"
+                },
+                "hasNext": {
+                    "type": "boolean"
+                },
+                "hasPrev": {
+                    "type": "boolean"
+                },
+                "perPage": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "features.FeatureResponse": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Resultado para quien hace la petición",
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "inventory"
+                },
+                "module": {
+                    "description": "true: activa un grupo de rutas de la API",
+                    "type": "boolean"
+                },
+                "rollout": {
+                    "description": "Porcentaje de sujetos con el flag encendido",
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "plan o clinic",
+                    "type": "string",
+                    "example": "plan"
+                }
+            }
+        },
+        "features.FeaturesResponse": {
+            "type": "object",
+            "properties": {
+                "clinicId": {
+                    "type": "string"
+                },
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/features.FeatureResponse"
+                    }
+                }
+            }
+        },
+        "features.SetFeatureRequest": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "rollout": {
+                    "description": "Opcional: encendido para ese porcentaje",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 25
+                }
+            }
+        },
+        "healthz.CheckResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "latencyMs": {
+                    "type": "number",
+                    "example": 1.25
+                },
+                "status": {
+                    "description": "ok o fail",
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "healthz.LivenessResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "healthz.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/healthz.CheckResponse"
+                    }
+                },
+                "status": {
+                    "description": "ok, fail o draining",
+                    "type": "string",
+                    "example": "ok"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "imports.FieldResponse": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "example": "patient.species"
+                },
+                "required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "imports.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "clinicId": {
+                    "type": "string"
+                },
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/imports.FieldResponse"
+                    }
+                },
+                "fileName": {
+                    "type": "string",
+                    "example": "pacientes.xlsx"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string",
+                    "example": "xlsx"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mapping": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "stats": {
+                    "$ref": "#/definitions/models.ImportStats"
+                },
+                "status": {
+                    "description": "uploaded, queued, running, completed o failed",
+                    "type": "string",
+                    "example": "running"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "imports.InvalidRowsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/imports.RowErrorsResponse"
+                    }
+                },
+                "nextAfter": {
+                    "description": "NextAfter se pasa como after para pedir la siguiente página (ausente en la última)",
+                    "type": "integer"
+                }
+            }
+        },
+        "imports.MappingRequest": {
+            "type": "object",
+            "properties": {
+                "mapping": {
+                    "description": "Campo de importación -\u003e nombre de la columna del archivo",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "owner.fullName": "Tutor",
+                        "patient.name": "Mascota",
+                        "patient.species": "Especie"
+                    }
+                }
+            }
+        },
+        "imports.PreviewResponse": {
+            "type": "object",
+            "properties": {
+                "invalid": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "description": "Primeras filas inválidas (máx. 100)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/imports.RowErrorsResponse"
+                    }
+                },
+                "sample": {
+                    "description": "Primeras filas válidas ya mapeadas",
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "type": "integer"
+                }
+            }
+        },
+        "imports.RowErrorsResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ValidationError"
+                    }
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.ImportStats": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "type": "integer"
+                },
+                "invalid": {
+                    "type": "integer"
+                },
+                "ownersCreated": {
+                    "type": "integer"
+                },
+                "ownersMatched": {
+                    "description": "Tutores existentes reutilizados por email o teléfono",
+                    "type": "integer"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TransferCounts": {
+            "type": "object",
+            "properties": {
+                "owners": {
+                    "type": "integer"
+                },
+                "patients": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.User": {
+            "type": "object",
+            "properties": {
+                "clinicId": {
+                    "description": "¡El discriminador de Tenant!",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "description": "DeletedAt se establece cuando la clínica del usuario se elimina en cascada.",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "fullName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "ej: \"admin\", \"vet\"",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "palettes.ContrastResult": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "primary"
+                },
+                "passes": {
+                    "type": "boolean"
+                },
+                "ratio": {
+                    "description": "Redondeado hacia abajo a dos decimales",
+                    "type": "number",
+                    "example": 4.01
+                }
+            }
+        },
+        "palettes.GenerateRequest": {
+            "type": "object",
+            "required": [
+                "color"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#0EA5E9"
+                }
+            }
+        },
+        "palettes.GenerateResponse": {
+            "type": "object",
+            "properties": {
+                "dark": {
+                    "$ref": "#/definitions/palettes.GeneratedPalette"
+                },
+                "dominant": {
+                    "description": "Colores dominantes del logo, de más a menos frecuente",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "level": {
+                    "description": "Nivel de WCAG 2.1 configurado (PALETTE_CONTRAST_LEVEL)",
+                    "type": "string",
+                    "example": "AA-large"
+                },
+                "light": {
+                    "$ref": "#/definitions/palettes.GeneratedPalette"
+                },
+                "required": {
+                    "description": "Contraste mínimo de primary y secondary con el fondo",
+                    "type": "number",
+                    "example": 3
+                }
+            }
+        },
+        "palettes.GeneratedPalette": {
+            "type": "object",
+            "properties": {
+                "background": {
+                    "type": "string",
+                    "example": "#F9FBFC"
+                },
+                "contrast": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/palettes.ContrastResult"
+                    }
+                },
+                "primary": {
+                    "type": "string",
+                    "example": "#0B84BA"
+                },
+                "quaternary": {
+                    "type": "string",
+                    "example": "#D65A2B"
+                },
+                "secondary": {
+                    "type": "string",
+                    "example": "#1769D4"
+                },
+                "tertiary": {
+                    "type": "string",
+                    "example": "#242E33"
+                },
+                "text": {
+                    "$ref": "#/definitions/palettes.TextColors"
+                }
+            }
+        },
+        "palettes.TextColors": {
+            "type": "object",
+            "properties": {
+                "onBackground": {
+                    "type": "string",
+                    "example": "#111827"
+                },
+                "onPrimary": {
+                    "type": "string",
+                    "example": "#FFFFFF"
+                },
+                "onQuaternary": {
+                    "type": "string",
+                    "example": "#FFFFFF"
+                },
+                "onSecondary": {
+                    "type": "string",
+                    "example": "#FFFFFF"
+                },
+                "onTertiary": {
+                    "type": "string",
+                    "example": "#FFFFFF"
+                }
+            }
+        },
+        "plans.PlanResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "free"
+                },
+                "maxPatients": {
+                    "description": "null: ilimitado",
+                    "type": "integer",
+                    "example": 200
+                },
+                "maxUsers": {
+                    "description": "null: ilimitado",
+                    "type": "integer",
+                    "example": 3
+                },
+                "modules": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "search",
+                        "transfers"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Free"
+                },
+                "storageMB": {
+                    "description": "null: ilimitado",
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "plans.ResourceUsageResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "description": "null: ilimitado",
+                    "type": "integer",
+                    "example": 3
+                },
+                "remaining": {
+                    "description": "null: ilimitado",
+                    "type": "integer",
+                    "example": 1
+                },
+                "used": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "plans.UsageResponse": {
+            "type": "object",
+            "properties": {
+                "clinicId": {
+                    "type": "string"
+                },
+                "patients": {
+                    "$ref": "#/definitions/plans.ResourceUsageResponse"
+                },
+                "plan": {
+                    "$ref": "#/definitions/plans.PlanResponse"
+                },
+                "storage": {
+                    "description": "En bytes",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/plans.ResourceUsageResponse"
+                        }
+                    ]
+                },
+                "users": {
+                    "$ref": "#/definitions/plans.ResourceUsageResponse"
+                }
+            }
+        },
+        "response.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Estable: usar este para decidir en el cliente",
+                    "type": "string",
+                    "example": "CLINIC_NAME_TAKEN"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "A clinic with that name already exists"
+                },
+                "errors": {
+                    "description": "Errores por campo (VALIDATION_FAILED)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ValidationError"
                     }
+                },
+                "instance": {
+                    "description": "Ruta de la petición",
+                    "type": "string",
+                    "example": "/api/v1/clinics"
+                },
+                "requestId": {
+                    "type": "string",
+                    "example": "4f7d1c2e9a0b4c3d8e6f5a4b3c2d1e0f"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 409
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Clinic Name Taken"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "urn:go-vet-api:problem:clinic-name-taken"
                 }
             }
-        }
-    },
-    "definitions": {
-        "clinics.ClinicResponse": {
+        },
+        "response.SuccessResponse": {
             "type": "object",
             "properties": {
-                "address": {
+                "data": {},
+                "message": {
                     "type": "string"
                 },
-                "createdAt": {
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.ValidationError": {
+            "type": "object",
+            "properties": {
+                "field": {
                     "type": "string"
                 },
-                "description": {
+                "message": {
                     "type": "string"
                 },
-                "displayName": {
+                "value": {
                     "type": "string"
-                },
-                "email": {
+                }
+            }
+        },
+        "search.HitResponse": {
+            "type": "object",
+            "properties": {
+                "clinicId": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "isActive": {
-                    "type": "boolean"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "palette": {
-                    "$ref": "#/definitions/clinics.ColorPaletteResponse"
+                "score": {
+                    "description": "Entre 0 y 1",
+                    "type": "number"
                 },
-                "phone": {
+                "subtitle": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "title": {
                     "type": "string"
                 },
-                "website": {
-                    "type": "string"
+                "type": {
+                    "description": "clinics, owners o pets",
+                    "type": "string",
+                    "example": "pets"
                 }
             }
         },
-        "clinics.ColorPaletteDTO": {
+        "search.SearchResponse": {
             "type": "object",
             "properties": {
-                "background": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/search.HitResponse"
+                    }
+                }
+            }
+        },
+        "services.BatchMode": {
+            "type": "string",
+            "enum": [
+                "atomic",
+                "best_effort"
+            ],
+            "x-enum-varnames": [
+                "BatchAtomic",
+                "BatchBestEffort"
+            ]
+        },
+        "themes.AssetResponse": {
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "type": "string",
+                    "example": "image/png"
                 },
-                "primary": {
-                    "type": "string"
+                "size": {
+                    "description": "Bytes de todas las variantes",
+                    "type": "integer"
                 },
-                "quaternary": {
+                "uploadedAt": {
                     "type": "string"
                 },
-                "secondary": {
-                    "type": "string"
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/themes.VariantResponse"
+                    }
+                }
+            }
+        },
+        "themes.DarkThemeResponse": {
+            "type": "object",
+            "properties": {
+                "palette": {
+                    "$ref": "#/definitions/themes.PaletteResponse"
                 },
-                "tertiary": {
-                    "type": "string"
+                "text": {
+                    "$ref": "#/definitions/themes.TextColorsResponse"
                 }
             }
         },
-        "clinics.ColorPaletteResponse": {
+        "themes.PaletteResponse": {
             "type": "object",
             "properties": {
                 "background": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "#FFFFFF"
                 },
                 "primary": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "#3B82F6"
                 },
                 "quaternary": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "#1F2937"
                 },
                 "secondary": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "#8B5CF6"
                 },
                 "tertiary": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "#1F2937"
                 }
             }
         },
-        "clinics.CreateClinicRequest": {
+        "themes.TextColorsResponse": {
             "type": "object",
-            "required": [
-                "displayName",
-                "name"
-            ],
             "properties": {
-                "address": {
+                "onBackground": {
                     "type": "string",
-                    "maxLength": 200,
-                    "minLength": 5
+                    "example": "#111827"
                 },
-                "description": {
+                "onPrimary": {
                     "type": "string",
-                    "maxLength": 500
+                    "example": "#FFFFFF"
                 },
-                "displayName": {
+                "onQuaternary": {
                     "type": "string",
-                    "maxLength": 150,
-                    "minLength": 2
-                },
-                "email": {
-                    "type": "string"
+                    "example": "#FFFFFF"
                 },
-                "name": {
+                "onSecondary": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
-                },
-                "palette": {
-                    "$ref": "#/definitions/clinics.ColorPaletteDTO"
+                    "example": "#FFFFFF"
                 },
-                "phone": {
+                "onTertiary": {
                     "type": "string",
-                    "maxLength": 20,
-                    "minLength": 7
-                },
-                "website": {
-                    "type": "string"
+                    "example": "#FFFFFF"
                 }
             }
         },
-        "clinics.ListClinicsResponse": {
+        "themes.ThemeResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/clinics.ClinicResponse"
+                "assets": {
+                    "description": "Por tipo: logo, favicon, emailHeader (solo los subidos)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/themes.AssetResponse"
                     }
                 },
-                "pagination": {
-                    "$ref": "#/definitions/dto.PaginationResponse"
-                }
-            }
-        },
-        "clinics.UpdateClinicRequest": {
-            "type": "object",
-            "properties": {
-                "address": {
-                    "type": "string",
-                    "maxLength": 200,
-                    "minLength": 5
-                },
-                "description": {
-                    "type": "string",
-                    "maxLength": 500
+                "dark": {
+                    "description": "Variante para modo oscuro",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/themes.DarkThemeResponse"
+                        }
+                    ]
                 },
                 "displayName": {
                     "type": "string",
-                    "maxLength": 150,
-                    "minLength": 2
-                },
-                "email": {
-                    "type": "string"
-                },
-                "isActive": {
-                    "type": "boolean"
+                    "example": "Veterinaria Central"
                 },
                 "name": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                    "example": "vet-central"
                 },
                 "palette": {
-                    "$ref": "#/definitions/clinics.ColorPaletteDTO"
+                    "$ref": "#/definitions/themes.PaletteResponse"
                 },
-                "phone": {
-                    "type": "string",
-                    "maxLength": 20,
-                    "minLength": 7
-                },
-                "website": {
-                    "type": "string"
+                "text": {
+                    "description": "Color de texto legible sobre cada color de la paleta",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/themes.TextColorsResponse"
+                        }
+                    ]
                 }
             }
         },
-        "dto.PaginationResponse": {
+        "themes.VariantResponse": {
             "type": "object",
             "properties": {
-                "currentPage": {
-                    "type": "integer"
-                },
-                "hasNext": {
-                    "type": "boolean"
-                },
-                "hasPrev": {
-                    "type": "boolean"
+                "height": {
+                    "type": "integer",
+                    "example": 256
                 },
-                "perPage": {
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "example": "md"
                 },
-                "total": {
-                    "type": "integer"
+                "url": {
+                    "description": "Relativa a la API; cambia con cada subida",
+                    "type": "string",
+                    "example": "/api/v1/clinics/vet-central/branding/logo/md?v=9f86d081884c7d65"
                 },
-                "totalPages": {
-                    "type": "integer"
+                "width": {
+                    "type": "integer",
+                    "example": 256
                 }
             }
         },
-        "models.User": {
+        "transfers.TransferResponse": {
             "type": "object",
             "properties": {
                 "clinicId": {
-                    "description": "¡El discriminador de Tenant!",
+                    "description": "En una importación, el ID de la clínica nueva",
                     "type": "string"
                 },
-                "createdAt": {
-                    "type": "string"
+                "counts": {
+                    "$ref": "#/definitions/models.TransferCounts"
                 },
-                "email": {
+                "createdAt": {
                     "type": "string"
                 },
-                "fullName": {
+                "error": {
                     "type": "string"
                 },
-                "id": {
+                "expiresAt": {
+                    "description": "Cuándo se borran el traspaso y su archivo",
                     "type": "string"
                 },
-                "role": {
-                    "description": "ej: \"admin\", \"vet\"",
+                "finishedAt": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
-                }
-            }
-        },
-        "response.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
+                "id": {
                     "type": "string"
                 },
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "response.SuccessResponse": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "message": {
-                    "type": "string"
+                "kind": {
+                    "description": "export o import",
+                    "type": "string",
+                    "example": "export"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "response.ValidationError": {
-            "type": "object",
-            "properties": {
-                "field": {
-                    "type": "string"
+                "size": {
+                    "description": "Bytes del archivo exportado",
+                    "type": "integer"
                 },
-                "message": {
+                "sourceClinicId": {
+                    "description": "SourceClinicID es el ID de la clínica en el entorno de origen (solo importaciones)",
                     "type": "string"
                 },
-                "value": {
-                    "type": "string"
-                }
-            }
-        },
-        "response.ValidationErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
+                "startedAt": {
                     "type": "string"
                 },
-                "fields": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.ValidationError"
-                    }
+                "status": {
+                    "description": "queued, running, completed o failed",
+                    "type": "string",
+                    "example": "running"
                 },
-                "message": {
+                "updatedAt": {
                     "type": "string"
                 }
             }
@@ -666,6 +3827,30 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "users.registerUsersBatchRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/users.registerUserRequest"
+                    }
+                },
+                "mode": {
+                    "description": "Por defecto atomic",
+                    "enum": [
+                        "atomic",
+                        "best_effort"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/services.BatchMode"
+                        }
+                    ],
+                    "example": "atomic"
+                }
+            }
         }
     }
 }`
@@ -1,10 +1,31 @@
 package auth
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// cost es el coste de bcrypt para los hashes nuevos. Se configura al arrancar con SetCost.
+var cost = bcrypt.DefaultCost
+
+// SetCost cambia el coste de bcrypt usado por HashPassword.
+func SetCost(c int) error {
+	if c < bcrypt.MinCost || c > bcrypt.MaxCost {
+		return fmt.Errorf("coste de bcrypt fuera de rango (%d-%d): %d", bcrypt.MinCost, bcrypt.MaxCost, c)
+	}
+	cost = c
+	return nil
+}
+
+// Cost devuelve el coste de bcrypt configurado.
+func Cost() int {
+	return cost
+}
 
 // HashPassword genera un hash a partir de una contraseña en texto plano.
 func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), cost)
 	return string(bytes), err
 }
 
@@ -12,4 +33,14 @@ func HashPassword(password string) (string, error) {
 func CheckPasswordHash(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
-}
\ No newline at end of file
+}
+
+// NeedsRehash indica si un hash se generó con un coste menor al configurado
+// (o no es un hash bcrypt válido).
+func NeedsRehash(hash string) bool {
+	hashCost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return true
+	}
+	return hashCost < cost
+}
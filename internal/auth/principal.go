@@ -0,0 +1,17 @@
+package auth
+
+import "context"
+
+type principalKey struct{}
+
+// WithPrincipal devuelve una copia de ctx con el ID de quien hace la petición.
+// Lo pone el middleware de autenticación.
+func WithPrincipal(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, principalKey{}, id)
+}
+
+// PrincipalFromContext devuelve el ID de quien hace la petición, o "" si es anónima.
+func PrincipalFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(principalKey{}).(string)
+	return id
+}
@@ -0,0 +1,100 @@
+// Package blob guarda los ficheros adjuntos de las clínicas (historias
+// clínicas, resultados de laboratorio, imágenes...) en un almacén de objetos:
+// el disco local o un servicio compatible con S3 (AWS, MinIO...). Los
+// metadatos de cada adjunto van aparte, en la colección attachments.
+//
+// Las claves se agrupan por clínica y se derivan del contenido (ver Key): el
+// mismo fichero subido dos veces en una clínica se guarda una sola vez.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Errores de los almacenes
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// MaxSignedURLTTL es la validez máxima de una URL firmada (la que admite S3).
+const MaxSignedURLTTL = 7 * 24 * time.Hour
+
+// Store es un almacén de objetos. Las claves son rutas relativas con
+// segmentos de letras, dígitos, '.', '-' y '_' separados por '/' (ver
+// CheckKey); el almacén no interpreta su contenido.
+type Store interface {
+	// Put guarda el contenido de r (size bytes) con la clave; si ya existe lo
+	// sustituye. Un fallo a medias no deja un objeto incompleto.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get abre el objeto; ErrNotFound si no existe. El llamante cierra el lector.
+	Get(ctx context.Context, key string) (io.ReadCloser, *Info, error)
+	// Delete borra el objeto; borrar uno que no existe no es un error.
+	Delete(ctx context.Context, key string) error
+	// DeletePrefix borra todos los objetos cuyas claves empiezan por prefix
+	// (p. ej. ClinicPrefix), que debe ser una clave válida terminada en '/'.
+	DeletePrefix(ctx context.Context, prefix string) error
+	// SignedURL devuelve una URL con la que descargar el objeto sin más
+	// credenciales durante ttl (como mucho MaxSignedURLTTL).
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// Info describe un objeto guardado.
+type Info struct {
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// Key es la clave del contenido con ese hash (SHA-256 en hexadecimal) en la
+// clínica: clinics/<clinicID>/<2 primeros caracteres del hash>/<hash>. El
+// prefijo de dos caracteres reparte los objetos entre directorios.
+func Key(clinicID, hash string) string {
+	return "clinics/" + clinicID + "/" + hash[:2] + "/" + hash
+}
+
+// ClinicPrefix es el prefijo de todas las claves de la clínica.
+func ClinicPrefix(clinicID string) string {
+	return "clinics/" + clinicID + "/"
+}
+
+// CheckKey rechaza las claves vacías, absolutas, con segmentos vacíos, "." o
+// ".." o con caracteres fuera de [A-Za-z0-9._-].
+func CheckKey(key string) error {
+	if key == "" || len(key) > 1024 {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+		for _, c := range segment {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '-' || c == '_') {
+				return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+			}
+		}
+	}
+	return nil
+}
+
+// checkPrefix comprueba un prefijo de DeletePrefix: una clave seguida de '/'
+func checkPrefix(prefix string) error {
+	key, ok := strings.CutSuffix(prefix, "/")
+	if !ok {
+		return fmt.Errorf("%w: prefix %q must end with '/'", ErrInvalidKey, prefix)
+	}
+	return CheckKey(key)
+}
+
+// checkTTL limita la validez de una URL firmada
+func checkTTL(ttl time.Duration) error {
+	if ttl <= 0 || ttl > MaxSignedURLTTL {
+		return fmt.Errorf("signed URL TTL must be between 1s and %s, got %s", MaxSignedURLTTL, ttl)
+	}
+	return nil
+}
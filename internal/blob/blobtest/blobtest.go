@@ -0,0 +1,223 @@
+// Package blobtest es la batería de conformidad de blob.Store: cualquier
+// driver (disco local, S3 contra s3fake o un servicio real) debe pasarla.
+package blobtest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/blob"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RunStoreTests ejecuta la batería de conformidad de blob.Store. Si las URLs
+// firmadas del almacén son absolutas se descargan con client.
+func RunStoreTests(t *testing.T, newStore func(t *testing.T) blob.Store, client *http.Client) {
+	t.Helper()
+	ctx := context.Background()
+
+	t.Run("PutAndGet", func(t *testing.T) {
+		store := newStore(t)
+		key := newKey("informe.pdf")
+		data := []byte("%PDF-1.4 informe de laboratorio")
+		if err := store.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "application/pdf"); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+		got, info := get(t, store, key)
+		if !bytes.Equal(got, data) || info.Size != int64(len(data)) || info.ContentType != "application/pdf" {
+			t.Fatalf("esperado el contenido guardado, obtenido %q, %+v", got, info)
+		}
+	})
+
+	t.Run("PutReplaces", func(t *testing.T) {
+		store := newStore(t)
+		key := newKey("nota.txt")
+		for _, data := range []string{"primera versión", "segunda"} {
+			if err := store.Put(ctx, key, strings.NewReader(data), int64(len(data)), "text/plain"); err != nil {
+				t.Fatalf("Put %q: %v", data, err)
+			}
+		}
+		if got, _ := get(t, store, key); string(got) != "segunda" {
+			t.Fatalf("esperado el último contenido, obtenido %q", got)
+		}
+	})
+
+	t.Run("PutEmpty", func(t *testing.T) {
+		store := newStore(t)
+		key := newKey("vacio")
+		if err := store.Put(ctx, key, strings.NewReader(""), 0, "application/octet-stream"); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+		if got, info := get(t, store, key); len(got) != 0 || info.Size != 0 {
+			t.Fatalf("esperado un objeto vacío, obtenido %q, %+v", got, info)
+		}
+	})
+
+	t.Run("ShortPutLeavesNothing", func(t *testing.T) {
+		store := newStore(t)
+		key := newKey("corto")
+		if err := store.Put(ctx, key, strings.NewReader("abc"), 10, "text/plain"); err == nil {
+			t.Fatal("esperado error con menos bytes de los anunciados")
+		}
+		if _, _, err := store.Get(ctx, key); !errors.Is(err, blob.ErrNotFound) {
+			t.Fatalf("esperado ErrNotFound tras un Put fallido, obtenido %v", err)
+		}
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		store := newStore(t)
+		if _, _, err := store.Get(ctx, newKey("no-existe")); !errors.Is(err, blob.ErrNotFound) {
+			t.Fatalf("esperado ErrNotFound, obtenido %v", err)
+		}
+	})
+
+	t.Run("DeleteIsIdempotent", func(t *testing.T) {
+		store := newStore(t)
+		key := newKey("borrar")
+		if err := store.Put(ctx, key, strings.NewReader("x"), 1, "text/plain"); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+		for i := 0; i < 2; i++ {
+			if err := store.Delete(ctx, key); err != nil {
+				t.Fatalf("Delete %d: %v", i+1, err)
+			}
+		}
+		if _, _, err := store.Get(ctx, key); !errors.Is(err, blob.ErrNotFound) {
+			t.Fatalf("esperado ErrNotFound tras borrar, obtenido %v", err)
+		}
+	})
+
+	t.Run("DeletePrefix", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID().Hex()
+		prefix := blob.ClinicPrefix(clinicID)
+		var keys []string
+		for _, hash := range []string{"aa01", "aa02", "bb03", "cc04", "dd05"} {
+			keys = append(keys, blob.Key(clinicID, hash))
+		}
+		// Una clínica cuyo ID empieza igual no debe verse afectada
+		others := []string{newKey("otra"), blob.ClinicPrefix(clinicID+"x") + "vecina"}
+		for _, key := range append(keys, others...) {
+			if err := store.Put(ctx, key, strings.NewReader(key), int64(len(key)), "text/plain"); err != nil {
+				t.Fatalf("Put %q: %v", key, err)
+			}
+		}
+
+		if err := store.DeletePrefix(ctx, prefix); err != nil {
+			t.Fatalf("DeletePrefix: %v", err)
+		}
+		for _, key := range keys {
+			if _, _, err := store.Get(ctx, key); !errors.Is(err, blob.ErrNotFound) {
+				t.Fatalf("esperado ErrNotFound para %q tras DeletePrefix, obtenido %v", key, err)
+			}
+		}
+		for _, key := range others {
+			if got, _ := get(t, store, key); string(got) != key {
+				t.Fatalf("DeletePrefix no debe tocar %q, obtenido %q", key, got)
+			}
+		}
+
+		if err := store.DeletePrefix(ctx, prefix); err != nil {
+			t.Fatalf("DeletePrefix de un prefijo vacío: %v", err)
+		}
+		for _, prefix := range []string{"", "/", "clinics", "clinics/../", "/clinics/"} {
+			if err := store.DeletePrefix(ctx, prefix); !errors.Is(err, blob.ErrInvalidKey) {
+				t.Errorf("DeletePrefix %q: esperado ErrInvalidKey, obtenido %v", prefix, err)
+			}
+		}
+	})
+
+	t.Run("InvalidKeys", func(t *testing.T) {
+		store := newStore(t)
+		for _, key := range []string{"", "/absoluta", "a/../b", "a//b", "a/./b", "con espacio", `a\b`} {
+			if err := store.Put(ctx, key, strings.NewReader("x"), 1, "text/plain"); !errors.Is(err, blob.ErrInvalidKey) {
+				t.Errorf("Put %q: esperado ErrInvalidKey, obtenido %v", key, err)
+			}
+			if _, _, err := store.Get(ctx, key); !errors.Is(err, blob.ErrInvalidKey) {
+				t.Errorf("Get %q: esperado ErrInvalidKey, obtenido %v", key, err)
+			}
+		}
+	})
+
+	t.Run("ConcurrentPutsOfSameContent", func(t *testing.T) {
+		store := newStore(t)
+		key := newKey("mismo")
+		data := strings.Repeat("radiografía ", 1000)
+		var wg sync.WaitGroup
+		errs := make(chan error, 8)
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- store.Put(ctx, key, strings.NewReader(data), int64(len(data)), "text/plain")
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Fatalf("Put concurrente: %v", err)
+			}
+		}
+		if got, _ := get(t, store, key); string(got) != data {
+			t.Fatalf("esperado el contenido íntegro, obtenido %d bytes", len(got))
+		}
+	})
+
+	t.Run("SignedURL", func(t *testing.T) {
+		store := newStore(t)
+		key := newKey("firmada.txt")
+		if err := store.Put(ctx, key, strings.NewReader("descarga"), 8, "text/plain"); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+		if _, err := store.SignedURL(ctx, key, 0); err == nil {
+			t.Fatal("esperado error con una validez de 0")
+		}
+		if _, err := store.SignedURL(ctx, key, blob.MaxSignedURLTTL+time.Second); err == nil {
+			t.Fatal("esperado error con una validez mayor que MaxSignedURLTTL")
+		}
+
+		signed, err := store.SignedURL(ctx, key, time.Minute)
+		if err != nil || signed == "" {
+			t.Fatalf("SignedURL: %v, %q", err, signed)
+		}
+		if !strings.HasPrefix(signed, "http://") && !strings.HasPrefix(signed, "https://") {
+			return // Relativa: la sirve la API, no el almacén
+		}
+		resp, err := client.Get(signed)
+		if err != nil {
+			t.Fatalf("GET de la URL firmada: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK || string(body) != "descarga" {
+			t.Fatalf("esperado 200 con el contenido, obtenido %d %q", resp.StatusCode, body)
+		}
+	})
+}
+
+// newKey es una clave de una clínica nueva, para que los subtests no choquen
+func newKey(name string) string {
+	return blob.ClinicPrefix(primitive.NewObjectID().Hex()) + name
+}
+
+func get(t *testing.T, store blob.Store, key string) ([]byte, *blob.Info) {
+	t.Helper()
+	r, info, err := store.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get %q: %v", key, err)
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("leer %q: %v", key, err)
+	}
+	return data, info
+}
@@ -0,0 +1,210 @@
+package blob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errores de las URLs firmadas del almacén local
+var (
+	ErrInvalidSignature = errors.New("invalid download signature")
+	ErrURLExpired       = errors.New("download URL has expired")
+)
+
+// DownloadPath es la ruta de la API que sirve las URLs firmadas del almacén
+// local; la clave va a continuación.
+const DownloadPath = "/api/v1/files/"
+
+// typeSuffix es la extensión del fichero con el tipo de contenido de cada objeto
+const typeSuffix = ".type"
+
+// LocalStore guarda cada objeto como un fichero bajo un directorio, con la
+// clave como ruta. Con varias réplicas el directorio debe ser compartido. Sus
+// URLs firmadas apuntan a la propia API (DownloadPath), que las comprueba con
+// Verify.
+type LocalStore struct {
+	dir     string
+	secret  []byte
+	baseURL string
+}
+
+var _ Store = (*LocalStore)(nil)
+
+// NewLocalStore crea el almacén en dir (vacío: el temporal del sistema).
+// secret firma las URLs de descarga y baseURL es la URL pública de la API
+// (vacía: las URLs son relativas).
+func NewLocalStore(dir string, secret []byte, baseURL string) (*LocalStore, error) {
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "go-vet-api-blobs")
+	}
+	if len(secret) < 16 {
+		return nil, errors.New("el secreto de las URLs de descarga debe tener al menos 16 bytes")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("no se pudo crear el directorio de ficheros: %w", err)
+	}
+	return &LocalStore{dir: dir, secret: secret, baseURL: baseURL}, nil
+}
+
+// Dir es el directorio del almacén.
+func (s *LocalStore) Dir() string {
+	return s.dir
+}
+
+// Put escribe a un temporal y lo renombra, así nunca queda un fichero a medias.
+func (s *LocalStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	if err := writeAtomic(path+typeSuffix, func(w io.Writer) error {
+		_, err := io.WriteString(w, contentType)
+		return err
+	}); err != nil {
+		return err
+	}
+	return writeAtomic(path, func(w io.Writer) error {
+		n, err := io.Copy(w, io.LimitReader(r, size+1))
+		if err == nil && n != size {
+			err = fmt.Errorf("blob size mismatch: expected %d bytes, read %d", size, n)
+		}
+		return err
+	})
+}
+
+// Get abre el fichero del objeto.
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, *Info, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to stat blob: %w", err)
+	}
+
+	contentType := "application/octet-stream"
+	if data, err := os.ReadFile(path + typeSuffix); err == nil && len(data) > 0 {
+		contentType = string(data)
+	}
+	return file, &Info{Size: stat.Size(), ContentType: contentType, ModTime: stat.ModTime().UTC()}, nil
+}
+
+// Delete borra el fichero del objeto y el de su tipo.
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	for _, p := range []string{path, path + typeSuffix} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to delete blob: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeletePrefix borra el directorio del prefijo con todo su contenido.
+func (s *LocalStore) DeletePrefix(ctx context.Context, prefix string) error {
+	if err := checkPrefix(prefix); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(s.dir, filepath.FromSlash(prefix))); err != nil {
+		return fmt.Errorf("failed to delete blobs: %w", err)
+	}
+	return nil
+}
+
+// SignedURL firma la clave y la caducidad con HMAC-SHA256.
+func (s *LocalStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := CheckKey(key); err != nil {
+		return "", err
+	}
+	if err := checkTTL(ttl); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	query := url.Values{"expires": {expires}, "signature": {s.sign(key, expires)}}
+	return s.baseURL + DownloadPath + key + "?" + query.Encode(), nil
+}
+
+// Verify comprueba la firma y la caducidad de una URL de SignedURL.
+func (s *LocalStore) Verify(key, expires, signature string, now time.Time) error {
+	if err := CheckKey(key); err != nil {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		return ErrInvalidSignature
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if now.Unix() > unix {
+		return ErrURLExpired
+	}
+	return nil
+}
+
+func (s *LocalStore) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// path es la ruta del fichero de la clave, que no puede salir de dir ni
+// coincidir con el fichero del tipo de otra clave
+func (s *LocalStore) path(key string) (string, error) {
+	if err := CheckKey(key); err != nil {
+		return "", err
+	}
+	if strings.HasSuffix(key, typeSuffix) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// writeAtomic escribe el fichero en un temporal junto a path y lo renombra
+func writeAtomic(path string, write func(io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".part-*")
+	if err != nil {
+		return fmt.Errorf("failed to create blob file: %w", err)
+	}
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to save blob: %w", err)
+	}
+	return nil
+}
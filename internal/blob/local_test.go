@@ -0,0 +1,19 @@
+package blob_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/zabaletac3/go-vet-api/internal/blob"
+	"github.com/zabaletac3/go-vet-api/internal/blob/blobtest"
+)
+
+func TestLocalStore(t *testing.T) {
+	blobtest.RunStoreTests(t, func(t *testing.T) blob.Store {
+		store, err := blob.NewLocalStore(t.TempDir(), []byte("local-store-test-secret"), "")
+		if err != nil {
+			t.Fatalf("NewLocalStore: %v", err)
+		}
+		return store
+	}, http.DefaultClient)
+}
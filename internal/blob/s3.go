@@ -0,0 +1,268 @@
+package blob
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/blob/sigv4"
+)
+
+// S3Config configura un almacén compatible con S3.
+type S3Config struct {
+	// Endpoint es la URL del servicio, p. ej. https://s3.eu-west-1.amazonaws.com
+	// o http://localhost:9000 para MinIO
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PathStyle pone el bucket en la ruta (endpoint/bucket/clave) en vez de
+	// en el host (bucket.endpoint/clave); MinIO y la mayoría de compatibles lo necesitan
+	PathStyle bool
+	// Client hace las peticiones; nil usa uno con un timeout de 5 minutos
+	Client *http.Client
+}
+
+// S3Store guarda los objetos en un bucket de S3 o de un servicio compatible.
+// Habla la API REST directamente, firmando con SigV4 (ver sigv4).
+type S3Store struct {
+	endpoint *url.URL
+	bucket   string
+	path     bool
+	creds    sigv4.Credentials
+	client   *http.Client
+}
+
+var _ Store = (*S3Store)(nil)
+
+// NewS3Store crea el almacén. No comprueba que el bucket exista: ver Ping.
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("endpoint de S3 inválido %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" || cfg.Region == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, errors.New("S3 requiere bucket, región y credenciales")
+	}
+	client := cfg.Client
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Minute}
+	}
+	return &S3Store{
+		endpoint: endpoint,
+		bucket:   cfg.Bucket,
+		path:     cfg.PathStyle,
+		creds:    sigv4.Credentials{AccessKey: cfg.AccessKey, SecretKey: cfg.SecretKey, Region: cfg.Region, Service: "s3"},
+		client:   client,
+	}, nil
+}
+
+// Put sube el objeto en una sola petición (S3 admite hasta 5 GiB así).
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	req, err := s.request(ctx, http.MethodPut, key, io.LimitReader(r, size))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to put blob: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Get descarga el objeto; el cuerpo de la respuesta es el lector.
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, *Info, error) {
+	req, err := s.request(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("failed to get blob: %w", err)
+	}
+
+	info := &Info{Size: resp.ContentLength, ContentType: resp.Header.Get("Content-Type")}
+	if modTime, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		info.ModTime = modTime.UTC()
+	}
+	return resp.Body, info, nil
+}
+
+// Delete borra el objeto; S3 responde 204 aunque no exista.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := s.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// DeletePrefix lista las claves del prefijo (ListObjectsV2, por páginas) y
+// las borra una a una; así no hace falta el Content-MD5 que exige el borrado
+// múltiple.
+func (s *S3Store) DeletePrefix(ctx context.Context, prefix string) error {
+	if err := checkPrefix(prefix); err != nil {
+		return err
+	}
+
+	token := ""
+	for {
+		page, err := s.list(ctx, prefix, token)
+		if err != nil {
+			return fmt.Errorf("failed to list blobs: %w", err)
+		}
+		for _, object := range page.Contents {
+			if err := s.Delete(ctx, object.Key); err != nil {
+				return err
+			}
+		}
+		if !page.IsTruncated {
+			return nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// listBucketResult es la respuesta de ListObjectsV2
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// list pide una página de claves con el prefijo
+func (s *S3Store) list(ctx context.Context, prefix, token string) (*listBucketResult, error) {
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+	if token != "" {
+		query.Set("continuation-token", token)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL("")+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	s.creds.Sign(req, sigv4.UnsignedPayload, time.Now())
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var page listBucketResult
+	if err := xml.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("invalid list response: %w", err)
+	}
+	return &page, nil
+}
+
+// SignedURL prefirma un GET del objeto; la URL apunta directamente al servicio.
+func (s *S3Store) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := checkTTL(ttl); err != nil {
+		return "", err
+	}
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return "", err
+	}
+	s.creds.Presign(req, ttl, time.Now())
+	return req.URL.String(), nil
+}
+
+// Ping comprueba que el bucket existe y las credenciales valen (HEAD del bucket).
+func (s *S3Store) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, s.objectURL(""), nil)
+	if err != nil {
+		return err
+	}
+	s.creds.Sign(req, sigv4.UnsignedPayload, time.Now())
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("bucket %s: %w", s.bucket, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// request crea la petición del objeto firmada en la cabecera
+func (s *S3Store) request(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	req, err := s.newRequest(ctx, method, key, body)
+	if err != nil {
+		return nil, err
+	}
+	s.creds.Sign(req, sigv4.UnsignedPayload, time.Now())
+	return req, nil
+}
+
+func (s *S3Store) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	if err := CheckKey(key); err != nil {
+		return nil, err
+	}
+	return http.NewRequestWithContext(ctx, method, s.objectURL(key), body)
+}
+
+// objectURL es la URL de la clave (o del bucket, con la clave vacía)
+func (s *S3Store) objectURL(key string) string {
+	u := *s.endpoint
+	base := strings.TrimSuffix(u.Path, "/")
+	if s.path {
+		u.Path = base + "/" + s.bucket + "/" + key
+	} else {
+		u.Host = s.bucket + "." + u.Host
+		u.Path = base + "/" + key
+	}
+	return u.String()
+}
+
+// do envía la petición y convierte las respuestas de error de S3 en errores;
+// NoSuchKey (o un 404 sin cuerpo de un objeto) es ErrNotFound.
+func (s *S3Store) do(req *http.Request) (*http.Response, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	var s3err struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	_ = xml.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&s3err)
+	if s3err.Code == "NoSuchKey" || (resp.StatusCode == http.StatusNotFound && s3err.Code == "" && req.Method != http.MethodHead) {
+		return nil, ErrNotFound
+	}
+	if s3err.Code == "" {
+		s3err.Code = strconv.Itoa(resp.StatusCode)
+	}
+	return nil, fmt.Errorf("s3 %s %s: %s %s", req.Method, req.URL.Path, s3err.Code, s3err.Message)
+}
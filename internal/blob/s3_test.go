@@ -0,0 +1,41 @@
+package blob_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zabaletac3/go-vet-api/internal/blob"
+	"github.com/zabaletac3/go-vet-api/internal/blob/blobtest"
+	"github.com/zabaletac3/go-vet-api/internal/blob/s3fake"
+)
+
+func TestS3Store(t *testing.T) {
+	fake := s3fake.New("us-east-1", "minio", "minio-secret")
+	fake.MaxKeys = 2 // Para que DeletePrefix recorra varias páginas
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	blobtest.RunStoreTests(t, func(t *testing.T) blob.Store {
+		// Un bucket por prueba para que no compartan objetos
+		bucket := "attachments-" + strings.ToLower(strings.ReplaceAll(t.Name(), "/", "-"))
+		fake.CreateBucket(bucket)
+		store, err := blob.NewS3Store(blob.S3Config{
+			Endpoint:  srv.URL,
+			Region:    "us-east-1",
+			Bucket:    bucket,
+			AccessKey: "minio",
+			SecretKey: "minio-secret",
+			PathStyle: true,
+			Client:    srv.Client(),
+		})
+		if err != nil {
+			t.Fatalf("NewS3Store: %v", err)
+		}
+		if err := store.Ping(context.Background()); err != nil {
+			t.Fatalf("Ping: %v", err)
+		}
+		return store
+	}, srv.Client())
+}
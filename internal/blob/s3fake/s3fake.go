@@ -0,0 +1,294 @@
+// Package s3fake es un servidor S3 en memoria, al estilo de MinIO, para
+// probar el almacén S3 de blob sin red ni servicios externos:
+//
+//	fake := s3fake.New("us-east-1", "minio", "minio-secret")
+//	fake.CreateBucket("attachments")
+//	srv := httptest.NewServer(fake)
+//	store, _ := blob.NewS3Store(blob.S3Config{Endpoint: srv.URL, PathStyle: true, ...})
+//
+// Solo admite peticiones con el bucket en la ruta y las operaciones que usa
+// blob (PUT, GET, HEAD y DELETE de objetos; PUT, HEAD y ListObjectsV2 de
+// buckets), pero comprueba las firmas SigV4, de cabecera y de URL, igual que S3.
+package s3fake
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/blob/sigv4"
+)
+
+// Server es el servidor falso; implementa http.Handler.
+type Server struct {
+	region    string
+	accessKey string
+	secretKey string
+
+	// MaxKeys es el tamaño de página de ListObjectsV2 (S3 usa 1000)
+	MaxKeys int
+
+	mu      sync.Mutex
+	buckets map[string]map[string]*object
+
+	// Now es el reloj con el que se comprueban las firmas (para probar caducidades)
+	Now func() time.Time
+}
+
+type object struct {
+	data        []byte
+	contentType string
+	etag        string
+	modTime     time.Time
+}
+
+// New crea el servidor con una sola clave de acceso y sin buckets.
+func New(region, accessKey, secretKey string) *Server {
+	return &Server{
+		region:    region,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		buckets:   map[string]map[string]*object{},
+		MaxKeys:   1000,
+		Now:       time.Now,
+	}
+}
+
+// CreateBucket crea el bucket si no existe.
+func (s *Server) CreateBucket(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.buckets[name] == nil {
+		s.buckets[name] = map[string]*object{}
+	}
+}
+
+// Keys devuelve las claves del bucket, ordenadas.
+func (s *Server) Keys(bucket string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.keys(bucket)
+}
+
+// keys son las claves ordenadas del bucket (con el mutex tomado)
+func (s *Server) keys(bucket string) []string {
+	keys := make([]string, 0, len(s.buckets[bucket]))
+	for key := range s.buckets[bucket] {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := sigv4.Verify(r, s.region, "s3", s.secret, s.Now()); err != nil {
+		switch {
+		case errors.Is(err, sigv4.ErrUnknownAccessKey):
+			writeError(w, r, http.StatusForbidden, "InvalidAccessKeyId", "The AWS Access Key Id you provided does not exist in our records.")
+		case errors.Is(err, sigv4.ErrSignatureMismatch):
+			writeError(w, r, http.StatusForbidden, "SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided.")
+		case errors.Is(err, sigv4.ErrExpired):
+			writeError(w, r, http.StatusForbidden, "AccessDenied", "Request has expired")
+		default:
+			writeError(w, r, http.StatusForbidden, "AccessDenied", err.Error())
+		}
+		return
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	switch {
+	case bucket == "":
+		writeError(w, r, http.StatusNotImplemented, "NotImplemented", "Listing buckets is not supported")
+	case key == "":
+		s.serveBucket(w, r, bucket)
+	default:
+		s.serveObject(w, r, bucket, key)
+	}
+}
+
+func (s *Server) serveBucket(w http.ResponseWriter, r *http.Request, bucket string) {
+	s.mu.Lock()
+	_, exists := s.buckets[bucket]
+	s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		if exists {
+			writeError(w, r, http.StatusConflict, "BucketAlreadyOwnedByYou", "Your previous request to create the named bucket succeeded and you already own it.")
+			return
+		}
+		s.CreateBucket(bucket)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodHead:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		if r.URL.Query().Get("list-type") != "2" {
+			writeError(w, r, http.StatusNotImplemented, "NotImplemented", "Only ListObjectsV2 is supported")
+			return
+		}
+		if !exists {
+			writeError(w, r, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+			return
+		}
+		s.list(w, r, bucket)
+	default:
+		writeError(w, r, http.StatusNotImplemented, "NotImplemented", "Only PUT, HEAD and GET (ListObjectsV2) are supported on buckets")
+	}
+}
+
+// list responde a ListObjectsV2. El token de continuación es la última
+// clave devuelta: la siguiente página empieza después de ella.
+func (s *Server) list(w http.ResponseWriter, r *http.Request, bucket string) {
+	query := r.URL.Query()
+	prefix, after := query.Get("prefix"), query.Get("continuation-token")
+
+	type content struct {
+		Key          string
+		Size         int
+		ETag         string
+		LastModified string
+	}
+	result := struct {
+		XMLName               xml.Name `xml:"ListBucketResult"`
+		Name                  string
+		Prefix                string
+		KeyCount              int
+		MaxKeys               int
+		IsTruncated           bool
+		Contents              []content
+		NextContinuationToken string `xml:",omitempty"`
+	}{Name: bucket, Prefix: prefix, MaxKeys: s.MaxKeys}
+
+	s.mu.Lock()
+	for _, key := range s.keys(bucket) {
+		if !strings.HasPrefix(key, prefix) || key <= after {
+			continue
+		}
+		if len(result.Contents) == s.MaxKeys {
+			result.IsTruncated = true
+			result.NextContinuationToken = result.Contents[len(result.Contents)-1].Key
+			break
+		}
+		obj := s.buckets[bucket][key]
+		result.Contents = append(result.Contents, content{
+			Key:          key,
+			Size:         len(obj.data),
+			ETag:         obj.etag,
+			LastModified: obj.modTime.Format(time.RFC3339),
+		})
+	}
+	s.mu.Unlock()
+	result.KeyCount = len(result.Contents)
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	xml.NewEncoder(w).Encode(result)
+}
+
+func (s *Server) serveObject(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "IncompleteBody", "You did not provide the number of bytes specified by the Content-Length HTTP header.")
+			return
+		}
+		if hash := r.Header.Get("X-Amz-Content-Sha256"); hash != sigv4.UnsignedPayload {
+			if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != hash {
+				writeError(w, r, http.StatusBadRequest, "XAmzContentSHA256Mismatch", "The provided 'x-amz-content-sha256' header does not match what was computed.")
+				return
+			}
+		}
+		sum := md5.Sum(data)
+		obj := &object{
+			data:        data,
+			contentType: r.Header.Get("Content-Type"),
+			etag:        `"` + hex.EncodeToString(sum[:]) + `"`,
+			modTime:     s.Now().UTC().Truncate(time.Second),
+		}
+		if obj.contentType == "" {
+			obj.contentType = "binary/octet-stream"
+		}
+
+		s.mu.Lock()
+		objects, ok := s.buckets[bucket]
+		if ok {
+			objects[key] = obj
+		}
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, r, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+			return
+		}
+		w.Header().Set("ETag", obj.etag)
+		w.WriteHeader(http.StatusOK)
+
+	case http.MethodGet, http.MethodHead:
+		s.mu.Lock()
+		objects, ok := s.buckets[bucket]
+		obj := objects[key]
+		s.mu.Unlock()
+		switch {
+		case !ok:
+			writeError(w, r, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+			return
+		case obj == nil:
+			writeError(w, r, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.data)))
+		w.Header().Set("ETag", obj.etag)
+		w.Header().Set("Last-Modified", obj.modTime.Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(obj.data)
+		}
+
+	case http.MethodDelete:
+		s.mu.Lock()
+		objects, ok := s.buckets[bucket]
+		delete(objects, key)
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, r, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, r, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+	}
+}
+
+func (s *Server) secret(accessKey string) (string, bool) {
+	return s.secretKey, accessKey == s.accessKey
+}
+
+// writeError responde con el XML de error de S3 (HEAD, sin cuerpo)
+func writeError(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	if r.Method == http.MethodHead {
+		return
+	}
+	xml.NewEncoder(w).Encode(struct {
+		XMLName  xml.Name `xml:"Error"`
+		Code     string
+		Message  string
+		Resource string
+	}{Code: code, Message: message, Resource: r.URL.Path})
+}
@@ -0,0 +1,66 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrInfected lo devuelve Scan si el antivirus detecta una amenaza.
+var ErrInfected = errors.New("file is infected")
+
+// Scanner revisa el contenido de un fichero antes de guardarlo (p. ej. con
+// clamd o un servicio externo). Un error es un fallo del antivirus, no una
+// amenaza: las amenazas se informan en Verdict.
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (Verdict, error)
+}
+
+// ScannerFunc adapta una función a Scanner.
+type ScannerFunc func(ctx context.Context, r io.Reader) (Verdict, error)
+
+func (f ScannerFunc) Scan(ctx context.Context, r io.Reader) (Verdict, error) {
+	return f(ctx, r)
+}
+
+// Verdict es el resultado de un análisis.
+type Verdict struct {
+	Infected bool
+	Threat   string // Nombre de la amenaza, si la hay
+	Engine   string // Antivirus que hizo el análisis, para los metadatos
+}
+
+// Antivirus de los ficheros subidos. Se registra al arrancar con SetScanner;
+// sin él los adjuntos se guardan como no analizados.
+var (
+	scannerMu sync.RWMutex
+	scanner   Scanner
+)
+
+// SetScanner registra el antivirus (nil lo quita).
+func SetScanner(s Scanner) {
+	scannerMu.Lock()
+	defer scannerMu.Unlock()
+	scanner = s
+}
+
+// Scan analiza el fichero con el antivirus registrado. Sin antivirus devuelve
+// false y ningún veredicto; con una amenaza devuelve el veredicto y ErrInfected.
+func Scan(ctx context.Context, s *Spooled) (Verdict, bool, error) {
+	scannerMu.RLock()
+	current := scanner
+	scannerMu.RUnlock()
+	if current == nil {
+		return Verdict{}, false, nil
+	}
+
+	verdict, err := current.Scan(ctx, s.Reader())
+	if err != nil {
+		return Verdict{}, false, err
+	}
+	if verdict.Infected {
+		return verdict, true, ErrInfected
+	}
+	return verdict, true, nil
+}
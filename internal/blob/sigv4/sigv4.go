@@ -0,0 +1,251 @@
+// Package sigv4 firma y verifica peticiones con AWS Signature Version 4, el
+// esquema de S3 y de los servicios compatibles (MinIO, Ceph, R2...). Lo usan
+// el almacén S3 de blob para firmar y el falso de s3fake para verificar.
+package sigv4
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Algorithm es el único algoritmo de firma admitido.
+const Algorithm = "AWS4-HMAC-SHA256"
+
+// UnsignedPayload firma la petición sin el hash del cuerpo (S3 lo admite y
+// evita leer el cuerpo dos veces).
+const UnsignedPayload = "UNSIGNED-PAYLOAD"
+
+// Formatos de fecha de la firma
+const (
+	timeFormat = "20060102T150405Z"
+	dateFormat = "20060102"
+)
+
+// Errores de Verify
+var (
+	ErrMissingAuth       = errors.New("request is not signed")
+	ErrMalformedAuth     = errors.New("malformed request signature")
+	ErrUnknownAccessKey  = errors.New("unknown access key")
+	ErrSignatureMismatch = errors.New("request signature does not match")
+	ErrExpired           = errors.New("request has expired")
+)
+
+// Credentials son las credenciales y el ámbito de la firma.
+type Credentials struct {
+	AccessKey string
+	SecretKey string
+	Region    string
+	Service   string // "s3"
+}
+
+// Sign firma la petición en la cabecera Authorization. payloadHash es el
+// SHA-256 del cuerpo en hexadecimal o UnsignedPayload.
+func (c Credentials) Sign(r *http.Request, payloadHash string, t time.Time) {
+	t = t.UTC()
+	r.Header.Set("X-Amz-Date", t.Format(timeFormat))
+	r.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signed := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	signature := c.signature(r, r.URL.Query(), signed, payloadHash, t)
+	r.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		Algorithm, c.AccessKey, c.scope(t), strings.Join(signed, ";"), signature))
+}
+
+// Presign firma la petición en la URL (solo la cabecera host) para que sea
+// válida durante ttl sin más credenciales.
+func (c Credentials) Presign(r *http.Request, ttl time.Duration, t time.Time) {
+	t = t.UTC()
+	query := r.URL.Query()
+	query.Set("X-Amz-Algorithm", Algorithm)
+	query.Set("X-Amz-Credential", c.AccessKey+"/"+c.scope(t))
+	query.Set("X-Amz-Date", t.Format(timeFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(ttl.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	signature := c.signature(r, query, []string{"host"}, UnsignedPayload, t)
+	query.Set("X-Amz-Signature", signature)
+	r.URL.RawQuery = canonicalQuery(query)
+}
+
+// Verify comprueba la firma de una petición recibida, en la cabecera o en la
+// URL. secretKey devuelve el secreto de una clave de acceso (false si no existe).
+func Verify(r *http.Request, region, service string, secretKey func(accessKey string) (string, bool), now time.Time) error {
+	query := r.URL.Query()
+	if query.Get("X-Amz-Signature") != "" {
+		return verifyPresigned(r, query, region, service, secretKey, now)
+	}
+
+	auth := r.Header.Get("Authorization")
+	if auth == "" {
+		return ErrMissingAuth
+	}
+	fields, ok := strings.CutPrefix(auth, Algorithm+" ")
+	if !ok {
+		return ErrMalformedAuth
+	}
+	params := map[string]string{}
+	for _, field := range strings.Split(fields, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+		params[name] = value
+	}
+	t, err := time.Parse(timeFormat, r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return ErrMalformedAuth
+	}
+	creds, err := credentialsFor(params["Credential"], t, region, service, secretKey)
+	if err != nil {
+		return err
+	}
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash == "" {
+		return ErrMalformedAuth
+	}
+	// Como S3, una firma de cabecera vale 15 minutos alrededor de su fecha
+	if now.Sub(t).Abs() > 15*time.Minute {
+		return ErrExpired
+	}
+
+	signature := creds.signature(r, query, strings.Split(params["SignedHeaders"], ";"), payloadHash, t)
+	if !hmac.Equal([]byte(signature), []byte(params["Signature"])) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+func verifyPresigned(r *http.Request, query url.Values, region, service string, secretKey func(string) (string, bool), now time.Time) error {
+	if query.Get("X-Amz-Algorithm") != Algorithm {
+		return ErrMalformedAuth
+	}
+	t, err := time.Parse(timeFormat, query.Get("X-Amz-Date"))
+	if err != nil {
+		return ErrMalformedAuth
+	}
+	expires, err := strconv.Atoi(query.Get("X-Amz-Expires"))
+	if err != nil || expires <= 0 {
+		return ErrMalformedAuth
+	}
+	creds, err := credentialsFor(query.Get("X-Amz-Credential"), t, region, service, secretKey)
+	if err != nil {
+		return err
+	}
+	if now.After(t.Add(time.Duration(expires) * time.Second)) {
+		return ErrExpired
+	}
+
+	given := query.Get("X-Amz-Signature")
+	query.Del("X-Amz-Signature")
+	signature := creds.signature(r, query, strings.Split(query.Get("X-Amz-SignedHeaders"), ";"), UnsignedPayload, t)
+	if !hmac.Equal([]byte(signature), []byte(given)) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+// credentialsFor comprueba el ámbito de la credencial ("clave/fecha/región/servicio/aws4_request")
+func credentialsFor(credential string, t time.Time, region, service string, secretKey func(string) (string, bool)) (Credentials, error) {
+	parts := strings.Split(credential, "/")
+	if len(parts) != 5 || parts[1] != t.Format(dateFormat) || parts[2] != region || parts[3] != service || parts[4] != "aws4_request" {
+		return Credentials{}, ErrMalformedAuth
+	}
+	secret, ok := secretKey(parts[0])
+	if !ok {
+		return Credentials{}, ErrUnknownAccessKey
+	}
+	return Credentials{AccessKey: parts[0], SecretKey: secret, Region: region, Service: service}, nil
+}
+
+func (c Credentials) scope(t time.Time) string {
+	return t.Format(dateFormat) + "/" + c.Region + "/" + c.Service + "/aws4_request"
+}
+
+// signature calcula la firma de la petición canónica
+func (c Credentials) signature(r *http.Request, query url.Values, signedHeaders []string, payloadHash string, t time.Time) string {
+	headers := make([]string, 0, len(signedHeaders))
+	for _, name := range signedHeaders {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+			if value == "" {
+				value = r.URL.Host
+			}
+		}
+		headers = append(headers, name+":"+strings.TrimSpace(value)+"\n")
+	}
+
+	canonical := strings.Join([]string{
+		r.Method,
+		canonicalURI(r.URL.Path),
+		canonicalQuery(query),
+		strings.Join(headers, ""),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+	digest := sha256.Sum256([]byte(canonical))
+	stringToSign := Algorithm + "\n" + t.Format(timeFormat) + "\n" + c.scope(t) + "\n" + hex.EncodeToString(digest[:])
+
+	key := hmacSHA256([]byte("AWS4"+c.SecretKey), t.Format(dateFormat))
+	key = hmacSHA256(key, c.Region)
+	key = hmacSHA256(key, c.Service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalURI codifica cada segmento de la ruta (S3 no la normaliza)
+func canonicalURI(path string) string {
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = encode(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// canonicalQuery ordena los parámetros por nombre y valor y los codifica
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var pairs []string
+	for _, name := range names {
+		values := slices.Clone(query[name])
+		slices.Sort(values)
+		for _, value := range values {
+			pairs = append(pairs, encode(name)+"="+encode(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// encode es la codificación de URI de SigV4: todo salvo A-Z a-z 0-9 - _ . ~
+func encode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
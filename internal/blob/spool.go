@@ -0,0 +1,70 @@
+package blob
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// ErrTooLarge lo devuelve Spool si el contenido supera el máximo.
+var ErrTooLarge = errors.New("file is too large")
+
+// Spooled es un fichero subido, copiado a un temporal mientras se calcula su
+// hash. Se puede leer varias veces (p. ej. para el antivirus y para el
+// almacén) con Reader. Close borra el temporal.
+type Spooled struct {
+	Hash        string // SHA-256 del contenido en hexadecimal
+	Size        int64
+	ContentType string // Detectado a partir del contenido, no del nombre
+
+	file *os.File
+}
+
+// Spool copia r a un fichero temporal calculando su hash y su tipo. Con más
+// de max bytes devuelve ErrTooLarge (max <= 0 es ilimitado).
+func Spool(r io.Reader, max int64) (*Spooled, error) {
+	file, err := os.CreateTemp("", "go-vet-api-upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload file: %w", err)
+	}
+	s := &Spooled{file: file}
+
+	hash := sha256.New()
+	src := r
+	if max > 0 {
+		src = io.LimitReader(r, max+1)
+	}
+	s.Size, err = io.Copy(io.MultiWriter(file, hash), src)
+	if err != nil {
+		s.Close()
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if max > 0 && s.Size > max {
+		s.Close()
+		return nil, ErrTooLarge
+	}
+	s.Hash = hex.EncodeToString(hash.Sum(nil))
+
+	mtype, err := mimetype.DetectReader(s.Reader())
+	if err != nil {
+		s.Close()
+		return nil, fmt.Errorf("failed to detect upload type: %w", err)
+	}
+	s.ContentType = mtype.String()
+	return s, nil
+}
+
+// Reader lee el contenido desde el principio. Cada lector es independiente.
+func (s *Spooled) Reader() io.Reader {
+	return io.NewSectionReader(s.file, 0, s.Size)
+}
+
+// Close borra el fichero temporal.
+func (s *Spooled) Close() error {
+	return errors.Join(s.file.Close(), os.Remove(s.file.Name()))
+}
@@ -0,0 +1,236 @@
+// Package branding valida y redimensiona las imágenes de marca de las
+// clínicas (logo, favicon y cabecera de correo) y guarda sus variantes en el
+// almacén de ficheros (ver blob.Store).
+package branding
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"golang.org/x/image/draw"
+
+	// Decodificadores registrados en image.Decode
+	_ "image/gif"
+
+	_ "golang.org/x/image/webp"
+)
+
+// Errores de las imágenes subidas
+var (
+	ErrUnsupportedImage = errors.New("image must be PNG, JPEG, GIF or WebP")
+	ErrImageTooLarge    = errors.New("image file is too large")
+	ErrImageDimensions  = errors.New("image dimensions are out of range")
+	ErrUnreadableImage  = errors.New("image could not be decoded")
+)
+
+// MaxPixelsPerSide limita el original: una imagen pequeña en bytes puede
+// ocupar mucha memoria al decodificarla.
+const MaxPixelsPerSide = 4096
+
+// acceptedTypes son los tipos detectados por contenido que se aceptan. SVG
+// no: no se puede redimensionar y puede llevar scripts.
+var acceptedTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp"}
+
+// size es la caja en la que se encaja una variante
+type size struct {
+	name          string
+	width, height int
+}
+
+// spec son los límites y las variantes de un tipo de imagen
+type spec struct {
+	maxBytes  int64
+	minWidth  int
+	minHeight int
+	square    bool // Se recorta al cuadrado central
+	upscale   bool // Las variantes tienen el tamaño exacto aunque el original sea menor
+	sizes     []size
+}
+
+var specs = map[string]spec{
+	models.AssetLogo: {
+		maxBytes: 2 << 20, minWidth: 64, minHeight: 64,
+		sizes: []size{{"sm", 128, 128}, {"md", 256, 256}, {"lg", 512, 512}},
+	},
+	models.AssetFavicon: {
+		maxBytes: 1 << 20, minWidth: 32, minHeight: 32, square: true, upscale: true,
+		sizes: []size{{"16", 16, 16}, {"32", 32, 32}, {"180", 180, 180}, {"192", 192, 192}, {"512", 512, 512}},
+	},
+	models.AssetEmailHeader: {
+		maxBytes: 2 << 20, minWidth: 300, minHeight: 32,
+		sizes: []size{{"1x", 600, 300}, {"2x", 1200, 600}},
+	},
+}
+
+// MaxBytes es el tamaño máximo del original de un tipo de imagen (0 si no existe).
+func MaxBytes(kind string) int64 {
+	return specs[kind].maxBytes
+}
+
+// Variant es una variante ya codificada y lista para guardar.
+type Variant struct {
+	models.AssetVariant
+	Data []byte
+}
+
+// Image es una imagen subida ya procesada.
+type Image struct {
+	Hash        string
+	ContentType string
+	Variants    []Variant
+}
+
+// Size es el total de bytes de las variantes.
+func (img *Image) Size() int64 {
+	var total int64
+	for _, v := range img.Variants {
+		total += v.Size
+	}
+	return total
+}
+
+// Asset es el registro de la imagen para la clínica.
+func (img *Image) Asset() models.BrandAsset {
+	variants := make([]models.AssetVariant, len(img.Variants))
+	for i, v := range img.Variants {
+		variants[i] = v.AssetVariant
+	}
+	return models.BrandAsset{Hash: img.Hash, ContentType: img.ContentType, Variants: variants, Size: img.Size()}
+}
+
+// Hash es el SHA-256 (hex) del original; dos subidas iguales tienen el mismo.
+func Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Read lee el original de un tipo de imagen respetando su tamaño máximo.
+func Read(kind string, r io.Reader) ([]byte, error) {
+	limit := MaxBytes(kind)
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return nil, ErrImageTooLarge
+	}
+	return data, nil
+}
+
+// Decode detecta el tipo por el contenido (no por el nombre ni el
+// Content-Type del cliente), comprueba tamaño y dimensiones según el tipo de
+// imagen kind y la decodifica. Devuelve también el formato (png, jpeg, gif o webp).
+func Decode(kind string, data []byte) (image.Image, string, error) {
+	spec, ok := specs[kind]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown branding asset %q", kind)
+	}
+	if int64(len(data)) > spec.maxBytes {
+		return nil, "", ErrImageTooLarge
+	}
+	if !isAccepted(mimetype.Detect(data)) {
+		return nil, "", ErrUnsupportedImage
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", ErrUnreadableImage
+	}
+	if config.Width < spec.minWidth || config.Height < spec.minHeight ||
+		config.Width > MaxPixelsPerSide || config.Height > MaxPixelsPerSide {
+		return nil, "", ErrImageDimensions
+	}
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", ErrUnreadableImage
+	}
+	return img, format, nil
+}
+
+// Process decodifica la imagen (ver Decode) y genera las variantes del tipo
+// kind. Los originales JPEG dan variantes JPEG y el resto PNG, que conserva
+// la transparencia.
+func Process(kind string, data []byte) (*Image, error) {
+	src, format, err := Decode(kind, data)
+	if err != nil {
+		return nil, err
+	}
+	spec := specs[kind]
+
+	crop := src.Bounds()
+	if spec.square {
+		crop = centerSquare(crop)
+	}
+
+	hash := Hash(data)
+	img := &Image{Hash: hash, ContentType: "image/png"}
+	ext := ".png"
+	if format == "jpeg" {
+		img.ContentType, ext = "image/jpeg", ".jpg"
+	}
+
+	for _, box := range spec.sizes {
+		width, height := fit(crop.Dx(), crop.Dy(), box.width, box.height, spec.upscale)
+		dst := image.NewRGBA(image.Rect(0, 0, width, height))
+		draw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, draw.Src, nil)
+
+		var buf bytes.Buffer
+		if format == "jpeg" {
+			err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+		} else {
+			err = png.Encode(&buf, dst)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s variant: %w", box.name, err)
+		}
+
+		img.Variants = append(img.Variants, Variant{
+			AssetVariant: models.AssetVariant{
+				Name:   box.name,
+				Width:  width,
+				Height: height,
+				Size:   int64(buf.Len()),
+				File:   fmt.Sprintf("%s-%s-%s%s", kind, box.name, hash[:16], ext),
+			},
+			Data: buf.Bytes(),
+		})
+	}
+	return img, nil
+}
+
+func isAccepted(mtype *mimetype.MIME) bool {
+	for _, accepted := range acceptedTypes {
+		if mtype.Is(accepted) {
+			return true
+		}
+	}
+	return false
+}
+
+// centerSquare es el cuadrado más grande centrado en r
+func centerSquare(r image.Rectangle) image.Rectangle {
+	side := min(r.Dx(), r.Dy())
+	x := r.Min.X + (r.Dx()-side)/2
+	y := r.Min.Y + (r.Dy()-side)/2
+	return image.Rect(x, y, x+side, y+side)
+}
+
+// fit encaja width×height en la caja sin deformarla; sin upscale nunca
+// agranda el original.
+func fit(width, height, boxWidth, boxHeight int, upscale bool) (int, int) {
+	scale := math.Min(float64(boxWidth)/float64(width), float64(boxHeight)/float64(height))
+	if !upscale {
+		scale = math.Min(scale, 1)
+	}
+	return max(1, int(math.Round(float64(width)*scale))), max(1, int(math.Round(float64(height)*scale)))
+}
@@ -0,0 +1,42 @@
+package branding
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/zabaletac3/go-vet-api/internal/blob"
+)
+
+// Key es la clave de un fichero de la clínica en el almacén de ficheros. Va
+// bajo blob.ClinicPrefix, así que se borra con el resto al purgar la clínica.
+func Key(clinicID, file string) string {
+	return blob.ClinicPrefix(clinicID) + "branding/" + file
+}
+
+// Save guarda las variantes de la clínica; si alguna falla se borran las ya
+// guardadas.
+func Save(ctx context.Context, store blob.Store, clinicID, contentType string, variants []Variant) error {
+	for i, v := range variants {
+		if err := store.Put(ctx, Key(clinicID, v.File), bytes.NewReader(v.Data), v.Size, contentType); err != nil {
+			files := make([]string, i)
+			for j, saved := range variants[:i] {
+				files[j] = saved.File
+			}
+			return errors.Join(fmt.Errorf("failed to save branding image: %w", err), Remove(ctx, store, clinicID, files...))
+		}
+	}
+	return nil
+}
+
+// Remove borra ficheros de la clínica; los que ya no existen no son error.
+func Remove(ctx context.Context, store blob.Store, clinicID string, files ...string) error {
+	var errs []error
+	for _, file := range files {
+		if err := store.Delete(ctx, Key(clinicID, file)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -11,8 +12,93 @@ import (
 type Config struct {
 	Port     int    `envconfig:"PORT" default:"8080"`
 	Env      string `envconfig:"ENV" default:"development"`
-	MongoURI string `envconfig:"MONGO_URI" required:"true"`
-	DBName   string `envconfig:"DB_NAME" required:"true"`
+	MongoURI string `envconfig:"MONGO_URI"` // Obligatorio con STORAGE_DRIVER=mongo
+	DBName   string `envconfig:"DB_NAME"`   // Obligatorio con STORAGE_DRIVER=mongo
+
+	// StorageDriver elige el backend de datos: "mongo" o "memory" (sin base de datos, para demos).
+	StorageDriver string `envconfig:"STORAGE_DRIVER" default:"mongo"`
+
+	// BcryptCost es el coste de bcrypt para los hashes de contraseña nuevos.
+	BcryptCost int `envconfig:"BCRYPT_COST" default:"10"`
+
+	// CursorSecret firma los cursores de paginación. Si está vacío se genera uno
+	// aleatorio al arrancar (los cursores no sobreviven a reinicios ni sirven entre réplicas).
+	CursorSecret string `envconfig:"CURSOR_SECRET"`
+
+	// DefaultLocale es el idioma de los mensajes de error (es, en o pt) cuando ni
+	// el cliente (Accept-Language) ni la clínica de la petición indican uno.
+	DefaultLocale string `envconfig:"DEFAULT_LOCALE" default:"en"`
+
+	// AutoMigrate aplica las migraciones pendientes al arrancar la API.
+	AutoMigrate bool `envconfig:"AUTO_MIGRATE" default:"true"`
+
+	// Retención de la papelera de clínicas (0 desactiva la purga).
+	ClinicRetentionDays int           `envconfig:"CLINIC_RETENTION_DAYS" default:"30"`
+	ClinicPurgeInterval time.Duration `envconfig:"CLINIC_PURGE_INTERVAL" default:"1h"`
+
+	// ImportPollInterval es cada cuánto se buscan importaciones en cola (0 las desactiva).
+	ImportPollInterval time.Duration `envconfig:"IMPORT_POLL_INTERVAL" default:"5s"`
+
+	// Exportación e importación de clínicas completas: directorio de los
+	// archivos (vacío: el temporal del sistema; compartido si hay varias
+	// réplicas) y cada cuánto se buscan trabajos en cola (0 los desactiva).
+	TransferDir          string        `envconfig:"TRANSFER_DIR"`
+	TransferPollInterval time.Duration `envconfig:"TRANSFER_POLL_INTERVAL" default:"5s"`
+
+	// Ficheros de las clínicas (adjuntos e imágenes de marca). BlobDriver es "local" (en BlobDir;
+	// vacío: el temporal del sistema) o "s3" (cualquier servicio compatible,
+	// como MinIO; con S3PathStyle el bucket va en la ruta y no en el host).
+	// BlobSigningSecret firma las URLs de descarga del driver local (vacío:
+	// uno aleatorio y las URLs caducan al reiniciar) y BlobPublicURL es la
+	// URL base con la que se construyen.
+	BlobDriver        string `envconfig:"BLOB_DRIVER" default:"local"`
+	BlobDir           string `envconfig:"BLOB_DIR"`
+	BlobSigningSecret string `envconfig:"BLOB_SIGNING_SECRET"`
+	BlobPublicURL     string `envconfig:"BLOB_PUBLIC_URL"`
+	S3Endpoint        string `envconfig:"S3_ENDPOINT"`
+	S3Region          string `envconfig:"S3_REGION" default:"us-east-1"`
+	S3Bucket          string `envconfig:"S3_BUCKET"`
+	S3AccessKey       string `envconfig:"S3_ACCESS_KEY"`
+	S3SecretKey       string `envconfig:"S3_SECRET_KEY"`
+	S3PathStyle       bool   `envconfig:"S3_PATH_STYLE" default:"true"`
+
+	// Contraste de las paletas (WCAG 2.1) de primary y secondary con el
+	// fondo: PaletteContrast es "off", "warn" (se guardan con avisos) o
+	// "error" (se rechazan); PaletteContrastLevel es AA-large (3:1), AA (4.5:1)
+	// o AAA (7:1). Las paletas generadas siempre llegan al nivel; la paleta
+	// por defecto solo llega a AA-large.
+	PaletteContrast      string `envconfig:"PALETTE_CONTRAST" default:"warn"`
+	PaletteContrastLevel string `envconfig:"PALETTE_CONTRAST_LEVEL" default:"AA-large"`
+
+	// Trazas distribuidas (OpenTelemetry). TracingExporter es "otlp" (OTLP por
+	// HTTP a TracingEndpoint, p. ej. http://localhost:4318), "stdout" (para
+	// desarrollo y pruebas) o "none". TracingSampleRatio es la fracción de
+	// trazas nuevas que se guardan; las que llegan con traceparent siguen la
+	// decisión del llamante.
+	TracingExporter    string  `envconfig:"TRACING_EXPORTER" default:"none"`
+	TracingEndpoint    string  `envconfig:"TRACING_ENDPOINT"`
+	TracingServiceName string  `envconfig:"TRACING_SERVICE_NAME" default:"go-vet-api"`
+	TracingSampleRatio float64 `envconfig:"TRACING_SAMPLE_RATIO" default:"1"`
+
+	// Sondas de salud: tiempo máximo de cada check de la readiness y espacio
+	// libre mínimo en el directorio de traspasos (en MiB).
+	HealthCheckTimeout  time.Duration `envconfig:"HEALTH_CHECK_TIMEOUT" default:"2s"`
+	HealthMinFreeDiskMB uint64        `envconfig:"HEALTH_MIN_FREE_DISK_MB" default:"512"`
+
+	// ShutdownDrainDelay es cuánto falla la readiness antes de cerrar el
+	// servidor al apagarse (0 lo cierra en el acto).
+	ShutdownDrainDelay time.Duration `envconfig:"SHUTDOWN_DRAIN_DELAY" default:"5s"`
+
+	// Limitación de peticiones con cubos de tokens ("peticiones/duración").
+	// RateLimitStore es "memory" (una sola instancia) o "mongo" (cupos
+	// compartidos entre réplicas); vacío usa el de STORAGE_DRIVER. Con
+	// RateLimitTrustProxy la IP del cliente se toma de X-Forwarded-For.
+	RateLimitEnabled    bool   `envconfig:"RATE_LIMIT_ENABLED" default:"true"`
+	RateLimitStore      string `envconfig:"RATE_LIMIT_STORE"`
+	RateLimitPrincipal  string `envconfig:"RATE_LIMIT_PRINCIPAL" default:"600/1m"` // Por usuario (o IP si es anónimo)
+	RateLimitClinic     string `envconfig:"RATE_LIMIT_CLINIC" default:"3000/1m"`   // Por clínica, entre todos sus usuarios
+	RateLimitLogin      string `envconfig:"RATE_LIMIT_LOGIN" default:"10/15m"`     // Intentos de inicio de sesión por IP
+	RateLimitTrustProxy bool   `envconfig:"RATE_LIMIT_TRUST_PROXY" default:"false"`
 }
 
 // Load carga la configuración desde el archivo .env y el entorno.
@@ -29,5 +115,36 @@ func Load() *Config {
 		log.Fatalf("Fallo al procesar la configuración: %v", err)
 	}
 
+	switch cfg.StorageDriver {
+	case "mongo":
+		if cfg.MongoURI == "" || cfg.DBName == "" {
+			log.Fatalf("Fallo al procesar la configuración: MONGO_URI y DB_NAME son obligatorios con STORAGE_DRIVER=mongo")
+		}
+	case "memory":
+	default:
+		log.Fatalf("Fallo al procesar la configuración: STORAGE_DRIVER desconocido %q (use mongo o memory)", cfg.StorageDriver)
+	}
+
+	switch cfg.RateLimitStore {
+	case "":
+		cfg.RateLimitStore = cfg.StorageDriver
+	case "memory", "mongo":
+		if cfg.RateLimitStore == "mongo" && cfg.StorageDriver != "mongo" {
+			log.Fatalf("Fallo al procesar la configuración: RATE_LIMIT_STORE=mongo requiere STORAGE_DRIVER=mongo")
+		}
+	default:
+		log.Fatalf("Fallo al procesar la configuración: RATE_LIMIT_STORE desconocido %q (use mongo o memory)", cfg.RateLimitStore)
+	}
+
+	switch cfg.BlobDriver {
+	case "local":
+	case "s3":
+		if cfg.S3Endpoint == "" || cfg.S3Bucket == "" || cfg.S3AccessKey == "" || cfg.S3SecretKey == "" {
+			log.Fatalf("Fallo al procesar la configuración: S3_ENDPOINT, S3_BUCKET, S3_ACCESS_KEY y S3_SECRET_KEY son obligatorios con BLOB_DRIVER=s3")
+		}
+	default:
+		log.Fatalf("Fallo al procesar la configuración: BLOB_DRIVER desconocido %q (use local o s3)", cfg.BlobDriver)
+	}
+
 	return &cfg
 }
\ No newline at end of file
@@ -0,0 +1,269 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	migrationsCollection = "schema_migrations"
+	migrationsLockID     = "migrations"
+	migrationsLockTTL    = 10 * time.Minute
+)
+
+// ErrMigrationLocked indica que otra instancia está aplicando migraciones.
+var ErrMigrationLocked = errors.New("migrations are locked by another process")
+
+// Migration es un cambio de esquema versionado. Las versiones se aplican en
+// orden ascendente y nunca deben reutilizarse ni reordenarse una vez publicadas.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+	Down        func(ctx context.Context, db *mongo.Database) error
+}
+
+// MigrationStatus describe el estado de una migración conocida.
+type MigrationStatus struct {
+	Version     int        `json:"version"`
+	Description string     `json:"description"`
+	Applied     bool       `json:"applied"`
+	AppliedAt   *time.Time `json:"appliedAt,omitempty"`
+}
+
+// appliedMigration es el documento que se guarda en schema_migrations.
+type appliedMigration struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"appliedAt"`
+}
+
+// Migrator aplica y revierte migraciones sobre una base de datos.
+type Migrator struct {
+	db         *mongo.Database
+	migrations []Migration
+	owner      string
+	logger     *slog.Logger
+}
+
+// NewMigrator crea un Migrator con las migraciones indicadas, ordenadas por versión.
+func NewMigrator(db *mongo.Database, logger *slog.Logger, migrations []Migration) *Migrator {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	hostname, _ := os.Hostname()
+
+	return &Migrator{
+		db:         db,
+		migrations: sorted,
+		owner:      fmt.Sprintf("%s:%d", hostname, os.Getpid()),
+		logger:     logger.With("component", "migrator"),
+	}
+}
+
+// Up aplica todas las migraciones pendientes. Devuelve cuántas se aplicaron.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	if err := m.validate(); err != nil {
+		return 0, err
+	}
+
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		m.logger.Info("Aplicando migración", "version", migration.Version, "description", migration.Description)
+		if err := migration.Up(ctx, m.db); err != nil {
+			return count, fmt.Errorf("migración %d (%s) falló: %w", migration.Version, migration.Description, err)
+		}
+
+		record := appliedMigration{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now().UTC(),
+		}
+		if _, err := m.db.Collection(migrationsCollection).InsertOne(ctx, record); err != nil {
+			return count, fmt.Errorf("no se pudo registrar la migración %d: %w", migration.Version, err)
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// Down revierte las últimas `steps` migraciones aplicadas. Devuelve cuántas se revirtieron.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if err := m.validate(); err != nil {
+		return 0, err
+	}
+
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if migration.Down == nil {
+			return count, fmt.Errorf("la migración %d (%s) no se puede revertir", migration.Version, migration.Description)
+		}
+
+		m.logger.Info("Revirtiendo migración", "version", migration.Version, "description", migration.Description)
+		if err := migration.Down(ctx, m.db); err != nil {
+			return count, fmt.Errorf("revertir la migración %d (%s) falló: %w", migration.Version, migration.Description, err)
+		}
+
+		if _, err := m.db.Collection(migrationsCollection).DeleteOne(ctx, bson.M{"_id": migration.Version}); err != nil {
+			return count, fmt.Errorf("no se pudo desregistrar la migración %d: %w", migration.Version, err)
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// Status devuelve el estado de cada migración conocida, en orden de versión.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{
+			Version:     migration.Version,
+			Description: migration.Description,
+		}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Pending indica cuántas migraciones conocidas faltan por aplicar.
+func (m *Migrator) Pending(ctx context.Context) (int, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if !status.Applied {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// validate comprueba que no haya versiones repetidas ni migraciones sin Up.
+func (m *Migrator) validate() error {
+	seen := make(map[int]bool, len(m.migrations))
+	for _, migration := range m.migrations {
+		if migration.Version <= 0 {
+			return fmt.Errorf("versión de migración inválida: %d", migration.Version)
+		}
+		if seen[migration.Version] {
+			return fmt.Errorf("versión de migración duplicada: %d", migration.Version)
+		}
+		if migration.Up == nil {
+			return fmt.Errorf("la migración %d no tiene función Up", migration.Version)
+		}
+		seen[migration.Version] = true
+	}
+	return nil
+}
+
+// applied devuelve las migraciones registradas en schema_migrations, por versión.
+func (m *Migrator) applied(ctx context.Context) (map[int]appliedMigration, error) {
+	cursor, err := m.db.Collection(migrationsCollection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("no se pudieron leer las migraciones aplicadas: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var records []appliedMigration
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("no se pudieron decodificar las migraciones aplicadas: %w", err)
+	}
+
+	applied := make(map[int]appliedMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// lock adquiere el bloqueo de migraciones (un documento en schema_migrations_lock),
+// de modo que solo una instancia migra a la vez. Un bloqueo expirado (p. ej. de un
+// proceso que murió a mitad de camino) se puede volver a tomar.
+func (m *Migrator) lock(ctx context.Context) (func(), error) {
+	locks := m.db.Collection(migrationsCollection + "_lock")
+	now := time.Now().UTC()
+
+	// El filtro solo coincide si el bloqueo no existe o expiró; si lo tiene otro
+	// proceso, el upsert intenta insertar el mismo _id y falla por clave duplicada.
+	_, err := locks.UpdateOne(ctx,
+		bson.M{"_id": migrationsLockID, "expiresAt": bson.M{"$lt": now}},
+		bson.M{"$set": bson.M{
+			"owner":     m.owner,
+			"lockedAt":  now,
+			"expiresAt": now.Add(migrationsLockTTL),
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrMigrationLocked
+		}
+		return nil, fmt.Errorf("no se pudo adquirir el bloqueo de migraciones: %w", err)
+	}
+
+	unlock := func() {
+		// Contexto propio: el bloqueo debe liberarse aunque el de la operación se haya cancelado.
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := locks.DeleteOne(ctx, bson.M{"_id": migrationsLockID, "owner": m.owner}); err != nil {
+			m.logger.Error("No se pudo liberar el bloqueo de migraciones", "error", err)
+		}
+	}
+	return unlock, nil
+}
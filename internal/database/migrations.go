@@ -0,0 +1,469 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/search"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Nombres de índices referenciados desde los repositorios (p. ej. para
+// distinguir qué campo provocó un error de clave duplicada).
+const (
+	ClinicNameIndex         = "clinics_name_unique"
+	ClinicDisplayNameIndex  = "clinics_displayName_unique"
+	UserClinicEmailIndex    = "users_clinicId_email_unique"
+	ClinicTextIndex         = "clinics_text"
+	IdempotencyTTLIndex     = "idempotency_keys_expiresAt_ttl"
+	OwnerContactsIndex      = "owners_clinicId_contacts"
+	ImportRowsIndex         = "import_rows_jobId_index_unique"
+	ImportJobsClaimIndex    = "import_jobs_status_createdAt"
+	TransferJobsClaimIndex  = "transfer_jobs_status_createdAt"
+	RateLimitTTLIndex       = "rate_limits_expiresAt_ttl"
+	AttachmentHashIndex     = "attachments_clinicId_hash"
+	AttachmentListIndex     = "attachments_clinicId_createdAt"
+	AttachmentPatientIndex  = "attachments_clinicId_patientId_createdAt"
+	TransferJobsTTLIndex    = "transfer_jobs_expiresAt_ttl"
+	TransferJobsClinicIndex = "transfer_jobs_clinicId"
+)
+
+// TransferJobsTTLGrace es cuánto espera MongoDB tras expiresAt para borrar un
+// traspaso: antes lo borra el ejecutor junto con su archivo, y el índice TTL
+// solo recoge los que se le escapen.
+const TransferJobsTTLGrace = 24 * time.Hour
+
+// ClinicTextWeights son los pesos de los campos del índice de texto de clínicas
+// (el store en memoria los usa para aproximar la relevancia).
+var ClinicTextWeights = map[string]int32{
+	"name":        10,
+	"displayName": 10,
+	"address":     2,
+	"description": 1,
+}
+
+// searchGramsIndexes son los índices multikey de la búsqueda tolerante a
+// errores; tutores y pacientes se buscan siempre dentro de una clínica.
+var searchGramsIndexes = []struct {
+	collection string
+	index      mongo.IndexModel
+}{
+	{"clinics", mongo.IndexModel{
+		Keys:    bson.D{{Key: "search.grams", Value: 1}},
+		Options: options.Index().SetName("clinics_search_grams"),
+	}},
+	{"owners", mongo.IndexModel{
+		Keys:    bson.D{{Key: "clinicId", Value: 1}, {Key: "search.grams", Value: 1}},
+		Options: options.Index().SetName("owners_clinicId_search_grams"),
+	}},
+	{"patients", mongo.IndexModel{
+		Keys:    bson.D{{Key: "clinicId", Value: 1}, {Key: "search.grams", Value: 1}},
+		Options: options.Index().SetName("patients_clinicId_search_grams"),
+	}},
+}
+
+// CaseInsensitive es la collation de los índices únicos de texto: strength 2
+// compara sin distinguir mayúsculas/minúsculas pero sí acentos.
+var CaseInsensitive = &options.Collation{Locale: "en", Strength: 2}
+
+// Migrations es la lista ordenada de migraciones del esquema. Añadir siempre
+// al final con una versión nueva; nunca modificar una migración ya publicada.
+//
+// Los índices únicos de clínicas cubren también la papelera: el nombre de una
+// clínica eliminada queda reservado hasta que se purga.
+var Migrations = []Migration{
+	{
+		Version:     1,
+		Description: "unique case-insensitive index on clinics.name",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createIndex(ctx, db.Collection("clinics"), mongo.IndexModel{
+				Keys:    bson.D{{Key: "name", Value: 1}},
+				Options: options.Index().SetName(ClinicNameIndex).SetUnique(true).SetCollation(CaseInsensitive),
+			})
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndex(ctx, db.Collection("clinics"), ClinicNameIndex)
+		},
+	},
+	{
+		Version:     2,
+		Description: "unique case-insensitive index on clinics.displayName",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createIndex(ctx, db.Collection("clinics"), mongo.IndexModel{
+				Keys:    bson.D{{Key: "displayName", Value: 1}},
+				Options: options.Index().SetName(ClinicDisplayNameIndex).SetUnique(true).SetCollation(CaseInsensitive),
+			})
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndex(ctx, db.Collection("clinics"), ClinicDisplayNameIndex)
+		},
+	},
+	{
+		Version:     3,
+		Description: "unique index on users {clinicId, email}",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createIndex(ctx, db.Collection("users"), mongo.IndexModel{
+				Keys:    bson.D{{Key: "clinicId", Value: 1}, {Key: "email", Value: 1}},
+				Options: options.Index().SetName(UserClinicEmailIndex).SetUnique(true),
+			})
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndex(ctx, db.Collection("users"), UserClinicEmailIndex)
+		},
+	},
+	{
+		Version:     4,
+		Description: "initialize clinics.version for optimistic concurrency",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("clinics").UpdateMany(ctx,
+				bson.M{"version": bson.M{"$exists": false}},
+				bson.M{"$set": bson.M{"version": int64(1)}},
+			)
+			if err != nil {
+				return fmt.Errorf("no se pudo inicializar la versión de las clínicas: %w", err)
+			}
+			return nil
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("clinics").UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"version": ""}})
+			if err != nil {
+				return fmt.Errorf("no se pudo eliminar la versión de las clínicas: %w", err)
+			}
+			return nil
+		},
+	},
+	{
+		Version:     5,
+		Description: "text index on clinics for relevance-ranked search",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			// Sin idioma: los nombres propios no se lematizan ni se descartan
+			// como stop words. La versión 3 del índice ya ignora mayúsculas y acentos.
+			weights := bson.D{}
+			keys := bson.D{}
+			for _, field := range []string{"name", "displayName", "address", "description"} {
+				keys = append(keys, bson.E{Key: field, Value: "text"})
+				weights = append(weights, bson.E{Key: field, Value: ClinicTextWeights[field]})
+			}
+			return createIndex(ctx, db.Collection("clinics"), mongo.IndexModel{
+				Keys:    keys,
+				Options: options.Index().SetName(ClinicTextIndex).SetWeights(weights).SetDefaultLanguage("none"),
+			})
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndex(ctx, db.Collection("clinics"), ClinicTextIndex)
+		},
+	},
+	{
+		Version:     6,
+		Description: "search keys and trigram indexes on clinics, owners and patients",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			err := errors.Join(
+				backfillSearchKeys(ctx, db.Collection("clinics"), (*models.Clinic).SearchKeys),
+				backfillSearchKeys(ctx, db.Collection("owners"), (*models.Owner).SearchKeys),
+				backfillSearchKeys(ctx, db.Collection("patients"), (*models.Patient).SearchKeys),
+			)
+			if err != nil {
+				return err
+			}
+			for _, idx := range searchGramsIndexes {
+				if err := createIndex(ctx, db.Collection(idx.collection), idx.index); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			for _, idx := range searchGramsIndexes {
+				coll := db.Collection(idx.collection)
+				if err := dropIndex(ctx, coll, *idx.index.Options.Name); err != nil {
+					return err
+				}
+				if _, err := coll.UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"search": ""}}); err != nil {
+					return fmt.Errorf("no se pudieron eliminar las claves de búsqueda de %s: %w", coll.Name(), err)
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     7,
+		Description: "TTL index on idempotency_keys.expiresAt",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createIndex(ctx, db.Collection("idempotency_keys"), mongo.IndexModel{
+				Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+				Options: options.Index().SetName(IdempotencyTTLIndex).SetExpireAfterSeconds(0),
+			})
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndex(ctx, db.Collection("idempotency_keys"), IdempotencyTTLIndex)
+		},
+	},
+	{
+		Version:     8,
+		Description: "owner contact keys and import job indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := backfill(ctx, db.Collection("owners"), "contacts", (*models.Owner).ContactKeys); err != nil {
+				return err
+			}
+			return errors.Join(
+				createIndex(ctx, db.Collection("owners"), mongo.IndexModel{
+					Keys:    bson.D{{Key: "clinicId", Value: 1}, {Key: "contacts", Value: 1}},
+					Options: options.Index().SetName(OwnerContactsIndex),
+				}),
+				createIndex(ctx, db.Collection("import_rows"), mongo.IndexModel{
+					Keys:    bson.D{{Key: "jobId", Value: 1}, {Key: "index", Value: 1}},
+					Options: options.Index().SetName(ImportRowsIndex).SetUnique(true),
+				}),
+				createIndex(ctx, db.Collection("import_jobs"), mongo.IndexModel{
+					Keys:    bson.D{{Key: "status", Value: 1}, {Key: "createdAt", Value: 1}},
+					Options: options.Index().SetName(ImportJobsClaimIndex),
+				}),
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			err := errors.Join(
+				dropIndex(ctx, db.Collection("owners"), OwnerContactsIndex),
+				dropIndex(ctx, db.Collection("import_rows"), ImportRowsIndex),
+				dropIndex(ctx, db.Collection("import_jobs"), ImportJobsClaimIndex),
+			)
+			if err != nil {
+				return err
+			}
+			if _, err := db.Collection("owners").UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"contacts": ""}}); err != nil {
+				return fmt.Errorf("no se pudieron eliminar las claves de contacto de owners: %w", err)
+			}
+			return nil
+		},
+	},
+	{
+		Version:     9,
+		Description: "transfer job claim index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createIndex(ctx, db.Collection("transfer_jobs"), mongo.IndexModel{
+				Keys:    bson.D{{Key: "status", Value: 1}, {Key: "createdAt", Value: 1}},
+				Options: options.Index().SetName(TransferJobsClaimIndex),
+			})
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndex(ctx, db.Collection("transfer_jobs"), TransferJobsClaimIndex)
+		},
+	},
+	{
+		Version:     10,
+		Description: "TTL index on rate_limits.expiresAt",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createIndex(ctx, db.Collection("rate_limits"), mongo.IndexModel{
+				Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+				Options: options.Index().SetName(RateLimitTTLIndex).SetExpireAfterSeconds(0),
+			})
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndex(ctx, db.Collection("rate_limits"), RateLimitTTLIndex)
+		},
+	},
+	{
+		Version:     11,
+		Description: "subscription plan on clinics and clinic_usage counters",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			// Las clínicas anteriores a los planes conservan todo lo que tenían:
+			// se les asigna el plan sin límites, no el plan por defecto
+			_, err := db.Collection("clinics").UpdateMany(ctx,
+				bson.M{"plan": bson.M{"$exists": false}},
+				bson.M{"$set": bson.M{"plan": models.PlanPremium}},
+			)
+			if err != nil {
+				return fmt.Errorf("no se pudo asignar el plan a las clínicas: %w", err)
+			}
+			return errors.Join(
+				backfillUsage(ctx, db, db.Collection("users"), models.UsageUsers),
+				backfillUsage(ctx, db, db.Collection("patients"), models.UsagePatients),
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			if err := db.Collection("clinic_usage").Drop(ctx); err != nil {
+				return fmt.Errorf("no se pudo eliminar clinic_usage: %w", err)
+			}
+			if _, err := db.Collection("clinics").UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"plan": ""}}); err != nil {
+				return fmt.Errorf("no se pudo eliminar el plan de las clínicas: %w", err)
+			}
+			return nil
+		},
+	},
+	{
+		Version:     12,
+		Description: "attachments indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			coll := db.Collection("attachments")
+			// Deduplicación (FindByHash y CountByHash) y listados por clínica o por paciente
+			return errors.Join(
+				createIndex(ctx, coll, mongo.IndexModel{
+					Keys:    bson.D{{Key: "clinicId", Value: 1}, {Key: "hash", Value: 1}},
+					Options: options.Index().SetName(AttachmentHashIndex),
+				}),
+				createIndex(ctx, coll, mongo.IndexModel{
+					Keys:    bson.D{{Key: "clinicId", Value: 1}, {Key: "createdAt", Value: -1}},
+					Options: options.Index().SetName(AttachmentListIndex),
+				}),
+				createIndex(ctx, coll, mongo.IndexModel{
+					Keys:    bson.D{{Key: "clinicId", Value: 1}, {Key: "patientId", Value: 1}, {Key: "createdAt", Value: -1}},
+					Options: options.Index().SetName(AttachmentPatientIndex),
+				}),
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			coll := db.Collection("attachments")
+			return errors.Join(
+				dropIndex(ctx, coll, AttachmentHashIndex),
+				dropIndex(ctx, coll, AttachmentListIndex),
+				dropIndex(ctx, coll, AttachmentPatientIndex),
+			)
+		},
+	},
+	{
+		Version:     13,
+		Description: "TTL and clinic indexes on transfer_jobs",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			coll := db.Collection("transfer_jobs")
+			// Los traspasos terminados antes de esta versión no tienen expiresAt
+			_, err := coll.UpdateMany(ctx,
+				bson.M{"finishedAt": bson.M{"$exists": true}, "expiresAt": bson.M{"$exists": false}},
+				mongo.Pipeline{{{Key: "$set", Value: bson.M{"expiresAt": bson.M{"$add": bson.A{"$finishedAt", models.TransferArchiveTTL.Milliseconds()}}}}}},
+			)
+			if err != nil {
+				return fmt.Errorf("no se pudo asignar la caducidad a los traspasos: %w", err)
+			}
+			return errors.Join(
+				createIndex(ctx, coll, mongo.IndexModel{
+					Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+					Options: options.Index().SetName(TransferJobsTTLIndex).SetExpireAfterSeconds(int32(TransferJobsTTLGrace.Seconds())),
+				}),
+				createIndex(ctx, coll, mongo.IndexModel{
+					Keys:    bson.D{{Key: "clinicId", Value: 1}},
+					Options: options.Index().SetName(TransferJobsClinicIndex),
+				}),
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			coll := db.Collection("transfer_jobs")
+			err := errors.Join(
+				dropIndex(ctx, coll, TransferJobsTTLIndex),
+				dropIndex(ctx, coll, TransferJobsClinicIndex),
+			)
+			if err != nil {
+				return err
+			}
+			if _, err := coll.UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"expiresAt": ""}}); err != nil {
+				return fmt.Errorf("no se pudo eliminar la caducidad de los traspasos: %w", err)
+			}
+			return nil
+		},
+	},
+}
+
+// backfillUsage cuenta los documentos de coll por clínica y guarda el total en
+// el contador resource de clinic_usage.
+func backfillUsage(ctx context.Context, db *mongo.Database, coll *mongo.Collection, resource string) error {
+	cursor, err := coll.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": "$clinicId", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return fmt.Errorf("no se pudo contar %s por clínica: %w", coll.Name(), err)
+	}
+	defer cursor.Close(ctx)
+
+	now := time.Now().UTC()
+	var batch []mongo.WriteModel
+	for cursor.Next(ctx) {
+		var group struct {
+			ClinicID primitive.ObjectID `bson:"_id"`
+			Count    int64              `bson:"count"`
+		}
+		if err := cursor.Decode(&group); err != nil {
+			return fmt.Errorf("no se pudo decodificar el recuento de %s: %w", coll.Name(), err)
+		}
+		batch = append(batch, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": group.ClinicID}).
+			SetUpdate(bson.M{"$set": bson.M{resource: group.Count, "updatedAt": now}}).
+			SetUpsert(true))
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("no se pudo recorrer el recuento de %s: %w", coll.Name(), err)
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	if _, err := db.Collection("clinic_usage").BulkWrite(ctx, batch, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("no se pudo guardar el uso de %s: %w", coll.Name(), err)
+	}
+	return nil
+}
+
+// backfillSearchKeys calcula las claves de búsqueda de todos los documentos de
+// la colección, en lotes, a partir de su modelo.
+func backfillSearchKeys[T any](ctx context.Context, coll *mongo.Collection, keys func(*T) search.Keys) error {
+	return backfill(ctx, coll, "search", keys)
+}
+
+// backfill recalcula el campo derivado field de todos los documentos de la
+// colección, en lotes, a partir de su modelo.
+func backfill[T, V any](ctx context.Context, coll *mongo.Collection, field string, value func(*T) V) error {
+	const batchSize = 500
+
+	cursor, err := coll.Find(ctx, bson.M{})
+	if err != nil {
+		return fmt.Errorf("no se pudo leer %s: %w", coll.Name(), err)
+	}
+	defer cursor.Close(ctx)
+
+	flush := func(batch []mongo.WriteModel) error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if _, err := coll.BulkWrite(ctx, batch, options.BulkWrite().SetOrdered(false)); err != nil {
+			return fmt.Errorf("no se pudieron guardar las claves de búsqueda de %s: %w", coll.Name(), err)
+		}
+		return nil
+	}
+
+	var batch []mongo.WriteModel
+	for cursor.Next(ctx) {
+		var doc T
+		if err := cursor.Decode(&doc); err != nil {
+			return fmt.Errorf("no se pudo decodificar un documento de %s: %w", coll.Name(), err)
+		}
+		batch = append(batch, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": cursor.Current.Lookup("_id")}).
+			SetUpdate(bson.M{"$set": bson.M{field: value(&doc)}}))
+
+		if len(batch) == batchSize {
+			if err := flush(batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("no se pudo recorrer %s: %w", coll.Name(), err)
+	}
+	return flush(batch)
+}
+
+func createIndex(ctx context.Context, coll *mongo.Collection, model mongo.IndexModel) error {
+	if _, err := coll.Indexes().CreateOne(ctx, model); err != nil {
+		return fmt.Errorf("no se pudo crear el índice en %s: %w", coll.Name(), err)
+	}
+	return nil
+}
+
+func dropIndex(ctx context.Context, coll *mongo.Collection, name string) error {
+	if _, err := coll.Indexes().DropOne(ctx, name); err != nil {
+		return fmt.Errorf("no se pudo eliminar el índice %s de %s: %w", name, coll.Name(), err)
+	}
+	return nil
+}
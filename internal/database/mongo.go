@@ -6,9 +6,12 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/zabaletac3/go-vet-api/internal/metrics"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
 )
 
 // Connect ahora recibe el logger para un registro consistente.
@@ -16,7 +19,14 @@ func Connect(uri string, logger *slog.Logger) (*mongo.Client, func(), error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	// Los monitores alimentan las métricas de comandos y del pool (GET /metrics)
+	// y crean un span por comando, hijo del span de la operación en curso
+	opts := options.Client().
+		ApplyURI(uri).
+		SetMonitor(commandMonitors(metrics.CommandMonitor(), otelmongo.NewMonitor())).
+		SetPoolMonitor(metrics.PoolMonitor())
+
+	client, err := mongo.Connect(ctx, opts)
 	if err != nil {
 		return nil, nil, fmt.Errorf("no se pudo preparar la conexión con mongo: %w", err)
 	}
@@ -33,4 +43,32 @@ func Connect(uri string, logger *slog.Logger) (*mongo.Client, func(), error) {
 	}
 
 	return client, cleanup, nil
-}
\ No newline at end of file
+}
+
+// commandMonitors reparte los eventos de comandos entre varios monitores: el
+// driver solo admite uno.
+func commandMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			for _, m := range monitors {
+				if m.Started != nil {
+					m.Started(ctx, e)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			for _, m := range monitors {
+				if m.Succeeded != nil {
+					m.Succeeded(ctx, e)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			for _, m := range monitors {
+				if m.Failed != nil {
+					m.Failed(ctx, e)
+				}
+			}
+		},
+	}
+}
@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// Mongo comprueba que el primario del replica set responde a un ping.
+func Mongo(client *mongo.Client) Check {
+	return func(ctx context.Context) error {
+		return client.Ping(ctx, readpref.Primary())
+	}
+}
+
+// Migrations falla mientras queden migraciones por aplicar (p. ej. con
+// AUTO_MIGRATE=false, o mientras otra instancia las aplica). pending suele
+// ser database.Migrator.Pending.
+func Migrations(pending func(ctx context.Context) (int, error)) Check {
+	return func(ctx context.Context) error {
+		n, err := pending(ctx)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return fmt.Errorf("%d pending migrations", n)
+		}
+		return nil
+	}
+}
+
+// Heartbeat es el latido de un ejecutor en segundo plano: lo renueva en cada
+// vuelta de su bucle y mientras procesa un trabajo largo.
+type Heartbeat struct {
+	last atomic.Int64
+}
+
+// NewHeartbeat crea un latido que empieza ahora.
+func NewHeartbeat() *Heartbeat {
+	h := &Heartbeat{}
+	h.Beat()
+	return h
+}
+
+// Beat renueva el latido.
+func (h *Heartbeat) Beat() {
+	h.last.Store(time.Now().UnixNano())
+}
+
+// Last es el momento del último latido.
+func (h *Heartbeat) Last() time.Time {
+	return time.Unix(0, h.last.Load())
+}
+
+// Worker falla si el ejecutor de hb lleva más de maxAge sin latir: se ha
+// quedado bloqueado o su goroutine terminó.
+func Worker(hb *Heartbeat, maxAge time.Duration) Check {
+	return func(ctx context.Context) error {
+		if age := time.Since(hb.Last()); age > maxAge {
+			return fmt.Errorf("no heartbeat for %s", age.Round(time.Second))
+		}
+		return nil
+	}
+}
+
+// ErrDiskUnsupported lo devuelve DiskSpace en sistemas sin statfs.
+var ErrDiskUnsupported = errors.New("disk space check is not supported on this platform")
+
+// DiskSpace falla si el sistema de ficheros de dir tiene menos de minFree
+// bytes libres (los archivos subidos y exportados se guardan ahí).
+func DiskSpace(dir string, minFree uint64) Check {
+	return func(ctx context.Context) error {
+		free, err := freeSpace(dir)
+		if err != nil {
+			return err
+		}
+		if free < minFree {
+			return fmt.Errorf("%d MiB free in %s, need %d MiB", free>>20, dir, minFree>>20)
+		}
+		return nil
+	}
+}
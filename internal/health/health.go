@@ -0,0 +1,141 @@
+// Package health comprueba si la instancia puede atender tráfico: cada
+// dependencia (MongoDB, migraciones, ejecutores en segundo plano, disco) se
+// registra como un Check con nombre y la readiness es el resultado de todos.
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Estados de un check y del informe completo
+const (
+	StatusOK       = "ok"
+	StatusFail     = "fail"
+	StatusDraining = "draining"
+)
+
+// DefaultTimeout es el tiempo máximo de cada check si no se indica otro.
+const DefaultTimeout = 2 * time.Second
+
+// Check comprueba una dependencia; devuelve nil si está disponible. El ctx
+// caduca con el timeout del registro.
+type Check func(ctx context.Context) error
+
+// Result es el resultado de un check.
+type Result struct {
+	Status  string
+	Latency time.Duration
+	Error   string
+}
+
+// Report es el resultado de todos los checks. Status es StatusOK si todos
+// pasan, StatusFail si alguno falla y StatusDraining si la instancia se está
+// apagando (entonces no se ejecuta ninguno).
+type Report struct {
+	Status string
+	Checks map[string]Result
+}
+
+type entry struct {
+	name  string
+	check Check
+}
+
+// Registry guarda los checks de la readiness.
+type Registry struct {
+	timeout  time.Duration
+	mu       sync.RWMutex
+	checks   []entry
+	draining atomic.Bool
+}
+
+// NewRegistry crea un registro vacío; timeout limita cada check (0 usa DefaultTimeout).
+func NewRegistry(timeout time.Duration) *Registry {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Registry{timeout: timeout}
+}
+
+// Register añade un check. Un nombre repetido sustituye al anterior.
+func (r *Registry) Register(name string, check Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.checks {
+		if r.checks[i].name == name {
+			r.checks[i].check = check
+			return
+		}
+	}
+	r.checks = append(r.checks, entry{name: name, check: check})
+}
+
+// Drain marca la instancia como en apagado: desde ese momento la readiness
+// falla para que los balanceadores dejen de enviarle tráfico.
+func (r *Registry) Drain() {
+	r.draining.Store(true)
+}
+
+// Draining indica si se llamó a Drain.
+func (r *Registry) Draining() bool {
+	return r.draining.Load()
+}
+
+// Run ejecuta todos los checks a la vez y espera a que terminen o caduquen.
+func (r *Registry) Run(ctx context.Context) Report {
+	if r.Draining() {
+		return Report{Status: StatusDraining, Checks: map[string]Result{}}
+	}
+
+	r.mu.RLock()
+	checks := append([]entry(nil), r.checks...)
+	r.mu.RUnlock()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, e := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.run(ctx, e.check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(checks))}
+	for i, e := range checks {
+		report.Checks[e.name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+// run ejecuta un check con su timeout. Si el check no respeta el ctx se da por
+// fallido al caducar y se deja terminar en segundo plano.
+func (r *Registry) run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := Result{Status: StatusOK, Latency: time.Since(start)}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
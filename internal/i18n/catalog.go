@@ -0,0 +1,387 @@
+package i18n
+
+// catalog tiene las traducciones de cada mensaje, indexadas por el texto en
+// inglés. Al añadir un mensaje nuevo a la API hay que añadirlo aquí en todos
+// los idiomas; los verbos de fmt (%s) se mantienen en el mismo orden.
+var catalog = map[Locale]map[string]string{
+	Spanish: {
+		// Títulos de los problemas
+		"Bad Request":               "Solicitud incorrecta",
+		"Malformed JSON":            "JSON malformado",
+		"Validation Failed":         "Datos de entrada inválidos",
+		"Unauthorized":              "No autorizado",
+		"Forbidden":                 "Prohibido",
+		"Not Found":                 "No encontrado",
+		"Conflict":                  "Conflicto",
+		"Precondition Required":     "Precondición requerida",
+		"Unprocessable Entity":      "Entidad no procesable",
+		"Too Many Requests":         "Demasiadas solicitudes",
+		"Internal Server Error":     "Error interno del servidor",
+		"Clinic Name Required":      "Nombre de clínica requerido",
+		"Invalid Clinic Name":       "Nombre de clínica inválido",
+		"Clinic Name Taken":         "Nombre de clínica en uso",
+		"Display Name Required":     "Nombre visible requerido",
+		"Invalid Display Name":      "Nombre visible inválido",
+		"Clinic Display Name Taken": "Nombre visible en uso",
+		"Invalid Palette Color":     "Color de paleta inválido",
+		"Clinic Not Found":          "Clínica no encontrada",
+		"Invalid Clinic ID":         "ID de clínica inválido",
+		"Clinic Has Users":          "La clínica tiene usuarios",
+		"Clinic Version Conflict":   "Conflicto de versión de la clínica",
+		"Invalid Cursor":            "Cursor inválido",
+		"User Email Taken":          "Email de usuario en uso",
+		"Password Too Short":        "Contraseña demasiado corta",
+		"Search Query Required":     "Texto de búsqueda requerido",
+		"Search Clinic Required":    "Clínica de búsqueda requerida",
+		"Invalid Search Type":       "Tipo de búsqueda inválido",
+		"Payload Too Large":         "Cuerpo demasiado grande",
+		"Invalid Idempotency Key":   "Idempotency-Key inválida",
+		"Idempotency Key Reused":    "Idempotency-Key reutilizada",
+		"Request In Progress":       "Solicitud en curso",
+		"Transactions Unsupported":  "Transacciones no soportadas",
+
+		"Import Not Found":            "Importación no encontrada",
+		"Invalid Import ID":           "ID de importación inválido",
+		"Import State Conflict":       "Estado de importación incompatible",
+		"Unsupported Import Format":   "Formato de importación no soportado",
+		"Empty Import File":           "Archivo de importación vacío",
+		"Import Too Many Rows":        "Demasiadas filas para importar",
+		"Unreadable Import File":      "Archivo de importación ilegible",
+		"Invalid Plan":                "Plan inválido",
+		"Plan Limit Exceeded":         "Límite del plan alcanzado",
+		"Invalid Feature Key":         "Clave de flag inválida",
+		"Invalid Rollout":             "Despliegue inválido",
+		"Module Disabled":             "Módulo no habilitado",
+		"Invalid Image Kind":          "Tipo de imagen inválido",
+		"Image Not Found":             "Imagen no encontrada",
+		"Unsupported Image":           "Imagen no soportada",
+		"Image Too Large":             "Imagen demasiado grande",
+		"Invalid Image Dimensions":    "Dimensiones de imagen inválidas",
+		"Unreadable Image":            "Imagen ilegible",
+		"Invalid Colour":              "Color inválido",
+		"No Colours In Image":         "La imagen no tiene colores",
+		"Insufficient Contrast":       "Contraste insuficiente",
+		"Attachment Not Found":        "Adjunto no encontrado",
+		"Invalid Attachment ID":       "ID de adjunto inválido",
+		"Invalid Attachment Category": "Categoría de adjunto inválida",
+		"Empty Attachment":            "Adjunto vacío",
+		"Attachment Too Large":        "Adjunto demasiado grande",
+		"Infected File":               "Fichero infectado",
+		"Antivirus Unavailable":       "Antivirus no disponible",
+		"Patient Not Found":           "Paciente no encontrado",
+		"Invalid Patient ID":          "ID de paciente inválido",
+		"File Not Found":              "Fichero no encontrado",
+		"Invalid Download Signature":  "Firma de descarga inválida",
+		"Download URL Expired":        "URL de descarga caducada",
+		// Detalles de los problemas
+		"An unexpected error occurred":                                    "Ocurrió un error inesperado",
+		"Request body is not valid JSON":                                  "El formato del JSON enviado no es válido",
+		"Request body has invalid fields":                                 "Los datos enviados contienen errores de validación",
+		"Validation is not available":                                     "Sistema de validación no disponible",
+		"Invalid query parameters":                                        "Parámetros de consulta inválidos",
+		"Failed to list clinics":                                          "No se pudieron listar las clínicas",
+		"Clinic ID is required":                                           "El ID de la clínica es requerido",
+		"Invalid cascade value":                                           "Valor de cascade inválido",
+		"If-Match header with the clinic ETag is required":                "Se requiere la cabecera If-Match con el ETag de la clínica",
+		"Clinic name is required":                                         "El nombre de la clínica es requerido",
+		"Clinic name must be between 2 and 100 characters":                "El nombre de la clínica debe tener entre 2 y 100 caracteres",
+		"A clinic with that name already exists":                          "Ya existe una clínica con ese nombre",
+		"Display name is required":                                        "El nombre visible es requerido",
+		"Display name must be between 2 and 150 characters":               "El nombre visible debe tener entre 2 y 150 caracteres",
+		"A clinic with that display name already exists":                  "Ya existe una clínica con ese nombre visible",
+		"Palette colors must be hex colors like #1A2B3C":                  "Los colores de la paleta deben ser hexadecimales, como #1A2B3C",
+		"Clinic not found":                                                "Clínica no encontrada",
+		"Invalid clinic ID":                                               "ID de clínica inválido",
+		"Clinic still has users; delete with cascade=true to remove them": "La clínica todavía tiene usuarios; elimínela con cascade=true para borrarlos también",
+		"Clinic was modified; fetch it again and retry":                   "La clínica fue modificada; vuelva a obtenerla e inténtelo de nuevo",
+		"Invalid or expired cursor for this listing":                      "Cursor inválido o caducado para este listado",
+		"A user with that email already exists in this clinic":            "El usuario con ese email ya existe en esta clínica",
+		"Password must be at least 8 characters long":                     "La contraseña debe tener al menos 8 caracteres",
+		"Search query must contain at least one letter or digit":          "La búsqueda debe contener al menos una letra o un dígito",
+		"clinicId is required to search owners and pets":                  "Se requiere clinicId para buscar tutores y mascotas",
+		"Invalid search type; use clinics, owners or pets":                "Tipo de búsqueda inválido; use clinics, owners o pets",
+		"q must be at most 200 characters":                                "q no puede tener más de 200 caracteres",
+		"limit must be an integer between 1 and 50":                       "limit debe ser un entero entre 1 y 50",
+		"Request body is too large":                                       "El cuerpo de la solicitud es demasiado grande",
+		"Rate limit exceeded, retry later":                                "Se superó el límite de solicitudes; vuelva a intentarlo más tarde",
+		"Request body could not be read":                                  "No se pudo leer el cuerpo de la solicitud",
+		"Idempotency-Key must be at most 255 characters":                  "Idempotency-Key no puede tener más de 255 caracteres",
+		"Idempotency-Key was already used with a different request":       "La Idempotency-Key ya se usó con una solicitud diferente",
+		"A request with this Idempotency-Key is still being processed":    "Una solicitud con esta Idempotency-Key todavía se está procesando",
+		"Invalid batch request":                                           "Lote inválido",
+		"Item is not a valid JSON object":                                 "El elemento no es un objeto JSON válido",
+		"Item has invalid fields":                                         "El elemento contiene errores de validación",
+		"Atomic batches need a MongoDB replica set; use mode best_effort": "Los lotes atómicos requieren un replica set de MongoDB; use el modo best_effort",
+
+		"Import not found":  "Importación no encontrada",
+		"Invalid import ID": "ID de importación inválido",
+		"Only uploaded imports can be committed and only failed imports can be resumed": "Solo se pueden confirmar importaciones recién subidas y solo se pueden reanudar las fallidas",
+		"Upload a .csv or .xlsx file":               "Suba un archivo .csv o .xlsx",
+		"The file has no header row":                "El archivo no tiene fila de cabecera",
+		"The file has more than 10000 rows":         "El archivo tiene más de 10000 filas",
+		"The file could not be read as CSV or XLSX": "No se pudo leer el archivo como CSV o XLSX",
+		"File must be at most 10 MiB":               "El archivo no puede superar los 10 MiB",
+		"Invalid column mapping":                    "Mapeo de columnas inválido",
+		"limit must be an integer between 1 and %s": "limit debe ser un entero entre 1 y %s",
+
+		"Transfer not found":  "Traspaso no encontrado",
+		"Invalid transfer ID": "ID de traspaso inválido",
+		"Only failed transfers can be retried and only completed exports can be downloaded": "Solo se pueden reintentar los traspasos fallidos y solo se pueden descargar las exportaciones terminadas",
+		"The archive was exported with an unsupported format version":                       "El archivo se exportó con una versión de formato no soportada",
+		"The archive is corrupted: its contents do not match the manifest":                  "El archivo está dañado: su contenido no coincide con el manifiesto",
+		"The file is not a valid clinic archive":                                            "El fichero no es un archivo de clínica válido",
+		"Archive must be at most 512 MiB":                                                   "El archivo no puede superar los 512 MiB",
+
+		"Unknown plan; use free, standard or premium":                                             "Plan desconocido; use free, standard o premium",
+		"The clinic reached the user limit of its plan; upgrade the plan to add more users":       "La clínica alcanzó el límite de usuarios de su plan; mejore el plan para añadir más usuarios",
+		"The clinic reached the patient limit of its plan; upgrade the plan to add more patients": "La clínica alcanzó el límite de pacientes de su plan; mejore el plan para añadir más pacientes",
+		"The clinic reached the storage limit of its plan; upgrade the plan or free up space":     "La clínica alcanzó el límite de almacenamiento de su plan; mejore el plan o libere espacio",
+		"The clinic reached the patient limit of its plan":                                        "La clínica alcanzó el límite de pacientes de su plan",
+
+		"Feature keys are 2-50 lowercase letters, digits or underscores": "Las claves de flag tienen de 2 a 50 minúsculas, dígitos o guiones bajos",
+		"Rollout must be between 0 and 100":                              "El despliegue debe estar entre 0 y 100",
+		"This module is not enabled for the clinic":                      "Este módulo no está habilitado para la clínica",
+
+		"Unknown image kind; use logo, favicon or emailHeader":                                              "Tipo de imagen desconocido; use logo, favicon o emailHeader",
+		"Branding image not found":                                                                          "Imagen de marca no encontrada",
+		"Images must be PNG, JPEG, GIF or WebP":                                                             "Las imágenes deben ser PNG, JPEG, GIF o WebP",
+		"Images must be at most 2 MiB (favicons 1 MiB)":                                                     "Las imágenes no pueden superar los 2 MiB (los favicons, 1 MiB)",
+		"Images must be at most 4096 pixels per side and at least 64 (favicons 32, email headers 300 wide)": "Las imágenes deben tener como máximo 4096 píxeles por lado y al menos 64 (los favicons, 32; las cabeceras de correo, 300 de ancho)",
+		"The image could not be read":                                                                       "No se pudo leer la imagen",
+
+		"Brand colour must be a #RRGGBB hex colour":                              "El color de marca debe ser un color hexadecimal #RRGGBB",
+		"The image has no opaque pixels to take colours from":                    "La imagen no tiene píxeles opacos de los que tomar colores",
+		"Palette colours do not reach the required contrast with the background": "Los colores de la paleta no llegan al contraste exigido con el fondo",
+		"Contrast with the background is %s:1; at least %s:1 is required":        "El contraste con el fondo es de %s:1; se exige al menos %s:1",
+
+		"Attachment not found":  "Adjunto no encontrado",
+		"Invalid attachment ID": "ID de adjunto inválido",
+		"Unknown attachment category; use medicalRecord, labResult, imaging or document": "Categoría de adjunto desconocida; use medicalRecord, labResult, imaging o document",
+		"The file is empty":                               "El fichero está vacío",
+		"Attachments must be at most 25 MiB":              "Los adjuntos no pueden superar los 25 MiB",
+		"The file contains a threat and was rejected":     "El fichero contiene una amenaza y se ha rechazado",
+		"The antivirus is not available; try again later": "El antivirus no está disponible; inténtelo más tarde",
+		"Patient not found":                               "Paciente no encontrado",
+		"Invalid patient ID":                              "ID de paciente inválido",
+		"File not found":                                  "Fichero no encontrado",
+		"The download URL signature is not valid":         "La firma de la URL de descarga no es válida",
+		"The download URL has expired":                    "La URL de descarga ha caducado",
+		// Validaciones por campo
+		"This field is required":                "Este campo es requerido",
+		"Must be a valid email address":         "Debe ser un email válido",
+		"Must be a valid URL":                   "Debe ser una URL válida",
+		"Must be at least %s characters":        "Debe tener al menos %s caracteres",
+		"Must be at most %s characters":         "No puede tener más de %s caracteres",
+		"Must be exactly %s characters":         "Debe tener exactamente %s caracteres",
+		"Minimum value is %s":                   "El valor mínimo es %s",
+		"Maximum value is %s":                   "El valor máximo es %s",
+		"Must be one of: %s":                    "Debe ser uno de: %s",
+		"Must contain between 1 and %s items":   "Debe contener entre 1 y %s elementos",
+		"Must be greater than %s":               "Debe ser mayor que %s",
+		"Must be greater than or equal to %s":   "Debe ser mayor o igual que %s",
+		"Must be less than %s":                  "Debe ser menor que %s",
+		"Must be less than or equal to %s":      "Debe ser menor o igual que %s",
+		"Must be a valid ID":                    "Debe ser un ID de MongoDB válido",
+		"Must be a valid ISO 8601 date":         "Debe ser una fecha válida en formato ISO 8601",
+		"Must be a hex color like #1A2B3C":      "Debe ser un color hexadecimal, como #1A2B3C",
+		"Must be a number":                      "Debe ser un número",
+		"Unknown import field":                  "Campo de importación desconocido",
+		"Column not found in the file":          "La columna no existe en el archivo",
+		"A column must be mapped to this field": "Debe asignar una columna a este campo",
+		"Invalid value":                         "Valor inválido",
+		"Invalid species. Allowed species: %s":  "Especie no válida. Especies permitidas: %s",
+		"Password must be at least 8 characters long and include uppercase and lowercase letters, numbers and special characters": "La contraseña debe tener al menos 8 caracteres, incluyendo mayúsculas, minúsculas, números y caracteres especiales",
+		"Must be 2 to 100 characters and start with a letter, digit, hyphen, underscore or dot":                                   "Debe tener entre 2 y 100 caracteres y empezar por una letra, un dígito, un guion, un guion bajo o un punto",
+		"Must be 2 to 150 characters and contain only letters, digits, spaces and - _ . ( ) & ' , :":                              "Debe tener entre 2 y 150 caracteres y contener solo letras, dígitos, espacios y - _ . ( ) & ' , :",
+	},
+	Portuguese: {
+		// Títulos de los problemas
+		"Bad Request":               "Requisição inválida",
+		"Malformed JSON":            "JSON malformado",
+		"Validation Failed":         "Dados de entrada inválidos",
+		"Unauthorized":              "Não autorizado",
+		"Forbidden":                 "Proibido",
+		"Not Found":                 "Não encontrado",
+		"Conflict":                  "Conflito",
+		"Precondition Required":     "Pré-condição obrigatória",
+		"Unprocessable Entity":      "Entidade não processável",
+		"Too Many Requests":         "Requisições em excesso",
+		"Internal Server Error":     "Erro interno do servidor",
+		"Clinic Name Required":      "Nome da clínica obrigatório",
+		"Invalid Clinic Name":       "Nome da clínica inválido",
+		"Clinic Name Taken":         "Nome da clínica em uso",
+		"Display Name Required":     "Nome de exibição obrigatório",
+		"Invalid Display Name":      "Nome de exibição inválido",
+		"Clinic Display Name Taken": "Nome de exibição em uso",
+		"Invalid Palette Color":     "Cor da paleta inválida",
+		"Clinic Not Found":          "Clínica não encontrada",
+		"Invalid Clinic ID":         "ID da clínica inválido",
+		"Clinic Has Users":          "A clínica tem usuários",
+		"Clinic Version Conflict":   "Conflito de versão da clínica",
+		"Invalid Cursor":            "Cursor inválido",
+		"User Email Taken":          "E-mail de usuário em uso",
+		"Password Too Short":        "Senha muito curta",
+		"Search Query Required":     "Texto de busca obrigatório",
+		"Search Clinic Required":    "Clínica da busca obrigatória",
+		"Invalid Search Type":       "Tipo de busca inválido",
+		"Payload Too Large":         "Corpo muito grande",
+		"Invalid Idempotency Key":   "Idempotency-Key inválida",
+		"Idempotency Key Reused":    "Idempotency-Key reutilizada",
+		"Request In Progress":       "Requisição em andamento",
+		"Transactions Unsupported":  "Transações não suportadas",
+
+		"Import Not Found":            "Importação não encontrada",
+		"Invalid Import ID":           "ID de importação inválido",
+		"Import State Conflict":       "Estado de importação incompatível",
+		"Unsupported Import Format":   "Formato de importação não suportado",
+		"Empty Import File":           "Arquivo de importação vazio",
+		"Import Too Many Rows":        "Linhas demais para importar",
+		"Unreadable Import File":      "Arquivo de importação ilegível",
+		"Invalid Plan":                "Plano inválido",
+		"Plan Limit Exceeded":         "Limite do plano atingido",
+		"Invalid Feature Key":         "Chave de flag inválida",
+		"Invalid Rollout":             "Rollout inválido",
+		"Module Disabled":             "Módulo não habilitado",
+		"Invalid Image Kind":          "Tipo de imagem inválido",
+		"Image Not Found":             "Imagem não encontrada",
+		"Unsupported Image":           "Imagem não suportada",
+		"Image Too Large":             "Imagem muito grande",
+		"Invalid Image Dimensions":    "Dimensões de imagem inválidas",
+		"Unreadable Image":            "Imagem ilegível",
+		"Invalid Colour":              "Cor inválida",
+		"No Colours In Image":         "A imagem não tem cores",
+		"Insufficient Contrast":       "Contraste insuficiente",
+		"Attachment Not Found":        "Anexo não encontrado",
+		"Invalid Attachment ID":       "ID de anexo inválido",
+		"Invalid Attachment Category": "Categoria de anexo inválida",
+		"Empty Attachment":            "Anexo vazio",
+		"Attachment Too Large":        "Anexo muito grande",
+		"Infected File":               "Arquivo infectado",
+		"Antivirus Unavailable":       "Antivírus indisponível",
+		"Patient Not Found":           "Paciente não encontrado",
+		"Invalid Patient ID":          "ID de paciente inválido",
+		"File Not Found":              "Arquivo não encontrado",
+		"Invalid Download Signature":  "Assinatura de download inválida",
+		"Download URL Expired":        "URL de download expirada",
+		// Detalles de los problemas
+		"An unexpected error occurred":                                    "Ocorreu um erro inesperado",
+		"Request body is not valid JSON":                                  "O corpo da requisição não é um JSON válido",
+		"Request body has invalid fields":                                 "Os dados enviados contêm erros de validação",
+		"Validation is not available":                                     "Sistema de validação indisponível",
+		"Invalid query parameters":                                        "Parâmetros de consulta inválidos",
+		"Failed to list clinics":                                          "Não foi possível listar as clínicas",
+		"Clinic ID is required":                                           "O ID da clínica é obrigatório",
+		"Invalid cascade value":                                           "Valor de cascade inválido",
+		"If-Match header with the clinic ETag is required":                "O cabeçalho If-Match com o ETag da clínica é obrigatório",
+		"Clinic name is required":                                         "O nome da clínica é obrigatório",
+		"Clinic name must be between 2 and 100 characters":                "O nome da clínica deve ter entre 2 e 100 caracteres",
+		"A clinic with that name already exists":                          "Já existe uma clínica com esse nome",
+		"Display name is required":                                        "O nome de exibição é obrigatório",
+		"Display name must be between 2 and 150 characters":               "O nome de exibição deve ter entre 2 e 150 caracteres",
+		"A clinic with that display name already exists":                  "Já existe uma clínica com esse nome de exibição",
+		"Palette colors must be hex colors like #1A2B3C":                  "As cores da paleta devem ser hexadecimais, como #1A2B3C",
+		"Clinic not found":                                                "Clínica não encontrada",
+		"Invalid clinic ID":                                               "ID da clínica inválido",
+		"Clinic still has users; delete with cascade=true to remove them": "A clínica ainda tem usuários; exclua com cascade=true para removê-los também",
+		"Clinic was modified; fetch it again and retry":                   "A clínica foi modificada; obtenha-a novamente e tente de novo",
+		"Invalid or expired cursor for this listing":                      "Cursor inválido ou expirado para esta listagem",
+		"A user with that email already exists in this clinic":            "Já existe um usuário com esse e-mail nesta clínica",
+		"Password must be at least 8 characters long":                     "A senha deve ter pelo menos 8 caracteres",
+		"Search query must contain at least one letter or digit":          "A busca deve conter pelo menos uma letra ou um dígito",
+		"clinicId is required to search owners and pets":                  "clinicId é obrigatório para buscar tutores e pets",
+		"Invalid search type; use clinics, owners or pets":                "Tipo de busca inválido; use clinics, owners ou pets",
+		"q must be at most 200 characters":                                "q deve ter no máximo 200 caracteres",
+		"limit must be an integer between 1 and 50":                       "limit deve ser um inteiro entre 1 e 50",
+		"Request body is too large":                                       "O corpo da requisição é muito grande",
+		"Rate limit exceeded, retry later":                                "Limite de requisições excedido; tente novamente mais tarde",
+		"Request body could not be read":                                  "Não foi possível ler o corpo da requisição",
+		"Idempotency-Key must be at most 255 characters":                  "Idempotency-Key deve ter no máximo 255 caracteres",
+		"Idempotency-Key was already used with a different request":       "A Idempotency-Key já foi usada com uma requisição diferente",
+		"A request with this Idempotency-Key is still being processed":    "Uma requisição com esta Idempotency-Key ainda está sendo processada",
+		"Invalid batch request":                                           "Lote inválido",
+		"Item is not a valid JSON object":                                 "O item não é um objeto JSON válido",
+		"Item has invalid fields":                                         "O item contém erros de validação",
+		"Atomic batches need a MongoDB replica set; use mode best_effort": "Lotes atômicos exigem um replica set do MongoDB; use o modo best_effort",
+
+		"Import not found":  "Importação não encontrada",
+		"Invalid import ID": "ID de importação inválido",
+		"Only uploaded imports can be committed and only failed imports can be resumed": "Só é possível confirmar importações recém-enviadas e só é possível retomar as que falharam",
+		"Upload a .csv or .xlsx file":               "Envie um arquivo .csv ou .xlsx",
+		"The file has no header row":                "O arquivo não tem linha de cabeçalho",
+		"The file has more than 10000 rows":         "O arquivo tem mais de 10000 linhas",
+		"The file could not be read as CSV or XLSX": "Não foi possível ler o arquivo como CSV ou XLSX",
+		"File must be at most 10 MiB":               "O arquivo deve ter no máximo 10 MiB",
+		"Invalid column mapping":                    "Mapeamento de colunas inválido",
+		"limit must be an integer between 1 and %s": "limit deve ser um inteiro entre 1 e %s",
+
+		"Transfer not found":  "Transferência não encontrada",
+		"Invalid transfer ID": "ID de transferência inválido",
+		"Only failed transfers can be retried and only completed exports can be downloaded": "Só é possível repetir transferências que falharam e só é possível baixar exportações concluídas",
+		"The archive was exported with an unsupported format version":                       "O arquivo foi exportado com uma versão de formato não suportada",
+		"The archive is corrupted: its contents do not match the manifest":                  "O arquivo está corrompido: seu conteúdo não corresponde ao manifesto",
+		"The file is not a valid clinic archive":                                            "O arquivo não é um arquivo de clínica válido",
+		"Archive must be at most 512 MiB":                                                   "O arquivo deve ter no máximo 512 MiB",
+
+		"Unknown plan; use free, standard or premium":                                             "Plano desconhecido; use free, standard ou premium",
+		"The clinic reached the user limit of its plan; upgrade the plan to add more users":       "A clínica atingiu o limite de usuários do seu plano; faça upgrade do plano para adicionar mais usuários",
+		"The clinic reached the patient limit of its plan; upgrade the plan to add more patients": "A clínica atingiu o limite de pacientes do seu plano; faça upgrade do plano para adicionar mais pacientes",
+		"The clinic reached the storage limit of its plan; upgrade the plan or free up space":     "A clínica atingiu o limite de armazenamento do seu plano; faça upgrade do plano ou libere espaço",
+		"The clinic reached the patient limit of its plan":                                        "A clínica atingiu o limite de pacientes do seu plano",
+
+		"Feature keys are 2-50 lowercase letters, digits or underscores": "As chaves de flag têm de 2 a 50 letras minúsculas, dígitos ou sublinhados",
+		"Rollout must be between 0 and 100":                              "O rollout deve estar entre 0 e 100",
+		"This module is not enabled for the clinic":                      "Este módulo não está habilitado para a clínica",
+
+		"Unknown image kind; use logo, favicon or emailHeader":                                              "Tipo de imagem desconhecido; use logo, favicon ou emailHeader",
+		"Branding image not found":                                                                          "Imagem da marca não encontrada",
+		"Images must be PNG, JPEG, GIF or WebP":                                                             "As imagens devem ser PNG, JPEG, GIF ou WebP",
+		"Images must be at most 2 MiB (favicons 1 MiB)":                                                     "As imagens devem ter no máximo 2 MiB (favicons, 1 MiB)",
+		"Images must be at most 4096 pixels per side and at least 64 (favicons 32, email headers 300 wide)": "As imagens devem ter no máximo 4096 pixels por lado e no mínimo 64 (favicons, 32; cabeçalhos de e-mail, 300 de largura)",
+		"The image could not be read":                                                                       "Não foi possível ler a imagem",
+
+		"Brand colour must be a #RRGGBB hex colour":                              "A cor da marca deve ser uma cor hexadecimal #RRGGBB",
+		"The image has no opaque pixels to take colours from":                    "A imagem não tem pixels opacos dos quais extrair cores",
+		"Palette colours do not reach the required contrast with the background": "As cores da paleta não atingem o contraste exigido com o fundo",
+		"Contrast with the background is %s:1; at least %s:1 is required":        "O contraste com o fundo é de %s:1; exige-se pelo menos %s:1",
+
+		"Attachment not found":  "Anexo não encontrado",
+		"Invalid attachment ID": "ID de anexo inválido",
+		"Unknown attachment category; use medicalRecord, labResult, imaging or document": "Categoria de anexo desconhecida; use medicalRecord, labResult, imaging ou document",
+		"The file is empty":                               "O arquivo está vazio",
+		"Attachments must be at most 25 MiB":              "Os anexos devem ter no máximo 25 MiB",
+		"The file contains a threat and was rejected":     "O arquivo contém uma ameaça e foi rejeitado",
+		"The antivirus is not available; try again later": "O antivírus não está disponível; tente novamente mais tarde",
+		"Patient not found":                               "Paciente não encontrado",
+		"Invalid patient ID":                              "ID de paciente inválido",
+		"File not found":                                  "Arquivo não encontrado",
+		"The download URL signature is not valid":         "A assinatura da URL de download não é válida",
+		"The download URL has expired":                    "A URL de download expirou",
+		// Validaciones por campo
+		"This field is required":                "Este campo é obrigatório",
+		"Must be a valid email address":         "Deve ser um e-mail válido",
+		"Must be a valid URL":                   "Deve ser uma URL válida",
+		"Must be at least %s characters":        "Deve ter pelo menos %s caracteres",
+		"Must be at most %s characters":         "Deve ter no máximo %s caracteres",
+		"Must be exactly %s characters":         "Deve ter exatamente %s caracteres",
+		"Minimum value is %s":                   "O valor mínimo é %s",
+		"Maximum value is %s":                   "O valor máximo é %s",
+		"Must be one of: %s":                    "Deve ser um de: %s",
+		"Must contain between 1 and %s items":   "Deve conter entre 1 e %s itens",
+		"Must be greater than %s":               "Deve ser maior que %s",
+		"Must be greater than or equal to %s":   "Deve ser maior ou igual a %s",
+		"Must be less than %s":                  "Deve ser menor que %s",
+		"Must be less than or equal to %s":      "Deve ser menor ou igual a %s",
+		"Must be a valid ID":                    "Deve ser um ID válido",
+		"Must be a valid ISO 8601 date":         "Deve ser uma data válida no formato ISO 8601",
+		"Must be a hex color like #1A2B3C":      "Deve ser uma cor hexadecimal, como #1A2B3C",
+		"Must be a number":                      "Deve ser um número",
+		"Unknown import field":                  "Campo de importação desconhecido",
+		"Column not found in the file":          "A coluna não existe no arquivo",
+		"A column must be mapped to this field": "É preciso associar uma coluna a este campo",
+		"Invalid value":                         "Valor inválido",
+		"Invalid species. Allowed species: %s":  "Espécie inválida. Espécies permitidas: %s",
+		"Password must be at least 8 characters long and include uppercase and lowercase letters, numbers and special characters": "A senha deve ter pelo menos 8 caracteres, incluindo letras maiúsculas e minúsculas, números e caracteres especiais",
+		"Must be 2 to 100 characters and start with a letter, digit, hyphen, underscore or dot":                                   "Deve ter entre 2 e 100 caracteres e começar com uma letra, um dígito, um hífen, um sublinhado ou um ponto",
+		"Must be 2 to 150 characters and contain only letters, digits, spaces and - _ . ( ) & ' , :":                              "Deve ter entre 2 e 150 caracteres e conter apenas letras, dígitos, espaços e - _ . ( ) & ' , :",
+	},
+}
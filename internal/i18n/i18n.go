@@ -0,0 +1,157 @@
+// Package i18n traduce los mensajes de la API (errores y validaciones) al
+// idioma del cliente: el de Accept-Language si es uno de los soportados, si no
+// el idioma por defecto de la clínica de la petición y, en último caso, el de
+// la API (DEFAULT_LOCALE).
+//
+// El catálogo sigue el modelo de gettext: la clave de cada mensaje es su texto
+// en inglés, así que un mensaje sin traducción sale en inglés.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Locale es un idioma soportado (código ISO 639-1, sin región)
+type Locale string
+
+const (
+	English    Locale = "en"
+	Spanish    Locale = "es"
+	Portuguese Locale = "pt"
+)
+
+// Supported son los idiomas con catálogo, en orden de preferencia ante un empate
+var Supported = []Locale{English, Spanish, Portuguese}
+
+var (
+	defaultLocale = English
+	clinicLocale  func(ctx context.Context, clinicID string) string
+)
+
+// SetDefault fija el idioma de la API cuando ni el cliente ni la clínica indican uno.
+func SetDefault(tag string) error {
+	locale, ok := Parse(tag)
+	if !ok {
+		return fmt.Errorf("unsupported locale %q", tag)
+	}
+	defaultLocale = locale
+	return nil
+}
+
+// SetClinicLocales registra cómo obtener el idioma por defecto de una clínica
+// (vacío si no tiene o no existe). Se consulta solo al traducir un mensaje.
+func SetClinicLocales(fn func(ctx context.Context, clinicID string) string) {
+	clinicLocale = fn
+}
+
+// Parse reduce una etiqueta de idioma ("pt-BR", "es_CO", "EN") a un Locale soportado.
+func Parse(tag string) (Locale, bool) {
+	base, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	base, _, _ = strings.Cut(base, "_")
+	locale := Locale(strings.ToLower(base))
+	for _, supported := range Supported {
+		if locale == supported {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// Negotiate elige el idioma soportado de mayor calidad (q) de una cabecera
+// Accept-Language. ok es false si el cliente no pide ninguno soportado.
+func Negotiate(header string) (Locale, bool) {
+	type candidate struct {
+		locale  Locale
+		quality float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		locale, ok := Parse(tag)
+		if !ok {
+			continue
+		}
+		quality := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			candidates = append(candidates, candidate{locale, quality})
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].quality > candidates[j].quality })
+	return candidates[0].locale, true
+}
+
+// state es el idioma de una petición. Se resuelve de forma perezosa porque la
+// clínica solo se conoce después del enrutado (y consultarla cuesta una lectura).
+type state struct {
+	requested Locale // De Accept-Language; vacío si no pidió uno soportado
+	clinicID  string
+	once      sync.Once
+	resolved  Locale
+}
+
+type contextKey struct{}
+
+// Middleware guarda en el contexto el idioma pedido en Accept-Language.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s := &state{}
+		if locale, ok := Negotiate(r.Header.Get("Accept-Language")); ok {
+			s.requested = locale
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, s)))
+	})
+}
+
+// UseClinic indica la clínica de la petición, cuyo idioma se usa si el cliente
+// no pidió uno. No tiene efecto si el idioma ya se resolvió.
+func UseClinic(ctx context.Context, clinicID string) {
+	if s, ok := ctx.Value(contextKey{}).(*state); ok && clinicID != "" {
+		s.clinicID = clinicID
+	}
+}
+
+// FromContext devuelve el idioma de la petición.
+func FromContext(ctx context.Context) Locale {
+	s, ok := ctx.Value(contextKey{}).(*state)
+	if !ok {
+		return defaultLocale
+	}
+	s.once.Do(func() {
+		s.resolved = s.requested
+		if s.resolved == "" && s.clinicID != "" && clinicLocale != nil {
+			s.resolved, _ = Parse(clinicLocale(ctx, s.clinicID))
+		}
+		if s.resolved == "" {
+			s.resolved = defaultLocale
+		}
+	})
+	return s.resolved
+}
+
+// Translate devuelve el mensaje en el idioma indicado. Con args, el mensaje es
+// un formato de fmt (todas las traducciones usan los mismos verbos en el mismo orden).
+func Translate(locale Locale, msg string, args ...any) string {
+	if translated, ok := catalog[locale][msg]; ok {
+		msg = translated
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
@@ -0,0 +1,260 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/search"
+	"github.com/zabaletac3/go-vet-api/internal/validators"
+)
+
+// Campos de importación a los que se puede asignar una columna
+const (
+	FieldOwnerFullName    = "owner.fullName"
+	FieldOwnerEmail       = "owner.email"
+	FieldOwnerPhone       = "owner.phone"
+	FieldOwnerDocumentID  = "owner.documentId"
+	FieldOwnerAddress     = "owner.address"
+	FieldPatientName      = "patient.name"
+	FieldPatientSpecies   = "patient.species"
+	FieldPatientBreed     = "patient.breed"
+	FieldPatientSex       = "patient.sex"
+	FieldPatientBirthDate = "patient.birthDate"
+	FieldPatientWeightKg  = "patient.weightKg"
+	FieldPatientMicrochip = "patient.microchip"
+	FieldPatientNotes     = "patient.notes"
+)
+
+// Field describe un campo de importación.
+type Field struct {
+	Key      string
+	Required bool
+
+	// aliases son nombres de columna habituales (es, en, pt), ya normalizados
+	// con search.Normalize, con los que Suggest asigna la columna.
+	aliases []string
+}
+
+// Fields son los campos de importación, en el orden en que se documentan.
+var Fields = []Field{
+	{FieldOwnerFullName, true, []string{"owner", "owner name", "tutor", "nombre tutor", "nombre del tutor", "propietario", "dueno", "nombre propietario", "nombre dueno", "cliente", "nome tutor", "nome do tutor"}},
+	{FieldOwnerEmail, false, []string{"email", "e mail", "correo", "correo electronico", "owner email", "email tutor"}},
+	{FieldOwnerPhone, false, []string{"phone", "telephone", "mobile", "telefono", "celular", "movil", "telefone", "owner phone"}},
+	{FieldOwnerDocumentID, false, []string{"document", "document id", "documento", "cedula", "dni", "cpf", "rut", "identificacion"}},
+	{FieldOwnerAddress, false, []string{"address", "direccion", "endereco"}},
+	{FieldPatientName, true, []string{"pet", "pet name", "patient", "patient name", "name", "mascota", "nombre mascota", "nombre de la mascota", "paciente", "nombre paciente", "nombre", "nome", "nome do pet", "animal"}},
+	{FieldPatientSpecies, true, []string{"species", "especie", "especie animal"}},
+	{FieldPatientBreed, false, []string{"breed", "raza", "raca"}},
+	{FieldPatientSex, false, []string{"sex", "gender", "sexo", "genero"}},
+	{FieldPatientBirthDate, false, []string{"birth date", "birthdate", "date of birth", "dob", "fecha nacimiento", "fecha de nacimiento", "nacimiento", "data de nascimento", "data nascimento"}},
+	{FieldPatientWeightKg, false, []string{"weight", "weight kg", "peso", "peso kg"}},
+	{FieldPatientMicrochip, false, []string{"microchip", "chip"}},
+	{FieldPatientNotes, false, []string{"notes", "notas", "observaciones", "observacoes", "comments", "comentarios"}},
+}
+
+// ErrInvalidMapping indica un mapeo con campos desconocidos, columnas que no
+// existen o campos obligatorios sin columna.
+var ErrInvalidMapping = errors.New("invalid column mapping")
+
+// Suggest propone un mapeo comparando la cabecera con los nombres habituales
+// de cada campo. Cada columna se asigna como mucho a un campo.
+func Suggest(columns []string) map[string]string {
+	mapping := make(map[string]string)
+	used := make(map[string]bool)
+	for _, field := range Fields {
+		for _, column := range columns {
+			if !used[column] && slices.Contains(field.aliases, search.Normalize(column)) {
+				mapping[field.Key] = column
+				used[column] = true
+				break
+			}
+		}
+	}
+	return mapping
+}
+
+// CheckMapping valida el mapeo contra la cabecera del archivo. Los errores se
+// devuelven por campo, en el idioma indicado.
+func CheckMapping(mapping map[string]string, columns []string, locale i18n.Locale) []validators.FieldError {
+	var errs []validators.FieldError
+	for key, column := range mapping {
+		if !slices.ContainsFunc(Fields, func(f Field) bool { return f.Key == key }) {
+			errs = append(errs, validators.FieldError{Field: key, Message: i18n.Translate(locale, "Unknown import field")})
+			continue
+		}
+		if column != "" && !slices.Contains(columns, column) {
+			errs = append(errs, validators.FieldError{Field: key, Message: i18n.Translate(locale, "Column not found in the file"), Value: column})
+		}
+	}
+	for _, field := range Fields {
+		if field.Required && mapping[field.Key] == "" {
+			errs = append(errs, validators.FieldError{Field: field.Key, Message: i18n.Translate(locale, "A column must be mapped to this field")})
+		}
+	}
+	slices.SortFunc(errs, func(a, b validators.FieldError) int { return strings.Compare(a.Field, b.Field) })
+	return errs
+}
+
+// Record es una fila ya mapeada a los campos de importación. Se valida con las
+// mismas reglas que el resto de la API (valid_species, datetime...).
+type Record struct {
+	OwnerFullName    string `validate:"required,max=150"`
+	OwnerEmail       string `validate:"omitempty,email"`
+	OwnerPhone       string `validate:"omitempty,max=30"`
+	OwnerDocumentID  string `validate:"omitempty,max=50"`
+	OwnerAddress     string `validate:"omitempty,max=300"`
+	PatientName      string `validate:"required,max=100"`
+	PatientSpecies   string `validate:"required,valid_species"`
+	PatientBreed     string `validate:"omitempty,max=100"`
+	PatientSex       string `validate:"omitempty,oneof=male female"`
+	PatientBirthDate string `validate:"omitempty,datetime"`
+	PatientWeightKg  string `validate:"omitempty,numeric"`
+	PatientMicrochip string `validate:"omitempty,max=50"`
+	PatientNotes     string `validate:"omitempty,max=1000"`
+}
+
+// recordFields asocia cada campo de importación con su campo de Record.
+var recordFields = map[string]func(*Record) *string{
+	FieldOwnerFullName:    func(r *Record) *string { return &r.OwnerFullName },
+	FieldOwnerEmail:       func(r *Record) *string { return &r.OwnerEmail },
+	FieldOwnerPhone:       func(r *Record) *string { return &r.OwnerPhone },
+	FieldOwnerDocumentID:  func(r *Record) *string { return &r.OwnerDocumentID },
+	FieldOwnerAddress:     func(r *Record) *string { return &r.OwnerAddress },
+	FieldPatientName:      func(r *Record) *string { return &r.PatientName },
+	FieldPatientSpecies:   func(r *Record) *string { return &r.PatientSpecies },
+	FieldPatientBreed:     func(r *Record) *string { return &r.PatientBreed },
+	FieldPatientSex:       func(r *Record) *string { return &r.PatientSex },
+	FieldPatientBirthDate: func(r *Record) *string { return &r.PatientBirthDate },
+	FieldPatientWeightKg:  func(r *Record) *string { return &r.PatientWeightKg },
+	FieldPatientMicrochip: func(r *Record) *string { return &r.PatientMicrochip },
+	FieldPatientNotes:     func(r *Record) *string { return &r.PatientNotes },
+}
+
+// speciesAliases traduce los nombres de especie habituales en español y
+// portugués (ya normalizados) a los valores de valid_species.
+var speciesAliases = map[string]string{
+	"perro": "dog", "perra": "dog", "cao": "dog", "cachorro": "dog", "canino": "dog",
+	"gato": "cat", "gata": "cat", "felino": "cat",
+	"ave": "bird", "pajaro": "bird", "passaro": "bird",
+	"pez": "fish", "peixe": "fish",
+	"conejo": "rabbit", "coelho": "rabbit",
+	"huron": "ferret", "furao": "ferret",
+	"cobaya": "guinea_pig", "cuy": "guinea_pig", "porquinho da india": "guinea_pig", "guinea pig": "guinea_pig",
+	"reptil":  "reptile",
+	"caballo": "horse", "cavalo": "horse",
+	"vaca":  "cow",
+	"cerdo": "pig", "porco": "pig",
+	"cabra": "goat",
+	"oveja": "sheep", "ovelha": "sheep",
+}
+
+// sexAliases traduce los valores de sexo habituales a male o female.
+var sexAliases = map[string]string{
+	"m": "male", "macho": "male", "male": "male",
+	"f": "female", "h": "female", "hembra": "female", "femea": "female", "female": "female",
+}
+
+// Build construye el Record de una fila. Los valores se normalizan antes de
+// validarlos: especie y sexo en español o portugués, emails en minúsculas,
+// pesos con coma decimal y fechas de Excel (número de serie) en los XLSX.
+func Build(mapping map[string]string, columns, values []string, format string) Record {
+	var record Record
+	for key, column := range mapping {
+		i := slices.Index(columns, column)
+		target, ok := recordFields[key]
+		if !ok || i < 0 || i >= len(values) {
+			continue
+		}
+		*target(&record) = values[i]
+	}
+
+	record.OwnerEmail = strings.ToLower(record.OwnerEmail)
+	if species := search.Normalize(record.PatientSpecies); species != "" {
+		if alias, ok := speciesAliases[species]; ok {
+			species = alias
+		}
+		record.PatientSpecies = strings.ReplaceAll(species, " ", "_")
+	}
+	if sex := search.Normalize(record.PatientSex); sex != "" {
+		if alias, ok := sexAliases[sex]; ok {
+			sex = alias
+		}
+		record.PatientSex = sex
+	}
+	record.PatientWeightKg = strings.Replace(record.PatientWeightKg, ",", ".", 1)
+	if format == FormatXLSX {
+		if serial, err := strconv.ParseFloat(record.PatientBirthDate, 64); err == nil {
+			if date, err := excelize.ExcelDateToTime(serial, false); err == nil {
+				record.PatientBirthDate = date.Format(time.DateOnly)
+			}
+		}
+	}
+	return record
+}
+
+// Validate valida el registro y devuelve los errores por campo de importación
+// (p. ej. "patient.species") en el idioma indicado.
+func (r Record) Validate(locale i18n.Locale) []validators.FieldError {
+	errs := validators.Check(r, locale)
+	for i := range errs {
+		errs[i].Field = fieldKey(errs[i].Field)
+	}
+	if weight, err := r.Weight(); err == nil && weight < 0 {
+		errs = append(errs, validators.FieldError{
+			Field:   FieldPatientWeightKg,
+			Message: i18n.Translate(locale, "Must be greater than or equal to %s", "0"),
+			Value:   r.PatientWeightKg,
+		})
+	}
+	return errs
+}
+
+// fieldKey traduce el nombre (en minúsculas) de un campo de Record a su campo
+// de importación: "patientspecies" -> "patient.species".
+func fieldKey(name string) string {
+	for _, field := range Fields {
+		if strings.ToLower(strings.ReplaceAll(field.Key, ".", "")) == name {
+			return field.Key
+		}
+	}
+	return name
+}
+
+// Weight devuelve el peso en kg (0 si la fila no lo trae).
+func (r Record) Weight() (float64, error) {
+	if r.PatientWeightKg == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(r.PatientWeightKg, 64)
+}
+
+// BirthDate devuelve la fecha de nacimiento (nil si la fila no la trae).
+func (r Record) BirthDate() (*time.Time, error) {
+	if r.PatientBirthDate == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", time.DateOnly} {
+		if t, err := time.Parse(layout, r.PatientBirthDate); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid birth date %q", r.PatientBirthDate)
+}
+
+// Fields devuelve los valores no vacíos del registro por campo de importación.
+func (r Record) Fields() map[string]string {
+	fields := make(map[string]string)
+	for key, field := range recordFields {
+		if value := *field(&r); value != "" {
+			fields[key] = value
+		}
+	}
+	return fields
+}
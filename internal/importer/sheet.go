@@ -0,0 +1,192 @@
+// Package importer lee hojas de cálculo (CSV o XLSX) de pacientes y tutores y
+// convierte cada fila en un Record según el mapeo de columnas elegido.
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Formatos de archivo admitidos
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// MaxRows es el máximo de filas de datos por archivo.
+const MaxRows = 10000
+
+// maxUnzipSize limita lo que puede ocupar un XLSX descomprimido.
+const maxUnzipSize = 200 << 20
+
+// Errores al leer el archivo
+var (
+	ErrUnsupportedFormat = errors.New("unsupported file format")
+	ErrEmptySheet        = errors.New("file has no header row")
+	ErrTooManyRows       = errors.New("file has too many rows")
+	ErrUnreadableFile    = errors.New("file could not be read")
+)
+
+// Sheet es el contenido de un archivo: la cabecera y las filas de datos.
+type Sheet struct {
+	Format  string
+	Columns []string
+	Rows    [][]string // Cada fila tiene len(Columns) valores
+}
+
+// Parse lee un archivo CSV o XLSX; el formato se deduce de la extensión de name.
+// La primera fila no vacía es la cabecera; las filas vacías se ignoran.
+func Parse(name string, r io.Reader) (*Sheet, error) {
+	var (
+		records [][]string
+		err     error
+	)
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(name)), ".")
+	switch format {
+	case FormatCSV:
+		records, err = readCSV(r)
+	case FormatXLSX:
+		records, err = readXLSX(r)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, filepath.Ext(name))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := &Sheet{Format: format}
+	for _, record := range records {
+		if isBlank(record) {
+			continue
+		}
+		if sheet.Columns == nil {
+			sheet.Columns = columnNames(record)
+			continue
+		}
+		if len(sheet.Rows) == MaxRows {
+			return nil, fmt.Errorf("%w: max %d", ErrTooManyRows, MaxRows)
+		}
+		row := make([]string, len(sheet.Columns))
+		for i := range row {
+			if i < len(record) {
+				row[i] = strings.TrimSpace(record[i])
+			}
+		}
+		sheet.Rows = append(sheet.Rows, row)
+	}
+	if sheet.Columns == nil {
+		return nil, ErrEmptySheet
+	}
+	return sheet, nil
+}
+
+// readCSV lee un CSV separado por comas, punto y coma o tabuladores (Excel usa
+// punto y coma en los idiomas con coma decimal). Se descarta el BOM de UTF-8.
+func readCSV(r io.Reader) ([][]string, error) {
+	br := bufio.NewReader(r)
+	if bom, err := br.Peek(3); err == nil && bytes.Equal(bom, []byte{0xEF, 0xBB, 0xBF}) {
+		br.Discard(3)
+	}
+	first, _ := br.Peek(4096)
+
+	reader := csv.NewReader(br)
+	reader.Comma = detectDelimiter(first)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnreadableFile, err)
+	}
+	return records, nil
+}
+
+// detectDelimiter elige el separador más frecuente en la primera línea.
+func detectDelimiter(head []byte) rune {
+	if i := bytes.IndexByte(head, '\n'); i >= 0 {
+		head = head[:i]
+	}
+	best, bestCount := ',', bytes.Count(head, []byte{','})
+	for _, d := range []rune{';', '\t'} {
+		if n := bytes.Count(head, []byte(string(d))); n > bestCount {
+			best, bestCount = d, n
+		}
+	}
+	return best
+}
+
+// readXLSX lee la primera hoja del libro. Las celdas se leen sin el formato de
+// Excel (las fechas llegan como número de serie; ver Record).
+func readXLSX(r io.Reader) ([][]string, error) {
+	book, err := excelize.OpenReader(r, excelize.Options{
+		RawCellValue:   true,
+		UnzipSizeLimit: maxUnzipSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnreadableFile, err)
+	}
+	defer book.Close()
+
+	sheets := book.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, ErrEmptySheet
+	}
+	rows, err := book.Rows(sheets[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnreadableFile, err)
+	}
+	defer rows.Close()
+
+	var records [][]string
+	for rows.Next() {
+		// Cabecera incluida: se corta una fila después del máximo
+		if len(records) > MaxRows+1 {
+			return nil, fmt.Errorf("%w: max %d", ErrTooManyRows, MaxRows)
+		}
+		record, err := rows.Columns()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnreadableFile, err)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Error(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnreadableFile, err)
+	}
+	return records, nil
+}
+
+// columnNames limpia la cabecera: da nombre a las columnas vacías y numera las
+// repetidas, porque el mapeo identifica las columnas por su nombre.
+func columnNames(header []string) []string {
+	names := make([]string, len(header))
+	seen := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			name = "Column " + strconv.Itoa(i+1)
+		}
+		if seen[name]++; seen[name] > 1 {
+			name = fmt.Sprintf("%s (%d)", name, seen[name])
+		}
+		names[i] = name
+	}
+	return names
+}
+
+func isBlank(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/recorder"
+)
+
+// unmatchedRoute es la etiqueta de las peticiones que no coinciden con ningún
+// patrón: así las URLs inventadas (escaneos, 404) no crean series nuevas.
+const unmatchedRoute = "unmatched"
+
+var (
+	httpRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace, Subsystem: "http", Name: "requests_total",
+		Help: "HTTP requests by route pattern, method and status code.",
+	}, []string{"route", "method", "status"})
+	httpDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace, Subsystem: "http", Name: "request_duration_seconds",
+		Help:    "HTTP request latency by route pattern and method.",
+		Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"route", "method"})
+	httpInFlight = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace, Subsystem: "http", Name: "requests_in_flight",
+		Help: "HTTP requests being served.",
+	})
+)
+
+// Middleware mide cada petición. Debe envolver directamente al ServeMux: la
+// ruta se toma de r.Pattern, que el mux rellena en la misma petición que recibe
+// (p. ej. "GET /api/v1/clinics/{id}"), así que la cardinalidad está acotada.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httpInFlight.Inc()
+		defer httpInFlight.Dec()
+
+		start := time.Now()
+		rec := recorder.Wrap(w)
+		next.ServeHTTP(rec, r)
+
+		route := r.Pattern
+		if route == "" {
+			route = unmatchedRoute
+		}
+		httpRequests.WithLabelValues(route, r.Method, strconv.Itoa(status(rec))).Inc()
+		httpDuration.WithLabelValues(route, r.Method).Observe(time.Since(start).Seconds())
+	})
+}
+
+// status es el código de la respuesta; un handler que no escribió nada
+// responde 200 al terminar
+func status(rec *recorder.Recorder) int {
+	if rec.Status() == 0 {
+		return http.StatusOK
+	}
+	return rec.Status()
+}
@@ -0,0 +1,68 @@
+// Package metrics expone las métricas de la API en formato Prometheus: HTTP
+// (por patrón de ruta del ServeMux, sin instrumentar cada handler), comandos y
+// pool de conexiones de MongoDB, y contadores de negocio.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace es el prefijo de todas las métricas propias
+const namespace = "vetapi"
+
+// Registry tiene todas las métricas de la API, más las del runtime de Go y
+// del proceso. Es propio (no el global de Prometheus) para que ninguna
+// dependencia añada métricas sin querer.
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler sirve las métricas en el formato de texto de Prometheus.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// Contadores de negocio. Los incrementan los servicios al confirmar cada
+// operación (en un lote atómico, solo si el lote se confirma).
+var (
+	ClinicsCreated = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Name: "clinics_created_total",
+		Help: "Clinics created.",
+	})
+	ClinicsDeleted = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Name: "clinics_deleted_total",
+		Help: "Clinics moved to the trash.",
+	})
+	ClinicsRestored = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Name: "clinics_restored_total",
+		Help: "Clinics restored from the trash.",
+	})
+	ClinicsPurged = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Name: "clinics_purged_total",
+		Help: "Clinics permanently deleted after the retention period.",
+	})
+	UsersRegistered = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Name: "users_registered_total",
+		Help: "Users registered.",
+	})
+	ImportsFinished = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace, Name: "imports_finished_total",
+		Help: "Patient and owner imports finished, by status.",
+	}, []string{"status"})
+	TransfersFinished = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace, Name: "transfers_finished_total",
+		Help: "Clinic exports and imports finished, by kind and status.",
+	}, []string{"kind", "status"})
+)
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+var (
+	mongoCommandDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace, Subsystem: "mongodb", Name: "command_duration_seconds",
+		Help:    "MongoDB command latency by command name and outcome.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 5},
+	}, []string{"command", "outcome"})
+	mongoPoolOpen = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace, Subsystem: "mongodb", Name: "pool_connections_open",
+		Help: "Open connections in the MongoDB connection pools.",
+	})
+	mongoPoolInUse = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace, Subsystem: "mongodb", Name: "pool_connections_in_use",
+		Help: "MongoDB connections checked out by operations.",
+	})
+	mongoPoolCheckoutFailures = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Subsystem: "mongodb", Name: "pool_checkout_failures_total",
+		Help: "Operations that could not get a MongoDB connection (e.g. pool wait timeout).",
+	})
+	mongoPoolCleared = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Subsystem: "mongodb", Name: "pool_cleared_total",
+		Help: "Times a MongoDB connection pool was cleared after a network error.",
+	})
+)
+
+// CommandMonitor mide la duración de cada comando de MongoDB.
+func CommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			mongoCommandDuration.WithLabelValues(e.CommandName, "success").Observe(e.Duration.Seconds())
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			mongoCommandDuration.WithLabelValues(e.CommandName, "failure").Observe(e.Duration.Seconds())
+		},
+	}
+}
+
+// PoolMonitor lleva el estado de los pools de conexiones de MongoDB.
+func PoolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			switch e.Type {
+			case event.ConnectionCreated:
+				mongoPoolOpen.Inc()
+			case event.ConnectionClosed:
+				mongoPoolOpen.Dec()
+			case event.GetSucceeded:
+				mongoPoolInUse.Inc()
+			case event.ConnectionReturned:
+				mongoPoolInUse.Dec()
+			case event.GetFailed:
+				mongoPoolCheckoutFailures.Inc()
+			case event.PoolCleared:
+				mongoPoolCleared.Inc()
+			}
+		},
+	}
+}
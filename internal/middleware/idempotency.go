@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+)
+
+const (
+	// IdempotencyKeyHeader es la cabecera con la que el cliente identifica un intento
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotencyReplayedHeader marca las respuestas repetidas desde el registro
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	// IdempotencyTTL es cuánto se recuerda una clave (y su respuesta)
+	IdempotencyTTL = 24 * time.Hour
+
+	maxIdempotencyKeyLength = 255
+	maxIdempotentBody       = 1 << 20
+)
+
+// replayedHeaders son las cabeceras de la respuesta original que se repiten
+var replayedHeaders = []string{"Content-Type", "Content-Language", "ETag", "Location"}
+
+// Idempotency hace que los POST con Idempotency-Key se ejecuten una sola vez:
+// un reintento con la misma clave y el mismo cuerpo recibe la respuesta
+// original, con otro cuerpo recibe 409 y, si la original sigue en curso, 409
+// con Retry-After. Las peticiones sin la cabecera pasan sin cambios.
+//
+// Las respuestas 5xx no se guardan: la clave se libera para poder reintentar.
+func Idempotency(store storage.IdempotencyStorer, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				response.Error(w, r, response.CodeInvalidIdempotencyKey, "Idempotency-Key must be at most 255 characters")
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBody))
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					response.Error(w, r, response.CodePayloadTooLarge, "Request body is too large")
+					return
+				}
+				response.Error(w, r, response.CodeBadRequest, "Request body could not be read")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			ts := time.Now().UTC()
+			id := storage.IdempotencyID(r.Method, r.URL.Path, key)
+			// Si esta petición tarda más que el bloqueo y otra toma la clave,
+			// el owner impide que esta complete o libere la reserva ajena
+			owner := rand.Text()
+			existing, err := store.Reserve(r.Context(), &models.IdempotencyRecord{
+				ID:          id,
+				Fingerprint: fingerprint(r.Method, r.URL.Path, body),
+				Status:      models.IdempotencyProcessing,
+				Owner:       owner,
+				LockedUntil: ts.Add(storage.IdempotencyLock),
+				CreatedAt:   ts,
+				ExpiresAt:   ts.Add(IdempotencyTTL),
+			})
+			if err != nil {
+				logger.ErrorContext(r.Context(), "Error reserving idempotency key", "error", err)
+				response.Error(w, r, response.CodeInternal, "An unexpected error occurred")
+				return
+			}
+			if existing != nil {
+				replay(w, r, existing, fingerprint(r.Method, r.URL.Path, body))
+				return
+			}
+
+			// El registro se completa o libera aunque el cliente se desconecte
+			ctx := context.WithoutCancel(r.Context())
+			rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			defer func() {
+				if p := recover(); p != nil {
+					release(ctx, store, id, owner, logger)
+					panic(p)
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+
+			if rec.status >= http.StatusInternalServerError {
+				release(ctx, store, id, owner, logger)
+				return
+			}
+			cached := models.CachedResponse{Status: rec.status, Header: map[string]string{}, Body: rec.body.Bytes()}
+			for _, name := range replayedHeaders {
+				if value := w.Header().Get(name); value != "" {
+					cached.Header[name] = value
+				}
+			}
+			if err := store.Complete(ctx, id, owner, cached); err != nil {
+				logger.ErrorContext(r.Context(), "Error saving idempotent response", "error", err)
+			}
+		})
+	}
+}
+
+// replay responde a un reintento según el estado del registro existente
+func replay(w http.ResponseWriter, r *http.Request, existing *models.IdempotencyRecord, fp string) {
+	switch {
+	case existing.Fingerprint != fp:
+		response.Error(w, r, response.CodeIdempotencyKeyReused, "Idempotency-Key was already used with a different request")
+	case existing.Status != models.IdempotencyCompleted || existing.Response == nil:
+		w.Header().Set("Retry-After", "1")
+		response.Error(w, r, response.CodeIdempotencyInProgress, "A request with this Idempotency-Key is still being processed")
+	default:
+		for name, value := range existing.Response.Header {
+			w.Header().Set(name, value)
+		}
+		w.Header().Set(IdempotencyReplayedHeader, "true")
+		w.Header().Set("Content-Length", strconv.Itoa(len(existing.Response.Body)))
+		w.WriteHeader(existing.Response.Status)
+		w.Write(existing.Response.Body)
+	}
+}
+
+func release(ctx context.Context, store storage.IdempotencyStorer, id, owner string, logger *slog.Logger) {
+	if err := store.Release(ctx, id, owner); err != nil {
+		logger.ErrorContext(ctx, "Error releasing idempotency key", "error", err, "key", id)
+	}
+}
+
+// fingerprint identifica la petición: un reintento legítimo es idéntico
+func fingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder copia el status y el cuerpo de la respuesta mientras se envía
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -4,129 +4,69 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
-	"strings"
 
-	"github.com/go-playground/validator/v10"
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/logging"
 	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
 	"github.com/zabaletac3/go-vet-api/internal/validators"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-// formatValidationErrors formatea los errores de validación para que sean legibles
-func formatValidationErrors(err error) []response.ValidationError {
+// formatValidationErrors formatea los errores de validación para que sean
+// legibles, en el idioma de la petición
+func formatValidationErrors(err error, locale i18n.Locale) []response.ValidationError {
+	return toResponse(validators.Describe(err, locale))
+}
+
+// toResponse convierte los errores por campo de validators al formato de la API
+func toResponse(fieldErrors []validators.FieldError) []response.ValidationError {
 	var errors []response.ValidationError
-	
-	if validationErrors, ok := err.(validator.ValidationErrors); ok {
-		for _, fieldError := range validationErrors {
-			field := strings.ToLower(fieldError.Field())
-			var message string
-			
-			switch fieldError.Tag() {
-			case "required":
-				message = "Este campo es requerido"
-			case "email":
-				message = "Debe ser un email válido"
-			case "min":
-				if fieldError.Kind().String() == "string" {
-					message = "Debe tener al menos " + fieldError.Param() + " caracteres"
-				} else {
-					message = "El valor mínimo es " + fieldError.Param()
-				}
-			case "max":
-				if fieldError.Kind().String() == "string" {
-					message = "No puede tener más de " + fieldError.Param() + " caracteres"
-				} else {
-					message = "El valor máximo es " + fieldError.Param()
-				}
-			case "len":
-				message = "Debe tener exactamente " + fieldError.Param() + " caracteres"
-			case "oneof":
-				options := strings.ReplaceAll(fieldError.Param(), " ", ", ")
-				message = "Debe ser uno de: " + options
-			case "gt":
-				message = "Debe ser mayor que " + fieldError.Param()
-			case "gte":
-				message = "Debe ser mayor o igual que " + fieldError.Param()
-			case "lt":
-				message = "Debe ser menor que " + fieldError.Param()
-			case "lte":
-				message = "Debe ser menor o igual que " + fieldError.Param()
-			case "strong_password":
-				message = "La contraseña debe tener al menos 8 caracteres, incluyendo mayúsculas, minúsculas, números y caracteres especiales"
-			case "valid_species":
-				message = "Especie no válida. Especies permitidas: dog, cat, bird, fish, rabbit, hamster, guinea_pig, ferret, reptile, horse, cow, pig, goat, sheep"
-			case "mongodb_id":
-				message = "Debe ser un ID de MongoDB válido"
-			case "datetime":
-				message = "Debe ser una fecha válida en formato ISO 8601"
-			default:
-				message = "Valor inválido"
-			}
-			
-			// Convertir el valor a string de manera segura
-			var valueStr string
-			if fieldError.Value() != nil {
-				if str, ok := fieldError.Value().(string); ok {
-					valueStr = str
-				} else {
-					valueStr = "" // O podrías usar fmt.Sprintf("%v", fieldError.Value())
-				}
-			}
-			
-			errors = append(errors, response.ValidationError{
-				Field:   field,
-				Message: message,
-				Value:   valueStr,
-			})
-		}
+	for _, fe := range fieldErrors {
+		errors = append(errors, response.ValidationError{
+			Field:   fe.Field,
+			Message: fe.Message,
+			Value:   fe.Value,
+		})
 	}
-	
 	return errors
 }
 
+// ValidationErrors valida v con las reglas de validators y devuelve sus
+// errores por campo en el idioma de la petición (nil si es válido). Sirve para
+// validar cada elemento de un lote con las mismas reglas que ValidateRequest.
+func ValidationErrors(r *http.Request, v any) []response.ValidationError {
+	return toResponse(validators.Check(v, i18n.FromContext(r.Context())))
+}
+
 // ValidateRequest es un middleware genérico para validar requests JSON
 func ValidateRequest[T any](handler func(w http.ResponseWriter, r *http.Request, req T, db *mongo.Database, logger *slog.Logger)) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Obtener dependencias del contexto
 		db := r.Context().Value("db").(*mongo.Database)
-		logger := r.Context().Value("logger").(*slog.Logger)
+		logger := logging.FromContext(r.Context())
 		
 		var req T
 		
 		// Decodificar JSON
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			logger.Error("Error decodificando JSON", "error", err, "path", r.URL.Path)
-			response.JSON(w, http.StatusBadRequest, response.ErrorResponse{
-				Error:   "JSON malformado",
-				Message: "El formato del JSON enviado no es válido",
-			})
+			logger.ErrorContext(r.Context(), "Error decodificando JSON", "error", err)
+			response.Error(w, r, response.CodeMalformedJSON, "Request body is not valid JSON")
 			return
 		}
 		
 		// Validar estructura
 		validate := validators.GetValidator()
 		if validate == nil {
-			logger.Error("Error: validator no inicializado")
-			response.JSON(w, http.StatusInternalServerError, response.ErrorResponse{
-				Error:   "Error interno del servidor",
-				Message: "Sistema de validación no disponible",
-			})
+			logger.ErrorContext(r.Context(), "Error: validator no inicializado")
+			response.Error(w, r, response.CodeInternal, "Validation is not available")
 			return
 		}
 		
 		if err := validate.Struct(req); err != nil {
-			logger.Warn("Errores de validación", 
-				"errors", err.Error(), 
-				"path", r.URL.Path,
-				"method", r.Method,
-			)
+			logger.WarnContext(r.Context(), "Errores de validación", "errors", err.Error())
 			
-			validationErrors := formatValidationErrors(err)
-			response.JSON(w, http.StatusBadRequest, response.ValidationErrorResponse{
-				Error:   "Datos de entrada inválidos",
-				Message: "Los datos enviados contienen errores de validación",
-				Fields:  validationErrors,
-			})
+			validationErrors := formatValidationErrors(err, i18n.FromContext(r.Context()))
+			response.ValidationErrorRes(w, r, "Request body has invalid fields", validationErrors)
 			return
 		}
 		
@@ -142,38 +82,28 @@ func ValidateRequestWithDeps[T any](handler func(w http.ResponseWriter, r *http.
 		
 		// Decodificar JSON
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			logger.Error("Error decodificando JSON", "error", err, "path", r.URL.Path)
-			response.JSON(w, http.StatusBadRequest, response.ErrorResponse{
-				Error:   "JSON malformado",
-				Message: "El formato del JSON enviado no es válido",
-			})
+			logger.ErrorContext(r.Context(), "Error decodificando JSON", "error", err, "path", r.URL.Path)
+			response.Error(w, r, response.CodeMalformedJSON, "Request body is not valid JSON")
 			return
 		}
 		
 		// Validar estructura
 		validate := validators.GetValidator()
 		if validate == nil {
-			logger.Error("Error: validator no inicializado")
-			response.JSON(w, http.StatusInternalServerError, response.ErrorResponse{
-				Error:   "Error interno del servidor",
-				Message: "Sistema de validación no disponible",
-			})
+			logger.ErrorContext(r.Context(), "Error: validator no inicializado")
+			response.Error(w, r, response.CodeInternal, "Validation is not available")
 			return
 		}
 		
 		if err := validate.Struct(req); err != nil {
-			logger.Warn("Errores de validación", 
+			logger.WarnContext(r.Context(), "Errores de validación", 
 				"errors", err.Error(), 
 				"path", r.URL.Path,
 				"method", r.Method,
 			)
 			
-			validationErrors := formatValidationErrors(err)
-			response.JSON(w, http.StatusBadRequest, response.ValidationErrorResponse{
-				Error:   "Datos de entrada inválidos",
-				Message: "Los datos enviados contienen errores de validación",
-				Fields:  validationErrors,
-			})
+			validationErrors := formatValidationErrors(err, i18n.FromContext(r.Context()))
+			response.ValidationErrorRes(w, r, "Request body has invalid fields", validationErrors)
 			return
 		}
 		
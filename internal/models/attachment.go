@@ -0,0 +1,57 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Categorías de los ficheros adjuntos
+const (
+	AttachmentMedicalRecord = "medicalRecord" // Historia clínica, informes, consentimientos
+	AttachmentLabResult     = "labResult"     // Resultados de laboratorio
+	AttachmentImaging       = "imaging"       // Radiografías, ecografías...
+	AttachmentDocument      = "document"      // Cualquier otro documento de la clínica
+)
+
+// AttachmentCategories son todas las categorías de adjuntos.
+var AttachmentCategories = []string{AttachmentMedicalRecord, AttachmentLabResult, AttachmentImaging, AttachmentDocument}
+
+// IsAttachmentCategory indica si category es una categoría de adjuntos.
+func IsAttachmentCategory(category string) bool {
+	return slices.Contains(AttachmentCategories, category)
+}
+
+// Estados del análisis antivirus de un adjunto. Los infectados no se guardan.
+const (
+	ScanClean     = "clean"     // El antivirus no encontró amenazas
+	ScanUnscanned = "unscanned" // No había antivirus configurado al subirlo
+)
+
+// Attachment son los metadatos de un fichero adjunto de una clínica. El
+// contenido está en el almacén de ficheros (ver el paquete blob) con la clave
+// Key; los adjuntos de una clínica con el mismo contenido comparten fichero.
+type Attachment struct {
+	ID        primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	ClinicID  primitive.ObjectID  `bson:"clinicId" json:"clinicId"`
+	PatientID *primitive.ObjectID `bson:"patientId,omitempty" json:"patientId,omitempty"` // Paciente al que pertenece, si es de uno
+	Category  string              `bson:"category" json:"category"`
+
+	Filename    string `bson:"filename" json:"filename"`       // Nombre original del fichero
+	ContentType string `bson:"contentType" json:"contentType"` // Detectado a partir del contenido
+	Size        int64  `bson:"size" json:"size"`
+	Hash        string `bson:"hash" json:"hash"` // SHA-256 del contenido en hexadecimal
+	Key         string `bson:"key" json:"-"`     // Clave en el almacén de ficheros
+
+	Scan AttachmentScan `bson:"scan" json:"scan"`
+
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+}
+
+// AttachmentScan es el resultado del análisis antivirus al subir el fichero.
+type AttachmentScan struct {
+	Status    string     `bson:"status" json:"status"`                     // clean o unscanned
+	Engine    string     `bson:"engine,omitempty" json:"engine,omitempty"` // Antivirus que lo analizó
+	ScannedAt *time.Time `bson:"scannedAt,omitempty" json:"scannedAt,omitempty"`
+}
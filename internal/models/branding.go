@@ -0,0 +1,59 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Imágenes de marca que puede subir una clínica
+const (
+	AssetLogo        = "logo"
+	AssetFavicon     = "favicon"
+	AssetEmailHeader = "emailHeader" // Cabecera de los correos que envía la clínica
+)
+
+// AssetKinds son los tipos de imagen de marca, en el orden en que se muestran.
+var AssetKinds = []string{AssetLogo, AssetFavicon, AssetEmailHeader}
+
+// IsAssetKind indica si kind es un tipo de imagen de marca.
+func IsAssetKind(kind string) bool {
+	return slices.Contains(AssetKinds, kind)
+}
+
+// BrandAsset es una imagen de marca ya redimensionada a sus variantes. El
+// original no se guarda: solo las variantes, que son lo que cuenta para el
+// almacenamiento del plan.
+type BrandAsset struct {
+	Hash        string         `bson:"hash" json:"hash"`               // SHA-256 (hex) del original: versiona las URLs
+	ContentType string         `bson:"contentType" json:"contentType"` // De las variantes (image/png o image/jpeg)
+	Variants    []AssetVariant `bson:"variants" json:"variants"`       // De menor a mayor
+	Size        int64          `bson:"size" json:"size"`               // Bytes de todas las variantes
+	UploadedAt  time.Time      `bson:"uploadedAt" json:"uploadedAt"`
+}
+
+// AssetVariant es una de las copias redimensionadas de una imagen de marca.
+type AssetVariant struct {
+	Name   string `bson:"name" json:"name"`
+	Width  int    `bson:"width" json:"width"`
+	Height int    `bson:"height" json:"height"`
+	Size   int64  `bson:"size" json:"size"`
+	File   string `bson:"file" json:"-"` // Nombre del fichero en el directorio de la clínica
+}
+
+// Variant devuelve la variante con ese nombre.
+func (a *BrandAsset) Variant(name string) (AssetVariant, bool) {
+	for _, v := range a.Variants {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return AssetVariant{}, false
+}
+
+// Asset devuelve la imagen de marca de ese tipo, o nil si la clínica no la ha subido.
+func (c *Clinic) Asset(kind string) *BrandAsset {
+	if asset, ok := c.Assets[kind]; ok {
+		return &asset
+	}
+	return nil
+}
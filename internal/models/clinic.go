@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/zabaletac3/go-vet-api/internal/search"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -21,6 +22,22 @@ type Clinic struct {
     Description string             `bson:"description,omitempty" json:"description,omitempty"`
     Palette     ColorPalette       `bson:"palette" json:"palette"`                   // Colores para UI
     IsActive    bool               `bson:"isActive" json:"isActive"`
+    Locale      string             `bson:"locale,omitempty" json:"locale,omitempty"` // Idioma por defecto de los mensajes de la API (es, en, pt)
+    Plan        string             `bson:"plan,omitempty" json:"plan,omitempty"`     // Plan de suscripción (ver Plans); vacío es DefaultPlan
+
+    // Flags propios de la clínica: sustituyen a los valores por defecto del plan
+    Features    map[string]FeatureFlag `bson:"features,omitempty" json:"features,omitempty"`
+
+    // Imágenes de marca por tipo (ver AssetKinds); los ficheros los guarda el paquete branding
+    Assets      map[string]BrandAsset  `bson:"assets,omitempty" json:"assets,omitempty"`
+
+    // Control de concurrencia optimista: se incrementa en cada escritura
+    Version     int64              `bson:"version" json:"version"`
+
+    // Claves de la búsqueda tolerante a errores (ver SearchKeys) y relevancia
+    // en un listado con search: Score solo se rellena al listar, no se guarda
+    Search      search.Keys        `bson:"search" json:"-"`
+    Score       float64            `bson:"score,omitempty" json:"-"`
     
     // Soft Delete simple
     DeletedAt   *time.Time `bson:"deletedAt,omitempty" json:"deletedAt,omitempty"`
@@ -38,6 +55,11 @@ type ColorPalette struct {
     Background string `bson:"background,omitempty" json:"background,omitempty"` // Color de fondo
 }
 
+// SearchKeys calcula las claves de búsqueda a partir de los nombres de la clínica
+func (c *Clinic) SearchKeys() search.Keys {
+    return search.NewKeys(c.Name, c.DisplayName)
+}
+
 // Validaciones de negocio
 func (c *Clinic) IsValid() error {
     if strings.TrimSpace(c.Name) == "" {
@@ -119,6 +141,15 @@ func (c *Clinic) GetDisplayName() string {
     return c.Name
 }
 
+// GetPlan devuelve el plan de la clínica; uno desconocido se trata como DefaultPlan
+func (c *Clinic) GetPlan() Plan {
+    if plan, ok := FindPlan(c.Plan); ok {
+        return plan
+    }
+    plan, _ := FindPlan(DefaultPlan)
+    return plan
+}
+
 func (c *Clinic) GetPrimaryColor() string {
     return c.Palette.Primary
 }
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"math"
+	"strconv"
+)
+
+// Colores de texto que se proponen sobre los de la paleta
+const (
+	TextDark  = "#111827" // Gray-900
+	TextLight = "#FFFFFF"
+)
+
+// RelativeLuminance es la luminancia relativa de WCAG 2.1 de un color
+// #RRGGBB, entre 0 (negro) y 1 (blanco). Un color inválido devuelve false.
+func RelativeLuminance(hex string) (float64, bool) {
+	if hex == "" || !isValidHexColor(hex) {
+		return 0, false
+	}
+	rgb, _ := strconv.ParseUint(hex[1:], 16, 32)
+	channel := func(shift uint) float64 {
+		c := float64((rgb>>shift)&0xFF) / 255
+		if c <= 0.03928 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(16) + 0.7152*channel(8) + 0.0722*channel(0), true
+}
+
+// ContrastRatio es el contraste de WCAG 2.1 entre dos colores, de 1 a 21.
+// Si alguno es inválido devuelve 0.
+func ContrastRatio(a, b string) float64 {
+	la, okA := RelativeLuminance(a)
+	lb, okB := RelativeLuminance(b)
+	if !okA || !okB {
+		return 0
+	}
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// TextColorOn devuelve el color de texto (TextDark o TextLight) que más
+// contrasta con background.
+func TextColorOn(background string) string {
+	if ContrastRatio(TextDark, background) >= ContrastRatio(TextLight, background) {
+		return TextDark
+	}
+	return TextLight
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestContrastRatio(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"#000000", "#FFFFFF", 21},
+		{"#FFFFFF", "#000000", 21}, // El orden no importa
+		{"#777777", "#FFFFFF", 4.48},
+		{"#3B82F6", "#FFFFFF", 3.68},
+		{"#FFFFFF", "#FFFFFF", 1},
+		{"#ffffff", "#000000", 21}, // Minúsculas
+	}
+	for _, tt := range tests {
+		if got := ContrastRatio(tt.a, tt.b); math.Abs(got-tt.want) > 0.01 {
+			t.Errorf("ContrastRatio(%s, %s) = %.3f, esperado %.2f", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestContrastRatioInvalidColor(t *testing.T) {
+	for _, color := range []string{"", "#FFF", "FFFFFF", "#GGGGGG", "#FFFFFFF"} {
+		if got := ContrastRatio(color, "#FFFFFF"); got != 0 {
+			t.Errorf("ContrastRatio(%q) = %v, esperado 0", color, got)
+		}
+		if _, ok := RelativeLuminance(color); ok {
+			t.Errorf("RelativeLuminance(%q) debe rechazar el color", color)
+		}
+	}
+}
+
+func TestRelativeLuminance(t *testing.T) {
+	tests := []struct {
+		color string
+		want  float64
+	}{
+		{"#000000", 0},
+		{"#FFFFFF", 1},
+		{"#FF0000", 0.2126},
+		{"#00FF00", 0.7152},
+		{"#0000FF", 0.0722},
+		{"#808080", 0.2159},
+	}
+	for _, tt := range tests {
+		got, ok := RelativeLuminance(tt.color)
+		if !ok || math.Abs(got-tt.want) > 0.0001 {
+			t.Errorf("RelativeLuminance(%s) = %.4f, %v; esperado %.4f", tt.color, got, ok, tt.want)
+		}
+	}
+}
+
+func TestTextColorOn(t *testing.T) {
+	tests := map[string]string{
+		"#FFFFFF": TextDark,
+		"#FACC15": TextDark,
+		"#000000": TextLight,
+		"#1D4ED8": TextLight,
+	}
+	for background, want := range tests {
+		if got := TextColorOn(background); got != want {
+			t.Errorf("TextColorOn(%s) = %s, esperado %s", background, got, want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"hash/fnv"
+	"regexp"
+	"slices"
+)
+
+// Modules son todos los módulos que se pueden activar por clínica; cada uno
+// es también un flag con su mismo nombre.
+var Modules = []string{ModuleSearch, ModuleImports, ModuleTransfers, ModuleBoarding, ModuleLabs, ModuleInventory}
+
+// featureKeyPattern es el formato de los nombres de flag
+var featureKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+// IsValidFeatureKey indica si key sirve como nombre de flag.
+func IsValidFeatureKey(key string) bool {
+	return featureKeyPattern.MatchString(key)
+}
+
+// IsModule indica si el flag key activa un módulo.
+func IsModule(key string) bool {
+	return slices.Contains(Modules, key)
+}
+
+// FeatureFlag es el valor de un flag: encendido o apagado o, con Rollout,
+// encendido para un porcentaje estable de sujetos (usuarios o clínicas).
+type FeatureFlag struct {
+	Enabled bool `bson:"enabled" json:"enabled"`
+	Rollout *int `bson:"rollout,omitempty" json:"rollout,omitempty"` // 0-100; si está, manda sobre Enabled
+}
+
+// EnabledFor evalúa el flag key para subject. Con Rollout, el mismo sujeto
+// obtiene siempre el mismo resultado y subir el porcentaje solo añade sujetos.
+func (f FeatureFlag) EnabledFor(key, subject string) bool {
+	if f.Rollout == nil {
+		return f.Enabled
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key + ":" + subject))
+	return int(h.Sum32()%100) < *f.Rollout
+}
+
+// FeatureDefault es el valor de un flag en el plan: los módulos del plan
+// están encendidos y el resto de flags toman su valor de Flags (o apagados).
+func (p Plan) FeatureDefault(key string) FeatureFlag {
+	if p.HasModule(key) {
+		return FeatureFlag{Enabled: true}
+	}
+	return p.Flags[key]
+}
+
+// Feature devuelve el flag key de la clínica, y si viene de la propia clínica
+// (true) o del plan.
+func (c *Clinic) Feature(key string) (FeatureFlag, bool) {
+	if flag, ok := c.Features[key]; ok {
+		return flag, true
+	}
+	return c.GetPlan().FeatureDefault(key), false
+}
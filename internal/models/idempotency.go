@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Estados de un registro de idempotencia
+const (
+	IdempotencyProcessing = "processing" // La petición original sigue en curso
+	IdempotencyCompleted  = "completed"  // Response tiene la respuesta a repetir
+)
+
+// IdempotencyRecord guarda la primera petición hecha con un Idempotency-Key y
+// su respuesta, para repetirla si el cliente reintenta con la misma clave.
+type IdempotencyRecord struct {
+	ID          string          `bson:"_id"`         // Método, ruta y clave (ver storage.IdempotencyID)
+	Fingerprint string          `bson:"fingerprint"` // Hash de método, ruta y cuerpo
+	Status      string          `bson:"status"`
+	Owner       string          `bson:"owner"` // Token de la petición que tiene la reserva
+	Response    *CachedResponse `bson:"response,omitempty"`
+	LockedUntil time.Time       `bson:"lockedUntil"` // Pasado este instante, un registro en curso se da por abandonado
+	CreatedAt   time.Time       `bson:"createdAt"`
+	ExpiresAt   time.Time       `bson:"expiresAt"` // Índice TTL
+}
+
+// CachedResponse es la respuesta guardada de una petición idempotente.
+type CachedResponse struct {
+	Status int               `bson:"status"`
+	Header map[string]string `bson:"header,omitempty"`
+	Body   []byte            `bson:"body"`
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Estados de un trabajo de importación
+const (
+	ImportUploaded  = "uploaded"  // Archivo leído; se puede previsualizar y ajustar el mapeo
+	ImportQueued    = "queued"    // Confirmado, a la espera de un ejecutor
+	ImportRunning   = "running"   // Un ejecutor está procesando las filas
+	ImportCompleted = "completed" // Todas las filas procesadas (algunas pueden haber fallado)
+	ImportFailed    = "failed"    // Se detuvo por un error del servidor; se puede reanudar
+)
+
+// Estados de una fila importada
+const (
+	ImportRowPending  = "pending"
+	ImportRowImported = "imported"
+	ImportRowInvalid  = "invalid"
+)
+
+// ImportJob es la importación de un archivo CSV o XLSX de pacientes y tutores
+// a una clínica. Las filas se guardan aparte (ImportRow) para poder procesarlas
+// por lotes y reanudar el trabajo por donde se quedó.
+type ImportJob struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ClinicID primitive.ObjectID `bson:"clinicId" json:"clinicId"`
+	FileName string             `bson:"fileName" json:"fileName"`
+	Format   string             `bson:"format" json:"format"`   // "csv" o "xlsx"
+	Columns  []string           `bson:"columns" json:"columns"` // Cabecera del archivo
+
+	// Mapping asocia cada campo de importación (p. ej. "patient.name") con una
+	// columna del archivo. Al subirlo se propone uno a partir de la cabecera.
+	Mapping map[string]string `bson:"mapping" json:"mapping"`
+
+	Status string      `bson:"status" json:"status"`
+	Locale string      `bson:"locale,omitempty" json:"locale,omitempty"` // Idioma de los errores por fila
+	Stats  ImportStats `bson:"stats" json:"stats"`
+	Error  string      `bson:"error,omitempty" json:"error,omitempty"` // Motivo del último fallo
+
+	CreatedAt   time.Time  `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time  `bson:"updatedAt" json:"updatedAt"`
+	StartedAt   *time.Time `bson:"startedAt,omitempty" json:"startedAt,omitempty"`
+	FinishedAt  *time.Time `bson:"finishedAt,omitempty" json:"finishedAt,omitempty"`
+	HeartbeatAt *time.Time `bson:"heartbeatAt,omitempty" json:"-"` // Lo renueva el ejecutor mientras trabaja
+}
+
+// ImportStats es el progreso de un trabajo de importación.
+type ImportStats struct {
+	Total         int `bson:"total" json:"total"`
+	Processed     int `bson:"processed" json:"processed"`
+	Imported      int `bson:"imported" json:"imported"`
+	Invalid       int `bson:"invalid" json:"invalid"`
+	OwnersCreated int `bson:"ownersCreated" json:"ownersCreated"`
+	OwnersMatched int `bson:"ownersMatched" json:"ownersMatched"` // Tutores existentes reutilizados por email o teléfono
+}
+
+// ImportRow es una fila de datos del archivo importado.
+type ImportRow struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty"`
+	JobID  primitive.ObjectID `bson:"jobId"`
+	Index  int                `bson:"index"`  // Posición entre las filas de datos, desde 0
+	Values []string           `bson:"values"` // Alineados con ImportJob.Columns
+	Status string             `bson:"status"`
+
+	// IDs reservados al subir el archivo: si el trabajo se interrumpe entre la
+	// creación del tutor o del paciente y el registro de la fila, al reanudar se
+	// detecta que ya existen en lugar de duplicarlos.
+	OwnerID   primitive.ObjectID `bson:"ownerId"`
+	PatientID primitive.ObjectID `bson:"patientId"`
+
+	OwnerCreated bool             `bson:"ownerCreated,omitempty"` // false si se reutilizó un tutor existente
+	Errors       []ImportRowError `bson:"errors,omitempty"`
+}
+
+// ImportRowError es un error de validación de un campo de una fila.
+type ImportRowError struct {
+	Field   string `bson:"field" json:"field"`
+	Message string `bson:"message" json:"message"`
+	Value   string `bson:"value,omitempty" json:"value,omitempty"`
+}
+
+// WithTotals completa los contadores derivados: filas procesadas y tutores
+// reutilizados (filas importadas que no crearon tutor).
+func (s ImportStats) WithTotals() ImportStats {
+	s.Processed = s.Imported + s.Invalid
+	s.OwnersMatched = s.Imported - s.OwnersCreated
+	return s
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/search"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Owner representa al tutor (dueño) de uno o varios pacientes dentro de una clínica.
+type Owner struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ClinicID   primitive.ObjectID `bson:"clinicId" json:"clinicId"`
+	FullName   string             `bson:"fullName" json:"fullName"`
+	Email      string             `bson:"email,omitempty" json:"email,omitempty"`
+	Phone      string             `bson:"phone,omitempty" json:"phone,omitempty"`
+	DocumentID string             `bson:"documentId,omitempty" json:"documentId,omitempty"` // Cédula, CPF, etc.
+	Address    string             `bson:"address,omitempty" json:"address,omitempty"`
+	Search     search.Keys        `bson:"search" json:"-"`             // Ver SearchKeys
+	Contacts   []string           `bson:"contacts,omitempty" json:"-"` // Ver ContactKeys
+	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt  time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// SearchKeys calcula las claves de búsqueda: nombre, email y documento.
+func (o *Owner) SearchKeys() search.Keys {
+	return search.NewKeys(o.FullName, o.Email, o.DocumentID)
+}
+
+// ContactKeys calcula las claves con las que se reconoce a un tutor existente
+// (p. ej. al importar): el email en minúsculas y el teléfono normalizado.
+func (o *Owner) ContactKeys() []string {
+	var keys []string
+	if email := strings.ToLower(strings.TrimSpace(o.Email)); email != "" {
+		keys = append(keys, email)
+	}
+	if phone := NormalizePhone(o.Phone); phone != "" {
+		keys = append(keys, phone)
+	}
+	return keys
+}
+
+// NormalizePhone deja solo los dígitos del teléfono y el + inicial, para que
+// "+57 300 123-4567" y "+573001234567" coincidan. Devuelve "" si no hay dígitos.
+func NormalizePhone(phone string) string {
+	var b strings.Builder
+	for i, c := range strings.TrimSpace(phone) {
+		if (c >= '0' && c <= '9') || (c == '+' && i == 0) {
+			b.WriteRune(c)
+		}
+	}
+	if strings.Trim(b.String(), "+") == "" {
+		return ""
+	}
+	return b.String()
+}
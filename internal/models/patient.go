@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/search"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Patient representa a una mascota atendida por una clínica.
+type Patient struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ClinicID  primitive.ObjectID `bson:"clinicId" json:"clinicId"`
+	OwnerID   primitive.ObjectID `bson:"ownerId" json:"ownerId"`
+	Name      string             `bson:"name" json:"name"`
+	Species   string             `bson:"species" json:"species"` // ver validators.GetSpeciesOptions
+	Breed     string             `bson:"breed,omitempty" json:"breed,omitempty"`
+	Sex       string             `bson:"sex,omitempty" json:"sex,omitempty"` // "male", "female"
+	BirthDate *time.Time         `bson:"birthDate,omitempty" json:"birthDate,omitempty"`
+	WeightKg  float64            `bson:"weightKg,omitempty" json:"weightKg,omitempty"`
+	Microchip string             `bson:"microchip,omitempty" json:"microchip,omitempty"`
+	Notes     string             `bson:"notes,omitempty" json:"notes,omitempty"`
+	Search    search.Keys        `bson:"search" json:"-"` // Ver SearchKeys
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// SearchKeys calcula las claves de búsqueda: nombre, raza y microchip.
+func (p *Patient) SearchKeys() search.Keys {
+	return search.NewKeys(p.Name, p.Breed, p.Microchip)
+}
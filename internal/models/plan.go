@@ -0,0 +1,112 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Módulos que un plan puede incluir
+const (
+	ModuleSearch    = "search"    // Búsqueda global tolerante a errores
+	ModuleImports   = "imports"   // Importación de pacientes desde CSV o XLSX
+	ModuleTransfers = "transfers" // Exportación de la clínica completa; todos los planes la incluyen (portabilidad)
+
+	// Aún sin rutas: sus grupos de rutas se protegen con el flag al añadirlos
+	ModuleBoarding  = "boarding"  // Hospitalización y residencia
+	ModuleLabs      = "labs"      // Pedidos y resultados de laboratorio
+	ModuleInventory = "inventory" // Inventario de productos y medicamentos
+)
+
+// Identificadores de los planes
+const (
+	PlanFree     = "free"
+	PlanStandard = "standard"
+	PlanPremium  = "premium"
+)
+
+// DefaultPlan es el plan de las clínicas que no indican ninguno.
+const DefaultPlan = PlanFree
+
+// Plan es un nivel de suscripción con sus límites. Un límite 0 es ilimitado.
+type Plan struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	MaxUsers    int64    `json:"maxUsers"`
+	MaxPatients int64    `json:"maxPatients"`
+	StorageMB   int64    `json:"storageMB"`
+	Modules     []string `json:"modules"`
+
+	// Flags son los valores por defecto de los flags que no son módulos;
+	// cada clínica puede sustituirlos (ver Clinic.Features).
+	Flags map[string]FeatureFlag `json:"flags,omitempty"`
+}
+
+// Plans son los planes a la venta, de menor a mayor.
+var Plans = []Plan{
+	{ID: PlanFree, Name: "Free", MaxUsers: 3, MaxPatients: 200, StorageMB: 100, Modules: []string{ModuleSearch, ModuleTransfers}},
+	{ID: PlanStandard, Name: "Standard", MaxUsers: 15, MaxPatients: 5000, StorageMB: 5 * 1024, Modules: []string{ModuleSearch, ModuleImports, ModuleTransfers, ModuleBoarding}},
+	{ID: PlanPremium, Name: "Premium", Modules: []string{ModuleSearch, ModuleImports, ModuleTransfers, ModuleBoarding, ModuleLabs, ModuleInventory}},
+}
+
+// FindPlan devuelve el plan con ese ID; un ID vacío es DefaultPlan.
+func FindPlan(id string) (Plan, bool) {
+	if id == "" {
+		id = DefaultPlan
+	}
+	for _, p := range Plans {
+		if p.ID == id {
+			return p, true
+		}
+	}
+	return Plan{}, false
+}
+
+// HasModule indica si el plan incluye el módulo.
+func (p Plan) HasModule(module string) bool {
+	return slices.Contains(p.Modules, module)
+}
+
+// Limit devuelve el límite del plan para un recurso de ClinicUsage (0: ilimitado).
+func (p Plan) Limit(resource string) int64 {
+	switch resource {
+	case UsageUsers:
+		return p.MaxUsers
+	case UsagePatients:
+		return p.MaxPatients
+	case UsageStorage:
+		return p.StorageMB * 1024 * 1024
+	}
+	return 0
+}
+
+// Recursos contabilizados en ClinicUsage; son también los nombres de sus campos
+const (
+	UsageUsers    = "users"
+	UsagePatients = "patients"
+	UsageStorage  = "storageBytes"
+)
+
+// ClinicUsage son los contadores de uso de una clínica frente a su plan. Se
+// actualizan en cada escritura, no se recalculan al consultarlos.
+type ClinicUsage struct {
+	ClinicID     primitive.ObjectID `bson:"_id" json:"clinicId"`
+	Users        int64              `bson:"users" json:"users"`
+	Patients     int64              `bson:"patients" json:"patients"`
+	StorageBytes int64              `bson:"storageBytes" json:"storageBytes"`
+	UpdatedAt    time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// Get devuelve el contador de un recurso.
+func (u *ClinicUsage) Get(resource string) int64 {
+	switch resource {
+	case UsageUsers:
+		return u.Users
+	case UsagePatients:
+		return u.Patients
+	case UsageStorage:
+		return u.StorageBytes
+	}
+	return 0
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Tipos de traspaso de una clínica completa
+const (
+	TransferExport = "export" // Genera el archivo con todos los datos de la clínica
+	TransferImport = "import" // Recrea una clínica a partir de un archivo exportado
+)
+
+// Estados de un traspaso
+const (
+	TransferQueued    = "queued"
+	TransferRunning   = "running"
+	TransferCompleted = "completed"
+	TransferFailed    = "failed"
+)
+
+// TransferArchiveTTL es cuánto se conservan un traspaso terminado y su archivo,
+// que contiene todos los datos de la clínica (hashes de contraseñas incluidos).
+const TransferArchiveTTL = 7 * 24 * time.Hour
+
+// TransferJob es la exportación o la importación en segundo plano de todos los
+// datos de una clínica (portabilidad). El archivo (ver el paquete tenant) se
+// guarda aparte, con el ID del trabajo como nombre.
+type TransferJob struct {
+	ID   primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Kind string             `bson:"kind" json:"kind"` // "export" o "import"
+
+	// ClinicID es la clínica exportada o, en una importación, la clínica que se
+	// crea (su ID se deriva del original, ver tenant.Remap).
+	ClinicID primitive.ObjectID `bson:"clinicId" json:"clinicId"`
+	// SourceClinicID es el ID de la clínica en el archivo importado
+	SourceClinicID *primitive.ObjectID `bson:"sourceClinicId,omitempty" json:"sourceClinicId,omitempty"`
+
+	// Nombre y nombre para mostrar de la clínica importada, si no se usan los
+	// del archivo (p. ej. porque la original sigue existiendo en este entorno)
+	Name        string `bson:"name,omitempty" json:"name,omitempty"`
+	DisplayName string `bson:"displayName,omitempty" json:"displayName,omitempty"`
+
+	Status string         `bson:"status" json:"status"`
+	Counts TransferCounts `bson:"counts" json:"counts"`
+	Size   int64          `bson:"size,omitempty" json:"size,omitempty"` // Tamaño del archivo en bytes
+	Error  string         `bson:"error,omitempty" json:"error,omitempty"`
+
+	CreatedAt   time.Time  `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time  `bson:"updatedAt" json:"updatedAt"`
+	StartedAt   *time.Time `bson:"startedAt,omitempty" json:"startedAt,omitempty"`
+	FinishedAt  *time.Time `bson:"finishedAt,omitempty" json:"finishedAt,omitempty"`
+	HeartbeatAt *time.Time `bson:"heartbeatAt,omitempty" json:"-"` // Lo renueva el ejecutor mientras trabaja
+	// ExpiresAt es cuándo se borran el trabajo terminado y su archivo (ver
+	// TransferArchiveTTL); se quita al volver a ponerlo en cola.
+	ExpiresAt *time.Time `bson:"expiresAt,omitempty" json:"expiresAt,omitempty"`
+}
+
+// TransferCounts cuenta los documentos exportados o importados por colección.
+type TransferCounts struct {
+	Users    int `bson:"users" json:"users"`
+	Owners   int `bson:"owners" json:"owners"`
+	Patients int `bson:"patients" json:"patients"`
+}
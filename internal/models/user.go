@@ -13,7 +13,15 @@ type User struct {
 	FullName       string             `bson:"fullName" json:"fullName"`
 	Email          string             `bson:"email" json:"email"`
 	HashedPassword string             `bson:"hashedPassword" json:"-"` // `json:"-"` para nunca exponerlo en las respuestas
-	Role           string             `bson:"role" json:"role"`       // ej: "admin", "vet"
-	CreatedAt      time.Time          `bson:"createdAt" json:"createdAt"`
-	UpdatedAt      time.Time          `bson:"updatedAt" json:"updatedAt"`
-}
\ No newline at end of file
+	Role           string             `bson:"role" json:"role"`        // ej: "admin", "vet"
+
+	// PasswordNeedsRehash marca hashes con un coste de bcrypt antiguo; se
+	// vuelven a generar en el siguiente inicio de sesión correcto.
+	PasswordNeedsRehash bool `bson:"passwordNeedsRehash,omitempty" json:"-"`
+
+	// DeletedAt se establece cuando la clínica del usuario se elimina en cascada.
+	DeletedAt *time.Time `bson:"deletedAt,omitempty" json:"deletedAt,omitempty"`
+
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
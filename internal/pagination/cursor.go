@@ -0,0 +1,105 @@
+// Package pagination implementa la paginación por cursor (keyset) reutilizable
+// por cualquier listado: cursores opacos y firmados que codifican el valor del
+// campo de orden y el _id del elemento frontera.
+package pagination
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrInvalidCursor indica un cursor mal formado, con firma inválida o emitido
+// para otro orden.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// minSecretLength es la longitud mínima del secreto de firma.
+const minSecretLength = 32
+
+var (
+	mu     sync.RWMutex
+	secret = randomSecret()
+)
+
+// SetSecret fija el secreto con el que se firman los cursores. Sin él se usa uno
+// aleatorio por proceso: los cursores dejan de valer al reiniciar y no sirven
+// entre réplicas.
+func SetSecret(s []byte) error {
+	if len(s) < minSecretLength {
+		return fmt.Errorf("el secreto de los cursores debe tener al menos %d bytes", minSecretLength)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	secret = bytes.Clone(s)
+	return nil
+}
+
+func randomSecret() []byte {
+	b := make([]byte, minSecretLength)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("pagination: no se pudo generar el secreto: %v", err))
+	}
+	return b
+}
+
+// Cursor es una posición dentro de un listado ordenado por Sort y _id.
+type Cursor struct {
+	Sort     string             `bson:"s"` // Orden canónico (dto.SortString) con el que se emitió
+	Values   []interface{}      `bson:"v"` // Valor de cada campo de orden (string, fecha...)
+	ID       primitive.ObjectID `bson:"i"` // Desempate: el orden siempre termina en _id
+	Backward bool               `bson:"b"` // true: pide la página anterior a esta posición
+}
+
+// Encode serializa el cursor (BSON, para conservar los tipos) y lo firma con HMAC-SHA256.
+func Encode(c Cursor) (string, error) {
+	payload, err := bson.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("no se pudo codificar el cursor: %w", err)
+	}
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(sign(payload)), nil
+}
+
+// Decode verifica la firma del cursor y que corresponda al orden pedido.
+func Decode(token, sort string) (*Cursor, error) {
+	enc := base64.RawURLEncoding
+	data, mac, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	payload, err := enc.DecodeString(data)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	sig, err := enc.DecodeString(mac)
+	if err != nil || !hmac.Equal(sig, sign(payload)) {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := bson.Unmarshal(payload, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	// Un cursor solo tiene sentido con el orden con el que se generó
+	if c.Sort != sort || len(c.Values) != len(strings.Split(sort, ",")) || c.ID.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+func sign(payload []byte) []byte {
+	mu.RLock()
+	defer mu.RUnlock()
+	h := hmac.New(sha256.New, secret)
+	h.Write(payload)
+	return h.Sum(nil)
+}
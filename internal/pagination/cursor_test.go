@@ -0,0 +1,191 @@
+package pagination
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/dto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 10, 30, 0, 123e6, time.UTC)
+	ownerID := primitive.NewObjectID()
+
+	tests := []struct {
+		name   string
+		sort   string
+		values []interface{}
+		check  func(t *testing.T, values []interface{})
+	}{
+		{"String", "name", []interface{}{"Patitas"}, func(t *testing.T, values []interface{}) {
+			if values[0] != "Patitas" {
+				t.Fatalf("esperado el nombre, obtenido %#v", values[0])
+			}
+		}},
+		{"Time", "-createdAt", []interface{}{createdAt}, func(t *testing.T, values []interface{}) {
+			got, ok := values[0].(primitive.DateTime)
+			if !ok || !got.Time().Equal(createdAt) {
+				t.Fatalf("esperada la fecha %s, obtenido %#v", createdAt, values[0])
+			}
+		}},
+		{"ObjectIDAndMultiSort", "ownerId,-name", []interface{}{ownerID, "Luna"}, func(t *testing.T, values []interface{}) {
+			if values[0] != ownerID || values[1] != "Luna" {
+				t.Fatalf("esperados los valores originales, obtenido %#v", values)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := primitive.NewObjectID()
+			token, err := Encode(Cursor{Sort: tt.sort, Values: tt.values, ID: id, Backward: true})
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			c, err := Decode(token, tt.sort)
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if c.ID != id || !c.Backward || c.Sort != tt.sort || len(c.Values) != len(tt.values) {
+				t.Fatalf("cursor decodificado distinto: %+v", c)
+			}
+			tt.check(t, c.Values)
+		})
+	}
+}
+
+func TestDecodeRejectsInvalidCursors(t *testing.T) {
+	token, err := Encode(Cursor{Sort: "name", Values: []interface{}{"Patitas"}, ID: primitive.NewObjectID()})
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	payload, mac, _ := strings.Cut(token, ".")
+
+	// Otro payload firmado con la firma del original
+	other, _ := Encode(Cursor{Sort: "name", Values: []interface{}{"Zorro"}, ID: primitive.NewObjectID()})
+	otherPayload, _, _ := strings.Cut(other, ".")
+
+	tests := map[string]string{
+		"Empty":          "",
+		"NoSignature":    payload,
+		"Truncated":      token[:len(token)-4],
+		"TamperedMAC":    payload + "." + strings.Repeat("A", len(mac)),
+		"SwappedPayload": otherPayload + "." + mac,
+		"NotBase64":      "!!!." + mac,
+	}
+	for name, tok := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Decode(tok, "name"); !errors.Is(err, ErrInvalidCursor) {
+				t.Fatalf("esperado ErrInvalidCursor, obtenido %v", err)
+			}
+		})
+	}
+
+	t.Run("OtherSort", func(t *testing.T) {
+		if _, err := Decode(token, "-name"); !errors.Is(err, ErrInvalidCursor) {
+			t.Fatalf("un cursor de otro orden debe rechazarse, obtenido %v", err)
+		}
+	})
+
+	t.Run("OtherSecret", func(t *testing.T) {
+		setTestSecret(t, bytes.Repeat([]byte("x"), minSecretLength))
+		if _, err := Decode(token, "name"); !errors.Is(err, ErrInvalidCursor) {
+			t.Fatalf("un cursor firmado con otro secreto debe rechazarse, obtenido %v", err)
+		}
+	})
+}
+
+func TestSetSecretRequiresMinimumLength(t *testing.T) {
+	if err := SetSecret([]byte("corto")); err == nil {
+		t.Fatal("esperado error con un secreto corto")
+	}
+}
+
+type item struct {
+	ID   primitive.ObjectID `bson:"_id"`
+	Name string             `bson:"name"`
+}
+
+func TestWindowCursorsAtTheEnds(t *testing.T) {
+	sort := []dto.SortField{{Field: "name", Column: "name"}}
+	key := BSONKey[item](sort)
+	items := func(n int) []item {
+		list := make([]item, n)
+		for i := range list {
+			list[i] = item{ID: primitive.NewObjectID(), Name: string(rune('a' + i))}
+		}
+		return list
+	}
+	forward := &Cursor{Sort: "name", Values: []interface{}{"0"}, ID: primitive.NewObjectID()}
+	backward := &Cursor{Sort: "name", Values: []interface{}{"z"}, ID: primitive.NewObjectID(), Backward: true}
+
+	tests := []struct {
+		name       string
+		items      []item
+		after      *Cursor
+		wantItems  int
+		wantPrev   bool
+		wantNext   bool
+		firstIndex int // Nombre esperado del primer elemento ('a' + índice)
+	}{
+		{"SinglePage", items(2), nil, 2, false, false, 0},
+		{"FirstPage", items(3), nil, 2, false, true, 0},
+		{"MiddlePage", items(3), forward, 2, true, true, 0},
+		{"LastPage", items(2), forward, 2, true, false, 0},
+		{"BackwardToStart", items(2), backward, 2, false, true, 0},
+		{"BackwardWithMore", items(3), backward, 2, true, true, 1},
+		{"Empty", nil, nil, 0, false, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, meta, err := Window(tt.items, 2, tt.after, sort, key)
+			if err != nil {
+				t.Fatalf("Window: %v", err)
+			}
+			if len(page) != tt.wantItems {
+				t.Fatalf("esperados %d elementos, obtenidos %d", tt.wantItems, len(page))
+			}
+			if len(page) > 0 && page[0].Name != string(rune('a'+tt.firstIndex)) {
+				t.Fatalf("primer elemento inesperado: %q", page[0].Name)
+			}
+			if meta.HasPrev != tt.wantPrev || (meta.PrevCursor != "") != tt.wantPrev {
+				t.Fatalf("prev: hasPrev=%v prevCursor=%q, esperado %v", meta.HasPrev, meta.PrevCursor, tt.wantPrev)
+			}
+			if meta.HasNext != tt.wantNext || (meta.NextCursor != "") != (tt.wantNext && len(page) > 0) {
+				t.Fatalf("next: hasNext=%v nextCursor=%q, esperado %v", meta.HasNext, meta.NextCursor, tt.wantNext)
+			}
+
+			if meta.NextCursor != "" {
+				next, err := Decode(meta.NextCursor, "name")
+				if err != nil || next.Backward || next.ID != page[len(page)-1].ID {
+					t.Fatalf("nextCursor debe apuntar al último elemento hacia delante: %v, %+v", err, next)
+				}
+			}
+			if meta.PrevCursor != "" {
+				prev, err := Decode(meta.PrevCursor, "name")
+				if err != nil || !prev.Backward || prev.ID != page[0].ID {
+					t.Fatalf("prevCursor debe apuntar al primer elemento hacia atrás: %v, %+v", err, prev)
+				}
+			}
+		})
+	}
+}
+
+// setTestSecret cambia el secreto de firma durante la prueba
+func setTestSecret(t *testing.T, s []byte) {
+	t.Helper()
+	mu.RLock()
+	previous := secret
+	mu.RUnlock()
+	if err := SetSecret(s); err != nil {
+		t.Fatalf("SetSecret: %v", err)
+	}
+	t.Cleanup(func() {
+		mu.Lock()
+		secret = previous
+		mu.Unlock()
+	})
+}
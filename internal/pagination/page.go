@@ -0,0 +1,150 @@
+package pagination
+
+import (
+	"fmt"
+
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/dto"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Key devuelve el valor de cada campo de orden y el _id de un elemento.
+type Key[T any] func(item T) ([]interface{}, primitive.ObjectID, error)
+
+// BSONKey construye una Key que lee los campos de orden del documento BSON del
+// elemento, así sirve para cualquier modelo sin escribir un extractor a mano.
+func BSONKey[T any](sort []dto.SortField) Key[T] {
+	return func(item T) ([]interface{}, primitive.ObjectID, error) {
+		raw, err := bson.Marshal(item)
+		if err != nil {
+			return nil, primitive.NilObjectID, fmt.Errorf("no se pudo leer la clave del cursor: %w", err)
+		}
+		var doc bson.M
+		if err := bson.Unmarshal(raw, &doc); err != nil {
+			return nil, primitive.NilObjectID, fmt.Errorf("no se pudo leer la clave del cursor: %w", err)
+		}
+
+		values := make([]interface{}, len(sort))
+		for i, s := range sort {
+			values[i] = doc[s.Column]
+		}
+		id, _ := doc["_id"].(primitive.ObjectID)
+		return values, id, nil
+	}
+}
+
+// Result es una página de un listado. Pagination solo se rellena en modo
+// página (page/limit); Cursor siempre, para poder continuar por keyset.
+type Result[T any] struct {
+	Items      []T
+	Pagination *dto.PaginationResponse
+	Cursor     *dto.CursorPaginationResponse
+}
+
+// Window recorta el resultado de una consulta keyset hecha con limit+1 (el
+// elemento sobrante solo indica que hay más) y calcula los cursores. El
+// almacenamiento devuelve los elementos en el orden de presentación también
+// cuando after.Backward, así que el sobrante queda al principio.
+func Window[T any](items []T, limit int, after *Cursor, sort []dto.SortField, key Key[T]) ([]T, *dto.CursorPaginationResponse, error) {
+	more := len(items) > limit
+	hasPrev, hasNext := after != nil, more
+
+	if after != nil && after.Backward {
+		if more {
+			items = items[len(items)-limit:]
+		}
+		hasPrev, hasNext = more, true
+	} else if more {
+		items = items[:limit]
+	}
+
+	meta, err := Edges(items, limit, sort, hasPrev, hasNext, key)
+	return items, meta, err
+}
+
+// Edges construye los cursores de una página ya recortada a partir de su
+// primer y último elemento.
+func Edges[T any](items []T, limit int, sort []dto.SortField, hasPrev, hasNext bool, key Key[T]) (*dto.CursorPaginationResponse, error) {
+	meta := &dto.CursorPaginationResponse{
+		PerPage: limit,
+		HasNext: hasNext,
+		HasPrev: hasPrev,
+	}
+	if len(items) == 0 {
+		return meta, nil
+	}
+
+	if hasNext {
+		next, err := encodeAt(items[len(items)-1], sort, false, key)
+		if err != nil {
+			return nil, err
+		}
+		meta.NextCursor = next
+	}
+	if hasPrev {
+		prev, err := encodeAt(items[0], sort, true, key)
+		if err != nil {
+			return nil, err
+		}
+		meta.PrevCursor = prev
+	}
+
+	return meta, nil
+}
+
+func encodeAt[T any](item T, sort []dto.SortField, backward bool, key Key[T]) (string, error) {
+	values, id, err := key(item)
+	if err != nil {
+		return "", err
+	}
+	return Encode(Cursor{Sort: dto.SortString(sort), Values: values, ID: id, Backward: backward})
+}
+
+// SortBSON es el orden de MongoDB de un listado paginable: los campos pedidos
+// más _id como desempate (con la dirección del último campo), invertido al
+// leer la página anterior.
+func SortBSON(sort []dto.SortField, after *Cursor) bson.D {
+	backward := after != nil && after.Backward
+	doc := dto.SortBSON(sort, backward)
+	return append(doc, bson.E{Key: "_id", Value: idDirection(sort, backward)})
+}
+
+// KeysetBSON es la condición que selecciona los elementos posteriores (o
+// anteriores) al cursor según el orden: para (a, b, _id) es
+// a > va  OR  (a = va AND b > vb)  OR  (a = va AND b = vb AND _id > id),
+// con < en los campos descendentes.
+func KeysetBSON(sort []dto.SortField, after *Cursor) bson.M {
+	backward := after.Backward
+	var or []bson.M
+	eq := bson.M{}
+	for i, s := range sort {
+		cond := bson.M{}
+		for k, v := range eq {
+			cond[k] = v
+		}
+		cond[s.Column] = bson.M{compareOp(s.Desc, backward): after.Values[i]}
+		or = append(or, cond)
+		eq[s.Column] = after.Values[i]
+	}
+
+	last := bson.M{"_id": bson.M{compareOp(idDirection(sort, false) < 0, backward): after.ID}}
+	for k, v := range eq {
+		last[k] = v
+	}
+	return bson.M{"$or": append(or, last)}
+}
+
+func compareOp(desc, backward bool) string {
+	if desc != backward {
+		return "$lt"
+	}
+	return "$gt"
+}
+
+func idDirection(sort []dto.SortField, backward bool) int {
+	desc := len(sort) > 0 && sort[len(sort)-1].Desc
+	if desc != backward {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,145 @@
+// Package palette comprueba el contraste de las paletas de las clínicas
+// (WCAG 2.1) y genera paletas nuevas a partir de un color de marca o de un
+// logo, con su variante para modo oscuro.
+package palette
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// Qué se hace con una paleta que no llega al contraste exigido
+const (
+	StrictnessOff   = "off"   // No se comprueba
+	StrictnessWarn  = "warn"  // Se guarda y la respuesta lleva avisos
+	StrictnessError = "error" // Se rechaza
+)
+
+// Niveles de WCAG 2.1 para el contraste de primary y secondary con background
+const (
+	LevelAALarge = "AA-large" // 3:1, texto grande y componentes de interfaz (1.4.3 y 1.4.11)
+	LevelAA      = "AA"       // 4.5:1, texto normal (1.4.3)
+	LevelAAA     = "AAA"      // 7:1, texto normal (1.4.6)
+)
+
+var levels = map[string]float64{LevelAALarge: 3, LevelAA: 4.5, LevelAAA: 7}
+
+// ErrInsufficientContrast lo devuelve Enforce con la estrictez "error".
+var ErrInsufficientContrast = errors.New("palette colours do not reach the required contrast with the background")
+
+// Política de contraste. Se configura al arrancar con SetPolicy.
+var (
+	strictness = StrictnessWarn
+	level      = LevelAALarge
+)
+
+// SetPolicy cambia la estrictez (off, warn o error) y el nivel exigido
+// (AA-large, AA o AAA).
+func SetPolicy(s, l string) error {
+	switch s {
+	case StrictnessOff, StrictnessWarn, StrictnessError:
+	default:
+		return fmt.Errorf("estrictez de contraste desconocida %q (use off, warn o error)", s)
+	}
+	if _, ok := levels[l]; !ok {
+		return fmt.Errorf("nivel de contraste desconocido %q (use AA-large, AA o AAA)", l)
+	}
+	strictness, level = s, l
+	return nil
+}
+
+// Level devuelve el nivel de contraste configurado.
+func Level() string {
+	return level
+}
+
+// Required es el contraste mínimo del nivel configurado.
+func Required() float64 {
+	return levels[level]
+}
+
+// Check es el contraste de un color de la paleta con el fondo.
+type Check struct {
+	Color      string  // primary o secondary
+	Foreground string  // El color
+	Background string  // El fondo de la paleta (blanco si no tiene)
+	Ratio      float64 // De 1 a 21
+	Required   float64
+	Passes     bool
+}
+
+// CheckPalette mide primary y secondary contra el fondo con el nivel configurado.
+func CheckPalette(p models.ColorPalette) []Check {
+	background := p.Background
+	if background == "" {
+		background = models.GetDefaultPalette().Background
+	}
+	required := Required()
+
+	checks := make([]Check, 0, 2)
+	for _, c := range []struct{ name, color string }{{"primary", p.Primary}, {"secondary", p.Secondary}} {
+		if c.color == "" {
+			continue
+		}
+		ratio := models.ContrastRatio(c.color, background)
+		checks = append(checks, Check{
+			Color:      c.name,
+			Foreground: c.color,
+			Background: background,
+			Ratio:      ratio,
+			Required:   required,
+			Passes:     ratio >= required,
+		})
+	}
+	return checks
+}
+
+// Issues son las comprobaciones que no llegan al nivel configurado.
+func Issues(p models.ColorPalette) []Check {
+	var issues []Check
+	for _, check := range CheckPalette(p) {
+		if !check.Passes {
+			issues = append(issues, check)
+		}
+	}
+	return issues
+}
+
+// Warnings son los avisos de la paleta: solo con la estrictez "warn".
+func Warnings(p models.ColorPalette) []Check {
+	if strictness != StrictnessWarn {
+		return nil
+	}
+	return Issues(p)
+}
+
+// Enforce rechaza la paleta si la estrictez es "error" y no llega al nivel.
+func Enforce(p models.ColorPalette) error {
+	if strictness != StrictnessError {
+		return nil
+	}
+	if issues := Issues(p); len(issues) > 0 {
+		return &ContrastError{Issues: issues}
+	}
+	return nil
+}
+
+// ContrastError detalla qué colores no llegan al contraste exigido.
+type ContrastError struct {
+	Issues []Check
+}
+
+func (e *ContrastError) Error() string {
+	parts := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		parts[i] = fmt.Sprintf("%s %.2f:1 (required %g:1)", issue.Color, issue.Ratio, issue.Required)
+	}
+	return ErrInsufficientContrast.Error() + ": " + strings.Join(parts, ", ")
+}
+
+func (e *ContrastError) Unwrap() error {
+	return ErrInsufficientContrast
+}
@@ -0,0 +1,91 @@
+package palette
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// setPolicy cambia la política de contraste durante la prueba
+func setPolicy(t *testing.T, s, l string) {
+	t.Helper()
+	prevStrictness, prevLevel := strictness, level
+	if err := SetPolicy(s, l); err != nil {
+		t.Fatalf("SetPolicy: %v", err)
+	}
+	t.Cleanup(func() { strictness, level = prevStrictness, prevLevel })
+}
+
+func TestSetPolicyRejectsUnknownValues(t *testing.T) {
+	if err := SetPolicy("strict", LevelAA); err == nil {
+		t.Fatal("esperado error con una estrictez desconocida")
+	}
+	if err := SetPolicy(StrictnessError, "AAAA"); err == nil {
+		t.Fatal("esperado error con un nivel desconocido")
+	}
+	if strictness != StrictnessWarn || level != LevelAALarge {
+		t.Fatalf("una política inválida no debe cambiar la actual: %s, %s", strictness, level)
+	}
+}
+
+func TestEnforce(t *testing.T) {
+	lowContrast := models.ColorPalette{Primary: "#777777", Secondary: "#1D4ED8", Background: "#FFFFFF"}
+	good := models.ColorPalette{Primary: "#1D4ED8", Secondary: "#111827", Background: "#FFFFFF"}
+
+	t.Run("ErrorRejectsLowContrast", func(t *testing.T) {
+		setPolicy(t, StrictnessError, LevelAA)
+
+		err := Enforce(lowContrast)
+		var contrastErr *ContrastError
+		if !errors.Is(err, ErrInsufficientContrast) || !errors.As(err, &contrastErr) {
+			t.Fatalf("esperado ContrastError, obtenido %v", err)
+		}
+		if len(contrastErr.Issues) != 1 || contrastErr.Issues[0].Color != "primary" || contrastErr.Issues[0].Required != 4.5 {
+			t.Fatalf("esperado solo primary por debajo de 4.5:1, obtenido %+v", contrastErr.Issues)
+		}
+		if err := Enforce(good); err != nil {
+			t.Fatalf("una paleta con contraste suficiente no se rechaza: %v", err)
+		}
+		if w := Warnings(lowContrast); w != nil {
+			t.Fatalf("con la estrictez error no hay avisos: %+v", w)
+		}
+	})
+
+	t.Run("WarnOnlyWarns", func(t *testing.T) {
+		setPolicy(t, StrictnessWarn, LevelAA)
+
+		if err := Enforce(lowContrast); err != nil {
+			t.Fatalf("con warn no se rechaza: %v", err)
+		}
+		if w := Warnings(lowContrast); len(w) != 1 || w[0].Color != "primary" {
+			t.Fatalf("esperado un aviso de primary, obtenido %+v", w)
+		}
+	})
+
+	t.Run("LevelDecides", func(t *testing.T) {
+		setPolicy(t, StrictnessError, LevelAALarge)
+
+		// #777777 llega a 3:1 pero no a 4.5:1
+		if err := Enforce(lowContrast); err != nil {
+			t.Fatalf("con AA-large #777777 pasa: %v", err)
+		}
+	})
+
+	t.Run("OffSkipsChecks", func(t *testing.T) {
+		setPolicy(t, StrictnessOff, LevelAAA)
+
+		if err := Enforce(lowContrast); err != nil || Warnings(lowContrast) != nil {
+			t.Fatalf("con off no se comprueba nada: %v", err)
+		}
+	})
+
+	t.Run("DefaultBackgroundIsUsed", func(t *testing.T) {
+		setPolicy(t, StrictnessError, LevelAA)
+
+		checks := CheckPalette(models.ColorPalette{Primary: "#777777"})
+		if len(checks) != 1 || checks[0].Background != models.GetDefaultPalette().Background {
+			t.Fatalf("sin fondo se usa el de la paleta por defecto: %+v", checks)
+		}
+	})
+}
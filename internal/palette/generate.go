@@ -0,0 +1,181 @@
+package palette
+
+import (
+	"cmp"
+	"errors"
+	"image"
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// Errores de la generación de paletas
+var (
+	ErrInvalidColor = errors.New("brand colour must be a #RRGGBB hex colour")
+	ErrNoColors     = errors.New("image has no opaque pixels to take colours from")
+)
+
+// maxDominant es cuántos colores dominantes se sacan de un logo
+const maxDominant = 5
+
+// Generated es una paleta generada con su variante oscura.
+type Generated struct {
+	Light    models.ColorPalette
+	Dark     models.ColorPalette
+	Dominant []string // Colores dominantes del logo, de más a menos frecuente (vacío desde un color)
+}
+
+// FromColor genera una paleta armónica a partir del color de marca:
+//   - primary: el color de marca, oscurecido si no llega al contraste exigido
+//   - secondary: análogo (30° de tono)
+//   - tertiary: neutro oscuro con el tono de la marca, para texto y superficies
+//   - quaternary: complementario, para acentos
+//   - background: blanco con un tinte de la marca
+func FromColor(brand string) (*Generated, error) {
+	base, ok := hexToHSL(brand)
+	if !ok {
+		return nil, ErrInvalidColor
+	}
+	light := generate(brand, base.rotate(30).hex())
+	return &Generated{Light: light, Dark: Dark(light)}, nil
+}
+
+// FromImage toma los colores dominantes del logo. El de marca es el más
+// frecuente con color (no casi blanco, negro o gris); el secundario, el
+// siguiente de otro tono, si lo hay.
+func FromImage(img image.Image) (*Generated, error) {
+	dominant := dominantColors(img)
+	if len(dominant) == 0 {
+		return nil, ErrNoColors
+	}
+
+	brand, secondary := "", ""
+	for _, c := range dominant {
+		h, _ := hexToHSL(c)
+		if h.s < 0.2 || h.l < 0.12 || h.l > 0.92 {
+			continue
+		}
+		if brand == "" {
+			brand = c
+			continue
+		}
+		if b, _ := hexToHSL(brand); hueDistance(b.h, h.h) >= 20 {
+			secondary = c
+			break
+		}
+	}
+	if brand == "" {
+		brand = dominant[0]
+	}
+	if secondary == "" {
+		b, _ := hexToHSL(brand)
+		secondary = b.rotate(30).hex()
+	}
+
+	light := generate(brand, secondary)
+	return &Generated{Light: light, Dark: Dark(light), Dominant: dominant}, nil
+}
+
+// Dark es la variante oscura de una paleta: fondo casi negro con el tono de
+// primary, neutro claro en tertiary y el resto aclarado lo justo para el
+// contraste exigido.
+func Dark(p models.ColorPalette) models.ColorPalette {
+	defaults := models.GetDefaultPalette()
+	primary := cmp.Or(p.Primary, defaults.Primary)
+	base, _ := hexToHSL(primary)
+
+	background := hsl{h: base.h, s: math.Min(base.s, 0.25), l: 0.09}.hex()
+	required := Required()
+	return models.ColorPalette{
+		Primary:    withContrast(primary, background, required, true),
+		Secondary:  withContrast(cmp.Or(p.Secondary, defaults.Secondary), background, required, true),
+		Tertiary:   hsl{h: base.h, s: math.Min(base.s, 0.15), l: 0.9}.hex(),
+		Quaternary: withContrast(cmp.Or(p.Quaternary, defaults.Quaternary), background, required, true),
+		Background: background,
+	}
+}
+
+// generate arma la paleta clara con el color de marca y el secundario
+func generate(brand, secondary string) models.ColorPalette {
+	base, _ := hexToHSL(brand)
+	background := hsl{h: base.h, s: math.Min(base.s, 0.3), l: 0.98}.hex()
+	required := Required()
+
+	return models.ColorPalette{
+		Primary:    withContrast(brand, background, required, false),
+		Secondary:  withContrast(secondary, background, required, false),
+		Tertiary:   hsl{h: base.h, s: math.Min(base.s, 0.2), l: 0.18}.hex(),
+		Quaternary: withContrast(base.rotate(180).hex(), background, required, false),
+		Background: background,
+	}
+}
+
+// bucket acumula los píxeles de un color cuantizado
+type bucket struct {
+	count   int
+	r, g, b float64
+	key     uint16
+}
+
+// dominantColors muestrea hasta unos 100×100 píxeles opacos, los agrupa en
+// 16 niveles por canal y devuelve el color medio de los grupos más
+// frecuentes, descartando los muy parecidos a uno ya elegido.
+func dominantColors(img image.Image) []string {
+	bounds := img.Bounds()
+	step := max(1, max(bounds.Dx(), bounds.Dy())/100)
+
+	buckets := map[uint16]*bucket{}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A < 128 {
+				continue
+			}
+			key := uint16(c.R>>4)<<8 | uint16(c.G>>4)<<4 | uint16(c.B>>4)
+			bk, ok := buckets[key]
+			if !ok {
+				bk = &bucket{key: key}
+				buckets[key] = bk
+			}
+			bk.count++
+			bk.r += float64(c.R)
+			bk.g += float64(c.G)
+			bk.b += float64(c.B)
+		}
+	}
+
+	sorted := make([]*bucket, 0, len(buckets))
+	for _, bk := range buckets {
+		sorted = append(sorted, bk)
+	}
+	slices.SortFunc(sorted, func(a, b *bucket) int {
+		return cmp.Or(cmp.Compare(b.count, a.count), cmp.Compare(a.key, b.key))
+	})
+
+	var dominant []string
+	var chosen [][3]float64
+	for _, bk := range sorted {
+		rgb := [3]float64{bk.r / float64(bk.count), bk.g / float64(bk.count), bk.b / float64(bk.count)}
+		if slices.ContainsFunc(chosen, func(c [3]float64) bool { return rgbDistance(c, rgb) < 48 }) {
+			continue
+		}
+		chosen = append(chosen, rgb)
+		dominant = append(dominant, toHex(rgb[0]/255, rgb[1]/255, rgb[2]/255))
+		if len(dominant) == maxDominant {
+			break
+		}
+	}
+	return dominant
+}
+
+func rgbDistance(a, b [3]float64) float64 {
+	return math.Sqrt((a[0]-b[0])*(a[0]-b[0]) + (a[1]-b[1])*(a[1]-b[1]) + (a[2]-b[2])*(a[2]-b[2]))
+}
+
+// hueDistance es la distancia entre dos tonos en el círculo (0-180)
+func hueDistance(a, b float64) float64 {
+	d := math.Abs(a - b)
+	return math.Min(d, 360-d)
+}
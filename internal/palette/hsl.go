@@ -0,0 +1,118 @@
+package palette
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// hsl es un color en tono (0-360), saturación y luminosidad (0-1)
+type hsl struct {
+	h, s, l float64
+}
+
+// parseHex convierte un color #RRGGBB en sus canales (0-1)
+func parseHex(hex string) (r, g, b float64, ok bool) {
+	if _, valid := models.RelativeLuminance(hex); !valid {
+		return 0, 0, 0, false
+	}
+	rgb, _ := strconv.ParseUint(hex[1:], 16, 32)
+	return float64(rgb>>16&0xFF) / 255, float64(rgb>>8&0xFF) / 255, float64(rgb&0xFF) / 255, true
+}
+
+// toHex convierte canales (0-1) en #RRGGBB
+func toHex(r, g, b float64) string {
+	channel := func(c float64) int {
+		return int(math.Round(math.Max(0, math.Min(1, c)) * 255))
+	}
+	return fmt.Sprintf("#%02X%02X%02X", channel(r), channel(g), channel(b))
+}
+
+func hexToHSL(hex string) (hsl, bool) {
+	r, g, b, ok := parseHex(hex)
+	if !ok {
+		return hsl{}, false
+	}
+	return rgbToHSL(r, g, b), true
+}
+
+func rgbToHSL(r, g, b float64) hsl {
+	maxC, minC := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	c := hsl{l: (maxC + minC) / 2}
+	delta := maxC - minC
+	if delta == 0 {
+		return c
+	}
+
+	c.s = delta / (1 - math.Abs(2*c.l-1))
+	switch maxC {
+	case r:
+		c.h = 60 * math.Mod((g-b)/delta, 6)
+	case g:
+		c.h = 60 * ((b-r)/delta + 2)
+	default:
+		c.h = 60 * ((r-g)/delta + 4)
+	}
+	if c.h < 0 {
+		c.h += 360
+	}
+	return c
+}
+
+func (c hsl) hex() string {
+	h := math.Mod(math.Mod(c.h, 360)+360, 360)
+	s, l := math.Max(0, math.Min(1, c.s)), math.Max(0, math.Min(1, c.l))
+
+	chroma := (1 - math.Abs(2*l-1)) * s
+	x := chroma * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - chroma/2
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = chroma, x, 0
+	case h < 120:
+		r, g, b = x, chroma, 0
+	case h < 180:
+		r, g, b = 0, chroma, x
+	case h < 240:
+		r, g, b = 0, x, chroma
+	case h < 300:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+	return toHex(r+m, g+m, b+m)
+}
+
+// rotate devuelve el color con el tono girado deg grados
+func (c hsl) rotate(deg float64) hsl {
+	c.h = math.Mod(c.h+deg+360, 360)
+	return c
+}
+
+// withContrast oscurece (o aclara, con lighten) el color lo justo para que
+// llegue al contraste required con background, conservando tono y
+// saturación. Si ya llega no lo cambia.
+func withContrast(color, background string, required float64, lighten bool) string {
+	if models.ContrastRatio(color, background) >= required {
+		return color
+	}
+	c, ok := hexToHSL(color)
+	if !ok {
+		return color
+	}
+	step := -0.01
+	if lighten {
+		step = 0.01
+	}
+	for c.l > 0 && c.l < 1 {
+		c.l += step
+		if candidate := c.hex(); models.ContrastRatio(candidate, background) >= required {
+			return candidate
+		}
+	}
+	return c.hex()
+}
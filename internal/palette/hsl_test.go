@@ -0,0 +1,64 @@
+package palette
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+func TestWithContrast(t *testing.T) {
+	const brand = "#3B82F6" // Azul de tono medio: 3.68:1 sobre blanco
+
+	tests := []struct {
+		name       string
+		background string
+		level      string
+		lighten    bool
+	}{
+		{"DarkenToAA", "#FFFFFF", LevelAA, false},
+		{"DarkenToAAA", "#FFFFFF", LevelAAA, false},
+		{"LightenToAAOnDark", "#374151", LevelAA, true},
+		{"LightenToAAAOnDark", "#374151", LevelAAA, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			required := levels[tt.level]
+			got := withContrast(brand, tt.background, required, tt.lighten)
+
+			ratio := models.ContrastRatio(got, tt.background)
+			if ratio < required {
+				t.Fatalf("withContrast = %s con %.2f:1, esperado al menos %g:1", got, ratio, required)
+			}
+			if got == brand {
+				return // Ya llegaba
+			}
+
+			// Conserva tono y saturación
+			c, _ := hexToHSL(got)
+			want, _ := hexToHSL(brand)
+			if math.Abs(c.h-want.h) > 2 || math.Abs(c.s-want.s) > 0.05 {
+				t.Fatalf("withContrast = %s (h=%.0f s=%.2f), esperado el tono %.0f y saturación %.2f", got, c.h, c.s, want.h, want.s)
+			}
+
+			// Lo justo: un paso menos ya no llega
+			step := 0.01
+			if tt.lighten {
+				step = -0.01
+			}
+			c.l += step
+			if previous := c.hex(); previous != brand && models.ContrastRatio(previous, tt.background) >= required {
+				t.Fatalf("withContrast cambia más de lo necesario: %s ya llegaba", previous)
+			}
+		})
+	}
+}
+
+func TestWithContrastKeepsPassingColor(t *testing.T) {
+	if got := withContrast("#1D4ED8", "#FFFFFF", levels[LevelAA], false); got != "#1D4ED8" {
+		t.Fatalf("un color que ya llega no debe cambiar, obtenido %s", got)
+	}
+	if got := withContrast("nope", "#FFFFFF", levels[LevelAA], false); got != "nope" {
+		t.Fatalf("un color inválido se devuelve tal cual, obtenido %s", got)
+	}
+}
@@ -0,0 +1,198 @@
+package ratelimit
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/auth"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/logging"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+)
+
+// Cabeceras de cupo (draft-ietf-httpapi-ratelimit-headers)
+const (
+	HeaderLimit     = "RateLimit-Limit"
+	HeaderRemaining = "RateLimit-Remaining"
+	HeaderReset     = "RateLimit-Reset"
+)
+
+// defaultBucket agrupa los cubos de los límites generales: el cupo se comparte
+// entre todas las rutas que no tienen límites propios
+const defaultBucket = "*"
+
+// Limiter aplica los límites generales a todas las rutas y los propios a las
+// que se configuren con Route.
+type Limiter struct {
+	store      storage.RateLimitStorer
+	defaults   []Limit
+	routes     map[string][]Limit
+	trustProxy bool
+	logger     *slog.Logger
+}
+
+// New crea un Limiter con los límites generales. Con trustProxy la IP del
+// cliente es la última de X-Forwarded-For (la que añadió el proxy de confianza).
+func New(store storage.RateLimitStorer, defaults []Limit, trustProxy bool, logger *slog.Logger) *Limiter {
+	return &Limiter{
+		store:      store,
+		defaults:   defaults,
+		routes:     make(map[string][]Limit),
+		trustProxy: trustProxy,
+		logger:     logger.With("component", "rate_limiter"),
+	}
+}
+
+// Route sustituye los límites generales de la ruta pattern (tal como se
+// registró en el mux, p. ej. "POST /api/v1/users/login") por limits, con
+// cubos propios. Sin limits la ruta queda sin límite.
+func (l *Limiter) Route(pattern string, limits ...Limit) {
+	l.routes[pattern] = limits
+}
+
+// routeResolver lo implementa http.ServeMux: da el patrón que atenderá la
+// petición antes de servirla
+type routeResolver interface {
+	Handler(r *http.Request) (http.Handler, string)
+}
+
+// bucketState es el resultado de consumir un token de un límite
+type bucketState struct {
+	limit     Limit
+	remaining float64
+	allowed   bool
+}
+
+// Middleware limita las peticiones a next, que debe ser el ServeMux (o un
+// handler que lo envuelva sin copiar la petición): la ruta se averigua antes
+// de servirla. Responde 429 con Retry-After al agotar un cupo y añade las
+// cabeceras RateLimit-* del cupo más ajustado. Si el store falla, la petición
+// pasa: es preferible a dejar la API sin servicio.
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	resolver, _ := next.(routeResolver)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pattern := r.Pattern
+		if resolver != nil {
+			_, pattern = resolver.Handler(r)
+		}
+		if pattern == "" {
+			// Sin ruta: 404 o 405 del mux
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		limits, bucket := l.defaults, defaultBucket
+		if routeLimits, ok := l.routes[pattern]; ok {
+			limits, bucket = routeLimits, pattern
+		}
+
+		now := time.Now()
+		var tightest *bucketState
+		for _, limit := range limits {
+			value := l.subject(r, pattern, limit.Scope)
+			if value == "" {
+				continue
+			}
+			key := bucket + "|" + string(limit.Scope) + ":" + value
+			remaining, allowed, err := l.store.Take(r.Context(), key, limit.capacity(), limit.rate(), now)
+			if err != nil {
+				logging.FromContext(r.Context()).ErrorContext(r.Context(), "Error checking rate limit", "error", err, "scope", limit.Scope)
+				continue
+			}
+			state := &bucketState{limit: limit, remaining: remaining, allowed: allowed}
+			if tightest == nil || !allowed || state.ratio() < tightest.ratio() {
+				tightest = state
+			}
+			if !allowed {
+				break
+			}
+		}
+
+		if tightest != nil {
+			setHeaders(w, tightest)
+			if !tightest.allowed {
+				wait := math.Ceil((1 - tightest.remaining) / tightest.limit.rate())
+				w.Header().Set("Retry-After", strconv.Itoa(max(1, int(wait))))
+				// El mux no llegará a rellenar la ruta: se deja en la petición
+				// para que las métricas y el log de acceso etiqueten el 429
+				r.Pattern = pattern
+				if id := clinicID(pattern, r.URL.Path); id != "" {
+					r.SetPathValue("id", id)
+				}
+				response.TooManyRequests(w, r, "Rate limit exceeded, retry later")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ratio es la fracción del cupo que queda
+func (s *bucketState) ratio() float64 {
+	return s.remaining / s.limit.capacity()
+}
+
+func setHeaders(w http.ResponseWriter, s *bucketState) {
+	// Reset: segundos hasta que el cubo vuelva a estar lleno
+	reset := math.Ceil((s.limit.capacity() - s.remaining) / s.limit.rate())
+	h := w.Header()
+	h.Set(HeaderLimit, strconv.Itoa(s.limit.Requests))
+	h.Set(HeaderRemaining, strconv.Itoa(int(s.remaining)))
+	h.Set(HeaderReset, strconv.Itoa(int(reset)))
+}
+
+// subject es el valor por el que se cuenta el límite, o "" si no aplica
+func (l *Limiter) subject(r *http.Request, pattern string, scope Scope) string {
+	switch scope {
+	case ScopePrincipal:
+		if id := auth.PrincipalFromContext(r.Context()); id != "" {
+			return id
+		}
+		return "ip:" + l.clientIP(r)
+	case ScopeClinic:
+		return clinicID(pattern, r.URL.Path)
+	case ScopeIP:
+		return l.clientIP(r)
+	}
+	return ""
+}
+
+func (l *Limiter) clientIP(r *http.Request) string {
+	if l.trustProxy {
+		if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+			last := forwarded[len(forwarded)-1]
+			if i := strings.LastIndex(last, ","); i >= 0 {
+				last = last[i+1:]
+			}
+			if ip := strings.TrimSpace(last); ip != "" {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// clinicID toma de path el segmento que ocupa {id} tras "clinics" en el patrón:
+// el mux aún no ha rellenado PathValue cuando se comprueban los límites
+func clinicID(pattern, path string) string {
+	if _, route, ok := strings.Cut(pattern, " "); ok {
+		pattern = route
+	}
+	patternSegments := strings.Split(pattern, "/")
+	pathSegments := strings.Split(path, "/")
+	for i := 1; i < len(patternSegments) && i < len(pathSegments); i++ {
+		if patternSegments[i] == "{id}" && patternSegments[i-1] == "clinics" {
+			return pathSegments[i]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+package ratelimit_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/ratelimit"
+	"github.com/zabaletac3/go-vet-api/internal/storage/memory"
+)
+
+const route = "GET /api/v1/clinics/{id}/patients"
+
+// newServer monta el limitador delante de un mux con una ruta de clínica.
+// seen recibe la petición tal como la ven los middlewares de fuera (métricas,
+// log de acceso) al terminar.
+func newServer(t *testing.T, limits []ratelimit.Limit, seen func(*http.Request)) (http.Handler, *int) {
+	t.Helper()
+	served := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc(route, func(w http.ResponseWriter, r *http.Request) {
+		served++
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	limiter := ratelimit.New(memory.NewRateLimitStore(), limits, false, logger)
+	limiter.Route("GET /healthz")
+	inner := limiter.Middleware(mux)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inner.ServeHTTP(w, r)
+		if seen != nil {
+			seen(r)
+		}
+	}), &served
+}
+
+func TestMiddlewareExhaustsBucket(t *testing.T) {
+	var pattern, clinicID string
+	handler, served := newServer(t, []ratelimit.Limit{{Scope: ratelimit.ScopeIP, Requests: 2, Per: time.Minute}}, func(r *http.Request) {
+		pattern, clinicID = r.Pattern, r.PathValue("id")
+	})
+
+	tests := []struct {
+		status    int
+		remaining string
+		reset     string
+	}{
+		{http.StatusOK, "1", "30"},
+		{http.StatusOK, "0", "60"},
+		{http.StatusTooManyRequests, "0", "60"},
+	}
+	for i, tt := range tests {
+		pattern, clinicID = "", ""
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/clinics/abc123/patients", nil))
+
+		if rec.Code != tt.status {
+			t.Fatalf("petición %d: status %d, esperado %d", i+1, rec.Code, tt.status)
+		}
+		h := rec.Header()
+		if got := h.Get(ratelimit.HeaderLimit); got != "2" {
+			t.Fatalf("petición %d: %s = %q, esperado 2", i+1, ratelimit.HeaderLimit, got)
+		}
+		if got := h.Get(ratelimit.HeaderRemaining); got != tt.remaining {
+			t.Fatalf("petición %d: %s = %q, esperado %s", i+1, ratelimit.HeaderRemaining, got, tt.remaining)
+		}
+		if got := h.Get(ratelimit.HeaderReset); got != tt.reset {
+			t.Fatalf("petición %d: %s = %q, esperado %s", i+1, ratelimit.HeaderReset, got, tt.reset)
+		}
+		if pattern != route || clinicID != "abc123" {
+			t.Fatalf("petición %d: ruta %q y clínica %q vistas desde fuera, esperadas %q y abc123", i+1, pattern, clinicID, route)
+		}
+
+		retryAfter := h.Get("Retry-After")
+		if tt.status == http.StatusTooManyRequests {
+			// Falta un token entero: 60s/2 peticiones
+			if retryAfter != "30" {
+				t.Fatalf("Retry-After = %q, esperado 30", retryAfter)
+			}
+		} else if retryAfter != "" {
+			t.Fatalf("petición %d: Retry-After inesperado %q", i+1, retryAfter)
+		}
+	}
+	if *served != 2 {
+		t.Fatalf("la petición limitada no debe llegar al handler: servidas %d", *served)
+	}
+}
+
+func TestMiddlewareRouteWithoutLimits(t *testing.T) {
+	handler, _ := newServer(t, []ratelimit.Limit{{Scope: ratelimit.ScopeIP, Requests: 1, Per: time.Minute}}, nil)
+
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		if rec.Code != http.StatusOK || rec.Header().Get(ratelimit.HeaderLimit) != "" {
+			t.Fatalf("una ruta sin límites no se limita: status %d, cabeceras %v", rec.Code, rec.Header())
+		}
+	}
+}
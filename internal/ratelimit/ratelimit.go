@@ -0,0 +1,61 @@
+// Package ratelimit limita las peticiones con cubos de tokens: cada límite
+// admite ráfagas de hasta Requests peticiones y se recupera al ritmo de
+// Requests por Per. Los cubos se guardan en un storage.RateLimitStorer (en
+// memoria para una instancia, en MongoDB si hay varias réplicas).
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Scope es aquello por lo que se cuenta un límite.
+type Scope string
+
+const (
+	// ScopePrincipal cuenta por usuario autenticado; las peticiones anónimas
+	// cuentan por IP.
+	ScopePrincipal Scope = "principal"
+	// ScopeClinic cuenta por la clínica de la ruta (/api/v1/clinics/{id}/...);
+	// no se aplica a las rutas sin clínica.
+	ScopeClinic Scope = "clinic"
+	// ScopeIP cuenta por la IP del cliente.
+	ScopeIP Scope = "ip"
+)
+
+// Limit es un límite de Requests peticiones por Per, contado por Scope.
+type Limit struct {
+	Scope    Scope
+	Requests int
+	Per      time.Duration
+}
+
+// capacity es el tamaño del cubo (la ráfaga máxima)
+func (l Limit) capacity() float64 {
+	return float64(l.Requests)
+}
+
+// rate son los tokens que se recuperan por segundo
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Per.Seconds()
+}
+
+// ParseLimit lee un límite con el formato "peticiones/duración", p. ej.
+// "600/1m" o "10/15m".
+func ParseLimit(scope Scope, s string) (Limit, error) {
+	requests, per, ok := strings.Cut(s, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: expected requests/duration", s)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || n <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: requests must be a positive integer", s)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(per))
+	if err != nil || d <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: duration must be positive", s)
+	}
+	return Limit{Scope: scope, Requests: n, Per: d}, nil
+}
@@ -0,0 +1,174 @@
+// Package search implementa la búsqueda tolerante a errores tipográficos:
+// normalización de texto (minúsculas y sin acentos), las claves que se guardan
+// en cada documento (términos y trigramas) y la puntuación de los candidatos.
+//
+// Mongo solo se usa para preseleccionar candidatos que comparten trigramas con
+// la consulta (índice multikey sobre search.grams); la similitud real se
+// calcula aquí, igual para cualquier backend de storage.
+package search
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxQueryTerms limita los términos de una consulta (el coste es lineal en ellos).
+const MaxQueryTerms = 8
+
+// Keys son las claves de búsqueda de un documento. Se recalculan en cada
+// escritura de los campos buscables y nunca salen en la API.
+type Keys struct {
+	Terms []string `bson:"terms" json:"-"` // Términos normalizados, sin repetir
+	Grams []string `bson:"grams" json:"-"` // Trigramas de los términos (preselección de candidatos)
+}
+
+// NewKeys calcula las claves de búsqueda a partir de los campos buscables.
+func NewKeys(fields ...string) Keys {
+	terms := Terms(fields...)
+	return Keys{Terms: terms, Grams: Grams(terms)}
+}
+
+var fold = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// Normalize pasa el texto a minúsculas, quita los acentos y sustituye todo lo
+// que no sea letra o dígito por espacios. El resultado es seguro para usarse
+// como búsqueda literal: no contiene ningún carácter especial.
+func Normalize(s string) string {
+	folded, _, err := transform.String(fold, s)
+	if err != nil {
+		folded = s
+	}
+	folded = strings.ToLower(folded)
+
+	return strings.Join(strings.FieldsFunc(folded, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// Terms devuelve los términos normalizados y sin repetir de los campos.
+func Terms(fields ...string) []string {
+	var terms []string
+	for _, field := range fields {
+		for _, term := range strings.Fields(Normalize(field)) {
+			if !slices.Contains(terms, term) {
+				terms = append(terms, term)
+			}
+		}
+	}
+	return terms
+}
+
+// Grams devuelve los trigramas de los términos, sin repetir. Cada término se
+// rodea de espacios para que el principio y el final también cuenten.
+func Grams(terms []string) []string {
+	var grams []string
+	for _, term := range terms {
+		padded := []rune(" " + term + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			gram := string(padded[i : i+3])
+			if !slices.Contains(grams, gram) {
+				grams = append(grams, gram)
+			}
+		}
+	}
+	return grams
+}
+
+// Score puntúa un documento frente a los términos de la consulta, entre 0 y 1.
+// Todos los términos deben encontrar pareja (exacta, como prefijo o con hasta
+// MaxEdits ediciones); si alguno no la encuentra, ok es false.
+func Score(query []string, keys Keys) (score float64, ok bool) {
+	if len(query) == 0 {
+		return 0, false
+	}
+
+	var total float64
+	for _, q := range query {
+		best := 0.0
+		for _, term := range keys.Terms {
+			if s := termScore(q, term); s > best {
+				best = s
+			}
+		}
+		if best == 0 {
+			return 0, false
+		}
+		total += best
+	}
+	return total / float64(len(query)), true
+}
+
+// termScore compara un término de la consulta con uno del documento
+func termScore(q, term string) float64 {
+	if q == term {
+		return 1
+	}
+
+	qr, tr := []rune(q), []rune(term)
+	// Prefijo: "vet" encuentra "veterinaria" mientras se escribe
+	if len(qr) >= 2 && strings.HasPrefix(term, q) {
+		return 0.9 - 0.3*float64(len(tr)-len(qr))/float64(len(tr))
+	}
+
+	allowed := MaxEdits(len(qr))
+	if allowed == 0 {
+		return 0
+	}
+	// Con errores se compara también contra el prefijo del mismo largo, para
+	// tolerar un error al escribir el principio de una palabra larga
+	d := distance(qr, tr)
+	if len(tr) > len(qr) {
+		if p := distance(qr, tr[:len(qr)]); p < d && p <= allowed {
+			return 0.6 - 0.1*float64(p)
+		}
+	}
+	if d > allowed {
+		return 0
+	}
+	return 0.8 - 0.1*float64(d)
+}
+
+// MaxEdits es el número de errores tolerados según el largo del término: ninguno
+// en términos cortos, donde una edición cambia la palabra entera.
+func MaxEdits(length int) int {
+	switch {
+	case length < 4:
+		return 0
+	case length < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// distance es la distancia de Damerau-Levenshtein (variante de alineamiento
+// óptimo): inserciones, borrados, sustituciones y transposiciones adyacentes.
+func distance(a, b []rune) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
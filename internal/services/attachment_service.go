@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+// Límites de los ficheros adjuntos
+const (
+	MaxAttachmentSize = 25 << 20         // 25 MiB por fichero
+	AttachmentURLTTL  = 15 * time.Minute // Validez de las URLs de descarga
+)
+
+// UploadAttachmentParams es un fichero adjunto nuevo.
+type UploadAttachmentParams struct {
+	ClinicID  string
+	PatientID string // Opcional: paciente al que pertenece
+	Category  string // Ver models.AttachmentCategories
+	Filename  string // Nombre original; solo se conserva el último segmento de la ruta
+	Content   io.Reader
+}
+
+// AttachmentDownload es un adjunto con su URL firmada de descarga.
+type AttachmentDownload struct {
+	Attachment *models.Attachment
+	URL        string
+	ExpiresAt  time.Time
+}
+
+// AttachmentService guarda los ficheros adjuntos de las clínicas: el
+// contenido en el almacén de ficheros (storage.Stores.Blobs) y los metadatos
+// en la colección attachments. El tamaño de cada fichero distinto cuenta en el
+// almacenamiento del plan.
+type AttachmentService interface {
+	// Upload analiza el fichero con el antivirus (si hay uno registrado, ver
+	// blob.SetScanner) y lo guarda. Si la clínica ya tiene un fichero con el
+	// mismo contenido, el adjunto nuevo lo comparte.
+	Upload(ctx context.Context, params UploadAttachmentParams) (*models.Attachment, error)
+	List(ctx context.Context, clinicID string, filter storage.AttachmentFilter) ([]*models.Attachment, error)
+	GetByID(ctx context.Context, clinicID, attachmentID string) (*models.Attachment, error)
+	// Download devuelve el adjunto con una URL firmada para descargarlo.
+	Download(ctx context.Context, clinicID, attachmentID string) (*AttachmentDownload, error)
+	// Delete borra el adjunto y, si era el último con ese contenido, el fichero.
+	Delete(ctx context.Context, clinicID, attachmentID string) error
+}
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/blob"
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Errores de los adjuntos
+var (
+	ErrAttachmentNotFound        = errors.New("attachment not found")
+	ErrInvalidAttachmentID       = errors.New("invalid attachment ID")
+	ErrInvalidAttachmentCategory = errors.New("unknown attachment category")
+	ErrEmptyAttachment           = errors.New("attachment is empty")
+	ErrPatientNotFound           = errors.New("patient not found")
+	ErrInvalidPatientID          = errors.New("invalid patient ID")
+	ErrScanUnavailable           = errors.New("antivirus scan is not available")
+)
+
+// maxFilenameLength es la longitud máxima del nombre original que se guarda
+const maxFilenameLength = 255
+
+type attachmentService struct {
+	attachments storage.AttachmentStorer
+	patients    storage.PatientStorer
+	clinics     storage.ClinicStorer
+	blobs       blob.Store
+	plans       PlanService
+	logger      *slog.Logger
+}
+
+// NewAttachmentService crea el servicio de ficheros adjuntos.
+func NewAttachmentService(stores *storage.Stores, logger *slog.Logger) AttachmentService {
+	return tracedAttachmentService{next: &attachmentService{
+		attachments: stores.Attachments,
+		patients:    stores.Patients,
+		clinics:     stores.Clinics,
+		blobs:       stores.Blobs,
+		plans:       NewPlanService(stores, logger),
+		logger:      logger.With("service", "attachment"),
+	}}
+}
+
+// Upload copia el fichero a un temporal mientras calcula su hash, lo analiza
+// y solo lo sube al almacén si la clínica no tiene ya ese contenido. Si lo
+// sube, reserva su tamaño en el plan.
+func (s *attachmentService) Upload(ctx context.Context, params UploadAttachmentParams) (*models.Attachment, error) {
+	if !models.IsAttachmentCategory(params.Category) {
+		return nil, ErrInvalidAttachmentCategory
+	}
+	if _, err := s.getClinic(ctx, params.ClinicID); err != nil {
+		return nil, err
+	}
+	attachment := &models.Attachment{
+		Category: params.Category,
+		Filename: cleanFilename(params.Filename),
+	}
+	attachment.ClinicID, _ = primitive.ObjectIDFromHex(params.ClinicID)
+	if params.PatientID != "" {
+		patientID, err := s.checkPatient(ctx, params.ClinicID, params.PatientID)
+		if err != nil {
+			return nil, err
+		}
+		attachment.PatientID = &patientID
+	}
+
+	spooled, err := blob.Spool(params.Content, MaxAttachmentSize)
+	if err != nil {
+		return nil, err
+	}
+	defer spooled.Close()
+	if spooled.Size == 0 {
+		return nil, ErrEmptyAttachment
+	}
+	attachment.ContentType = spooled.ContentType
+	attachment.Size = spooled.Size
+	attachment.Hash = spooled.Hash
+	attachment.Key = blob.Key(params.ClinicID, spooled.Hash)
+
+	attachment.Scan, err = s.scan(ctx, params.ClinicID, spooled)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.attachments.FindByHash(ctx, params.ClinicID, spooled.Hash)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error finding attachment by hash", "error", err, "clinic_id", params.ClinicID)
+		return nil, fmt.Errorf("failed to upload attachment: %w", err)
+	}
+	stored := existing == nil
+	if stored {
+		if err := s.plans.Reserve(ctx, params.ClinicID, models.UsageStorage, spooled.Size); err != nil {
+			return nil, err
+		}
+		if err := s.blobs.Put(ctx, attachment.Key, spooled.Reader(), spooled.Size, spooled.ContentType); err != nil {
+			s.logger.ErrorContext(ctx, "Error storing attachment", "error", err, "clinic_id", params.ClinicID, "key", attachment.Key)
+			return nil, errors.Join(fmt.Errorf("failed to store attachment: %w", err), s.plans.Release(ctx, params.ClinicID, models.UsageStorage, spooled.Size))
+		}
+	}
+
+	if err := s.attachments.Create(ctx, attachment); err != nil {
+		s.logger.ErrorContext(ctx, "Error creating attachment", "error", err, "clinic_id", params.ClinicID)
+		if stored {
+			s.discard(ctx, attachment)
+		}
+		return nil, fmt.Errorf("failed to upload attachment: %w", err)
+	}
+	if stored {
+		s.settleReservation(ctx, attachment)
+	}
+
+	s.logger.InfoContext(ctx, "Attachment uploaded", "clinic_id", params.ClinicID, "attachment_id", attachment.ID.Hex(),
+		"category", attachment.Category, "bytes", attachment.Size, "deduplicated", !stored, "scan", attachment.Scan.Status)
+	return attachment, nil
+}
+
+// List devuelve los adjuntos de la clínica que cumplen el filtro.
+func (s *attachmentService) List(ctx context.Context, clinicID string, filter storage.AttachmentFilter) ([]*models.Attachment, error) {
+	if _, err := s.getClinic(ctx, clinicID); err != nil {
+		return nil, err
+	}
+	if filter.PatientID != "" {
+		if _, err := primitive.ObjectIDFromHex(filter.PatientID); err != nil {
+			return nil, ErrInvalidPatientID
+		}
+	}
+	if filter.Category != "" && !models.IsAttachmentCategory(filter.Category) {
+		return nil, ErrInvalidAttachmentCategory
+	}
+
+	attachments, err := s.attachments.List(ctx, clinicID, filter)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error listing attachments", "error", err, "clinic_id", clinicID)
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// GetByID devuelve un adjunto de la clínica.
+func (s *attachmentService) GetByID(ctx context.Context, clinicID, attachmentID string) (*models.Attachment, error) {
+	if _, err := primitive.ObjectIDFromHex(clinicID); err != nil {
+		return nil, ErrInvalidClinicID
+	}
+	if _, err := primitive.ObjectIDFromHex(attachmentID); err != nil {
+		return nil, ErrInvalidAttachmentID
+	}
+
+	attachment, err := s.attachments.FindByID(ctx, clinicID, attachmentID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting attachment", "error", err, "clinic_id", clinicID, "attachment_id", attachmentID)
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	if attachment == nil {
+		return nil, ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// Download firma la clave del fichero durante AttachmentURLTTL.
+func (s *attachmentService) Download(ctx context.Context, clinicID, attachmentID string) (*AttachmentDownload, error) {
+	attachment, err := s.GetByID(ctx, clinicID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(AttachmentURLTTL).UTC().Truncate(time.Second)
+	url, err := s.blobs.SignedURL(ctx, attachment.Key, AttachmentURLTTL)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error signing attachment URL", "error", err, "clinic_id", clinicID, "attachment_id", attachmentID)
+		return nil, fmt.Errorf("failed to sign attachment URL: %w", err)
+	}
+	return &AttachmentDownload{Attachment: attachment, URL: url, ExpiresAt: expiresAt}, nil
+}
+
+// Delete borra los metadatos primero: si falla el borrado del fichero queda
+// huérfano en el almacén, pero ningún adjunto apunta a un fichero borrado.
+func (s *attachmentService) Delete(ctx context.Context, clinicID, attachmentID string) error {
+	if _, err := primitive.ObjectIDFromHex(clinicID); err != nil {
+		return ErrInvalidClinicID
+	}
+	if _, err := primitive.ObjectIDFromHex(attachmentID); err != nil {
+		return ErrInvalidAttachmentID
+	}
+
+	deleted, err := s.attachments.Delete(ctx, clinicID, attachmentID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error deleting attachment", "error", err, "clinic_id", clinicID, "attachment_id", attachmentID)
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	if deleted == nil {
+		return ErrAttachmentNotFound
+	}
+
+	remaining, err := s.attachments.CountByHash(ctx, clinicID, deleted.Hash)
+	if err != nil {
+		// Sin saber si otro adjunto lo comparte, el fichero se conserva
+		s.logger.WarnContext(ctx, "Error counting attachments sharing a file", "error", err, "clinic_id", clinicID, "hash", deleted.Hash)
+	} else if remaining == 0 {
+		s.discard(ctx, deleted)
+	}
+
+	s.logger.InfoContext(ctx, "Attachment deleted", "clinic_id", clinicID, "attachment_id", attachmentID, "file_deleted", err == nil && remaining == 0)
+	return nil
+}
+
+// scan pasa el fichero por el antivirus registrado. Un fichero infectado se
+// rechaza y, si el antivirus falla, la subida también: nunca se guarda un
+// fichero sin analizar habiendo antivirus.
+func (s *attachmentService) scan(ctx context.Context, clinicID string, spooled *blob.Spooled) (models.AttachmentScan, error) {
+	verdict, scanned, err := blob.Scan(ctx, spooled)
+	switch {
+	case errors.Is(err, blob.ErrInfected):
+		s.logger.WarnContext(ctx, "Infected attachment rejected", "clinic_id", clinicID, "hash", spooled.Hash, "threat", verdict.Threat, "engine", verdict.Engine)
+		return models.AttachmentScan{}, err
+	case err != nil:
+		s.logger.ErrorContext(ctx, "Error scanning attachment", "error", err, "clinic_id", clinicID, "hash", spooled.Hash)
+		return models.AttachmentScan{}, ErrScanUnavailable
+	case !scanned:
+		return models.AttachmentScan{Status: models.ScanUnscanned}, nil
+	}
+	now := time.Now().UTC()
+	return models.AttachmentScan{Status: models.ScanClean, Engine: verdict.Engine, ScannedAt: &now}, nil
+}
+
+// settleReservation deshace la reserva si otra subida simultánea del mismo
+// contenido guardó antes su adjunto: solo paga el más antiguo.
+func (s *attachmentService) settleReservation(ctx context.Context, attachment *models.Attachment) {
+	clinicID := attachment.ClinicID.Hex()
+	first, err := s.attachments.FindByHash(ctx, clinicID, attachment.Hash)
+	if err != nil || first == nil || first.ID == attachment.ID {
+		return
+	}
+	if err := s.plans.Release(ctx, clinicID, models.UsageStorage, attachment.Size); err != nil {
+		s.logger.WarnContext(ctx, "Error releasing duplicate attachment storage", "error", err, "clinic_id", clinicID)
+	}
+}
+
+// discard borra el fichero del almacén y libera su tamaño en el plan
+func (s *attachmentService) discard(ctx context.Context, attachment *models.Attachment) {
+	clinicID := attachment.ClinicID.Hex()
+	if err := s.blobs.Delete(ctx, attachment.Key); err != nil {
+		s.logger.WarnContext(ctx, "Error deleting attachment file", "error", err, "clinic_id", clinicID, "key", attachment.Key)
+		return
+	}
+	if err := s.plans.Release(ctx, clinicID, models.UsageStorage, attachment.Size); err != nil {
+		s.logger.WarnContext(ctx, "Error releasing attachment storage", "error", err, "clinic_id", clinicID)
+	}
+}
+
+// checkPatient comprueba que el paciente existe en la clínica
+func (s *attachmentService) checkPatient(ctx context.Context, clinicID, patientID string) (primitive.ObjectID, error) {
+	objID, err := primitive.ObjectIDFromHex(patientID)
+	if err != nil {
+		return primitive.NilObjectID, ErrInvalidPatientID
+	}
+	patient, err := s.patients.FindByID(ctx, clinicID, patientID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting patient", "error", err, "clinic_id", clinicID, "patient_id", patientID)
+		return primitive.NilObjectID, fmt.Errorf("failed to get patient: %w", err)
+	}
+	if patient == nil {
+		return primitive.NilObjectID, ErrPatientNotFound
+	}
+	return objID, nil
+}
+
+func (s *attachmentService) getClinic(ctx context.Context, clinicID string) (*models.Clinic, error) {
+	clinic, err := findClinic(ctx, s.clinics, clinicID)
+	if err != nil && !errors.Is(err, ErrClinicNotFound) && !errors.Is(err, ErrInvalidClinicID) {
+		s.logger.ErrorContext(ctx, "Error getting clinic", "error", err, "clinic_id", clinicID)
+	}
+	return clinic, err
+}
+
+// cleanFilename se queda con el último segmento del nombre (los navegadores
+// antiguos envían la ruta completa) y lo recorta a maxFilenameLength bytes
+func cleanFilename(name string) string {
+	name = strings.TrimSpace(path.Base(strings.ReplaceAll(name, `\`, "/")))
+	if name == "." || name == "/" || name == "" {
+		return "file"
+	}
+	if len(name) > maxFilenameLength {
+		name = strings.ToValidUTF8(name[:maxFilenameLength], "")
+	}
+	return name
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+// BatchMode decide qué pasa con un lote cuando falla un elemento.
+type BatchMode string
+
+const (
+	// BatchAtomic ejecuta el lote en una transacción: o se crean todos o ninguno.
+	BatchAtomic BatchMode = "atomic"
+	// BatchBestEffort procesa cada elemento por separado; los fallos no afectan al resto.
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+// MaxBatchItems limita el tamaño de un lote (una transacción de Mongo no puede durar más de 60 s).
+const MaxBatchItems = 200
+
+// BatchStatus es el resultado de un elemento del lote.
+type BatchStatus string
+
+const (
+	BatchDone       BatchStatus = "done"        // Result tiene el recurso creado
+	BatchFailed     BatchStatus = "failed"      // Err tiene el motivo
+	BatchSkipped    BatchStatus = "skipped"     // No se ejecutó: el lote atómico ya había fallado
+	BatchRolledBack BatchStatus = "rolled_back" // Se ejecutó, pero el lote atómico se revirtió
+)
+
+// BatchOutcome es el resultado de un elemento, en la misma posición que su entrada.
+type BatchOutcome[R any] struct {
+	Status BatchStatus
+	Result R
+	Err    error
+}
+
+// errBatchAborted revierte la transacción de un lote atómico cuando falla un elemento
+var errBatchAborted = errors.New("batch aborted")
+
+// RunBatch ejecuta run para cada elemento de items en el modo indicado.
+// skip marca elementos que no se deben ejecutar (p. ej. inválidos según el
+// handler): en modo atómico, si hay alguno, no se ejecuta nada.
+//
+// El error devuelto es un fallo del lote en sí (p. ej. la transacción no pudo
+// confirmarse); los fallos de cada elemento van en su BatchOutcome.
+func RunBatch[P, R any](ctx context.Context, tx storage.Transactor, mode BatchMode, items []P, skip []bool, run func(ctx context.Context, params P) (R, error)) ([]BatchOutcome[R], error) {
+	outcomes := make([]BatchOutcome[R], len(items))
+
+	if mode != BatchAtomic {
+		for i, params := range items {
+			if i < len(skip) && skip[i] {
+				outcomes[i].Status = BatchSkipped
+				continue
+			}
+			outcomes[i] = runItem(ctx, params, run)
+		}
+		return outcomes, nil
+	}
+
+	for i := range outcomes {
+		outcomes[i].Status = BatchSkipped
+	}
+	for _, s := range skip {
+		if s {
+			return outcomes, nil
+		}
+	}
+
+	err := tx.WithTransaction(ctx, func(ctx context.Context) error {
+		// Cada intento empieza de cero: el driver puede reintentar la transacción
+		for i := range outcomes {
+			outcomes[i] = BatchOutcome[R]{Status: BatchSkipped}
+		}
+		for i, params := range items {
+			outcomes[i] = runItem(ctx, params, run)
+			if outcomes[i].Status == BatchFailed {
+				return errBatchAborted
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBatchAborted) {
+		return nil, err
+	}
+	if err != nil {
+		var zero R
+		for i := range outcomes {
+			if outcomes[i].Status == BatchDone {
+				outcomes[i] = BatchOutcome[R]{Status: BatchRolledBack, Result: zero}
+			}
+		}
+	}
+	return outcomes, nil
+}
+
+func runItem[P, R any](ctx context.Context, params P, run func(ctx context.Context, params P) (R, error)) BatchOutcome[R] {
+	result, err := run(ctx, params)
+	if err != nil {
+		return BatchOutcome[R]{Status: BatchFailed, Err: err}
+	}
+	return BatchOutcome[R]{Status: BatchDone, Result: result}
+}
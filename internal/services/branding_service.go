@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"io"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// Theme es lo que necesita el frontend de marca blanca de una clínica.
+type Theme struct {
+	Name        string
+	DisplayName string
+	Palette     models.ColorPalette
+	Text        ThemeTextColors
+	Dark        models.ColorPalette // Variante para modo oscuro (ver palette.Dark)
+	DarkText    ThemeTextColors
+	Assets      map[string]models.BrandAsset
+	Version     int64 // Versión de la clínica: cambia con la paleta y las imágenes
+}
+
+// ThemeTextColors es el color de texto legible sobre cada color de la paleta
+// (el de mayor contraste, ver models.TextColorOn).
+type ThemeTextColors struct {
+	OnPrimary    string
+	OnSecondary  string
+	OnTertiary   string
+	OnQuaternary string
+	OnBackground string
+}
+
+// BrandingService gestiona las imágenes de marca de cada clínica y su tema.
+// Las imágenes cuentan para el almacenamiento del plan.
+type BrandingService interface {
+	// Upload procesa la imagen y sustituye la anterior del mismo tipo.
+	// Devuelve la clínica ya con la imagen nueva.
+	Upload(ctx context.Context, clinicID, kind string, r io.Reader) (*models.Clinic, error)
+	// Delete borra la imagen de ese tipo y libera su almacenamiento.
+	Delete(ctx context.Context, clinicID, kind string) error
+	// Theme devuelve el tema de una clínica activa, por su nombre.
+	Theme(ctx context.Context, clinicName string) (*Theme, error)
+	// OpenAsset abre una variante de una imagen de una clínica activa, por su
+	// nombre. El llamante cierra el lector.
+	OpenAsset(ctx context.Context, clinicName, kind, variant string) (*models.BrandAsset, io.ReadCloser, error)
+}
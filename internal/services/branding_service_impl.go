@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/blob"
+	"github.com/zabaletac3/go-vet-api/internal/branding"
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/palette"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+// Errores de las imágenes de marca
+var (
+	ErrInvalidAssetKind = errors.New("unknown branding asset kind")
+	ErrAssetNotFound    = errors.New("branding asset not found")
+)
+
+// maxAssetSetAttempts son los intentos de guardar una imagen si otra
+// escritura cambia la clínica entre la lectura y la escritura
+const maxAssetSetAttempts = 3
+
+type brandingService struct {
+	clinics storage.ClinicStorer
+	plans   PlanService
+	blobs   blob.Store
+	logger  *slog.Logger
+}
+
+// NewBrandingService crea el servicio de imágenes de marca y temas.
+func NewBrandingService(stores *storage.Stores, logger *slog.Logger) BrandingService {
+	return tracedBrandingService{next: &brandingService{
+		clinics: stores.Clinics,
+		plans:   NewPlanService(stores, logger),
+		blobs:   stores.Blobs,
+		logger:  logger.With("service", "branding"),
+	}}
+}
+
+// Upload reserva en el plan el tamaño de las variantes antes de guardarlas y
+// libera el de la imagen sustituida. Subir la misma imagen no hace nada.
+func (s *brandingService) Upload(ctx context.Context, clinicID, kind string, r io.Reader) (*models.Clinic, error) {
+	if !models.IsAssetKind(kind) {
+		return nil, ErrInvalidAssetKind
+	}
+	clinic, err := s.getClinic(ctx, clinicID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := branding.Read(kind, r)
+	if err != nil {
+		return nil, err
+	}
+	if current := clinic.Asset(kind); current != nil && current.Hash == branding.Hash(data) {
+		return clinic, nil
+	}
+
+	img, err := branding.Process(kind, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.plans.Reserve(ctx, clinicID, models.UsageStorage, img.Size()); err != nil {
+		return nil, err
+	}
+	if err := branding.Save(ctx, s.blobs, clinicID, img.ContentType, img.Variants); err != nil {
+		s.logger.ErrorContext(ctx, "Error saving branding asset", "error", err, "clinic_id", clinicID, "kind", kind)
+		return nil, errors.Join(err, s.plans.Release(ctx, clinicID, models.UsageStorage, img.Size()))
+	}
+
+	asset := img.Asset()
+	asset.UploadedAt = time.Now().UTC()
+	clinic, previous, err := s.setAsset(ctx, clinicID, kind, &asset)
+	if err != nil {
+		branding.Remove(ctx, s.blobs, clinicID, assetFiles(&asset)...)
+		return nil, errors.Join(err, s.plans.Release(ctx, clinicID, models.UsageStorage, asset.Size))
+	}
+	if previous != nil {
+		s.discard(ctx, clinicID, previous, &asset)
+	}
+
+	s.logger.InfoContext(ctx, "Branding asset uploaded", "clinic_id", clinicID, "kind", kind, "bytes", asset.Size, "hash", asset.Hash)
+	return clinic, nil
+}
+
+// Delete quita la imagen de la clínica y después borra sus ficheros.
+func (s *brandingService) Delete(ctx context.Context, clinicID, kind string) error {
+	if !models.IsAssetKind(kind) {
+		return ErrInvalidAssetKind
+	}
+	_, previous, err := s.setAsset(ctx, clinicID, kind, nil)
+	if err != nil {
+		return err
+	}
+	if previous == nil {
+		return ErrAssetNotFound
+	}
+	s.discard(ctx, clinicID, previous, nil)
+
+	s.logger.InfoContext(ctx, "Branding asset deleted", "clinic_id", clinicID, "kind", kind)
+	return nil
+}
+
+// Theme calcula el color de texto legible sobre cada color de la paleta y la
+// variante oscura. Los colores vacíos toman el de la paleta por defecto.
+func (s *brandingService) Theme(ctx context.Context, clinicName string) (*Theme, error) {
+	clinic, err := s.getActiveByName(ctx, clinicName)
+	if err != nil {
+		return nil, err
+	}
+
+	light, defaults := clinic.Palette, models.GetDefaultPalette()
+	light.Primary = cmpOr(light.Primary, defaults.Primary)
+	light.Secondary = cmpOr(light.Secondary, defaults.Secondary)
+	light.Tertiary = cmpOr(light.Tertiary, defaults.Tertiary)
+	light.Quaternary = cmpOr(light.Quaternary, defaults.Quaternary)
+	light.Background = cmpOr(light.Background, defaults.Background)
+
+	dark := palette.Dark(light)
+	return &Theme{
+		Name:        clinic.Name,
+		DisplayName: clinic.GetDisplayName(),
+		Palette:     light,
+		Text:        textColors(light),
+		Dark:        dark,
+		DarkText:    textColors(dark),
+		Assets:      clinic.Assets,
+		Version:     clinic.Version,
+	}, nil
+}
+
+// textColors es el color de texto legible sobre cada color de la paleta
+func textColors(p models.ColorPalette) ThemeTextColors {
+	return ThemeTextColors{
+		OnPrimary:    models.TextColorOn(p.Primary),
+		OnSecondary:  models.TextColorOn(p.Secondary),
+		OnTertiary:   models.TextColorOn(p.Tertiary),
+		OnQuaternary: models.TextColorOn(p.Quaternary),
+		OnBackground: models.TextColorOn(p.Background),
+	}
+}
+
+// OpenAsset abre el fichero de una variante.
+func (s *brandingService) OpenAsset(ctx context.Context, clinicName, kind, variant string) (*models.BrandAsset, io.ReadCloser, error) {
+	if !models.IsAssetKind(kind) {
+		return nil, nil, ErrAssetNotFound
+	}
+	clinic, err := s.getActiveByName(ctx, clinicName)
+	if err != nil {
+		return nil, nil, err
+	}
+	asset := clinic.Asset(kind)
+	if asset == nil {
+		return nil, nil, ErrAssetNotFound
+	}
+	v, ok := asset.Variant(variant)
+	if !ok {
+		return nil, nil, ErrAssetNotFound
+	}
+
+	content, _, err := s.blobs.Get(ctx, branding.Key(clinic.ID.Hex(), v.File))
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			s.logger.WarnContext(ctx, "Branding asset file missing", "clinic_id", clinic.ID.Hex(), "kind", kind, "file", v.File)
+			return nil, nil, ErrAssetNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to open branding asset: %w", err)
+	}
+	return asset, content, nil
+}
+
+// setAsset guarda (o quita, con nil) la imagen de ese tipo y devuelve la
+// clínica ya actualizada y la imagen que había. Se reescribe el mapa entero
+// con control de versión.
+func (s *brandingService) setAsset(ctx context.Context, clinicID, kind string, asset *models.BrandAsset) (*models.Clinic, *models.BrandAsset, error) {
+	for attempt := 1; ; attempt++ {
+		clinic, err := s.getClinic(ctx, clinicID)
+		if err != nil {
+			return nil, nil, err
+		}
+		previous := clinic.Asset(kind)
+		if asset == nil && previous == nil {
+			return clinic, nil, nil
+		}
+
+		assets := maps.Clone(clinic.Assets)
+		if assets == nil {
+			assets = map[string]models.BrandAsset{}
+		}
+		if asset != nil {
+			assets[kind] = *asset
+		} else {
+			delete(assets, kind)
+		}
+
+		err = s.clinics.Update(ctx, clinicID, clinic.Version, map[string]interface{}{"assets": assets})
+		if errors.Is(err, storage.ErrVersionConflict) && attempt < maxAssetSetAttempts {
+			continue
+		}
+		if errors.Is(err, storage.ErrVersionConflict) {
+			return nil, nil, ErrClinicVersionConflict
+		}
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Error saving branding asset", "error", err, "clinic_id", clinicID, "kind", kind)
+			return nil, nil, fmt.Errorf("failed to save branding asset: %w", err)
+		}
+
+		clinic.Assets = assets
+		clinic.Version++
+		return clinic, previous, nil
+	}
+}
+
+// discard borra los ficheros de una imagen sustituida y libera su tamaño. Si
+// la nueva es la misma (dos subidas simultáneas) los ficheros se conservan.
+// Los fallos solo se registran: la clínica ya no apunta a ellos.
+func (s *brandingService) discard(ctx context.Context, clinicID string, previous, current *models.BrandAsset) {
+	if current == nil || previous.Hash != current.Hash {
+		if err := branding.Remove(ctx, s.blobs, clinicID, assetFiles(previous)...); err != nil {
+			s.logger.WarnContext(ctx, "Error removing branding asset files", "error", err, "clinic_id", clinicID)
+		}
+	}
+	s.plans.Release(ctx, clinicID, models.UsageStorage, previous.Size)
+}
+
+func (s *brandingService) getClinic(ctx context.Context, clinicID string) (*models.Clinic, error) {
+	clinic, err := findClinic(ctx, s.clinics, clinicID)
+	if err != nil && !errors.Is(err, ErrClinicNotFound) && !errors.Is(err, ErrInvalidClinicID) {
+		s.logger.ErrorContext(ctx, "Error getting clinic", "error", err, "clinic_id", clinicID)
+	}
+	return clinic, err
+}
+
+// getActiveByName busca una clínica para las rutas públicas: las inactivas
+// no existen para ellas.
+func (s *brandingService) getActiveByName(ctx context.Context, name string) (*models.Clinic, error) {
+	clinic, err := s.clinics.GetByName(ctx, name)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting clinic by name", "error", err, "name", name)
+		return nil, fmt.Errorf("failed to get clinic: %w", err)
+	}
+	if clinic == nil || !clinic.IsActiveClinic() {
+		return nil, ErrClinicNotFound
+	}
+	return clinic, nil
+}
+
+// assetFiles son los ficheros de las variantes de una imagen
+func assetFiles(asset *models.BrandAsset) []string {
+	files := make([]string, len(asset.Variants))
+	for i, v := range asset.Variants {
+		files[i] = v.File
+	}
+	return files
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/health"
+)
+
+// ClinicPurger aplica la política de retención de la papelera: cada cierto
+// intervalo borra definitivamente las clínicas eliminadas hace más de N días.
+type ClinicPurger struct {
+	service   ClinicService
+	retention time.Duration
+	interval  time.Duration
+	heartbeat *health.Heartbeat
+	logger    *slog.Logger
+}
+
+// NewClinicPurger crea el purgador. Una retención de 0 días desactiva la purga.
+func NewClinicPurger(svc ClinicService, retentionDays int, interval time.Duration, logger *slog.Logger) *ClinicPurger {
+	return &ClinicPurger{
+		service:   svc,
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+		interval:  interval,
+		heartbeat: health.NewHeartbeat(),
+		logger:    logger.With("job", "clinic_purger"),
+	}
+}
+
+// HealthCheck falla si la purga deja de latir durante dos intervalos.
+func (p *ClinicPurger) HealthCheck() health.Check {
+	return health.Worker(p.heartbeat, 2*p.interval)
+}
+
+// Run ejecuta la purga periódicamente hasta que se cancele el contexto.
+func (p *ClinicPurger) Run(ctx context.Context) {
+	if p.retention <= 0 || p.interval <= 0 {
+		p.logger.InfoContext(ctx, "Purga de clínicas desactivada")
+		return
+	}
+
+	p.logger.InfoContext(ctx, "Purga de clínicas iniciada", "retention", p.retention.String(), "interval", p.interval.String())
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.heartbeat.Beat()
+		p.purgeOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			p.logger.InfoContext(ctx, "Purga de clínicas detenida")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *ClinicPurger) purgeOnce(ctx context.Context) {
+	before := time.Now().UTC().Add(-p.retention)
+
+	purged, err := p.service.PurgeDeleted(ctx, before)
+	if err != nil {
+		p.logger.ErrorContext(ctx, "Errores durante la purga de clínicas", "error", err, "purged", purged)
+		return
+	}
+	if purged > 0 {
+		p.logger.InfoContext(ctx, "Clínicas purgadas", "purged", purged, "deleted_before", before)
+	}
+}
@@ -3,8 +3,10 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/pagination"
 	"github.com/zabaletac3/go-vet-api/internal/transport/http/dto"
 )
 
@@ -18,6 +20,8 @@ type CreateClinicParams struct {
     Website     string
     Description string
     Palette     models.ColorPalette
+    Locale      string
+    Plan        string // Vacío: models.DefaultPlan
 }
 
 // UpdateClinicParams - Parámetros para actualizar clínica
@@ -31,16 +35,36 @@ type UpdateClinicParams struct {
     Description *string
     Palette     *models.ColorPalette
     IsActive    *bool
+    Locale      *string
+    Plan        *string
+
+    // Version es la versión que el cliente leyó (If-Match). Si no coincide con
+    // la actual se devuelve ErrClinicVersionConflict; 0 omite la comprobación.
+    Version     int64
 }
 
 // ListClinicsParams - Parámetros para listar clínicas
 type ListClinicsParams struct {
-    Page     int
-    Limit    int
-    Search   string
-    IsActive *bool
-    SortBy   string
-    SortDesc bool
+    Page    int
+    Limit   int
+    Search  string
+    Filters []dto.Filter    // Ya validados contra la allowlist del handler
+    Sort    []dto.SortField // Vacío: relevancia si hay Search; si no, createdAt (deletedAt en la papelera)
+
+    // Cursor (nextCursor/prevCursor de una respuesta anterior) activa el modo
+    // keyset: se ignora Page. SkipCount omite el total (solo en modo cursor).
+    Cursor    string
+    SkipCount bool
+}
+
+// DeleteClinicParams - Parámetros para eliminar clínica
+type DeleteClinicParams struct {
+    // Cascade elimina también los usuarios de la clínica. Si es false y la
+    // clínica tiene dependencias, la eliminación se bloquea.
+    Cascade bool
+
+    // Version es la versión que el cliente leyó (If-Match); 0 omite la comprobación.
+    Version int64
 }
 
 // ClinicService - Interface principal de servicio
@@ -49,11 +73,22 @@ type ClinicService interface {
     Create(ctx context.Context, params CreateClinicParams) (*models.Clinic, error)
     GetByID(ctx context.Context, id string) (*models.Clinic, error)
     Update(ctx context.Context, id string, params UpdateClinicParams) (*models.Clinic, error)
-    Delete(ctx context.Context, id string) error
+    Delete(ctx context.Context, id string, params DeleteClinicParams) error
     
     // Operaciones de consulta (USA DTO REUTILIZABLE)
-    List(ctx context.Context, params ListClinicsParams) ([]*models.Clinic, dto.PaginationResponse, error)
+    List(ctx context.Context, params ListClinicsParams) (*pagination.Result[*models.Clinic], error)
     GetByName(ctx context.Context, name string) (*models.Clinic, error)
     GetByDisplayName(ctx context.Context, displayName string) (*models.Clinic, error)
     Exists(ctx context.Context, id string) (bool, error)
+
+    // Exportación: recorren el listado completo con los filtros, la búsqueda y
+    // el orden de List/ListDeleted (se ignoran Page, Limit y Cursor) sin cargarlo
+    // en memoria. Si each devuelve un error el recorrido se detiene con ese error.
+    Export(ctx context.Context, params ListClinicsParams, each func(*models.Clinic) error) error
+    ExportDeleted(ctx context.Context, params ListClinicsParams, each func(*models.Clinic) error) error
+
+    // Operaciones de papelera
+    ListDeleted(ctx context.Context, params ListClinicsParams) (*pagination.Result[*models.Clinic], error)
+    Restore(ctx context.Context, id string) (*models.Clinic, error)
+    PurgeDeleted(ctx context.Context, before time.Time) (int, error)
 }
\ No newline at end of file
@@ -7,31 +7,58 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
+	"github.com/zabaletac3/go-vet-api/internal/blob"
+	"github.com/zabaletac3/go-vet-api/internal/metrics"
 	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/pagination"
+	"github.com/zabaletac3/go-vet-api/internal/palette"
+	"github.com/zabaletac3/go-vet-api/internal/search"
 	"github.com/zabaletac3/go-vet-api/internal/storage"
 	"github.com/zabaletac3/go-vet-api/internal/transport/http/dto"
 )
 
 // Errores específicos del dominio de negocio
 var (
-    ErrClinicNameRequired = errors.New("clinic name is required")
-    ErrClinicNameExists   = errors.New("clinic with that name already exists")
-    ErrClinicNotFound     = errors.New("clinic not found")
-    ErrInvalidClinicID    = errors.New("invalid clinic ID")
-    ErrDisplayNameExists  = errors.New("clinic with that display name already exists")
+    ErrClinicNameRequired    = errors.New("clinic name is required")
+    ErrClinicNameExists      = errors.New("clinic with that name already exists")
+    ErrClinicNotFound        = errors.New("clinic not found")
+    ErrInvalidClinicID       = errors.New("invalid clinic ID")
+    ErrDisplayNameExists     = errors.New("clinic with that display name already exists")
+    ErrClinicHasUsers        = errors.New("clinic still has users; delete with cascade to remove them")
+    ErrClinicVersionConflict = errors.New("clinic was modified by another request")
+    ErrInvalidCursor         = errors.New("invalid cursor for this listing")
 )
 
 type clinicService struct {
-    store  storage.ClinicStorer
-    logger *slog.Logger
+    store       storage.ClinicStorer
+    userStore   storage.UserStorer
+    usageStore  storage.UsageStorer
+    owners      storage.OwnerStorer
+    patients    storage.PatientStorer
+    imports     storage.ImportStorer
+    transfers   storage.TransferStorer
+    attachments storage.AttachmentStorer
+    blobs       blob.Store
+    tx          storage.Transactor
+    logger      *slog.Logger
 }
 
-func NewClinicService(store storage.ClinicStorer, logger *slog.Logger) ClinicService {
-    return &clinicService{
-        store:  store,
-        logger: logger.With("service", "clinic"),
-    }
+func NewClinicService(stores *storage.Stores, logger *slog.Logger) ClinicService {
+    return tracedClinicService{next: &clinicService{
+        store:       stores.Clinics,
+        userStore:   stores.Users,
+        usageStore:  stores.Usage,
+        owners:      stores.Owners,
+        patients:    stores.Patients,
+        imports:     stores.Imports,
+        transfers:   stores.Transfers,
+        attachments: stores.Attachments,
+        blobs:       stores.Blobs,
+        tx:          stores.Tx,
+        logger:      logger.With("service", "clinic"),
+    }}
 }
 
 // Create - Lógica de creación robusta
@@ -40,11 +67,14 @@ func (s *clinicService) Create(ctx context.Context, params CreateClinicParams) (
     if strings.TrimSpace(params.Name) == "" {
         return nil, ErrClinicNameRequired
     }
+    if _, ok := models.FindPlan(params.Plan); !ok {
+        return nil, ErrInvalidPlan
+    }
 
     // Verificar unicidad del nombre
     existing, err := s.store.GetByName(ctx, params.Name)
     if err != nil {
-        s.logger.Error("Error checking unique name", "error", err, "name", params.Name)
+        s.logger.ErrorContext(ctx, "Error checking unique name", "error", err, "name", params.Name)
         return nil, fmt.Errorf("error checking unique name: %w", err)
     }
     if existing != nil {
@@ -54,7 +84,7 @@ func (s *clinicService) Create(ctx context.Context, params CreateClinicParams) (
     // Verificar unicidad del display name
     existingDisplay, err := s.store.GetByDisplayName(ctx, params.DisplayName)
     if err != nil {
-        s.logger.Error("Error checking unique display name", "error", err, "displayName", params.DisplayName)
+        s.logger.ErrorContext(ctx, "Error checking unique display name", "error", err, "displayName", params.DisplayName)
         return nil, fmt.Errorf("error checking unique display name: %w", err)
     }
     if existingDisplay != nil {
@@ -71,20 +101,31 @@ func (s *clinicService) Create(ctx context.Context, params CreateClinicParams) (
         Website:     strings.TrimSpace(params.Website),
         Description: strings.TrimSpace(params.Description),
         Palette:     params.Palette,
+        Locale:      params.Locale,
+        Plan:        cmpOr(params.Plan, models.DefaultPlan),
     }
 
     // Establecer paleta por defecto si está vacía
     if clinic.Palette.Primary == "" {
         clinic.Palette = models.GetDefaultPalette()
     }
+    // Con PALETTE_CONTRAST=error no se guarda una paleta sin contraste
+    if err := palette.Enforce(clinic.Palette); err != nil {
+        return nil, err
+    }
 
     // Persistir
     if err := s.store.Create(ctx, clinic); err != nil {
-        s.logger.Error("Error creating clinic", "error", err, "name", clinic.Name)
+        // El índice único cierra la carrera entre la verificación y la inserción
+        if uniqueErr := uniquenessError(err); uniqueErr != nil {
+            return nil, uniqueErr
+        }
+        s.logger.ErrorContext(ctx, "Error creating clinic", "error", err, "name", clinic.Name)
         return nil, fmt.Errorf("failed to create clinic: %w", err)
     }
 
-    s.logger.Info("Clinic created successfully", 
+    storage.AfterCommit(ctx, metrics.ClinicsCreated.Inc)
+    s.logger.InfoContext(ctx, "Clinic created successfully", 
         "clinic_id", clinic.ID.Hex(), 
         "name", clinic.Name,
         "display_name", clinic.DisplayName)
@@ -107,7 +148,7 @@ func (s *clinicService) GetByID(ctx context.Context, id string) (*models.Clinic,
             return nil, ErrInvalidClinicID
         }
         
-        s.logger.Error("Error getting clinic", "error", err, "id", id)
+        s.logger.ErrorContext(ctx, "Error getting clinic", "error", err, "id", id)
         return nil, fmt.Errorf("failed to get clinic: %w", err)
     }
 
@@ -122,6 +163,11 @@ func (s *clinicService) Update(ctx context.Context, id string, params UpdateClin
         return nil, err
     }
 
+    // Concurrencia optimista: fallar pronto si el cliente editó una versión vieja
+    if params.Version > 0 && existing.Version != params.Version {
+        return nil, ErrClinicVersionConflict
+    }
+
     // Crear map de campos a actualizar (solo los que vienen en el request)
     updateFields := make(map[string]interface{})
 
@@ -129,7 +175,7 @@ func (s *clinicService) Update(ctx context.Context, id string, params UpdateClin
     if params.Name != nil {
         nameExists, err := s.store.GetByName(ctx, *params.Name)
         if err != nil {
-            s.logger.Error("Error checking unique name", "error", err, "name", *params.Name)
+            s.logger.ErrorContext(ctx, "Error checking unique name", "error", err, "name", *params.Name)
             return nil, fmt.Errorf("error checking unique name: %w", err)
         }
         if nameExists != nil && nameExists.ID != existing.ID {
@@ -142,7 +188,7 @@ func (s *clinicService) Update(ctx context.Context, id string, params UpdateClin
     if params.DisplayName != nil {
         displayExists, err := s.store.GetByDisplayName(ctx, *params.DisplayName)
         if err != nil {
-            s.logger.Error("Error checking unique display name", "error", err, "displayName", *params.DisplayName)
+            s.logger.ErrorContext(ctx, "Error checking unique display name", "error", err, "displayName", *params.DisplayName)
             return nil, fmt.Errorf("error checking unique display name: %w", err)
         }
         if displayExists != nil && displayExists.ID != existing.ID {
@@ -171,8 +217,21 @@ func (s *clinicService) Update(ctx context.Context, id string, params UpdateClin
         updateFields["isActive"] = *params.IsActive
     }
     if params.Palette != nil {
+        // Con PALETTE_CONTRAST=error no se guarda una paleta sin contraste
+        if err := palette.Enforce(*params.Palette); err != nil {
+            return nil, err
+        }
         updateFields["palette"] = *params.Palette
     }
+    if params.Locale != nil {
+        updateFields["locale"] = *params.Locale
+    }
+    if params.Plan != nil {
+        if _, ok := models.FindPlan(*params.Plan); !ok {
+            return nil, ErrInvalidPlan
+        }
+        updateFields["plan"] = cmpOr(*params.Plan, models.DefaultPlan)
+    }
 
     // Si no hay campos para actualizar
     if len(updateFields) == 0 {
@@ -180,8 +239,16 @@ func (s *clinicService) Update(ctx context.Context, id string, params UpdateClin
     }
 
     // Persistir cambios (SOLO los campos enviados)
-    if err := s.store.Update(ctx, id, updateFields); err != nil {
-        s.logger.Error("Error updating clinic", "error", err, "id", id, "fields", updateFields)
+    // La escritura se condiciona a la versión leída: si otra petición se
+    // adelantó entre la lectura y el $set, falla en vez de pisarla
+    if err := s.store.Update(ctx, id, existing.Version, updateFields); err != nil {
+        if errors.Is(err, storage.ErrVersionConflict) {
+            return nil, ErrClinicVersionConflict
+        }
+        if uniqueErr := uniquenessError(err); uniqueErr != nil {
+            return nil, uniqueErr
+        }
+        s.logger.ErrorContext(ctx, "Error updating clinic", "error", err, "id", id, "fields", updateFields)
         return nil, fmt.Errorf("failed to update clinic: %w", err)
     }
 
@@ -191,7 +258,7 @@ func (s *clinicService) Update(ctx context.Context, id string, params UpdateClin
         return nil, fmt.Errorf("error retrieving updated clinic: %w", err)
     }
 
-    s.logger.Info("Clinic updated successfully", 
+    s.logger.InfoContext(ctx, "Clinic updated successfully", 
         "clinic_id", id, 
         "updated_fields", updateFields)
 
@@ -199,51 +266,390 @@ func (s *clinicService) Update(ctx context.Context, id string, params UpdateClin
 }
 
 // Delete - Eliminación segura (soft delete)
-func (s *clinicService) Delete(ctx context.Context, id string) error {
+func (s *clinicService) Delete(ctx context.Context, id string, params DeleteClinicParams) error {
     // Verificar que existe
-    _, err := s.GetByID(ctx, id)
+    existing, err := s.GetByID(ctx, id)
     if err != nil {
         return err
     }
+    if params.Version > 0 && existing.Version != params.Version {
+        return ErrClinicVersionConflict
+    }
 
-    // TODO: Verificar dependencias (usuarios, mascotas, etc.)
-    // Por ahora solo soft delete
-
-    if err := s.store.Delete(ctx, id); err != nil {
-        s.logger.Error("Error deleting clinic", "error", err, "id", id)
-        return fmt.Errorf("failed to delete clinic: %w", err)
+    // La eliminación, la comprobación de usuarios y la cascada van en una
+    // transacción: si algo falla la clínica no queda en la papelera
+    var deletedUsers int64
+    err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+        var err error
+        deletedUsers, err = s.deleteClinic(ctx, id, existing.Version, params.Cascade, false)
+        return err
+    })
+    if errors.Is(err, storage.ErrTransactionsUnsupported) {
+        // Sin replica set se deshace a mano lo que ya se hubiera eliminado
+        deletedUsers, err = s.deleteClinic(ctx, id, existing.Version, params.Cascade, true)
+    }
+    if err != nil {
+        if !errors.Is(err, ErrClinicVersionConflict) && !errors.Is(err, ErrClinicHasUsers) {
+            s.logger.ErrorContext(ctx, "Error deleting clinic", "error", err, "id", id, "cascade", params.Cascade)
+        }
+        return err
     }
 
-    s.logger.Info("Clinic deleted successfully", "clinic_id", id)
+    metrics.ClinicsDeleted.Inc()
+    s.logger.InfoContext(ctx, "Clinic deleted successfully", "clinic_id", id, "cascade", params.Cascade, "deleted_users", deletedUsers)
     return nil
 }
 
-// List - Listado robusto con validación de parámetros
-func (s *clinicService) List(ctx context.Context, params ListClinicsParams) ([]*models.Clinic, dto.PaginationResponse, error) {
+// deleteClinic marca la clínica como eliminada y, con cascade, sus usuarios
+// con la misma fecha para poder restaurarlos juntos. Los usuarios se cuentan
+// después de eliminar la clínica: desde ese momento no se pueden dar de alta
+// usuarios nuevos en ella, así que ninguno escapa al bloqueo sin cascade.
+// Con undo, si algo falla tras eliminar la clínica se restaura (para cuando
+// no hay transacción que lo revierta).
+func (s *clinicService) deleteClinic(ctx context.Context, id string, version int64, cascade, undo bool) (int64, error) {
+    if err := s.store.Delete(ctx, id, version); err != nil {
+        if errors.Is(err, storage.ErrVersionConflict) {
+            return 0, ErrClinicVersionConflict
+        }
+        return 0, fmt.Errorf("failed to delete clinic: %w", err)
+    }
+
+    deletedUsers, err := s.deleteClinicUsers(ctx, id, cascade)
+    if err != nil && undo {
+        s.undoDelete(ctx, id)
+    }
+    return deletedUsers, err
+}
+
+// deleteClinicUsers aplica a los usuarios de una clínica recién eliminada la
+// cascada, o devuelve ErrClinicHasUsers si tiene usuarios y no se pidió.
+func (s *clinicService) deleteClinicUsers(ctx context.Context, id string, cascade bool) (int64, error) {
+    userCount, err := s.userStore.CountByClinic(ctx, id)
+    if err != nil {
+        return 0, fmt.Errorf("error checking clinic dependencies: %w", err)
+    }
+    if userCount == 0 {
+        return 0, nil
+    }
+    if !cascade {
+        return 0, ErrClinicHasUsers
+    }
+
+    deleted, err := s.store.GetDeletedByID(ctx, id)
+    if err != nil {
+        return 0, fmt.Errorf("failed to cascade clinic deletion: %w", err)
+    }
+    deletedUsers, err := s.userStore.SoftDeleteByClinic(ctx, id, *deleted.DeletedAt)
+    if err != nil {
+        return 0, fmt.Errorf("failed to cascade clinic deletion: %w", err)
+    }
+    return deletedUsers, nil
+}
+
+// undoDelete saca de la papelera una clínica cuya eliminación falló a medias,
+// junto con los usuarios que se llegaran a eliminar en cascada.
+func (s *clinicService) undoDelete(ctx context.Context, id string) {
+    deleted, err := s.store.GetDeletedByID(ctx, id)
+    if err != nil {
+        s.logger.ErrorContext(ctx, "Error undoing clinic deletion", "error", err, "id", id)
+        return
+    }
+    if _, err := s.userStore.RestoreByClinic(ctx, id, *deleted.DeletedAt); err != nil {
+        s.logger.ErrorContext(ctx, "Error undoing clinic users deletion", "error", err, "id", id)
+    }
+    if err := s.store.Restore(ctx, id); err != nil {
+        s.logger.ErrorContext(ctx, "Error undoing clinic deletion", "error", err, "id", id)
+    }
+}
+
+// ListDeleted - Listado de la papelera
+func (s *clinicService) ListDeleted(ctx context.Context, params ListClinicsParams) (*pagination.Result[*models.Clinic], error) {
+    // En la papelera se ordena por fecha de eliminación salvo que se pida otra cosa
+    normalizedParams := s.normalizeListParams(params, dto.SortField{Field: "deletedAt", Column: "deletedAt"})
+
+    result, err := s.list(ctx, normalizedParams, s.store.ListDeleted)
+    if err != nil && !errors.Is(err, ErrInvalidCursor) {
+        s.logger.ErrorContext(ctx, "Error listing deleted clinics", "error", err, "params", normalizedParams)
+        return nil, fmt.Errorf("failed to list deleted clinics: %w", err)
+    }
+
+    return result, err
+}
+
+// Restore - Restaura una clínica de la papelera (y los usuarios eliminados con ella)
+func (s *clinicService) Restore(ctx context.Context, id string) (*models.Clinic, error) {
+    if strings.TrimSpace(id) == "" {
+        return nil, ErrInvalidClinicID
+    }
+
+    deleted, err := s.store.GetDeletedByID(ctx, id)
+    if err != nil {
+        if strings.Contains(err.Error(), "not found") {
+            return nil, ErrClinicNotFound
+        }
+        if strings.Contains(err.Error(), "invalid") {
+            return nil, ErrInvalidClinicID
+        }
+
+        s.logger.ErrorContext(ctx, "Error getting deleted clinic", "error", err, "id", id)
+        return nil, fmt.Errorf("failed to get deleted clinic: %w", err)
+    }
+
+    // Mientras estaba en la papelera otra clínica pudo tomar su nombre
+    nameExists, err := s.store.GetByName(ctx, deleted.Name)
+    if err != nil {
+        s.logger.ErrorContext(ctx, "Error checking unique name", "error", err, "name", deleted.Name)
+        return nil, fmt.Errorf("error checking unique name: %w", err)
+    }
+    if nameExists != nil {
+        return nil, ErrClinicNameExists
+    }
+
+    displayExists, err := s.store.GetByDisplayName(ctx, deleted.DisplayName)
+    if err != nil {
+        s.logger.ErrorContext(ctx, "Error checking unique display name", "error", err, "displayName", deleted.DisplayName)
+        return nil, fmt.Errorf("error checking unique display name: %w", err)
+    }
+    if displayExists != nil {
+        return nil, ErrDisplayNameExists
+    }
+
+    // La clínica y sus usuarios se restauran juntos, como en Delete
+    var restoredUsers int64
+    err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+        var err error
+        restoredUsers, err = s.restoreClinic(ctx, id, *deleted.DeletedAt, false)
+        return err
+    })
+    if errors.Is(err, storage.ErrTransactionsUnsupported) {
+        restoredUsers, err = s.restoreClinic(ctx, id, *deleted.DeletedAt, true)
+    }
+    if err != nil {
+        if uniqueErr := uniquenessError(err); uniqueErr != nil {
+            return nil, uniqueErr
+        }
+        s.logger.ErrorContext(ctx, "Error restoring clinic", "error", err, "id", id)
+        return nil, err
+    }
+
+    restored, err := s.GetByID(ctx, id)
+    if err != nil {
+        return nil, fmt.Errorf("error retrieving restored clinic: %w", err)
+    }
+
+    metrics.ClinicsRestored.Inc()
+    s.logger.InfoContext(ctx, "Clinic restored successfully", "clinic_id", id, "restored_users", restoredUsers)
+    return restored, nil
+}
+
+// restoreClinic restaura los usuarios eliminados con la clínica (deletedAt) y
+// después la clínica. Con undo, si la clínica no se puede restaurar los
+// usuarios vuelven a la papelera con la misma fecha (para cuando no hay
+// transacción que lo revierta); así la clínica sigue pudiéndose restaurar.
+func (s *clinicService) restoreClinic(ctx context.Context, id string, deletedAt time.Time, undo bool) (int64, error) {
+    restoredUsers, err := s.userStore.RestoreByClinic(ctx, id, deletedAt)
+    if err != nil {
+        err = fmt.Errorf("failed to restore clinic users: %w", err)
+    } else if err = s.store.Restore(ctx, id); err != nil {
+        err = fmt.Errorf("failed to restore clinic: %w", err)
+    }
+    if err != nil && undo {
+        if _, undoErr := s.userStore.SoftDeleteByClinic(ctx, id, deletedAt); undoErr != nil {
+            s.logger.ErrorContext(ctx, "Error undoing clinic users restore", "error", undoErr, "id", id)
+        }
+    }
+    return restoredUsers, err
+}
+
+// PurgeDeleted - Borra definitivamente las clínicas eliminadas antes de la fecha
+// indicada, junto con todos sus usuarios, tutores, pacientes, importaciones y
+// ficheros adjuntos. Devuelve cuántas clínicas se purgaron.
+func (s *clinicService) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+    clinics, err := s.store.ListDeletedBefore(ctx, before)
+    if err != nil {
+        s.logger.ErrorContext(ctx, "Error listing clinics to purge", "error", err, "before", before)
+        return 0, fmt.Errorf("failed to list clinics to purge: %w", err)
+    }
+
+    purged := 0
+    var errs []error
+    for _, clinic := range clinics {
+        id := clinic.ID.Hex()
+
+        // Primero sus datos: si algo falla, la clínica sigue en la papelera y se reintenta
+        purgedData, err := s.purgeClinicData(ctx, id)
+        if err != nil {
+            errs = append(errs, fmt.Errorf("clinic %s: %w", id, err))
+            continue
+        }
+        if err := s.store.HardDelete(ctx, id); err != nil {
+            s.logger.ErrorContext(ctx, "Error purging clinic", "error", err, "clinic_id", id)
+            errs = append(errs, fmt.Errorf("clinic %s: %w", id, err))
+            continue
+        }
+        // Sin la clínica los contadores ya no se consultan: un fallo no se reintenta
+        if err := s.usageStore.Delete(ctx, id); err != nil {
+            s.logger.WarnContext(ctx, "Error deleting clinic usage", "error", err, "clinic_id", id)
+        }
+
+        purged++
+        metrics.ClinicsPurged.Inc()
+        s.logger.InfoContext(ctx, "Clinic purged", append([]any{"clinic_id", id, "name", clinic.Name}, purgedData...)...)
+    }
+
+    return purged, errors.Join(errs...)
+}
+
+// purgeClinicData borra definitivamente los documentos que dependen de una
+// clínica. Devuelve cuántos se borraron de cada tipo, como atributos de log.
+func (s *clinicService) purgeClinicData(ctx context.Context, id string) ([]any, error) {
+    // Primero los ficheros (adjuntos e imágenes de marca): se borran por
+    // prefijo, así no queda nada aunque falten sus metadatos
+    if s.blobs != nil {
+        if err := s.blobs.DeletePrefix(ctx, blob.ClinicPrefix(id)); err != nil {
+            s.logger.ErrorContext(ctx, "Error purging clinic files", "error", err, "clinic_id", id)
+            return nil, err
+        }
+    }
+
+    steps := []struct {
+        what  string
+        purge func(context.Context, string) (int64, error)
+    }{
+        {"attachments", s.attachments.DeleteByClinic},
+        {"users", s.userStore.HardDeleteByClinic},
+        {"patients", s.patients.HardDeleteByClinic},
+        {"owners", s.owners.HardDeleteByClinic},
+        {"import_jobs", s.imports.DeleteByClinic},
+        {"transfer_jobs", s.purgeTransfers},
+    }
+
+    var counts []any
+    for _, step := range steps {
+        n, err := step.purge(ctx, id)
+        if err != nil {
+            s.logger.ErrorContext(ctx, "Error purging clinic "+strings.ReplaceAll(step.what, "_", " "), "error", err, "clinic_id", id)
+            return nil, err
+        }
+        counts = append(counts, "purged_"+step.what, n)
+    }
+    return counts, nil
+}
+
+// purgeTransfers borra los traspasos de una clínica con sus archivos, que
+// contienen todos sus datos.
+func (s *clinicService) purgeTransfers(ctx context.Context, id string) (int64, error) {
+    jobs, err := s.transfers.FindJobsByClinic(ctx, id)
+    if err != nil {
+        return 0, err
+    }
+    for _, job := range jobs {
+        if err := removeTransfer(ctx, s.transfers, job); err != nil {
+            return 0, err
+        }
+    }
+    return int64(len(jobs)), nil
+}
+
+// List - Listado robusto con paginación por página o por cursor
+func (s *clinicService) List(ctx context.Context, params ListClinicsParams) (*pagination.Result[*models.Clinic], error) {
     // Validar y normalizar parámetros
-    normalizedParams := s.normalizeListParams(params)
+    normalizedParams := s.normalizeListParams(params, dto.SortField{Field: "createdAt", Column: "createdAt"})
+
+    result, err := s.list(ctx, normalizedParams, s.store.List)
+    if err != nil && !errors.Is(err, ErrInvalidCursor) {
+        s.logger.ErrorContext(ctx, "Error listing clinics", "error", err, "params", normalizedParams)
+        return nil, fmt.Errorf("failed to list clinics: %w", err)
+    }
+
+    return result, err
+}
+
+// Export - Recorre todas las clínicas del listado (para descargas)
+func (s *clinicService) Export(ctx context.Context, params ListClinicsParams, each func(*models.Clinic) error) error {
+    normalizedParams := s.normalizeListParams(params, dto.SortField{Field: "createdAt", Column: "createdAt"})
+    return s.export(ctx, normalizedParams, s.store.StreamList, each, "clinics")
+}
+
+// ExportDeleted - Recorre todas las clínicas de la papelera (para descargas)
+func (s *clinicService) ExportDeleted(ctx context.Context, params ListClinicsParams, each func(*models.Clinic) error) error {
+    normalizedParams := s.normalizeListParams(params, dto.SortField{Field: "deletedAt", Column: "deletedAt"})
+    return s.export(ctx, normalizedParams, s.store.StreamDeleted, each, "deleted clinics")
+}
 
+// export recorre un listado ya normalizado. Los errores de each (p. ej. el
+// cliente cerró la conexión) se devuelven tal cual; solo se registran los del store.
+func (s *clinicService) export(ctx context.Context, params ListClinicsParams, stream func(context.Context, storage.ListFilters, func(*models.Clinic) error) error, each func(*models.Clinic) error, what string) error {
+    filters := storage.ListFilters{
+        Search:  params.Search,
+        Filters: params.Filters,
+        Sort:    params.Sort,
+    }
+
+    var eachErr error
+    err := stream(ctx, filters, func(clinic *models.Clinic) error {
+        eachErr = each(clinic)
+        return eachErr
+    })
+    if err != nil && eachErr == nil {
+        s.logger.ErrorContext(ctx, "Error exporting "+what, "error", err, "params", params)
+        return fmt.Errorf("failed to export %s: %w", what, err)
+    }
+    return err
+}
+
+// list ejecuta un listado ya normalizado en modo página o en modo cursor (keyset)
+func (s *clinicService) list(ctx context.Context, params ListClinicsParams, query func(context.Context, storage.ListFilters) ([]*models.Clinic, int64, error)) (*pagination.Result[*models.Clinic], error) {
     // Convertir a filtros de storage
     filters := storage.ListFilters{
-        Page:     normalizedParams.Page,
-        Limit:    normalizedParams.Limit,
-        Search:   normalizedParams.Search,
-        IsActive: normalizedParams.IsActive,
-        SortBy:   normalizedParams.SortBy,
-        SortDesc: normalizedParams.SortDesc,
+        Page:    params.Page,
+        Limit:   params.Limit,
+        Search:  params.Search,
+        Filters: params.Filters,
+        Sort:    params.Sort,
+    }
+    key := pagination.BSONKey[*models.Clinic](params.Sort)
+
+    // Modo página: skip/limit y total siempre (hace falta para totalPages)
+    if params.Cursor == "" {
+        clinics, total, err := query(ctx, filters)
+        if err != nil {
+            return nil, err
+        }
+
+        // Calcular metadatos de paginación (usando DTO reutilizable)
+        page := storage.CalculatePagination(params.Page, params.Limit, total)
+        cursor, err := pagination.Edges(clinics, params.Limit, params.Sort, page.HasPrev, page.HasNext, key)
+        if err != nil {
+            return nil, err
+        }
+
+        return &pagination.Result[*models.Clinic]{Items: clinics, Pagination: &page, Cursor: cursor}, nil
+    }
+
+    // Modo cursor: se pide un elemento de más para saber si hay otra página
+    after, err := pagination.Decode(params.Cursor, dto.SortString(params.Sort))
+    if err != nil {
+        return nil, ErrInvalidCursor
     }
+    filters.After = after
+    filters.Limit = params.Limit + 1
+    filters.SkipCount = params.SkipCount
 
-    // Ejecutar consulta
-    clinics, total, err := s.store.List(ctx, filters)
+    clinics, total, err := query(ctx, filters)
     if err != nil {
-        s.logger.Error("Error listing clinics", "error", err, "filters", filters)
-        return nil, dto.PaginationResponse{}, fmt.Errorf("failed to list clinics: %w", err)
+        return nil, err
     }
 
-    // Calcular metadatos de paginación (usando DTO reutilizable)
-    pagination := storage.CalculatePagination(normalizedParams.Page, normalizedParams.Limit, total)
+    clinics, cursor, err := pagination.Window(clinics, params.Limit, after, params.Sort, key)
+    if err != nil {
+        return nil, err
+    }
+    if !params.SkipCount {
+        cursor.Total = &total
+    }
 
-    return clinics, pagination, nil
+    return &pagination.Result[*models.Clinic]{Items: clinics, Cursor: cursor}, nil
 }
 
 // GetByName - Búsqueda por nombre
@@ -263,6 +669,19 @@ func (s *clinicService) Exists(ctx context.Context, id string) (bool, error) {
 
 // Métodos helper privados
 
+// uniquenessError traduce los errores de unicidad de storage a errores de negocio
+// (devuelve nil si err no es de unicidad)
+func uniquenessError(err error) error {
+    switch {
+    case errors.Is(err, storage.ErrDuplicateClinicName):
+        return ErrClinicNameExists
+    case errors.Is(err, storage.ErrDuplicateClinicDisplayName):
+        return ErrDisplayNameExists
+    default:
+        return nil
+    }
+}
+
 func (s *clinicService) mergeUpdateParams(existing *models.Clinic, params UpdateClinicParams) *models.Clinic {
     updated := *existing // Copia
 
@@ -293,11 +712,19 @@ func (s *clinicService) mergeUpdateParams(existing *models.Clinic, params Update
     if params.IsActive != nil {
         updated.IsActive = *params.IsActive
     }
+    if params.Locale != nil {
+        updated.Locale = *params.Locale
+    }
+    if params.Plan != nil {
+        updated.Plan = cmpOr(*params.Plan, models.DefaultPlan)
+    }
 
     return &updated
 }
 
-func (s *clinicService) normalizeListParams(params ListClinicsParams) ListClinicsParams {
+// normalizeListParams aplica los límites de paginación y el orden por defecto:
+// relevancia si hay búsqueda y, si no, defaultSort
+func (s *clinicService) normalizeListParams(params ListClinicsParams, defaultSort dto.SortField) ListClinicsParams {
     normalized := params
 
     if normalized.Page < 1 {
@@ -307,16 +734,12 @@ func (s *clinicService) normalizeListParams(params ListClinicsParams) ListClinic
         normalized.Limit = 10
     }
 
-    // Validar campo de ordenamiento
-    validSortFields := map[string]bool{
-        "name":         true,
-        "display_name": true,
-        "created_at":   true,
-        "updated_at":   true,
-    }
-
-    if normalized.SortBy == "" || !validSortFields[normalized.SortBy] {
-        normalized.SortBy = "created_at"
+    // Los campos ya vienen validados por el handler; aquí solo el orden por defecto
+    if len(normalized.Sort) == 0 {
+        normalized.Sort = []dto.SortField{defaultSort}
+        if search.Normalize(normalized.Search) != "" {
+            normalized.Sort = []dto.SortField{dto.Relevance}
+        }
     }
 
     return normalized
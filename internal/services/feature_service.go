@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// FeatureState es un flag ya evaluado para una clínica y un sujeto.
+type FeatureState struct {
+	Key     string
+	Module  bool // Activa un grupo de rutas (ver models.Modules)
+	Enabled bool // Resultado para el sujeto
+	Flag    models.FeatureFlag
+	Source  string // FeatureSourcePlan o FeatureSourceClinic
+}
+
+// Origen del valor de un flag
+const (
+	FeatureSourcePlan   = "plan"
+	FeatureSourceClinic = "clinic"
+)
+
+// FeatureService evalúa y configura los flags de cada clínica. El sujeto de
+// un flag con porcentaje es quien hace la petición o, si es anónima, la clínica.
+type FeatureService interface {
+	// List evalúa todos los flags conocidos de la clínica para subject.
+	List(ctx context.Context, clinicID, subject string) ([]FeatureState, error)
+	// Enabled evalúa un flag de la clínica para subject.
+	Enabled(ctx context.Context, clinicID, key, subject string) (bool, error)
+	// Set sustituye en la clínica el valor por defecto del flag; nil vuelve al del plan.
+	Set(ctx context.Context, clinicID, key string, flag *models.FeatureFlag) (*FeatureState, error)
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+// Errores de los flags
+var (
+	ErrInvalidFeatureKey = errors.New("invalid feature flag key")
+	ErrInvalidRollout    = errors.New("feature flag rollout must be between 0 and 100")
+	ErrModuleDisabled    = errors.New("module is not enabled for this clinic")
+)
+
+// maxFeatureSetAttempts son los intentos de Set si otra escritura cambia la
+// clínica entre la lectura y la escritura de sus flags
+const maxFeatureSetAttempts = 3
+
+type featureService struct {
+	clinics storage.ClinicStorer
+	logger  *slog.Logger
+}
+
+// NewFeatureService crea el servicio de flags por clínica.
+func NewFeatureService(stores *storage.Stores, logger *slog.Logger) FeatureService {
+	return tracedFeatureService{next: &featureService{
+		clinics: stores.Clinics,
+		logger:  logger.With("service", "feature"),
+	}}
+}
+
+// List evalúa los módulos, los flags del plan y los propios de la clínica.
+func (s *featureService) List(ctx context.Context, clinicID, subject string) ([]FeatureState, error) {
+	clinic, err := s.getClinic(ctx, clinicID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := slices.Clone(models.Modules)
+	keys = slices.AppendSeq(keys, maps.Keys(clinic.GetPlan().Flags))
+	keys = slices.AppendSeq(keys, maps.Keys(clinic.Features))
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+
+	states := make([]FeatureState, len(keys))
+	for i, key := range keys {
+		states[i] = featureState(clinic, key, cmpOr(subject, clinicID))
+	}
+	return states, nil
+}
+
+// Enabled evalúa un flag; uno que no existe está apagado.
+func (s *featureService) Enabled(ctx context.Context, clinicID, key, subject string) (bool, error) {
+	clinic, err := s.getClinic(ctx, clinicID)
+	if err != nil {
+		return false, err
+	}
+	return featureState(clinic, key, cmpOr(subject, clinicID)).Enabled, nil
+}
+
+// Set guarda el flag en la clínica con concurrencia optimista sobre el mapa
+// completo: si otra escritura cambió la clínica, se vuelve a leer y se reintenta.
+func (s *featureService) Set(ctx context.Context, clinicID, key string, flag *models.FeatureFlag) (*FeatureState, error) {
+	if !models.IsValidFeatureKey(key) {
+		return nil, ErrInvalidFeatureKey
+	}
+	if flag != nil && flag.Rollout != nil && (*flag.Rollout < 0 || *flag.Rollout > 100) {
+		return nil, ErrInvalidRollout
+	}
+
+	for attempt := 1; ; attempt++ {
+		clinic, err := s.getClinic(ctx, clinicID)
+		if err != nil {
+			return nil, err
+		}
+
+		features := maps.Clone(clinic.Features)
+		if features == nil {
+			features = map[string]models.FeatureFlag{}
+		}
+		if flag != nil {
+			features[key] = *flag
+		} else {
+			delete(features, key)
+		}
+
+		err = s.clinics.Update(ctx, clinicID, clinic.Version, map[string]interface{}{"features": features})
+		if errors.Is(err, storage.ErrVersionConflict) && attempt < maxFeatureSetAttempts {
+			continue
+		}
+		if errors.Is(err, storage.ErrVersionConflict) {
+			return nil, ErrClinicVersionConflict
+		}
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Error saving feature flag", "error", err, "clinic_id", clinicID, "feature", key)
+			return nil, fmt.Errorf("failed to save feature flag: %w", err)
+		}
+
+		clinic.Features = features
+		state := featureState(clinic, key, clinicID)
+		s.logger.InfoContext(ctx, "Feature flag updated", "clinic_id", clinicID, "feature", key, "source", state.Source, "enabled", state.Flag.Enabled)
+		return &state, nil
+	}
+}
+
+func (s *featureService) getClinic(ctx context.Context, clinicID string) (*models.Clinic, error) {
+	clinic, err := findClinic(ctx, s.clinics, clinicID)
+	if err != nil && !errors.Is(err, ErrClinicNotFound) && !errors.Is(err, ErrInvalidClinicID) {
+		s.logger.ErrorContext(ctx, "Error getting clinic", "error", err, "clinic_id", clinicID)
+	}
+	return clinic, err
+}
+
+// featureState evalúa el flag key de la clínica para subject
+func featureState(clinic *models.Clinic, key, subject string) FeatureState {
+	flag, own := clinic.Feature(key)
+	source := FeatureSourcePlan
+	if own {
+		source = FeatureSourceClinic
+	}
+	return FeatureState{
+		Key:     key,
+		Module:  models.IsModule(key),
+		Enabled: flag.EnabledFor(key, subject),
+		Flag:    flag,
+		Source:  source,
+	}
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/health"
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/importer"
+	"github.com/zabaletac3/go-vet-api/internal/metrics"
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// importBatchSize es cuántas filas se procesan entre dos registros de progreso.
+const importBatchSize = 100
+
+// ImportRunner procesa en segundo plano los trabajos de importación en cola.
+// Cada fila se registra al terminarla, así que un trabajo interrumpido (el
+// proceso se reinició o se detuvo) se retoma donde se quedó: cuando su latido
+// caduca, cualquier ejecutor lo vuelve a tomar.
+type ImportRunner struct {
+	imports   storage.ImportStorer
+	owners    storage.OwnerStorer
+	patients  storage.PatientStorer
+	plans     PlanService
+	interval  time.Duration
+	heartbeat *health.Heartbeat
+	logger    *slog.Logger
+}
+
+// NewImportRunner crea el ejecutor; interval es cada cuánto busca trabajos.
+func NewImportRunner(stores *storage.Stores, interval time.Duration, logger *slog.Logger) *ImportRunner {
+	return &ImportRunner{
+		imports:   stores.Imports,
+		owners:    stores.Owners,
+		patients:  stores.Patients,
+		plans:     NewPlanService(stores, logger),
+		interval:  interval,
+		heartbeat: health.NewHeartbeat(),
+		logger:    logger.With("job", "import_runner"),
+	}
+}
+
+// HealthCheck falla si el ejecutor deja de latir. Un trabajo largo renueva el
+// latido con cada lote, como el de su registro (storage.ImportHeartbeatTimeout).
+func (r *ImportRunner) HealthCheck() health.Check {
+	return health.Worker(r.heartbeat, r.interval+storage.ImportHeartbeatTimeout)
+}
+
+// Run procesa los trabajos en cola hasta que se cancele el contexto.
+func (r *ImportRunner) Run(ctx context.Context) {
+	if r.interval <= 0 {
+		r.logger.InfoContext(ctx, "Ejecutor de importaciones desactivado")
+		return
+	}
+
+	r.logger.InfoContext(ctx, "Ejecutor de importaciones iniciado", "interval", r.interval.String())
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.heartbeat.Beat()
+		r.runPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			r.logger.InfoContext(ctx, "Ejecutor de importaciones detenido")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runPending procesa uno tras otro los trabajos disponibles.
+func (r *ImportRunner) runPending(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := r.imports.ClaimJob(ctx, time.Now().UTC().Add(-storage.ImportHeartbeatTimeout))
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error al buscar importaciones pendientes", "error", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+		r.heartbeat.Beat()
+		r.process(ctx, job)
+	}
+}
+
+// process importa las filas pendientes del trabajo por lotes.
+func (r *ImportRunner) process(ctx context.Context, job *models.ImportJob) {
+	jobID := job.ID.Hex()
+	// Cada trabajo es una traza propia: no hay petición de la que colgar
+	ctx, span := tracing.Start(ctx, "ImportRunner.process", trace.WithAttributes(
+		attribute.String("import.id", jobID),
+		attribute.String("clinic.id", job.ClinicID.Hex()),
+	))
+	defer span.End()
+
+	logger := r.logger.With("import_id", jobID, "clinic_id", job.ClinicID.Hex())
+	logger.InfoContext(ctx, "Importación iniciada", "rows", job.Stats.Total)
+
+	err := r.importRows(ctx, job)
+	if ctx.Err() != nil {
+		// Sin cerrar el trabajo: otro ejecutor lo retomará al caducar el latido
+		logger.InfoContext(ctx, "Importación interrumpida; se reanudará más tarde")
+		return
+	}
+
+	stats, statsErr := r.imports.RowStats(ctx, jobID)
+	if statsErr != nil {
+		err = errors.Join(err, statsErr)
+	}
+	status, errMsg := models.ImportCompleted, ""
+	if err != nil {
+		status, errMsg = models.ImportFailed, "Import stopped by a server error; resume it to continue"
+		tracing.Fail(span, err)
+		logger.ErrorContext(ctx, "Importación fallida", "error", err)
+	}
+	if err := r.imports.FinishJob(ctx, jobID, status, stats, errMsg); err != nil {
+		logger.ErrorContext(ctx, "Error al cerrar la importación", "error", err)
+		return
+	}
+	metrics.ImportsFinished.WithLabelValues(status).Inc()
+	logger.InfoContext(ctx, "Importación terminada", "status", status, "imported", stats.Imported, "invalid", stats.Invalid)
+}
+
+func (r *ImportRunner) importRows(ctx context.Context, job *models.ImportJob) error {
+	jobID := job.ID.Hex()
+	locale, ok := i18n.Parse(job.Locale)
+	if !ok {
+		locale = i18n.English
+	}
+
+	after := -1
+	for {
+		rows, err := r.imports.ListRows(ctx, jobID, models.ImportRowPending, after, importBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		for _, row := range rows {
+			if err := r.importRow(ctx, job, row, locale); err != nil {
+				return fmt.Errorf("row %d: %w", row.Index, err)
+			}
+		}
+		after = rows[len(rows)-1].Index
+
+		stats, err := r.imports.RowStats(ctx, jobID)
+		if err != nil {
+			return err
+		}
+		if err := r.imports.SaveProgress(ctx, jobID, stats); err != nil {
+			return err
+		}
+		r.heartbeat.Beat()
+	}
+}
+
+// importRow valida la fila y crea su paciente, con un tutor nuevo o con el
+// tutor existente que tenga su mismo email o teléfono. Los IDs reservados en
+// la fila evitan duplicados si se repite tras una interrupción. Si la clínica
+// llegó al límite de pacientes de su plan, la fila queda inválida.
+func (r *ImportRunner) importRow(ctx context.Context, job *models.ImportJob, row *models.ImportRow, locale i18n.Locale) error {
+	clinicID := job.ClinicID.Hex()
+	record := importer.Build(job.Mapping, job.Columns, row.Values, job.Format)
+
+	if errs := record.Validate(locale); len(errs) > 0 {
+		row.Status = models.ImportRowInvalid
+		row.Errors = make([]models.ImportRowError, len(errs))
+		for i, e := range errs {
+			row.Errors[i] = models.ImportRowError{Field: e.Field, Message: e.Message, Value: e.Value}
+		}
+		return r.imports.SaveRow(ctx, row)
+	}
+	// Validate ya comprobó la fecha y el peso
+	birthDate, _ := record.BirthDate()
+	weight, _ := record.Weight()
+
+	patient, err := r.patients.FindByID(ctx, clinicID, row.PatientID.Hex())
+	if err != nil {
+		return err
+	}
+	if patient == nil {
+		// Antes de crear el tutor, para no dejarlo sin paciente
+		err := r.plans.Reserve(ctx, clinicID, models.UsagePatients, 1)
+		if errors.Is(err, ErrPatientLimitReached) {
+			row.Status = models.ImportRowInvalid
+			row.Errors = []models.ImportRowError{{Field: importer.FieldPatientName, Message: i18n.Translate(locale, "The clinic reached the patient limit of its plan")}}
+			return r.imports.SaveRow(ctx, row)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	owner := &models.Owner{
+		ID:         row.OwnerID,
+		ClinicID:   job.ClinicID,
+		FullName:   record.OwnerFullName,
+		Email:      record.OwnerEmail,
+		Phone:      record.OwnerPhone,
+		DocumentID: record.OwnerDocumentID,
+		Address:    record.OwnerAddress,
+	}
+	existing, err := r.owners.FindByContact(ctx, clinicID, owner.ContactKeys())
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		// Sin email ni teléfono no hay con qué deduplicar, pero puede que esta
+		// misma fila ya lo creara antes de una interrupción
+		existing, err = r.owners.FindByID(ctx, clinicID, row.OwnerID.Hex())
+		if err != nil {
+			return err
+		}
+	}
+	switch {
+	case existing == nil:
+		if err := r.owners.Create(ctx, owner); err != nil {
+			return err
+		}
+		row.OwnerCreated = true
+	case existing.ID == row.OwnerID:
+		row.OwnerCreated = true
+	default:
+		row.OwnerID = existing.ID
+	}
+
+	if patient == nil {
+		patient = &models.Patient{
+			ID:        row.PatientID,
+			ClinicID:  job.ClinicID,
+			OwnerID:   row.OwnerID,
+			Name:      record.PatientName,
+			Species:   record.PatientSpecies,
+			Breed:     record.PatientBreed,
+			Sex:       record.PatientSex,
+			BirthDate: birthDate,
+			WeightKg:  weight,
+			Microchip: record.PatientMicrochip,
+			Notes:     record.PatientNotes,
+		}
+		if err := r.patients.Create(ctx, patient); err != nil {
+			return errors.Join(err, r.plans.Release(ctx, clinicID, models.UsagePatients, 1))
+		}
+	}
+
+	row.Status = models.ImportRowImported
+	row.Errors = nil
+	return r.imports.SaveRow(ctx, row)
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"io"
+
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/validators"
+)
+
+// UploadImportParams es el archivo subido para importar.
+type UploadImportParams struct {
+	ClinicID string
+	FileName string // Su extensión decide el formato: .csv o .xlsx
+	File     io.Reader
+}
+
+// PreviewImportParams son los parámetros del dry-run de una importación.
+type PreviewImportParams struct {
+	// Mapping sustituye al mapeo guardado en el trabajo; nil usa el guardado.
+	Mapping map[string]string
+	Locale  i18n.Locale // Idioma de los errores por fila
+}
+
+// ImportPreview es el resultado del dry-run: cuántas filas se importarían y
+// los errores de las que no.
+type ImportPreview struct {
+	Total   int
+	Valid   int
+	Invalid int
+
+	// Rows son las primeras filas inválidas (hasta MaxPreviewErrors)
+	Rows []ImportRowResult
+	// Sample son las primeras filas válidas ya mapeadas, por campo de importación
+	Sample []map[string]string
+}
+
+// ImportRowResult son los errores de validación de una fila.
+type ImportRowResult struct {
+	Index  int
+	Errors []validators.FieldError
+}
+
+// MaxPreviewErrors es el máximo de filas inválidas que devuelve el dry-run.
+const MaxPreviewErrors = 100
+
+// ImportService gestiona la importación de pacientes y tutores desde CSV o XLSX:
+// subir el archivo, previsualizar el mapeo y confirmarlo como trabajo en segundo
+// plano (lo procesa ImportRunner).
+type ImportService interface {
+	Upload(ctx context.Context, params UploadImportParams) (*models.ImportJob, error)
+	GetByID(ctx context.Context, clinicID, jobID string) (*models.ImportJob, error)
+	Preview(ctx context.Context, clinicID, jobID string, params PreviewImportParams) (*ImportPreview, error)
+
+	// Commit guarda el mapeo y pone en cola un trabajo recién subido.
+	Commit(ctx context.Context, clinicID, jobID string, mapping map[string]string, locale i18n.Locale) (*models.ImportJob, error)
+	// Resume vuelve a poner en cola un trabajo fallido; las filas ya procesadas no se repiten.
+	Resume(ctx context.Context, clinicID, jobID string) (*models.ImportJob, error)
+	// InvalidRows devuelve las filas que no se importaron, con índice mayor que after.
+	InvalidRows(ctx context.Context, clinicID, jobID string, after, limit int) ([]*models.ImportRow, error)
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/importer"
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/validators"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Errores de las importaciones
+var (
+	ErrImportNotFound  = errors.New("import not found")
+	ErrInvalidImportID = errors.New("invalid import ID")
+	ErrImportState     = errors.New("import is not in a state that allows this operation")
+)
+
+// MappingError es un mapeo de columnas inválido, con el error de cada campo.
+type MappingError struct {
+	Errors []validators.FieldError
+}
+
+func (e *MappingError) Error() string {
+	return fmt.Sprintf("%s: %d errors", importer.ErrInvalidMapping, len(e.Errors))
+}
+
+func (e *MappingError) Unwrap() error {
+	return importer.ErrInvalidMapping
+}
+
+type importService struct {
+	clinics storage.ClinicStorer
+	imports storage.ImportStorer
+	logger  *slog.Logger
+}
+
+// NewImportService crea el servicio de importaciones.
+func NewImportService(stores *storage.Stores, logger *slog.Logger) ImportService {
+	return tracedImportService{next: &importService{
+		clinics: stores.Clinics,
+		imports: stores.Imports,
+		logger:  logger.With("service", "import"),
+	}}
+}
+
+// Upload lee el archivo y guarda sus filas en un trabajo nuevo, con un mapeo
+// propuesto a partir de la cabecera. No se importa nada hasta Commit.
+func (s *importService) Upload(ctx context.Context, params UploadImportParams) (*models.ImportJob, error) {
+	clinicID, err := s.checkClinic(ctx, params.ClinicID)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet, err := importer.Parse(params.FileName, params.File)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &models.ImportJob{
+		ClinicID: clinicID,
+		FileName: params.FileName,
+		Format:   sheet.Format,
+		Columns:  sheet.Columns,
+		Mapping:  importer.Suggest(sheet.Columns),
+		Status:   models.ImportUploaded,
+		Stats:    models.ImportStats{Total: len(sheet.Rows)},
+	}
+	rows := make([]*models.ImportRow, len(sheet.Rows))
+	for i, values := range sheet.Rows {
+		rows[i] = &models.ImportRow{
+			Index:     i,
+			Values:    values,
+			Status:    models.ImportRowPending,
+			OwnerID:   primitive.NewObjectID(),
+			PatientID: primitive.NewObjectID(),
+		}
+	}
+
+	if err := s.imports.CreateJob(ctx, job, rows); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to create import job", "error", err, "clinic_id", params.ClinicID)
+		return nil, fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "Import uploaded", "import_id", job.ID.Hex(), "clinic_id", params.ClinicID, "format", job.Format, "rows", len(rows))
+	return job, nil
+}
+
+// GetByID devuelve un trabajo de la clínica.
+func (s *importService) GetByID(ctx context.Context, clinicID, jobID string) (*models.ImportJob, error) {
+	if _, err := primitive.ObjectIDFromHex(clinicID); err != nil {
+		return nil, ErrInvalidClinicID
+	}
+	if _, err := primitive.ObjectIDFromHex(jobID); err != nil {
+		return nil, ErrInvalidImportID
+	}
+
+	job, err := s.imports.FindJob(ctx, clinicID, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import: %w", err)
+	}
+	if job == nil {
+		return nil, ErrImportNotFound
+	}
+	return job, nil
+}
+
+// Preview valida todas las filas con el mapeo indicado sin importar nada.
+func (s *importService) Preview(ctx context.Context, clinicID, jobID string, params PreviewImportParams) (*ImportPreview, error) {
+	job, err := s.GetByID(ctx, clinicID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	mapping := job.Mapping
+	if params.Mapping != nil {
+		mapping = params.Mapping
+	}
+	if errs := importer.CheckMapping(mapping, job.Columns, params.Locale); len(errs) > 0 {
+		return nil, &MappingError{Errors: errs}
+	}
+
+	rows, err := s.imports.ListRows(ctx, jobID, "", -1, importer.MaxRows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import rows: %w", err)
+	}
+
+	preview := &ImportPreview{Total: len(rows)}
+	for _, row := range rows {
+		record := importer.Build(mapping, job.Columns, row.Values, job.Format)
+		if errs := record.Validate(params.Locale); len(errs) > 0 {
+			preview.Invalid++
+			if len(preview.Rows) < MaxPreviewErrors {
+				preview.Rows = append(preview.Rows, ImportRowResult{Index: row.Index, Errors: errs})
+			}
+			continue
+		}
+		preview.Valid++
+		if len(preview.Sample) < importPreviewSample {
+			preview.Sample = append(preview.Sample, record.Fields())
+		}
+	}
+	return preview, nil
+}
+
+// importPreviewSample es cuántas filas válidas mapeadas devuelve el dry-run.
+const importPreviewSample = 5
+
+// Commit guarda el mapeo y pone en cola el trabajo; ImportRunner lo procesa.
+func (s *importService) Commit(ctx context.Context, clinicID, jobID string, mapping map[string]string, locale i18n.Locale) (*models.ImportJob, error) {
+	job, err := s.GetByID(ctx, clinicID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if mapping == nil {
+		mapping = job.Mapping
+	}
+	if errs := importer.CheckMapping(mapping, job.Columns, locale); len(errs) > 0 {
+		return nil, &MappingError{Errors: errs}
+	}
+
+	return s.queue(ctx, clinicID, jobID, []string{models.ImportUploaded}, mapping, string(locale))
+}
+
+// Resume pone en cola un trabajo fallido con su mapeo y su idioma.
+func (s *importService) Resume(ctx context.Context, clinicID, jobID string) (*models.ImportJob, error) {
+	job, err := s.GetByID(ctx, clinicID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return s.queue(ctx, clinicID, jobID, []string{models.ImportFailed}, job.Mapping, job.Locale)
+}
+
+func (s *importService) queue(ctx context.Context, clinicID, jobID string, from []string, mapping map[string]string, locale string) (*models.ImportJob, error) {
+	job, err := s.imports.QueueJob(ctx, clinicID, jobID, from, mapping, locale)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to queue import", "error", err, "import_id", jobID)
+		return nil, fmt.Errorf("failed to queue import: %w", err)
+	}
+	if job == nil {
+		// Existía al leerlo: otro cliente lo confirmó o reanudó antes
+		return nil, ErrImportState
+	}
+
+	s.logger.InfoContext(ctx, "Import queued", "import_id", jobID, "clinic_id", clinicID)
+	return job, nil
+}
+
+// InvalidRows devuelve las filas que no se importaron.
+func (s *importService) InvalidRows(ctx context.Context, clinicID, jobID string, after, limit int) ([]*models.ImportRow, error) {
+	if _, err := s.GetByID(ctx, clinicID, jobID); err != nil {
+		return nil, err
+	}
+	rows, err := s.imports.ListRows(ctx, jobID, models.ImportRowInvalid, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invalid rows: %w", err)
+	}
+	return rows, nil
+}
+
+// checkClinic valida el ID de la clínica y que exista.
+func (s *importService) checkClinic(ctx context.Context, clinicID string) (primitive.ObjectID, error) {
+	objID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return primitive.NilObjectID, ErrInvalidClinicID
+	}
+	exists, err := s.clinics.Exists(ctx, clinicID)
+	if err != nil {
+		return primitive.NilObjectID, fmt.Errorf("failed to check clinic: %w", err)
+	}
+	if !exists {
+		return primitive.NilObjectID, ErrClinicNotFound
+	}
+	return objID, nil
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// ResourceUsage es el uso de un recurso frente al límite del plan.
+type ResourceUsage struct {
+	Used  int64
+	Limit int64 // 0: ilimitado
+}
+
+// Remaining es lo que queda hasta el límite; -1 si es ilimitado.
+func (u ResourceUsage) Remaining() int64 {
+	if u.Limit <= 0 {
+		return -1
+	}
+	return max(0, u.Limit-u.Used)
+}
+
+// ClinicPlanUsage es el uso de una clínica frente a los límites de su plan.
+type ClinicPlanUsage struct {
+	ClinicID string
+	Plan     models.Plan
+	Users    ResourceUsage
+	Patients ResourceUsage
+	Storage  ResourceUsage // En bytes
+}
+
+// PlanService aplica los límites del plan de cada clínica. Reserve se llama
+// antes de cada alta y Release la deshace si el alta falla o se borra.
+type PlanService interface {
+	// Reserve suma n al recurso si cabe en el plan de la clínica; si no,
+	// devuelve el error del límite (p. ej. ErrUserLimitReached).
+	Reserve(ctx context.Context, clinicID, resource string, n int64) error
+	// Release resta n al recurso.
+	Release(ctx context.Context, clinicID, resource string, n int64) error
+	// Usage devuelve el uso de la clínica frente a su plan.
+	Usage(ctx context.Context, clinicID string) (*ClinicPlanUsage, error)
+}
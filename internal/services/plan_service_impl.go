@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+// Errores de los planes
+var (
+	ErrInvalidPlan         = errors.New("unknown subscription plan")
+	ErrUserLimitReached    = errors.New("clinic reached the user limit of its plan")
+	ErrPatientLimitReached = errors.New("clinic reached the patient limit of its plan")
+	ErrStorageLimitReached = errors.New("clinic reached the storage limit of its plan")
+)
+
+// limitErrors es el error de cada recurso al alcanzar su límite
+var limitErrors = map[string]error{
+	models.UsageUsers:    ErrUserLimitReached,
+	models.UsagePatients: ErrPatientLimitReached,
+	models.UsageStorage:  ErrStorageLimitReached,
+}
+
+type planService struct {
+	clinics storage.ClinicStorer
+	usage   storage.UsageStorer
+	logger  *slog.Logger
+}
+
+// NewPlanService crea el servicio de planes y cupos.
+func NewPlanService(stores *storage.Stores, logger *slog.Logger) PlanService {
+	return tracedPlanService{next: &planService{
+		clinics: stores.Clinics,
+		usage:   stores.Usage,
+		logger:  logger.With("service", "plan"),
+	}}
+}
+
+// Reserve suma al contador con el límite del plan actual de la clínica. Si
+// la clínica bajó a un plan menor, lo ya creado se conserva pero no se puede
+// crear más hasta volver por debajo del límite.
+func (s *planService) Reserve(ctx context.Context, clinicID, resource string, n int64) error {
+	clinic, err := s.getClinic(ctx, clinicID)
+	if err != nil {
+		return err
+	}
+
+	ok, err := s.usage.Add(ctx, clinicID, resource, n, clinic.GetPlan().Limit(resource))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error reserving clinic usage", "error", err, "clinic_id", clinicID, "resource", resource)
+		return fmt.Errorf("failed to reserve clinic usage: %w", err)
+	}
+	if !ok {
+		s.logger.InfoContext(ctx, "Plan limit reached", "clinic_id", clinicID, "plan", clinic.GetPlan().ID, "resource", resource)
+		return limitErrors[resource]
+	}
+	return nil
+}
+
+// Release resta del contador sin comprobar la clínica: puede estar ya borrada.
+func (s *planService) Release(ctx context.Context, clinicID, resource string, n int64) error {
+	if _, err := s.usage.Add(ctx, clinicID, resource, -n, 0); err != nil {
+		s.logger.ErrorContext(ctx, "Error releasing clinic usage", "error", err, "clinic_id", clinicID, "resource", resource)
+		return fmt.Errorf("failed to release clinic usage: %w", err)
+	}
+	return nil
+}
+
+// Usage devuelve los contadores de la clínica junto a los límites de su plan.
+func (s *planService) Usage(ctx context.Context, clinicID string) (*ClinicPlanUsage, error) {
+	clinic, err := s.getClinic(ctx, clinicID)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := s.usage.Get(ctx, clinicID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting clinic usage", "error", err, "clinic_id", clinicID)
+		return nil, fmt.Errorf("failed to get clinic usage: %w", err)
+	}
+
+	plan := clinic.GetPlan()
+	resource := func(name string) ResourceUsage {
+		return ResourceUsage{Used: usage.Get(name), Limit: plan.Limit(name)}
+	}
+	return &ClinicPlanUsage{
+		ClinicID: clinicID,
+		Plan:     plan,
+		Users:    resource(models.UsageUsers),
+		Patients: resource(models.UsagePatients),
+		Storage:  resource(models.UsageStorage),
+	}, nil
+}
+
+// getClinic busca la clínica activa, con los errores de ClinicService
+func (s *planService) getClinic(ctx context.Context, clinicID string) (*models.Clinic, error) {
+	clinic, err := findClinic(ctx, s.clinics, clinicID)
+	if err != nil && !errors.Is(err, ErrClinicNotFound) && !errors.Is(err, ErrInvalidClinicID) {
+		s.logger.ErrorContext(ctx, "Error getting clinic", "error", err, "clinic_id", clinicID)
+	}
+	return clinic, err
+}
+
+// findClinic busca una clínica activa y traduce los errores del store a los
+// de ClinicService.GetByID.
+func findClinic(ctx context.Context, clinics storage.ClinicStorer, clinicID string) (*models.Clinic, error) {
+	if strings.TrimSpace(clinicID) == "" {
+		return nil, ErrInvalidClinicID
+	}
+	clinic, err := clinics.GetByID(ctx, clinicID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrClinicNotFound
+		}
+		if strings.Contains(err.Error(), "invalid") {
+			return nil, ErrInvalidClinicID
+		}
+		return nil, fmt.Errorf("failed to get clinic: %w", err)
+	}
+	return clinic, nil
+}
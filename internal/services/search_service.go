@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Tipos de resultado de la búsqueda global
+const (
+	SearchClinics = "clinics"
+	SearchOwners  = "owners"
+	SearchPets    = "pets"
+)
+
+// SearchParams contiene los parámetros de la búsqueda global.
+type SearchParams struct {
+	Query    string
+	ClinicID string   // Obligatorio para buscar tutores y mascotas
+	Types    []string // Vacío: clínicas, y tutores y mascotas si hay ClinicID
+	Limit    int
+}
+
+// SearchHit es un resultado de la búsqueda, de cualquiera de los tipos.
+type SearchHit struct {
+	Type     string
+	ID       primitive.ObjectID
+	ClinicID primitive.ObjectID // Cero para las clínicas
+	Title    string             // Nombre para mostrar
+	Subtitle string             // Contexto: nombre interno, email, especie...
+	Score    float64            // Entre 0 y 1; mayor es más parecido
+}
+
+// SearchService define la búsqueda tolerante a errores tipográficos.
+type SearchService interface {
+	Search(ctx context.Context, params SearchParams) ([]SearchHit, error)
+}
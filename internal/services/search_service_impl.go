@@ -0,0 +1,186 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/search"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Errores de la búsqueda global
+var (
+	ErrSearchQueryRequired  = errors.New("search query must contain at least one letter or digit")
+	ErrSearchClinicRequired = errors.New("clinicId is required to search owners and pets")
+	ErrInvalidSearchType    = errors.New("invalid search type")
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+)
+
+type searchService struct {
+	clinics  storage.ClinicStorer
+	owners   storage.OwnerStorer
+	patients storage.PatientStorer
+	logger   *slog.Logger
+}
+
+// NewSearchService crea el servicio de búsqueda sobre los stores indicados.
+func NewSearchService(stores *storage.Stores, logger *slog.Logger) SearchService {
+	return tracedSearchService{next: &searchService{
+		clinics:  stores.Clinics,
+		owners:   stores.Owners,
+		patients: stores.Patients,
+		logger:   logger.With("service", "search"),
+	}}
+}
+
+// Search preselecciona candidatos por trigramas en cada colección y los ordena
+// por su similitud con la consulta (exacta, prefijo o con errores).
+func (s *searchService) Search(ctx context.Context, params SearchParams) ([]SearchHit, error) {
+	terms := search.Terms(params.Query)
+	if len(terms) == 0 {
+		return nil, ErrSearchQueryRequired
+	}
+	if len(terms) > search.MaxQueryTerms {
+		terms = terms[:search.MaxQueryTerms]
+	}
+
+	types, err := s.searchTypes(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	grams := search.Grams(terms)
+	var hits []SearchHit
+	for _, t := range types {
+		var found []SearchHit
+		switch t {
+		case SearchClinics:
+			found, err = s.searchClinics(ctx, terms, grams)
+		case SearchOwners:
+			found, err = s.searchOwners(ctx, params.ClinicID, terms, grams)
+		case SearchPets:
+			found, err = s.searchPets(ctx, params.ClinicID, terms, grams)
+		}
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Error searching", "error", err, "type", t)
+			return nil, fmt.Errorf("failed to search %s: %w", t, err)
+		}
+		hits = append(hits, found...)
+	}
+
+	slices.SortStableFunc(hits, func(a, b SearchHit) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Title, b.Title)
+	})
+
+	limit := params.Limit
+	if limit < 1 || limit > maxSearchLimit {
+		limit = defaultSearchLimit
+	}
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// searchTypes valida los tipos pedidos y la clínica de la que dependen tutores y mascotas
+func (s *searchService) searchTypes(ctx context.Context, params SearchParams) ([]string, error) {
+	types := params.Types
+	if len(types) == 0 {
+		types = []string{SearchClinics}
+		if params.ClinicID != "" {
+			types = append(types, SearchOwners, SearchPets)
+		}
+	}
+
+	scoped := false
+	for _, t := range types {
+		switch t {
+		case SearchClinics:
+		case SearchOwners, SearchPets:
+			scoped = true
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSearchType, t)
+		}
+	}
+	if !scoped {
+		return types, nil
+	}
+
+	if params.ClinicID == "" {
+		return nil, ErrSearchClinicRequired
+	}
+	if _, err := primitive.ObjectIDFromHex(params.ClinicID); err != nil {
+		return nil, ErrInvalidClinicID
+	}
+	exists, err := s.clinics.Exists(ctx, params.ClinicID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check clinic: %w", err)
+	}
+	if !exists {
+		return nil, ErrClinicNotFound
+	}
+	return types, nil
+}
+
+func (s *searchService) searchClinics(ctx context.Context, terms, grams []string) ([]SearchHit, error) {
+	clinics, err := s.clinics.SearchCandidates(ctx, grams, storage.MaxSearchCandidates)
+	if err != nil {
+		return nil, err
+	}
+	return scoreHits(terms, clinics, func(c *models.Clinic) (SearchHit, search.Keys) {
+		return SearchHit{Type: SearchClinics, ID: c.ID, Title: c.DisplayName, Subtitle: c.Name}, c.Search
+	}), nil
+}
+
+func (s *searchService) searchOwners(ctx context.Context, clinicID string, terms, grams []string) ([]SearchHit, error) {
+	owners, err := s.owners.SearchCandidates(ctx, clinicID, grams, storage.MaxSearchCandidates)
+	if err != nil {
+		return nil, err
+	}
+	return scoreHits(terms, owners, func(o *models.Owner) (SearchHit, search.Keys) {
+		return SearchHit{Type: SearchOwners, ID: o.ID, ClinicID: o.ClinicID, Title: o.FullName, Subtitle: cmp.Or(o.Email, o.Phone)}, o.Search
+	}), nil
+}
+
+func (s *searchService) searchPets(ctx context.Context, clinicID string, terms, grams []string) ([]SearchHit, error) {
+	patients, err := s.patients.SearchCandidates(ctx, clinicID, grams, storage.MaxSearchCandidates)
+	if err != nil {
+		return nil, err
+	}
+	return scoreHits(terms, patients, func(p *models.Patient) (SearchHit, search.Keys) {
+		subtitle := p.Species
+		if p.Breed != "" {
+			subtitle += " · " + p.Breed
+		}
+		return SearchHit{Type: SearchPets, ID: p.ID, ClinicID: p.ClinicID, Title: p.Name, Subtitle: subtitle}, p.Search
+	}), nil
+}
+
+// scoreHits puntúa los candidatos y descarta los que no se parecen a la consulta
+func scoreHits[T any](terms []string, items []*T, hit func(*T) (SearchHit, search.Keys)) []SearchHit {
+	var hits []SearchHit
+	for _, item := range items {
+		h, keys := hit(item)
+		score, ok := search.Score(terms, keys)
+		if !ok {
+			continue
+		}
+		h.Score = score
+		hits = append(hits, h)
+	}
+	return hits
+}
@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/pagination"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/tracing"
+)
+
+// Los constructores devuelven cada servicio envuelto en su versión trazada:
+// un span hijo por método (p. ej. "clinicService.Update") con el error, si lo
+// hay. Las llamadas a los stores dentro del método cuelgan de ese span.
+
+func traced[T any](ctx context.Context, name string, fn func(ctx context.Context) (T, error)) (T, error) {
+	ctx, span := tracing.Start(ctx, name)
+	result, err := fn(ctx)
+	tracing.End(span, err)
+	return result, err
+}
+
+func tracedErr(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	ctx, span := tracing.Start(ctx, name)
+	err := fn(ctx)
+	tracing.End(span, err)
+	return err
+}
+
+type tracedClinicService struct {
+	next ClinicService
+}
+
+func (s tracedClinicService) Create(ctx context.Context, params CreateClinicParams) (*models.Clinic, error) {
+	return traced(ctx, "clinicService.Create", func(ctx context.Context) (*models.Clinic, error) {
+		return s.next.Create(ctx, params)
+	})
+}
+
+func (s tracedClinicService) GetByID(ctx context.Context, id string) (*models.Clinic, error) {
+	return traced(ctx, "clinicService.GetByID", func(ctx context.Context) (*models.Clinic, error) {
+		return s.next.GetByID(ctx, id)
+	})
+}
+
+func (s tracedClinicService) Update(ctx context.Context, id string, params UpdateClinicParams) (*models.Clinic, error) {
+	return traced(ctx, "clinicService.Update", func(ctx context.Context) (*models.Clinic, error) {
+		return s.next.Update(ctx, id, params)
+	})
+}
+
+func (s tracedClinicService) Delete(ctx context.Context, id string, params DeleteClinicParams) error {
+	return tracedErr(ctx, "clinicService.Delete", func(ctx context.Context) error {
+		return s.next.Delete(ctx, id, params)
+	})
+}
+
+func (s tracedClinicService) List(ctx context.Context, params ListClinicsParams) (*pagination.Result[*models.Clinic], error) {
+	return traced(ctx, "clinicService.List", func(ctx context.Context) (*pagination.Result[*models.Clinic], error) {
+		return s.next.List(ctx, params)
+	})
+}
+
+func (s tracedClinicService) GetByName(ctx context.Context, name string) (*models.Clinic, error) {
+	return traced(ctx, "clinicService.GetByName", func(ctx context.Context) (*models.Clinic, error) {
+		return s.next.GetByName(ctx, name)
+	})
+}
+
+func (s tracedClinicService) GetByDisplayName(ctx context.Context, displayName string) (*models.Clinic, error) {
+	return traced(ctx, "clinicService.GetByDisplayName", func(ctx context.Context) (*models.Clinic, error) {
+		return s.next.GetByDisplayName(ctx, displayName)
+	})
+}
+
+func (s tracedClinicService) Exists(ctx context.Context, id string) (bool, error) {
+	return traced(ctx, "clinicService.Exists", func(ctx context.Context) (bool, error) {
+		return s.next.Exists(ctx, id)
+	})
+}
+
+func (s tracedClinicService) Export(ctx context.Context, params ListClinicsParams, each func(*models.Clinic) error) error {
+	return tracedErr(ctx, "clinicService.Export", func(ctx context.Context) error {
+		return s.next.Export(ctx, params, each)
+	})
+}
+
+func (s tracedClinicService) ExportDeleted(ctx context.Context, params ListClinicsParams, each func(*models.Clinic) error) error {
+	return tracedErr(ctx, "clinicService.ExportDeleted", func(ctx context.Context) error {
+		return s.next.ExportDeleted(ctx, params, each)
+	})
+}
+
+func (s tracedClinicService) ListDeleted(ctx context.Context, params ListClinicsParams) (*pagination.Result[*models.Clinic], error) {
+	return traced(ctx, "clinicService.ListDeleted", func(ctx context.Context) (*pagination.Result[*models.Clinic], error) {
+		return s.next.ListDeleted(ctx, params)
+	})
+}
+
+func (s tracedClinicService) Restore(ctx context.Context, id string) (*models.Clinic, error) {
+	return traced(ctx, "clinicService.Restore", func(ctx context.Context) (*models.Clinic, error) {
+		return s.next.Restore(ctx, id)
+	})
+}
+
+func (s tracedClinicService) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	return traced(ctx, "clinicService.PurgeDeleted", func(ctx context.Context) (int, error) {
+		return s.next.PurgeDeleted(ctx, before)
+	})
+}
+
+type tracedUserService struct {
+	next UserService
+}
+
+func (s tracedUserService) Register(ctx context.Context, params CreateUserParams) (*models.User, error) {
+	return traced(ctx, "userService.Register", func(ctx context.Context) (*models.User, error) {
+		return s.next.Register(ctx, params)
+	})
+}
+
+type tracedSearchService struct {
+	next SearchService
+}
+
+func (s tracedSearchService) Search(ctx context.Context, params SearchParams) ([]SearchHit, error) {
+	return traced(ctx, "searchService.Search", func(ctx context.Context) ([]SearchHit, error) {
+		return s.next.Search(ctx, params)
+	})
+}
+
+type tracedImportService struct {
+	next ImportService
+}
+
+func (s tracedImportService) Upload(ctx context.Context, params UploadImportParams) (*models.ImportJob, error) {
+	return traced(ctx, "importService.Upload", func(ctx context.Context) (*models.ImportJob, error) {
+		return s.next.Upload(ctx, params)
+	})
+}
+
+func (s tracedImportService) GetByID(ctx context.Context, clinicID, jobID string) (*models.ImportJob, error) {
+	return traced(ctx, "importService.GetByID", func(ctx context.Context) (*models.ImportJob, error) {
+		return s.next.GetByID(ctx, clinicID, jobID)
+	})
+}
+
+func (s tracedImportService) Preview(ctx context.Context, clinicID, jobID string, params PreviewImportParams) (*ImportPreview, error) {
+	return traced(ctx, "importService.Preview", func(ctx context.Context) (*ImportPreview, error) {
+		return s.next.Preview(ctx, clinicID, jobID, params)
+	})
+}
+
+func (s tracedImportService) Commit(ctx context.Context, clinicID, jobID string, mapping map[string]string, locale i18n.Locale) (*models.ImportJob, error) {
+	return traced(ctx, "importService.Commit", func(ctx context.Context) (*models.ImportJob, error) {
+		return s.next.Commit(ctx, clinicID, jobID, mapping, locale)
+	})
+}
+
+func (s tracedImportService) Resume(ctx context.Context, clinicID, jobID string) (*models.ImportJob, error) {
+	return traced(ctx, "importService.Resume", func(ctx context.Context) (*models.ImportJob, error) {
+		return s.next.Resume(ctx, clinicID, jobID)
+	})
+}
+
+func (s tracedImportService) InvalidRows(ctx context.Context, clinicID, jobID string, after, limit int) ([]*models.ImportRow, error) {
+	return traced(ctx, "importService.InvalidRows", func(ctx context.Context) ([]*models.ImportRow, error) {
+		return s.next.InvalidRows(ctx, clinicID, jobID, after, limit)
+	})
+}
+
+type tracedTransferService struct {
+	next TransferService
+}
+
+func (s tracedTransferService) Export(ctx context.Context, clinicID string) (*models.TransferJob, error) {
+	return traced(ctx, "transferService.Export", func(ctx context.Context) (*models.TransferJob, error) {
+		return s.next.Export(ctx, clinicID)
+	})
+}
+
+func (s tracedTransferService) Import(ctx context.Context, params ImportTenantParams) (*models.TransferJob, error) {
+	return traced(ctx, "transferService.Import", func(ctx context.Context) (*models.TransferJob, error) {
+		return s.next.Import(ctx, params)
+	})
+}
+
+func (s tracedTransferService) GetByID(ctx context.Context, jobID string) (*models.TransferJob, error) {
+	return traced(ctx, "transferService.GetByID", func(ctx context.Context) (*models.TransferJob, error) {
+		return s.next.GetByID(ctx, jobID)
+	})
+}
+
+func (s tracedTransferService) Retry(ctx context.Context, jobID string) (*models.TransferJob, error) {
+	return traced(ctx, "transferService.Retry", func(ctx context.Context) (*models.TransferJob, error) {
+		return s.next.Retry(ctx, jobID)
+	})
+}
+
+func (s tracedTransferService) OpenArchive(ctx context.Context, jobID string) (*models.TransferJob, *os.File, error) {
+	ctx, span := tracing.Start(ctx, "transferService.OpenArchive")
+	job, file, err := s.next.OpenArchive(ctx, jobID)
+	tracing.End(span, err)
+	return job, file, err
+}
+
+type tracedPlanService struct {
+	next PlanService
+}
+
+func (s tracedPlanService) Reserve(ctx context.Context, clinicID, resource string, n int64) error {
+	return tracedErr(ctx, "planService.Reserve", func(ctx context.Context) error {
+		return s.next.Reserve(ctx, clinicID, resource, n)
+	})
+}
+
+func (s tracedPlanService) Release(ctx context.Context, clinicID, resource string, n int64) error {
+	return tracedErr(ctx, "planService.Release", func(ctx context.Context) error {
+		return s.next.Release(ctx, clinicID, resource, n)
+	})
+}
+
+func (s tracedPlanService) Usage(ctx context.Context, clinicID string) (*ClinicPlanUsage, error) {
+	return traced(ctx, "planService.Usage", func(ctx context.Context) (*ClinicPlanUsage, error) {
+		return s.next.Usage(ctx, clinicID)
+	})
+}
+
+type tracedFeatureService struct {
+	next FeatureService
+}
+
+func (s tracedFeatureService) List(ctx context.Context, clinicID, subject string) ([]FeatureState, error) {
+	return traced(ctx, "featureService.List", func(ctx context.Context) ([]FeatureState, error) {
+		return s.next.List(ctx, clinicID, subject)
+	})
+}
+
+func (s tracedFeatureService) Enabled(ctx context.Context, clinicID, key, subject string) (bool, error) {
+	return traced(ctx, "featureService.Enabled", func(ctx context.Context) (bool, error) {
+		return s.next.Enabled(ctx, clinicID, key, subject)
+	})
+}
+
+func (s tracedFeatureService) Set(ctx context.Context, clinicID, key string, flag *models.FeatureFlag) (*FeatureState, error) {
+	return traced(ctx, "featureService.Set", func(ctx context.Context) (*FeatureState, error) {
+		return s.next.Set(ctx, clinicID, key, flag)
+	})
+}
+
+type tracedBrandingService struct {
+	next BrandingService
+}
+
+func (s tracedBrandingService) Upload(ctx context.Context, clinicID, kind string, r io.Reader) (*models.Clinic, error) {
+	return traced(ctx, "brandingService.Upload", func(ctx context.Context) (*models.Clinic, error) {
+		return s.next.Upload(ctx, clinicID, kind, r)
+	})
+}
+
+func (s tracedBrandingService) Delete(ctx context.Context, clinicID, kind string) error {
+	return tracedErr(ctx, "brandingService.Delete", func(ctx context.Context) error {
+		return s.next.Delete(ctx, clinicID, kind)
+	})
+}
+
+func (s tracedBrandingService) Theme(ctx context.Context, clinicName string) (*Theme, error) {
+	return traced(ctx, "brandingService.Theme", func(ctx context.Context) (*Theme, error) {
+		return s.next.Theme(ctx, clinicName)
+	})
+}
+
+func (s tracedBrandingService) OpenAsset(ctx context.Context, clinicName, kind, variant string) (*models.BrandAsset, io.ReadCloser, error) {
+	ctx, span := tracing.Start(ctx, "brandingService.OpenAsset")
+	asset, file, err := s.next.OpenAsset(ctx, clinicName, kind, variant)
+	tracing.End(span, err)
+	return asset, file, err
+}
+
+type tracedAttachmentService struct {
+	next AttachmentService
+}
+
+func (s tracedAttachmentService) Upload(ctx context.Context, params UploadAttachmentParams) (*models.Attachment, error) {
+	return traced(ctx, "attachmentService.Upload", func(ctx context.Context) (*models.Attachment, error) {
+		return s.next.Upload(ctx, params)
+	})
+}
+
+func (s tracedAttachmentService) List(ctx context.Context, clinicID string, filter storage.AttachmentFilter) ([]*models.Attachment, error) {
+	return traced(ctx, "attachmentService.List", func(ctx context.Context) ([]*models.Attachment, error) {
+		return s.next.List(ctx, clinicID, filter)
+	})
+}
+
+func (s tracedAttachmentService) GetByID(ctx context.Context, clinicID, attachmentID string) (*models.Attachment, error) {
+	return traced(ctx, "attachmentService.GetByID", func(ctx context.Context) (*models.Attachment, error) {
+		return s.next.GetByID(ctx, clinicID, attachmentID)
+	})
+}
+
+func (s tracedAttachmentService) Download(ctx context.Context, clinicID, attachmentID string) (*AttachmentDownload, error) {
+	return traced(ctx, "attachmentService.Download", func(ctx context.Context) (*AttachmentDownload, error) {
+		return s.next.Download(ctx, clinicID, attachmentID)
+	})
+}
+
+func (s tracedAttachmentService) Delete(ctx context.Context, clinicID, attachmentID string) error {
+	return tracedErr(ctx, "attachmentService.Delete", func(ctx context.Context) error {
+		return s.next.Delete(ctx, clinicID, attachmentID)
+	})
+}
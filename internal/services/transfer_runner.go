@@ -0,0 +1,397 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/health"
+	"github.com/zabaletac3/go-vet-api/internal/metrics"
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/tenant"
+	"github.com/zabaletac3/go-vet-api/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// transferBatchSize es cuántos registros se procesan entre dos registros de
+// progreso, y cuántos traspasos caducados se borran en cada pasada.
+const transferBatchSize = 100
+
+// TransferRunner procesa en segundo plano las exportaciones e importaciones de
+// clínicas completas. Una exportación interrumpida vuelve a empezar; una
+// importación se reanuda saltándose lo que ya creó (los IDs nuevos son
+// deterministas, ver tenant.Remapper). También borra los traspasos caducados
+// con sus archivos (ver models.TransferArchiveTTL).
+type TransferRunner struct {
+	clinics   storage.ClinicStorer
+	users     storage.UserStorer
+	owners    storage.OwnerStorer
+	patients  storage.PatientStorer
+	transfers storage.TransferStorer
+	usage     storage.UsageStorer
+	interval  time.Duration
+	heartbeat *health.Heartbeat
+	logger    *slog.Logger
+}
+
+// NewTransferRunner crea el ejecutor; interval es cada cuánto busca trabajos.
+func NewTransferRunner(stores *storage.Stores, interval time.Duration, logger *slog.Logger) *TransferRunner {
+	return &TransferRunner{
+		clinics:   stores.Clinics,
+		users:     stores.Users,
+		owners:    stores.Owners,
+		patients:  stores.Patients,
+		transfers: stores.Transfers,
+		usage:     stores.Usage,
+		interval:  interval,
+		heartbeat: health.NewHeartbeat(),
+		logger:    logger.With("job", "transfer_runner"),
+	}
+}
+
+// HealthCheck falla si el ejecutor deja de latir. Un trabajo largo renueva el
+// latido al guardar su progreso, como el de su registro (storage.TransferHeartbeatTimeout).
+func (r *TransferRunner) HealthCheck() health.Check {
+	return health.Worker(r.heartbeat, r.interval+storage.TransferHeartbeatTimeout)
+}
+
+// Run procesa los trabajos en cola hasta que se cancele el contexto.
+func (r *TransferRunner) Run(ctx context.Context) {
+	if r.interval <= 0 {
+		r.logger.InfoContext(ctx, "Ejecutor de traspasos desactivado")
+		return
+	}
+
+	r.logger.InfoContext(ctx, "Ejecutor de traspasos iniciado", "interval", r.interval.String())
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.heartbeat.Beat()
+		r.runPending(ctx)
+		r.removeExpired(ctx)
+
+		select {
+		case <-ctx.Done():
+			r.logger.InfoContext(ctx, "Ejecutor de traspasos detenido")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runPending procesa uno tras otro los trabajos disponibles.
+func (r *TransferRunner) runPending(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := r.transfers.ClaimJob(ctx, time.Now().UTC().Add(-storage.TransferHeartbeatTimeout))
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error al buscar traspasos pendientes", "error", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+		r.heartbeat.Beat()
+		r.process(ctx, job)
+	}
+}
+
+// removeExpired borra los traspasos caducados: primero el archivo y después el
+// trabajo, así un fallo se reintenta en la siguiente pasada sin dejar archivos
+// huérfanos. El índice TTL de transfer_jobs solo recoge los que se escapen.
+func (r *TransferRunner) removeExpired(ctx context.Context) {
+	for ctx.Err() == nil {
+		jobs, err := r.transfers.ExpiredJobs(ctx, time.Now().UTC(), transferBatchSize)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error al buscar traspasos caducados", "error", err)
+			return
+		}
+		for _, job := range jobs {
+			if err := removeTransfer(ctx, r.transfers, job); err != nil {
+				r.logger.ErrorContext(ctx, "Error al borrar un traspaso caducado", "error", err, "transfer_id", job.ID.Hex())
+				return
+			}
+			r.logger.InfoContext(ctx, "Traspaso caducado borrado", "transfer_id", job.ID.Hex(), "kind", job.Kind, "clinic_id", job.ClinicID.Hex())
+		}
+		if len(jobs) < transferBatchSize {
+			return
+		}
+	}
+}
+
+// removeTransfer borra el archivo de un traspaso y después el trabajo.
+func removeTransfer(ctx context.Context, transfers storage.TransferStorer, job *models.TransferJob) error {
+	if err := tenant.Remove(job.ID); err != nil {
+		return err
+	}
+	return transfers.DeleteJob(ctx, job.ID.Hex())
+}
+
+// process ejecuta el trabajo y lo cierra como completed o failed.
+func (r *TransferRunner) process(ctx context.Context, job *models.TransferJob) {
+	jobID := job.ID.Hex()
+	// Cada trabajo es una traza propia: no hay petición de la que colgar
+	ctx, span := tracing.Start(ctx, "TransferRunner.process", trace.WithAttributes(
+		attribute.String("transfer.id", jobID),
+		attribute.String("transfer.kind", job.Kind),
+		attribute.String("clinic.id", job.ClinicID.Hex()),
+	))
+	defer span.End()
+
+	logger := r.logger.With("transfer_id", jobID, "kind", job.Kind, "clinic_id", job.ClinicID.Hex())
+	logger.InfoContext(ctx, "Traspaso iniciado")
+
+	progress := &transferProgress{transfers: r.transfers, heartbeat: r.heartbeat, jobID: jobID}
+	var size int64
+	var err error
+	if job.Kind == models.TransferExport {
+		size, err = r.export(ctx, job, progress)
+	} else {
+		err = r.importArchive(ctx, job, progress)
+	}
+	if ctx.Err() != nil {
+		// Sin cerrar el trabajo: otro ejecutor lo retomará al caducar el latido
+		logger.InfoContext(ctx, "Traspaso interrumpido; se reanudará más tarde")
+		return
+	}
+
+	status, errMsg := models.TransferCompleted, ""
+	if err != nil {
+		status, errMsg = models.TransferFailed, transferFailure(err)
+		tracing.Fail(span, err)
+		logger.ErrorContext(ctx, "Traspaso fallido", "error", err)
+	}
+	if err := r.transfers.FinishJob(ctx, jobID, status, progress.counts, size, errMsg); err != nil {
+		logger.ErrorContext(ctx, "Error al cerrar el traspaso", "error", err)
+		return
+	}
+	metrics.TransfersFinished.WithLabelValues(job.Kind, status).Inc()
+	if job.Kind == models.TransferImport && status == models.TransferCompleted {
+		// El archivo subido solo hacía falta para reintentar
+		if err := tenant.Remove(job.ID); err != nil {
+			logger.WarnContext(ctx, "No se pudo borrar el archivo importado", "error", err)
+		}
+	}
+	logger.InfoContext(ctx, "Traspaso terminado", "status", status,
+		"users", progress.counts.Users, "owners", progress.counts.Owners, "patients", progress.counts.Patients)
+}
+
+// transferFailure es el mensaje que ve el cliente en un traspaso fallido
+func transferFailure(err error) string {
+	switch {
+	case errors.Is(err, storage.ErrDuplicateClinicName):
+		return "A clinic with that name already exists; import the archive again with another name"
+	case errors.Is(err, storage.ErrDuplicateClinicDisplayName):
+		return "A clinic with that display name already exists; import the archive again with another display name"
+	case errors.Is(err, ErrClinicNotFound):
+		return "The clinic no longer exists"
+	default:
+		return "Transfer stopped by a server error; retry it to continue"
+	}
+}
+
+// export escribe el archivo de la clínica en un fichero temporal y lo pone en
+// su sitio al terminar; devuelve su tamaño.
+func (r *TransferRunner) export(ctx context.Context, job *models.TransferJob, progress *transferProgress) (int64, error) {
+	clinicID := job.ClinicID.Hex()
+	exists, err := r.clinics.Exists(ctx, clinicID)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, ErrClinicNotFound
+	}
+	clinic, err := r.clinics.GetByID(ctx, clinicID)
+	if err != nil {
+		return 0, err
+	}
+
+	path := tenant.Path(job.ID)
+	tmp := path + ".part"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return 0, fmt.Errorf("error al crear el archivo: %w", err)
+	}
+	defer os.Remove(tmp)
+
+	err = r.writeArchive(ctx, tenant.NewWriter(file, job.ClinicID), clinic, progress)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return 0, fmt.Errorf("error al guardar el archivo: %w", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, fmt.Errorf("error al guardar el archivo: %w", err)
+	}
+	return info.Size(), nil
+}
+
+func (r *TransferRunner) writeArchive(ctx context.Context, w *tenant.Writer, clinic *models.Clinic, progress *transferProgress) error {
+	clinicID := clinic.ID.Hex()
+
+	if err := w.Begin(tenant.CollectionClinic); err != nil {
+		return err
+	}
+	if err := w.Add(tenant.NewClinic(clinic)); err != nil {
+		return err
+	}
+
+	if err := w.Begin(tenant.CollectionUsers); err != nil {
+		return err
+	}
+	err := r.users.StreamByClinic(ctx, clinicID, func(u *models.User) error {
+		if err := w.Add(tenant.NewUser(u)); err != nil {
+			return err
+		}
+		return progress.add(ctx, &progress.counts.Users)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := w.Begin(tenant.CollectionOwners); err != nil {
+		return err
+	}
+	err = r.owners.StreamByClinic(ctx, clinicID, func(o *models.Owner) error {
+		if err := w.Add(tenant.NewOwner(o)); err != nil {
+			return err
+		}
+		return progress.add(ctx, &progress.counts.Owners)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := w.Begin(tenant.CollectionPatients); err != nil {
+		return err
+	}
+	err = r.patients.StreamByClinic(ctx, clinicID, func(p *models.Patient) error {
+		if err := w.Add(tenant.NewPatient(p)); err != nil {
+			return err
+		}
+		return progress.add(ctx, &progress.counts.Patients)
+	})
+	if err != nil {
+		return err
+	}
+
+	return w.Close()
+}
+
+// importArchive crea la clínica del archivo con los IDs nuevos. Lo que ya
+// existe (de un intento anterior) se cuenta pero no se vuelve a crear. Los
+// usuarios y pacientes suman al uso de la clínica sin comprobar el límite de
+// su plan: un traspaso no deja la clínica a medias.
+func (r *TransferRunner) importArchive(ctx context.Context, job *models.TransferJob, progress *transferProgress) error {
+	archive, err := tenant.Open(tenant.Path(job.ID))
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	ids := tenant.Remapper{Seed: job.ID}
+	clinicID := job.ClinicID.Hex()
+
+	err = tenant.Each(archive, tenant.CollectionClinic, func(c *tenant.Clinic) error {
+		exists, err := r.clinics.Exists(ctx, clinicID)
+		if err != nil || exists {
+			return err
+		}
+		clinic := c.Model(ids)
+		clinic.Name = cmpOr(job.Name, clinic.Name)
+		clinic.DisplayName = cmpOr(job.DisplayName, clinic.DisplayName)
+		return r.clinics.Create(ctx, clinic)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = tenant.Each(archive, tenant.CollectionUsers, func(u *tenant.User) error {
+		existing, err := r.users.FindByEmail(ctx, clinicID, u.Email)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			if err := r.users.Create(ctx, u.Model(ids, job.ClinicID)); err != nil {
+				return err
+			}
+			if _, err := r.usage.Add(ctx, clinicID, models.UsageUsers, 1, 0); err != nil {
+				return err
+			}
+		}
+		return progress.add(ctx, &progress.counts.Users)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = tenant.Each(archive, tenant.CollectionOwners, func(o *tenant.Owner) error {
+		owner := o.Model(ids, job.ClinicID)
+		existing, err := r.owners.FindByID(ctx, clinicID, owner.ID.Hex())
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			if err := r.owners.Create(ctx, owner); err != nil {
+				return err
+			}
+		}
+		return progress.add(ctx, &progress.counts.Owners)
+	})
+	if err != nil {
+		return err
+	}
+
+	return tenant.Each(archive, tenant.CollectionPatients, func(p *tenant.Patient) error {
+		patient := p.Model(ids, job.ClinicID)
+		existing, err := r.patients.FindByID(ctx, clinicID, patient.ID.Hex())
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			if err := r.patients.Create(ctx, patient); err != nil {
+				return err
+			}
+			if _, err := r.usage.Add(ctx, clinicID, models.UsagePatients, 1, 0); err != nil {
+				return err
+			}
+		}
+		return progress.add(ctx, &progress.counts.Patients)
+	})
+}
+
+// transferProgress lleva los contadores del trabajo y los guarda (renovando el
+// latido) cada transferBatchSize registros.
+type transferProgress struct {
+	transfers storage.TransferStorer
+	heartbeat *health.Heartbeat
+	jobID     string
+	counts    models.TransferCounts
+	pending   int
+}
+
+func (p *transferProgress) add(ctx context.Context, counter *int) error {
+	*counter++
+	p.pending++
+	if p.pending < transferBatchSize {
+		return ctx.Err()
+	}
+	p.pending = 0
+	p.heartbeat.Beat()
+	return p.transfers.SaveProgress(ctx, p.jobID, p.counts)
+}
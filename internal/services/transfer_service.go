@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// ImportTenantParams es un archivo exportado para recrear su clínica.
+type ImportTenantParams struct {
+	Archive io.Reader
+
+	// Name y DisplayName sustituyen a los del archivo (vacíos: los del archivo).
+	// Hacen falta si la clínica original sigue existiendo en este entorno.
+	Name        string
+	DisplayName string
+}
+
+// TransferService gestiona la portabilidad de clínicas completas: exportar
+// todos sus datos a un archivo versionado y recrearla a partir de uno. Ambas
+// operaciones son trabajos en segundo plano (los procesa TransferRunner).
+type TransferService interface {
+	// Export pone en cola la exportación de la clínica.
+	Export(ctx context.Context, clinicID string) (*models.TransferJob, error)
+	// Import guarda y verifica el archivo (manifiesto, sumas y referencias) y
+	// pone en cola la creación de la clínica con IDs nuevos.
+	Import(ctx context.Context, params ImportTenantParams) (*models.TransferJob, error)
+	GetByID(ctx context.Context, jobID string) (*models.TransferJob, error)
+	// Retry vuelve a poner en cola un traspaso fallido; una importación no
+	// repite lo que ya creó.
+	Retry(ctx context.Context, jobID string) (*models.TransferJob, error)
+	// OpenArchive abre el archivo de una exportación terminada.
+	OpenArchive(ctx context.Context, jobID string) (*models.TransferJob, *os.File, error)
+}
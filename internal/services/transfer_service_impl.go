@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/tenant"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Errores de los traspasos
+var (
+	ErrTransferNotFound  = errors.New("transfer not found")
+	ErrInvalidTransferID = errors.New("invalid transfer ID")
+	ErrTransferState     = errors.New("transfer is not in a state that allows this operation")
+)
+
+type transferService struct {
+	clinics   storage.ClinicStorer
+	transfers storage.TransferStorer
+	logger    *slog.Logger
+}
+
+// NewTransferService crea el servicio de portabilidad de clínicas.
+func NewTransferService(stores *storage.Stores, logger *slog.Logger) TransferService {
+	return tracedTransferService{next: &transferService{
+		clinics:   stores.Clinics,
+		transfers: stores.Transfers,
+		logger:    logger.With("service", "transfer"),
+	}}
+}
+
+// Export pone en cola la exportación de una clínica activa.
+func (s *transferService) Export(ctx context.Context, clinicID string) (*models.TransferJob, error) {
+	objID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, ErrInvalidClinicID
+	}
+	exists, err := s.clinics.Exists(ctx, clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check clinic: %w", err)
+	}
+	if !exists {
+		return nil, ErrClinicNotFound
+	}
+
+	job := &models.TransferJob{Kind: models.TransferExport, ClinicID: objID, Status: models.TransferQueued}
+	if err := s.transfers.CreateJob(ctx, job); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to create export", "error", err, "clinic_id", clinicID)
+		return nil, fmt.Errorf("failed to create export: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "Clinic export queued", "transfer_id", job.ID.Hex(), "clinic_id", clinicID)
+	return job, nil
+}
+
+// Import guarda el archivo con el ID del trabajo como nombre, lo verifica y
+// pone en cola la importación. Si el archivo no es válido no queda nada.
+func (s *transferService) Import(ctx context.Context, params ImportTenantParams) (*models.TransferJob, error) {
+	jobID := primitive.NewObjectID()
+	path := tenant.Path(jobID)
+	if err := saveFile(path, params.Archive); err != nil {
+		return nil, err
+	}
+
+	job, err := s.queueImport(ctx, jobID, path, params)
+	if err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Clinic import queued", "transfer_id", job.ID.Hex(), "clinic_id", job.ClinicID.Hex())
+	return job, nil
+}
+
+func (s *transferService) queueImport(ctx context.Context, jobID primitive.ObjectID, path string, params ImportTenantParams) (*models.TransferJob, error) {
+	archive, err := tenant.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	if err := archive.Verify(); err != nil {
+		return nil, err
+	}
+
+	var clinic tenant.Clinic
+	err = tenant.Each(archive, tenant.CollectionClinic, func(c *tenant.Clinic) error {
+		clinic = *c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Se comprueba ya para no encolar un trabajo que fallaría; el índice único
+	// lo vuelve a comprobar al crear la clínica
+	name := cmpOr(strings.TrimSpace(params.Name), clinic.Name)
+	displayName := cmpOr(strings.TrimSpace(params.DisplayName), clinic.DisplayName)
+	if err := s.checkNames(ctx, name, displayName); err != nil {
+		return nil, err
+	}
+
+	source := archive.ClinicID()
+	job := &models.TransferJob{
+		ID:             jobID,
+		Kind:           models.TransferImport,
+		ClinicID:       tenant.Remapper{Seed: jobID}.ID(source),
+		SourceClinicID: &source,
+		Name:           strings.TrimSpace(params.Name),
+		DisplayName:    strings.TrimSpace(params.DisplayName),
+		Status:         models.TransferQueued,
+	}
+	if err := s.transfers.CreateJob(ctx, job); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to create import", "error", err)
+		return nil, fmt.Errorf("failed to create import: %w", err)
+	}
+	return job, nil
+}
+
+func (s *transferService) checkNames(ctx context.Context, name, displayName string) error {
+	existing, err := s.clinics.GetByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("error checking unique name: %w", err)
+	}
+	if existing != nil {
+		return ErrClinicNameExists
+	}
+	existing, err = s.clinics.GetByDisplayName(ctx, displayName)
+	if err != nil {
+		return fmt.Errorf("error checking unique display name: %w", err)
+	}
+	if existing != nil {
+		return ErrDisplayNameExists
+	}
+	return nil
+}
+
+// GetByID devuelve un traspaso.
+func (s *transferService) GetByID(ctx context.Context, jobID string) (*models.TransferJob, error) {
+	if _, err := primitive.ObjectIDFromHex(jobID); err != nil {
+		return nil, ErrInvalidTransferID
+	}
+
+	job, err := s.transfers.FindJob(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer: %w", err)
+	}
+	// Un traspaso caducado puede haber perdido ya su archivo
+	if job == nil || (job.ExpiresAt != nil && !job.ExpiresAt.After(time.Now())) {
+		return nil, ErrTransferNotFound
+	}
+	return job, nil
+}
+
+// Retry vuelve a poner en cola un traspaso fallido.
+func (s *transferService) Retry(ctx context.Context, jobID string) (*models.TransferJob, error) {
+	if _, err := s.GetByID(ctx, jobID); err != nil {
+		return nil, err
+	}
+
+	job, err := s.transfers.QueueJob(ctx, jobID, []string{models.TransferFailed})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to queue transfer", "error", err, "transfer_id", jobID)
+		return nil, fmt.Errorf("failed to queue transfer: %w", err)
+	}
+	if job == nil {
+		return nil, ErrTransferState
+	}
+
+	s.logger.InfoContext(ctx, "Transfer queued again", "transfer_id", jobID, "kind", job.Kind)
+	return job, nil
+}
+
+// OpenArchive abre el archivo de una exportación terminada.
+func (s *transferService) OpenArchive(ctx context.Context, jobID string) (*models.TransferJob, *os.File, error) {
+	job, err := s.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if job.Kind != models.TransferExport || job.Status != models.TransferCompleted {
+		return nil, nil, ErrTransferState
+	}
+
+	file, err := os.Open(tenant.Path(job.ID))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, ErrTransferNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to open transfer archive: %w", err)
+	}
+	return job, file, nil
+}
+
+// saveFile copia r en path pasando por un fichero temporal, para que nunca
+// quede un archivo a medias con el nombre definitivo.
+func saveFile(path string, r io.Reader) error {
+	tmp := path + ".part"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to save transfer archive: %w", err)
+	}
+	_, err = io.Copy(file, r)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to save transfer archive: %w", err)
+	}
+	return nil
+}
+
+// cmpOr devuelve value o, si está vacío, fallback
+func cmpOr(value, fallback string) string {
+	if value != "" {
+		return value
+	}
+	return fallback
+}
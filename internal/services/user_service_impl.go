@@ -8,6 +8,7 @@ import (
 
 	"github.com/jinzhu/copier"
 	"github.com/zabaletac3/go-vet-api/internal/auth"
+	"github.com/zabaletac3/go-vet-api/internal/metrics"
 	"github.com/zabaletac3/go-vet-api/internal/models"
 	"github.com/zabaletac3/go-vet-api/internal/storage"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -21,15 +22,18 @@ var (
 
 type userService struct {
 	userStore storage.UserStorer
+	plans     PlanService
 	logger    *slog.Logger
 }
 
 // NewUserService es el constructor para la implementación del servicio de usuario.
-func NewUserService(store storage.UserStorer, logger *slog.Logger) UserService {
-	return &userService{
+// plans limita los usuarios de cada clínica a los puestos de su plan.
+func NewUserService(store storage.UserStorer, plans PlanService, logger *slog.Logger) UserService {
+	return tracedUserService{next: &userService{
 		userStore: store,
+		plans:     plans,
 		logger:    logger.With("service", "user"),
-	}
+	}}
 }
 
 // Register implementa la lógica para registrar un nuevo usuario.
@@ -42,7 +46,7 @@ func (s *userService) Register(ctx context.Context, params CreateUserParams) (*m
 	// 2. Regla de Negocio: Verificar que el email no esté ya en uso en esa clínica.
 	existingUser, err := s.userStore.FindByEmail(ctx, params.ClinicID, params.Email)
 	if err != nil {
-		s.logger.Error("Error al verificar el email del usuario", "error", err)
+		s.logger.ErrorContext(ctx, "Error al verificar el email del usuario", "error", err)
 		return nil, fmt.Errorf("error al verificar el email: %w", err)
 	}
 	if existingUser != nil {
@@ -52,7 +56,7 @@ func (s *userService) Register(ctx context.Context, params CreateUserParams) (*m
 	// 3. Lógica de Aplicación: Hashear la contraseña.
 	hashedPassword, err := auth.HashPassword(params.Password)
 	if err != nil {
-		s.logger.Error("No se pudo hashear la contraseña", "error", err)
+		s.logger.ErrorContext(ctx, "No se pudo hashear la contraseña", "error", err)
 		return nil, fmt.Errorf("error interno al procesar la contraseña")
 	}
 
@@ -64,12 +68,26 @@ func (s *userService) Register(ctx context.Context, params CreateUserParams) (*m
 	newUser.ClinicID = clinicObjID
 	newUser.HashedPassword = hashedPassword
 
-	// 5. Persistir el nuevo usuario.
+	// 5. Regla de Negocio: reservar un puesto del plan de la clínica. Se
+	// libera si el alta falla (en una transacción se revierte con ella).
+	if err := s.plans.Reserve(ctx, params.ClinicID, models.UsageUsers, 1); err != nil {
+		return nil, err
+	}
+
+	// 6. Persistir el nuevo usuario.
 	if err := s.userStore.Create(ctx, &newUser); err != nil {
-		s.logger.Error("No se pudo guardar el usuario en la base de datos", "error", err)
+		if releaseErr := s.plans.Release(ctx, params.ClinicID, models.UsageUsers, 1); releaseErr != nil {
+			s.logger.ErrorContext(ctx, "No se pudo liberar el puesto reservado", "error", releaseErr)
+		}
+		// El índice único {clinicId, email} cubre registros simultáneos.
+		if errors.Is(err, storage.ErrDuplicateUserEmail) {
+			return nil, ErrUserAlreadyExists
+		}
+		s.logger.ErrorContext(ctx, "No se pudo guardar el usuario en la base de datos", "error", err)
 		return nil, fmt.Errorf("error al registrar el usuario: %w", err)
 	}
 
-	s.logger.Info("Usuario registrado exitosamente", "email", newUser.Email, "userID", newUser.ID.Hex())
+	storage.AfterCommit(ctx, metrics.UsersRegistered.Inc)
+	s.logger.InfoContext(ctx, "Usuario registrado exitosamente", "email", newUser.Email, "userID", newUser.ID.Hex())
 	return &newUser, nil
 }
\ No newline at end of file
@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AttachmentRepository implementa AttachmentStorer sobre la colección attachments.
+type AttachmentRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAttachmentRepository crea el repositorio de adjuntos.
+func NewAttachmentRepository(db *mongo.Database) *AttachmentRepository {
+	return &AttachmentRepository{collection: db.Collection("attachments")}
+}
+
+// Create inserta el adjunto.
+func (r *AttachmentRepository) Create(ctx context.Context, attachment *models.Attachment) error {
+	if attachment.ID.IsZero() {
+		attachment.ID = primitive.NewObjectID()
+	}
+	attachment.CreatedAt = time.Now().UTC()
+
+	if _, err := r.collection.InsertOne(ctx, attachment); err != nil {
+		return fmt.Errorf("error creating attachment: %w", err)
+	}
+	return nil
+}
+
+// FindByID busca un adjunto de la clínica.
+func (r *AttachmentRepository) FindByID(ctx context.Context, clinicID, attachmentID string) (*models.Attachment, error) {
+	filter, err := attachmentFilter(clinicID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(ctx, filter)
+}
+
+// FindByHash busca el adjunto más antiguo de la clínica con ese contenido.
+func (r *AttachmentRepository) FindByHash(ctx context.Context, clinicID, hash string) (*models.Attachment, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic ID '%s': %w", clinicID, err)
+	}
+	return r.findOne(ctx, bson.M{"clinicId": clinicObjID, "hash": hash}, options.FindOne().SetSort(bson.D{{Key: "_id", Value: 1}}))
+}
+
+// List usa el índice clinicId + patientId + createdAt (o clinicId + createdAt
+// sin paciente).
+func (r *AttachmentRepository) List(ctx context.Context, clinicID string, filter AttachmentFilter) ([]*models.Attachment, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic ID '%s': %w", clinicID, err)
+	}
+	query := bson.M{"clinicId": clinicObjID}
+	if filter.PatientID != "" {
+		patientObjID, err := primitive.ObjectIDFromHex(filter.PatientID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid patient ID '%s': %w", filter.PatientID, err)
+		}
+		query["patientId"] = patientObjID
+	}
+	if filter.Category != "" {
+		query["category"] = filter.Category
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing attachments: %w", err)
+	}
+	attachments := []*models.Attachment{}
+	if err := cursor.All(ctx, &attachments); err != nil {
+		return nil, fmt.Errorf("error decoding attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// CountByHash cuenta los adjuntos de la clínica con ese contenido.
+func (r *AttachmentRepository) CountByHash(ctx context.Context, clinicID, hash string) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clinic ID '%s': %w", clinicID, err)
+	}
+	count, err := r.collection.CountDocuments(ctx, bson.M{"clinicId": clinicObjID, "hash": hash})
+	if err != nil {
+		return 0, fmt.Errorf("error counting attachments: %w", err)
+	}
+	return count, nil
+}
+
+// Delete borra el adjunto y lo devuelve.
+func (r *AttachmentRepository) Delete(ctx context.Context, clinicID, attachmentID string) (*models.Attachment, error) {
+	filter, err := attachmentFilter(clinicID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	var attachment models.Attachment
+	if err := r.collection.FindOneAndDelete(ctx, filter).Decode(&attachment); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error deleting attachment: %w", err)
+	}
+	return &attachment, nil
+}
+
+// DeleteByClinic borra todos los adjuntos de la clínica.
+func (r *AttachmentRepository) DeleteByClinic(ctx context.Context, clinicID string) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clinic ID '%s': %w", clinicID, err)
+	}
+	result, err := r.collection.DeleteMany(ctx, bson.M{"clinicId": clinicObjID})
+	if err != nil {
+		return 0, fmt.Errorf("error deleting clinic attachments: %w", err)
+	}
+	return result.DeletedCount, nil
+}
+
+func (r *AttachmentRepository) findOne(ctx context.Context, filter bson.M, opts ...*options.FindOneOptions) (*models.Attachment, error) {
+	var attachment models.Attachment
+	if err := r.collection.FindOne(ctx, filter, opts...).Decode(&attachment); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error finding attachment: %w", err)
+	}
+	return &attachment, nil
+}
+
+// attachmentFilter acota el adjunto a su clínica
+func attachmentFilter(clinicID, attachmentID string) (bson.M, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic ID '%s': %w", clinicID, err)
+	}
+	objID, err := primitive.ObjectIDFromHex(attachmentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attachment ID '%s': %w", attachmentID, err)
+	}
+	return bson.M{"_id": objID, "clinicId": clinicObjID}, nil
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// AttachmentStorer guarda los metadatos de los ficheros adjuntos. El
+// contenido no se guarda aquí: está en el almacén de ficheros (ver blob).
+type AttachmentStorer interface {
+	// Create guarda el adjunto. Si attachment.ID viene vacío se genera uno.
+	Create(ctx context.Context, attachment *models.Attachment) error
+	// FindByID busca un adjunto de la clínica. nil si no existe.
+	FindByID(ctx context.Context, clinicID, attachmentID string) (*models.Attachment, error)
+	// FindByHash busca un adjunto de la clínica con ese contenido. nil si no hay ninguno.
+	FindByHash(ctx context.Context, clinicID, hash string) (*models.Attachment, error)
+	// List devuelve los adjuntos de la clínica que cumplen el filtro, del más
+	// reciente al más antiguo.
+	List(ctx context.Context, clinicID string, filter AttachmentFilter) ([]*models.Attachment, error)
+	// CountByHash cuenta los adjuntos de la clínica con ese contenido.
+	CountByHash(ctx context.Context, clinicID, hash string) (int64, error)
+	// Delete borra el adjunto y lo devuelve. nil si no existe.
+	Delete(ctx context.Context, clinicID, attachmentID string) (*models.Attachment, error)
+	// DeleteByClinic borra todos los adjuntos de la clínica y devuelve cuántos borró.
+	DeleteByClinic(ctx context.Context, clinicID string) (int64, error)
+}
+
+// AttachmentFilter acota un listado de adjuntos; los campos vacíos no filtran.
+type AttachmentFilter struct {
+	PatientID string
+	Category  string
+	Limit     int // Máximo de adjuntos (0: todos)
+}
@@ -5,9 +5,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/zabaletac3/go-vet-api/internal/database"
 	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/pagination"
+	"github.com/zabaletac3/go-vet-api/internal/search"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/dto"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -31,13 +37,17 @@ func (r *ClinicRepository) Create(ctx context.Context, clinic *models.Clinic) er
         return fmt.Errorf("validation failed: %w", err)
     }
 
-    // Establecer valores automáticos
-    clinic.ID = primitive.NewObjectID()
+    // Establecer valores automáticos (si clinic.ID viene vacío se genera uno)
+    if clinic.ID.IsZero() {
+        clinic.ID = primitive.NewObjectID()
+    }
     now := time.Now().UTC()
     clinic.CreatedAt = now
     clinic.UpdatedAt = now
     clinic.IsActive = true
+    clinic.Version = 1
     clinic.DeletedAt = nil // No eliminado
+    clinic.Search = clinic.SearchKeys()
 
     // Establecer paleta por defecto si no se proporciona
     if clinic.Palette.Primary == "" {
@@ -47,7 +57,7 @@ func (r *ClinicRepository) Create(ctx context.Context, clinic *models.Clinic) er
     _, err := r.collection.InsertOne(ctx, clinic)
     if err != nil {
         if mongo.IsDuplicateKeyError(err) {
-            return fmt.Errorf("clinic with that name already exists: %w", err)
+            return duplicateClinicError(err)
         }
         return fmt.Errorf("failed to create clinic: %w", err)
     }
@@ -80,7 +90,8 @@ func (r *ClinicRepository) GetByID(ctx context.Context, id string) (*models.Clin
 }
 
 // Update - Actualiza clínica con validación (VERDADERO PATCH)
-func (r *ClinicRepository) Update(ctx context.Context, id string, updateFields map[string]interface{}) error {
+// Si version > 0 solo escribe si la clínica sigue en esa versión.
+func (r *ClinicRepository) Update(ctx context.Context, id string, version int64, updateFields map[string]interface{}) error {
     objID, err := primitive.ObjectIDFromHex(id)
     if err != nil {
         return fmt.Errorf("invalid clinic ID '%s': %w", id, err)
@@ -97,25 +108,56 @@ func (r *ClinicRepository) Update(ctx context.Context, id string, updateFields m
     // Usar $set para actualización parcial (PATCH behavior)
     update := bson.M{
         "$set": updateFields,
+        "$inc": bson.M{"version": 1},
+    }
+
+    filter := bson.M{"_id": objID}
+    if version > 0 {
+        filter["version"] = version
     }
 
-    result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+    // Se lee el documento resultante para recalcular las claves de búsqueda
+    opts := options.FindOneAndUpdate().
+        SetReturnDocument(options.After).
+        SetProjection(bson.M{"name": 1, "displayName": 1, "version": 1})
+
+    var updated models.Clinic
+    err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
     if err != nil {
+        if errors.Is(err, mongo.ErrNoDocuments) {
+            return r.notMatchedError(ctx, bson.M{"_id": objID}, version, fmt.Errorf("clinic with ID '%s' not found", id))
+        }
         if mongo.IsDuplicateKeyError(err) {
-            return fmt.Errorf("clinic with that name already exists: %w", err)
+            return duplicateClinicError(err)
         }
         return fmt.Errorf("failed to update clinic: %w", err)
     }
 
-    if result.MatchedCount == 0 {
-        return fmt.Errorf("clinic with ID '%s' not found", id)
+    if touchesSearchKeys(updateFields) {
+        // Sin incrementar la versión; si otra escritura ya la cambió, esa
+        // escritura recalcula las claves con sus propios nombres
+        _, err := r.collection.UpdateOne(ctx,
+            bson.M{"_id": objID, "version": updated.Version},
+            bson.M{"$set": bson.M{"search": updated.SearchKeys()}},
+        )
+        if err != nil {
+            return fmt.Errorf("failed to update clinic search keys: %w", err)
+        }
     }
 
     return nil
 }
 
+// touchesSearchKeys indica si el PATCH cambia algún campo del que dependen las claves de búsqueda
+func touchesSearchKeys(updateFields map[string]interface{}) bool {
+    _, name := updateFields["name"]
+    _, displayName := updateFields["displayName"]
+    return name || displayName
+}
+
 // Delete - Soft delete simple (marca deletedAt)
-func (r *ClinicRepository) Delete(ctx context.Context, id string) error {
+// Si version > 0 solo elimina si la clínica sigue en esa versión.
+func (r *ClinicRepository) Delete(ctx context.Context, id string, version int64) error {
     objID, err := primitive.ObjectIDFromHex(id)
     if err != nil {
         return fmt.Errorf("invalid clinic ID '%s': %w", id, err)
@@ -127,18 +169,25 @@ func (r *ClinicRepository) Delete(ctx context.Context, id string) error {
             "deletedAt": now,
             "updatedAt": now,
         },
+        "$inc": bson.M{"version": 1},
     }
 
-    result, err := r.collection.UpdateOne(ctx, bson.M{
+    filter := bson.M{
         "_id":       objID,
         "deletedAt": bson.M{"$exists": false}, // Solo si no está eliminado
-    }, update)
+    }
+    if version > 0 {
+        filter["version"] = version
+    }
+
+    result, err := r.collection.UpdateOne(ctx, filter, update)
     if err != nil {
         return fmt.Errorf("failed to delete clinic: %w", err)
     }
 
     if result.MatchedCount == 0 {
-        return fmt.Errorf("clinic with ID '%s' not found or already deleted", id)
+        active := bson.M{"_id": objID, "deletedAt": bson.M{"$exists": false}}
+        return r.notMatchedError(ctx, active, version, fmt.Errorf("clinic with ID '%s' not found or already deleted", id))
     }
 
     return nil
@@ -146,32 +195,7 @@ func (r *ClinicRepository) Delete(ctx context.Context, id string) error {
 
 // List - Lista clínicas (EXCLUYE eliminadas)
 func (r *ClinicRepository) List(ctx context.Context, filters ListFilters) ([]*models.Clinic, int64, error) {
-    // Construir filtro MongoDB
-    filter := r.buildFilter(filters)
-
-    // Configurar opciones de consulta
-    findOptions := r.buildFindOptions(filters)
-
-    // Ejecutar consulta principal
-    cursor, err := r.collection.Find(ctx, filter, findOptions)
-    if err != nil {
-        return nil, 0, fmt.Errorf("failed to execute query: %w", err)
-    }
-    defer cursor.Close(ctx)
-
-    // Decodificar resultados
-    var clinics []*models.Clinic
-    if err := cursor.All(ctx, &clinics); err != nil {
-        return nil, 0, fmt.Errorf("failed to decode results: %w", err)
-    }
-
-    // Contar total para paginación
-    total, err := r.collection.CountDocuments(ctx, filter)
-    if err != nil {
-        return nil, 0, fmt.Errorf("failed to count documents: %w", err)
-    }
-
-    return clinics, total, nil
+    return r.list(ctx, filters, false)
 }
 
 // GetByName - Busca clínica por nombre (EXCLUYE eliminadas)
@@ -180,16 +204,15 @@ func (r *ClinicRepository) GetByName(ctx context.Context, name string) (*models.
         return nil, fmt.Errorf("name cannot be empty")
     }
 
+    // Igualdad literal sin distinguir mayúsculas: la collation del índice único
+    // hace la comparación (y permite usarlo); nada del valor se interpreta
     filter := bson.M{
-        "name": bson.M{
-            "$regex":   "^" + name + "$",
-            "$options": "i",
-        },
+        "name":      name,
         "deletedAt": bson.M{"$exists": false}, // Excluir eliminadas
     }
 
     var clinic models.Clinic
-    err := r.collection.FindOne(ctx, filter).Decode(&clinic)
+    err := r.collection.FindOne(ctx, filter, options.FindOne().SetCollation(database.CaseInsensitive)).Decode(&clinic)
     if err != nil {
         if errors.Is(err, mongo.ErrNoDocuments) {
             return nil, nil // No es error si no se encuentra
@@ -206,16 +229,14 @@ func (r *ClinicRepository) GetByDisplayName(ctx context.Context, displayName str
         return nil, fmt.Errorf("display name cannot be empty")
     }
 
+    // Igualdad literal sin distinguir mayúsculas (ver GetByName)
     filter := bson.M{
-        "displayName": bson.M{
-            "$regex":   "^" + displayName + "$",
-            "$options": "i",
-        },
-        "deletedAt": bson.M{"$exists": false}, // Excluir eliminadas
+        "displayName": displayName,
+        "deletedAt":   bson.M{"$exists": false}, // Excluir eliminadas
     }
 
     var clinic models.Clinic
-    err := r.collection.FindOne(ctx, filter).Decode(&clinic)
+    err := r.collection.FindOne(ctx, filter, options.FindOne().SetCollation(database.CaseInsensitive)).Decode(&clinic)
     if err != nil {
         if errors.Is(err, mongo.ErrNoDocuments) {
             return nil, nil // No es error si no se encuentra
@@ -244,65 +265,310 @@ func (r *ClinicRepository) Exists(ctx context.Context, id string) (bool, error)
     return count > 0, nil
 }
 
-// Método helper para construir filtros
-func (r *ClinicRepository) buildFilter(filters ListFilters) bson.M {
+// SearchCandidates - Preselección de la búsqueda tolerante a errores (EXCLUYE eliminadas)
+func (r *ClinicRepository) SearchCandidates(ctx context.Context, grams []string, limit int) ([]*models.Clinic, error) {
+    return findSearchCandidates[models.Clinic](ctx, r.collection, bson.M{"deletedAt": bson.M{"$exists": false}}, grams, limit)
+}
+
+// ListDeleted - Lista clínicas en la papelera (SOLO eliminadas)
+func (r *ClinicRepository) ListDeleted(ctx context.Context, filters ListFilters) ([]*models.Clinic, int64, error) {
+    return r.list(ctx, filters, true)
+}
+
+// GetDeletedByID - Obtiene una clínica de la papelera por ID (SOLO eliminadas)
+func (r *ClinicRepository) GetDeletedByID(ctx context.Context, id string) (*models.Clinic, error) {
+    objID, err := primitive.ObjectIDFromHex(id)
+    if err != nil {
+        return nil, fmt.Errorf("invalid clinic ID '%s': %w", id, err)
+    }
+
     filter := bson.M{
-        "deletedAt": bson.M{"$exists": false}, // SIEMPRE excluir eliminados
+        "_id":       objID,
+        "deletedAt": bson.M{"$exists": true},
     }
 
-    // Filtro de búsqueda por texto
-    if filters.Search != "" {
-        filter["$or"] = []bson.M{
-            {"name": bson.M{"$regex": filters.Search, "$options": "i"}},
-            {"displayName": bson.M{"$regex": filters.Search, "$options": "i"}},
-            {"description": bson.M{"$regex": filters.Search, "$options": "i"}},
-            {"address": bson.M{"$regex": filters.Search, "$options": "i"}},
+    var clinic models.Clinic
+    err = r.collection.FindOne(ctx, filter).Decode(&clinic)
+    if err != nil {
+        if errors.Is(err, mongo.ErrNoDocuments) {
+            return nil, fmt.Errorf("clinic with ID '%s' not found in trash", id)
         }
+        return nil, fmt.Errorf("failed to find deleted clinic: %w", err)
     }
 
-    // Filtro por estado activo
-    if filters.IsActive != nil {
-        filter["isActive"] = *filters.IsActive
+    return &clinic, nil
+}
+
+// ListDeletedBefore - Lista clínicas eliminadas antes de la fecha indicada (para la purga)
+func (r *ClinicRepository) ListDeletedBefore(ctx context.Context, before time.Time) ([]*models.Clinic, error) {
+    filter := bson.M{
+        "deletedAt": bson.M{"$lt": before},
     }
 
-    return filter
+    cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "deletedAt", Value: 1}}))
+    if err != nil {
+        return nil, fmt.Errorf("failed to execute query: %w", err)
+    }
+    defer cursor.Close(ctx)
+
+    var clinics []*models.Clinic
+    if err := cursor.All(ctx, &clinics); err != nil {
+        return nil, fmt.Errorf("failed to decode results: %w", err)
+    }
+
+    return clinics, nil
 }
 
-func (r *ClinicRepository) buildFindOptions(filters ListFilters) *options.FindOptions {
-    opts := options.Find()
+// Restore - Saca una clínica de la papelera (quita deletedAt)
+func (r *ClinicRepository) Restore(ctx context.Context, id string) error {
+    objID, err := primitive.ObjectIDFromHex(id)
+    if err != nil {
+        return fmt.Errorf("invalid clinic ID '%s': %w", id, err)
+    }
+
+    update := bson.M{
+        "$unset": bson.M{"deletedAt": ""},
+        "$set":   bson.M{"updatedAt": time.Now().UTC()},
+        "$inc":   bson.M{"version": 1},
+    }
 
-    // Ordenamiento
-    sortField := "createdAt"
-    if filters.SortBy != "" {
-        switch filters.SortBy {
-        case "created_at":
-            sortField = "createdAt"
-        case "updated_at":
-            sortField = "updatedAt"
-        case "name":
-            sortField = "name"
-        case "display_name":
-            sortField = "displayName"
-        default:
-            sortField = "createdAt"
+    result, err := r.collection.UpdateOne(ctx, bson.M{
+        "_id":       objID,
+        "deletedAt": bson.M{"$exists": true}, // Solo si está eliminada
+    }, update)
+    if err != nil {
+        if mongo.IsDuplicateKeyError(err) {
+            return duplicateClinicError(err)
         }
+        return fmt.Errorf("failed to restore clinic: %w", err)
     }
 
-    sortDirection := -1 // Descendente por defecto
-    if !filters.SortDesc {
-        sortDirection = 1
+    if result.MatchedCount == 0 {
+        return fmt.Errorf("clinic with ID '%s' not found in trash", id)
     }
 
-    opts.SetSort(bson.D{{Key: sortField, Value: sortDirection}})
+    return nil
+}
+
+// HardDelete - Borrado definitivo (SOLO clínicas que ya están en la papelera)
+func (r *ClinicRepository) HardDelete(ctx context.Context, id string) error {
+    objID, err := primitive.ObjectIDFromHex(id)
+    if err != nil {
+        return fmt.Errorf("invalid clinic ID '%s': %w", id, err)
+    }
+
+    result, err := r.collection.DeleteOne(ctx, bson.M{
+        "_id":       objID,
+        "deletedAt": bson.M{"$exists": true},
+    })
+    if err != nil {
+        return fmt.Errorf("failed to hard delete clinic: %w", err)
+    }
+
+    if result.DeletedCount == 0 {
+        return fmt.Errorf("clinic with ID '%s' not found in trash", id)
+    }
+
+    return nil
+}
+
+// notMatchedError distingue, cuando una escritura condicionada no encontró el
+// documento, entre un conflicto de versión (el documento sigue existiendo) y
+// un documento inexistente
+func (r *ClinicRepository) notMatchedError(ctx context.Context, filter bson.M, version int64, notFound error) error {
+    if version == 0 {
+        return notFound
+    }
+
+    count, err := r.collection.CountDocuments(ctx, filter)
+    if err != nil {
+        return fmt.Errorf("failed to check clinic version: %w", err)
+    }
+    if count > 0 {
+        return ErrVersionConflict
+    }
+
+    return notFound
+}
+
+// duplicateClinicError traduce un error de clave duplicada al error de storage
+// correspondiente según el índice único que lo provocó
+func duplicateClinicError(err error) error {
+    if strings.Contains(err.Error(), database.ClinicDisplayNameIndex) {
+        return fmt.Errorf("%w: %v", ErrDuplicateClinicDisplayName, err)
+    }
+    return fmt.Errorf("%w: %v", ErrDuplicateClinicName, err)
+}
+
+// list ejecuta un listado de activas o de la papelera (page/limit o keyset)
+func (r *ClinicRepository) list(ctx context.Context, filters ListFilters, deleted bool) ([]*models.Clinic, int64, error) {
+    // Construir filtro MongoDB
+    filter := r.buildFilter(filters, deleted)
+
+    // Ejecutar consulta principal (con el cursor aplicado)
+    cursor, err := r.query(ctx, filter, filters)
+    if err != nil {
+        return nil, 0, err
+    }
+    defer cursor.Close(ctx)
+
+    // Decodificar resultados
+    var clinics []*models.Clinic
+    if err := cursor.All(ctx, &clinics); err != nil {
+        return nil, 0, fmt.Errorf("failed to decode results: %w", err)
+    }
+
+    // Hacia atrás se consulta en orden inverso: devolver en orden de presentación
+    if filters.After != nil && filters.After.Backward {
+        slices.Reverse(clinics)
+    }
+
+    if filters.SkipCount {
+        return clinics, 0, nil
+    }
+
+    // Contar total para paginación (sin el cursor: es el total del listado)
+    total, err := r.collection.CountDocuments(ctx, filter)
+    if err != nil {
+        return nil, 0, fmt.Errorf("failed to count documents: %w", err)
+    }
+
+    return clinics, total, nil
+}
+
+// StreamList - Recorre las clínicas del listado (EXCLUYE eliminadas) una a una
+func (r *ClinicRepository) StreamList(ctx context.Context, filters ListFilters, each func(*models.Clinic) error) error {
+    return r.stream(ctx, filters, false, each)
+}
+
+// StreamDeleted - Recorre las clínicas de la papelera una a una
+func (r *ClinicRepository) StreamDeleted(ctx context.Context, filters ListFilters, each func(*models.Clinic) error) error {
+    return r.stream(ctx, filters, true, each)
+}
+
+// stream decodifica los documentos a medida que llegan del cursor de Mongo
+// (por lotes), en lugar de cargarlos todos con cursor.All
+func (r *ClinicRepository) stream(ctx context.Context, filters ListFilters, deleted bool, each func(*models.Clinic) error) error {
+    filters.Page, filters.Limit, filters.After = 0, 0, nil
+    cursor, err := r.query(ctx, r.buildFilter(filters, deleted), filters)
+    if err != nil {
+        return err
+    }
+    defer cursor.Close(ctx)
+
+    for cursor.Next(ctx) {
+        var clinic models.Clinic
+        if err := cursor.Decode(&clinic); err != nil {
+            return fmt.Errorf("failed to decode clinic: %w", err)
+        }
+        if err := each(&clinic); err != nil {
+            return err
+        }
+    }
+    if err := cursor.Err(); err != nil {
+        return fmt.Errorf("failed to iterate clinics: %w", err)
+    }
+    return nil
+}
+
+// query ejecuta la consulta de un listado (con el cursor aplicado)
+func (r *ClinicRepository) query(ctx context.Context, filter bson.M, filters ListFilters) (*mongo.Cursor, error) {
+    var cursor *mongo.Cursor
+    var err error
+    if textSearch(filters) != "" {
+        // La relevancia del índice de texto solo se puede ordenar y comparar
+        // (keyset) dentro de una agregación
+        cursor, err = r.collection.Aggregate(ctx, r.searchPipeline(filter, filters))
+    } else {
+        cursor, err = r.collection.Find(ctx, r.applyCursor(filter, filters), r.buildFindOptions(filters))
+    }
+    if err != nil {
+        return nil, fmt.Errorf("failed to execute query: %w", err)
+    }
+    return cursor, nil
+}
+
+// applyCursor añade la condición keyset del cursor al filtro
+func (r *ClinicRepository) applyCursor(filter bson.M, filters ListFilters) bson.M {
+    if filters.After == nil {
+        return filter
+    }
+
+    // $and para no pisar los filtros del listado
+    return bson.M{"$and": []bson.M{filter, pagination.KeysetBSON(clinicSort(filters.Sort), filters.After)}}
+}
+
+// Método helper para construir filtros (deleted indica si se listan las de la papelera)
+func (r *ClinicRepository) buildFilter(filters ListFilters, deleted bool) bson.M {
+    filter := bson.M{
+        "deletedAt": bson.M{"$exists": deleted}, // Activas o papelera, nunca mezcladas
+    }
+
+    // Búsqueda de texto completo sobre el índice de texto (nunca $regex con la
+    // entrada del cliente): los términos ya están normalizados
+    if terms := textSearch(filters); terms != "" {
+        filter["$text"] = bson.M{"$search": terms}
+    }
+
+    // Filtros del lenguaje de consulta (columnas y valores ya validados)
+    if len(filters.Filters) > 0 {
+        return bson.M{"$and": []bson.M{filter, dto.FilterBSON(filters.Filters)}}
+    }
+
+    return filter
+}
+
+func (r *ClinicRepository) buildFindOptions(filters ListFilters) *options.FindOptions {
+    opts := options.Find()
+
+    // Ordenamiento (con _id como desempate para que el keyset sea estable)
+    opts.SetSort(pagination.SortBSON(clinicSort(filters.Sort), filters.After))
 
     // Paginación
     if filters.Limit > 0 {
         opts.SetLimit(int64(filters.Limit))
-        if filters.Page > 1 {
+        if filters.After == nil && filters.Page > 1 {
             skip := int64((filters.Page - 1) * filters.Limit)
             opts.SetSkip(skip)
         }
     }
 
     return opts
-}
\ No newline at end of file
+}
+
+// searchPipeline es la consulta de un listado con búsqueda: añade la relevancia
+// (campo score) para poder ordenar y paginar por ella como por cualquier campo
+func (r *ClinicRepository) searchPipeline(filter bson.M, filters ListFilters) mongo.Pipeline {
+    sort := clinicSort(filters.Sort)
+    pipeline := mongo.Pipeline{
+        {{Key: "$match", Value: filter}},
+        {{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}},
+    }
+    if filters.After != nil {
+        pipeline = append(pipeline, bson.D{{Key: "$match", Value: pagination.KeysetBSON(sort, filters.After)}})
+    }
+    pipeline = append(pipeline, bson.D{{Key: "$sort", Value: pagination.SortBSON(sort, filters.After)}})
+
+    if filters.Limit > 0 {
+        if filters.After == nil && filters.Page > 1 {
+            pipeline = append(pipeline, bson.D{{Key: "$skip", Value: int64((filters.Page - 1) * filters.Limit)}})
+        }
+        pipeline = append(pipeline, bson.D{{Key: "$limit", Value: int64(filters.Limit)}})
+    }
+
+    return pipeline
+}
+
+// textSearch devuelve los términos de búsqueda normalizados (sin operadores
+// de $text como comillas o "-"), o "" si no hay búsqueda
+func textSearch(filters ListFilters) string {
+    return search.Normalize(filters.Search)
+}
+
+// clinicSort aplica el orden por defecto (fecha de creación ascendente)
+func clinicSort(sort []dto.SortField) []dto.SortField {
+    if len(sort) == 0 {
+        return []dto.SortField{{Field: "createdAt", Column: "createdAt"}}
+    }
+    return sort
+}
@@ -3,24 +3,54 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/pagination"
 	"github.com/zabaletac3/go-vet-api/internal/transport/http/dto"
 )
 
+// Errores de unicidad (los devuelven Create, Update y Restore cuando un índice único rechaza la escritura)
+var (
+    ErrDuplicateClinicName        = errors.New("clinic with that name already exists")
+    ErrDuplicateClinicDisplayName = errors.New("clinic with that display name already exists")
+)
+
+// ErrVersionConflict lo devuelven Update y Delete cuando la clínica existe pero
+// su versión ya no es la esperada (otra escritura se adelantó)
+var ErrVersionConflict = errors.New("clinic version conflict")
+
 // ClinicStorer - Interface para operaciones de clínica
 type ClinicStorer interface {
     // Operaciones CRUD básicas
     Create(ctx context.Context, clinic *models.Clinic) error
     GetByID(ctx context.Context, id string) (*models.Clinic, error)
-    Update(ctx context.Context, id string, updateFields map[string]interface{}) error
-    Delete(ctx context.Context, id string) error // Soft delete simple
+    // version es la versión esperada; 0 omite la comprobación
+    Update(ctx context.Context, id string, version int64, updateFields map[string]interface{}) error
+    Delete(ctx context.Context, id string, version int64) error // Soft delete simple
     
     // Operaciones de consulta
     List(ctx context.Context, filters ListFilters) ([]*models.Clinic, int64, error)
     GetByName(ctx context.Context, name string) (*models.Clinic, error)
     GetByDisplayName(ctx context.Context, displayName string) (*models.Clinic, error)
     Exists(ctx context.Context, id string) (bool, error)
+    // Búsqueda tolerante a errores: clínicas no eliminadas que comparten
+    // trigramas con la consulta (las puntúa el servicio con el paquete search)
+    SearchCandidates(ctx context.Context, grams []string, limit int) ([]*models.Clinic, error)
+
+    // Operaciones de papelera (SOLO eliminadas)
+    ListDeleted(ctx context.Context, filters ListFilters) ([]*models.Clinic, int64, error)
+    GetDeletedByID(ctx context.Context, id string) (*models.Clinic, error)
+    ListDeletedBefore(ctx context.Context, before time.Time) ([]*models.Clinic, error)
+    Restore(ctx context.Context, id string) error
+    HardDelete(ctx context.Context, id string) error // Borrado definitivo
+
+    // Exportación: recorren el listado completo con los filtros, la búsqueda y
+    // el orden de List/ListDeleted (se ignoran Page, Limit y After) sin cargarlo
+    // en memoria. Si each devuelve un error el recorrido se detiene con ese error.
+    StreamList(ctx context.Context, filters ListFilters, each func(*models.Clinic) error) error
+    StreamDeleted(ctx context.Context, filters ListFilters, each func(*models.Clinic) error) error
 }
 
 // ListFilters - Filtros para listados
@@ -28,9 +58,14 @@ type ListFilters struct {
     Page     int
     Limit    int
     Search   string
-    IsActive *bool
-    SortBy   string
-    SortDesc bool
+    Filters  []dto.Filter    // Condiciones ya validadas contra la allowlist del recurso
+    Sort     []dto.SortField // Vacío: por fecha de creación
+
+    // After activa la paginación por keyset: se ignora Page y se devuelven los
+    // elementos posteriores (o anteriores, si After.Backward) al cursor, siempre
+    // en el orden de presentación
+    After     *pagination.Cursor
+    SkipCount bool // No contar el total (se devuelve 0)
 }
 
 // CalculatePagination - Calcula metadatos de paginación (usa DTO reutilizable)
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// IdempotencyRepository implementa IdempotencyStorer sobre MongoDB. Los
+// registros caducan con un índice TTL sobre expiresAt.
+type IdempotencyRepository struct {
+	collection *mongo.Collection
+}
+
+// NewIdempotencyRepository crea el repositorio de claves de idempotencia.
+func NewIdempotencyRepository(db *mongo.Database) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		collection: db.Collection("idempotency_keys"),
+	}
+}
+
+// Reserve inserta el registro; la clave única (_id) resuelve la carrera entre
+// dos peticiones simultáneas con la misma clave.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record *models.IdempotencyRecord) (*models.IdempotencyRecord, error) {
+	existing, err := r.reserve(ctx, record)
+	if errors.Is(err, errIdempotencyGone) {
+		// Caducó o se liberó entre la inserción y la lectura: un reintento basta
+		existing, err = r.reserve(ctx, record)
+	}
+	return existing, err
+}
+
+// errIdempotencyGone indica que el registro desapareció durante la reserva
+var errIdempotencyGone = errors.New("idempotency key disappeared while reserving")
+
+func (r *IdempotencyRepository) reserve(ctx context.Context, record *models.IdempotencyRecord) (*models.IdempotencyRecord, error) {
+	_, err := r.collection.InsertOne(ctx, record)
+	if err == nil {
+		return nil, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, fmt.Errorf("error reserving idempotency key: %w", err)
+	}
+
+	// Tomar una reserva abandonada (la petición original no terminó a tiempo)
+	takeover := r.collection.FindOneAndUpdate(ctx,
+		bson.M{
+			"_id":         record.ID,
+			"status":      models.IdempotencyProcessing,
+			"lockedUntil": bson.M{"$lt": time.Now().UTC()},
+		},
+		bson.M{"$set": bson.M{
+			"fingerprint": record.Fingerprint,
+			"owner":       record.Owner,
+			"lockedUntil": record.LockedUntil,
+			"createdAt":   record.CreatedAt,
+			"expiresAt":   record.ExpiresAt,
+		}},
+	)
+	if err := takeover.Err(); err == nil {
+		return nil, nil
+	} else if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("error taking over idempotency key: %w", err)
+	}
+
+	var existing models.IdempotencyRecord
+	if err := r.collection.FindOne(ctx, bson.M{"_id": record.ID}).Decode(&existing); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errIdempotencyGone
+		}
+		return nil, fmt.Errorf("error reading idempotency key: %w", err)
+	}
+	return &existing, nil
+}
+
+// Complete guarda la respuesta y marca el registro como completado.
+func (r *IdempotencyRepository) Complete(ctx context.Context, id, owner string, response models.CachedResponse) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "owner": owner, "status": models.IdempotencyProcessing},
+		bson.M{"$set": bson.M{"status": models.IdempotencyCompleted, "response": response}},
+	)
+	if err != nil {
+		return fmt.Errorf("error completing idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Release borra una reserva en curso.
+func (r *IdempotencyRepository) Release(ctx context.Context, id, owner string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "owner": owner, "status": models.IdempotencyProcessing})
+	if err != nil {
+		return fmt.Errorf("error releasing idempotency key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// IdempotencyStorer guarda las claves de Idempotency-Key y sus respuestas.
+type IdempotencyStorer interface {
+	// Reserve registra una petición en curso con la clave id. Si la clave ya
+	// existe devuelve el registro existente y no reserva nada, salvo que sea
+	// una petición en curso abandonada (LockedUntil vencido): en ese caso la
+	// reserva pasa a esta petición y devuelve nil.
+	Reserve(ctx context.Context, record *models.IdempotencyRecord) (*models.IdempotencyRecord, error)
+	// Complete guarda la respuesta de la petición reservada con id. Solo
+	// afecta a la reserva de owner: si otra petición la tomó, no hace nada.
+	Complete(ctx context.Context, id, owner string, response models.CachedResponse) error
+	// Release borra la reserva de id de owner para que la petición pueda
+	// reintentarse. Si otra petición la tomó, no hace nada.
+	Release(ctx context.Context, id, owner string) error
+}
+
+// IdempotencyID es la clave de almacenamiento de un Idempotency-Key: se acota
+// al método y la ruta para que la misma clave no choque entre endpoints.
+func IdempotencyID(method, path, key string) string {
+	return method + " " + path + " " + key
+}
+
+// IdempotencyLock es cuánto puede tardar la petición original antes de que
+// otra con la misma clave pueda tomar su lugar (p. ej. si el proceso murió).
+const IdempotencyLock = time.Minute
@@ -0,0 +1,275 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// importRowsBatch es cuántas filas se insertan por llamada al crear un trabajo.
+const importRowsBatch = 1000
+
+// ImportRepository implementa ImportStorer sobre MongoDB, con los trabajos en
+// import_jobs y sus filas en import_rows.
+type ImportRepository struct {
+	jobs *mongo.Collection
+	rows *mongo.Collection
+}
+
+// NewImportRepository crea el repositorio de importaciones.
+func NewImportRepository(db *mongo.Database) *ImportRepository {
+	return &ImportRepository{
+		jobs: db.Collection("import_jobs"),
+		rows: db.Collection("import_rows"),
+	}
+}
+
+// CreateJob inserta el trabajo y sus filas por lotes. Si falla alguna
+// inserción se borra lo ya insertado.
+func (r *ImportRepository) CreateJob(ctx context.Context, job *models.ImportJob, rows []*models.ImportRow) error {
+	job.ID = primitive.NewObjectID()
+	now := time.Now().UTC()
+	job.CreatedAt = now
+	job.UpdatedAt = now
+
+	if _, err := r.jobs.InsertOne(ctx, job); err != nil {
+		return fmt.Errorf("error creating import job: %w", err)
+	}
+
+	for start := 0; start < len(rows); start += importRowsBatch {
+		batch := rows[start:min(start+importRowsBatch, len(rows))]
+		docs := make([]interface{}, len(batch))
+		for i, row := range batch {
+			row.ID = primitive.NewObjectID()
+			row.JobID = job.ID
+			docs[i] = row
+		}
+		if _, err := r.rows.InsertMany(ctx, docs); err != nil {
+			err = fmt.Errorf("error creating import rows: %w", err)
+			if _, cleanupErr := r.rows.DeleteMany(ctx, bson.M{"jobId": job.ID}); cleanupErr != nil {
+				return errors.Join(err, cleanupErr)
+			}
+			if _, cleanupErr := r.jobs.DeleteOne(ctx, bson.M{"_id": job.ID}); cleanupErr != nil {
+				return errors.Join(err, cleanupErr)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// FindJob busca un trabajo DENTRO de una clínica.
+func (r *ImportRepository) FindJob(ctx context.Context, clinicID, jobID string) (*models.ImportJob, error) {
+	filter, err := jobFilter(clinicID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	var job models.ImportJob
+	if err := r.jobs.FindOne(ctx, filter).Decode(&job); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error finding import job: %w", err)
+	}
+	return &job, nil
+}
+
+// QueueJob pone en cola el trabajo si su estado es uno de from.
+func (r *ImportRepository) QueueJob(ctx context.Context, clinicID, jobID string, from []string, mapping map[string]string, locale string) (*models.ImportJob, error) {
+	filter, err := jobFilter(clinicID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	filter["status"] = bson.M{"$in": from}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":    models.ImportQueued,
+			"mapping":   mapping,
+			"locale":    locale,
+			"updatedAt": time.Now().UTC(),
+		},
+		"$unset": bson.M{"error": "", "finishedAt": ""},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var job models.ImportJob
+	if err := r.jobs.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error queueing import job: %w", err)
+	}
+	return &job, nil
+}
+
+// ClaimJob asigna un trabajo pendiente con una actualización atómica, así dos
+// réplicas nunca procesan el mismo trabajo a la vez.
+func (r *ImportRepository) ClaimJob(ctx context.Context, staleBefore time.Time) (*models.ImportJob, error) {
+	now := time.Now().UTC()
+	filter := bson.M{"$or": []bson.M{
+		{"status": models.ImportQueued},
+		{"status": models.ImportRunning, "heartbeatAt": bson.M{"$lt": staleBefore}},
+	}}
+	update := bson.M{
+		"$set": bson.M{"status": models.ImportRunning, "heartbeatAt": now, "updatedAt": now},
+		"$min": bson.M{"startedAt": now}, // Solo la primera vez
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "createdAt", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var job models.ImportJob
+	if err := r.jobs.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error claiming import job: %w", err)
+	}
+	return &job, nil
+}
+
+// SaveProgress guarda el progreso y renueva el latido del ejecutor.
+func (r *ImportRepository) SaveProgress(ctx context.Context, jobID string, stats models.ImportStats) error {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return fmt.Errorf("invalid import job ID: %w", err)
+	}
+	now := time.Now().UTC()
+	_, err = r.jobs.UpdateOne(ctx,
+		bson.M{"_id": objID, "status": models.ImportRunning},
+		bson.M{"$set": bson.M{"stats": stats, "heartbeatAt": now, "updatedAt": now}},
+	)
+	if err != nil {
+		return fmt.Errorf("error saving import progress: %w", err)
+	}
+	return nil
+}
+
+// FinishJob cierra el trabajo.
+func (r *ImportRepository) FinishJob(ctx context.Context, jobID string, status string, stats models.ImportStats, errMsg string) error {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return fmt.Errorf("invalid import job ID: %w", err)
+	}
+	now := time.Now().UTC()
+	set := bson.M{"status": status, "stats": stats, "updatedAt": now, "finishedAt": now}
+	if errMsg != "" {
+		set["error"] = errMsg
+	}
+	if _, err := r.jobs.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": set, "$unset": bson.M{"heartbeatAt": ""}}); err != nil {
+		return fmt.Errorf("error finishing import job: %w", err)
+	}
+	return nil
+}
+
+// ListRows devuelve las filas del trabajo con índice mayor que after, en orden.
+func (r *ImportRepository) ListRows(ctx context.Context, jobID string, status string, after, limit int) ([]*models.ImportRow, error) {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid import job ID: %w", err)
+	}
+	filter := bson.M{"jobId": objID, "index": bson.M{"$gt": after}}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "index", Value: 1}}).SetLimit(int64(limit))
+
+	cursor, err := r.rows.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing import rows: %w", err)
+	}
+	var rows []*models.ImportRow
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("error decoding import rows: %w", err)
+	}
+	return rows, nil
+}
+
+// SaveRow reemplaza la fila con su resultado.
+func (r *ImportRepository) SaveRow(ctx context.Context, row *models.ImportRow) error {
+	if _, err := r.rows.ReplaceOne(ctx, bson.M{"_id": row.ID}, row); err != nil {
+		return fmt.Errorf("error saving import row: %w", err)
+	}
+	return nil
+}
+
+// RowStats agrega las filas del trabajo por resultado.
+func (r *ImportRepository) RowStats(ctx context.Context, jobID string) (models.ImportStats, error) {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return models.ImportStats{}, fmt.Errorf("invalid import job ID: %w", err)
+	}
+	count := func(cond bson.M) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{cond, 1, 0}}}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"jobId": objID}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           nil,
+			"total":         bson.M{"$sum": 1},
+			"imported":      count(bson.M{"$eq": bson.A{"$status", models.ImportRowImported}}),
+			"invalid":       count(bson.M{"$eq": bson.A{"$status", models.ImportRowInvalid}}),
+			"ownersCreated": count(bson.M{"$eq": bson.A{"$ownerCreated", true}}),
+		}}},
+	}
+
+	cursor, err := r.rows.Aggregate(ctx, pipeline)
+	if err != nil {
+		return models.ImportStats{}, fmt.Errorf("error counting import rows: %w", err)
+	}
+	var results []models.ImportStats
+	if err := cursor.All(ctx, &results); err != nil {
+		return models.ImportStats{}, fmt.Errorf("error decoding import stats: %w", err)
+	}
+	if len(results) == 0 {
+		return models.ImportStats{}, nil
+	}
+	return results[0].WithTotals(), nil
+}
+
+// DeleteByClinic borra los trabajos de la clínica y sus filas. Primero las
+// filas: si algo falla, los trabajos siguen ahí para reintentarlo.
+func (r *ImportRepository) DeleteByClinic(ctx context.Context, clinicID string) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clinic ID: %w", err)
+	}
+
+	jobIDs, err := r.jobs.Distinct(ctx, "_id", bson.M{"clinicId": clinicObjID})
+	if err != nil {
+		return 0, fmt.Errorf("error listing clinic import jobs: %w", err)
+	}
+	if len(jobIDs) == 0 {
+		return 0, nil
+	}
+	if _, err := r.rows.DeleteMany(ctx, bson.M{"jobId": bson.M{"$in": jobIDs}}); err != nil {
+		return 0, fmt.Errorf("error deleting clinic import rows: %w", err)
+	}
+	result, err := r.jobs.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": jobIDs}})
+	if err != nil {
+		return 0, fmt.Errorf("error deleting clinic import jobs: %w", err)
+	}
+	return result.DeletedCount, nil
+}
+
+// jobFilter filtra un trabajo por su ID dentro de una clínica.
+func jobFilter(clinicID, jobID string) (bson.M, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic ID: %w", err)
+	}
+	jobObjID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid import job ID: %w", err)
+	}
+	return bson.M{"_id": jobObjID, "clinicId": clinicObjID}, nil
+}
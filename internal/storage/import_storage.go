@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// ImportStorer guarda los trabajos de importación y sus filas.
+type ImportStorer interface {
+	// CreateJob guarda el trabajo y sus filas, y les asigna los IDs.
+	CreateJob(ctx context.Context, job *models.ImportJob, rows []*models.ImportRow) error
+	// FindJob busca un trabajo DENTRO de una clínica. nil si no existe.
+	FindJob(ctx context.Context, clinicID, jobID string) (*models.ImportJob, error)
+	// QueueJob pone en cola el trabajo con el mapeo y el idioma indicados si su
+	// estado es uno de from. Devuelve nil si no existe o está en otro estado.
+	QueueJob(ctx context.Context, clinicID, jobID string, from []string, mapping map[string]string, locale string) (*models.ImportJob, error)
+	// ClaimJob asigna al ejecutor el trabajo en cola más antiguo, o uno en curso
+	// cuyo ejecutor no da señales desde antes de staleBefore (se reanuda donde
+	// se quedó). nil si no hay ninguno.
+	ClaimJob(ctx context.Context, staleBefore time.Time) (*models.ImportJob, error)
+	// SaveProgress guarda el progreso y renueva el latido del ejecutor.
+	SaveProgress(ctx context.Context, jobID string, stats models.ImportStats) error
+	// FinishJob cierra el trabajo como completed o failed (errMsg explica el fallo).
+	FinishJob(ctx context.Context, jobID string, status string, stats models.ImportStats, errMsg string) error
+
+	// ListRows devuelve, por orden, hasta limit filas del trabajo con índice
+	// mayor que after. Con status vacío devuelve filas de cualquier estado.
+	ListRows(ctx context.Context, jobID string, status string, after, limit int) ([]*models.ImportRow, error)
+	// SaveRow guarda el resultado de procesar una fila.
+	SaveRow(ctx context.Context, row *models.ImportRow) error
+	// RowStats calcula el progreso del trabajo a partir de sus filas.
+	RowStats(ctx context.Context, jobID string) (models.ImportStats, error)
+	// DeleteByClinic borra todos los trabajos de una clínica con sus filas y
+	// devuelve cuántos trabajos borró.
+	DeleteByClinic(ctx context.Context, clinicID string) (int64, error)
+}
+
+// ImportHeartbeatTimeout es cuánto puede pasar sin latido antes de que otro
+// ejecutor retome un trabajo en curso (p. ej. si el proceso se reinició).
+const ImportHeartbeatTimeout = 2 * time.Minute
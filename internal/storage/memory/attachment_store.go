@@ -0,0 +1,171 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AttachmentStore implementa storage.AttachmentStorer en memoria.
+type AttachmentStore struct {
+	mu          sync.Mutex
+	attachments []*models.Attachment // En orden de creación
+}
+
+// NewAttachmentStore crea un AttachmentStore vacío.
+func NewAttachmentStore() *AttachmentStore {
+	return &AttachmentStore{}
+}
+
+var _ storage.AttachmentStorer = (*AttachmentStore)(nil)
+
+// Create guarda el adjunto. Si attachment.ID viene vacío se genera uno.
+func (s *AttachmentStore) Create(ctx context.Context, attachment *models.Attachment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if attachment.ID.IsZero() {
+		attachment.ID = primitive.NewObjectID()
+	}
+	attachment.CreatedAt = now()
+
+	stored, err := cloneDoc(attachment)
+	if err != nil {
+		return fmt.Errorf("error creating attachment: %w", err)
+	}
+	s.attachments = append(s.attachments, stored)
+	return nil
+}
+
+// FindByID busca un adjunto de la clínica.
+func (s *AttachmentStore) FindByID(ctx context.Context, clinicID, attachmentID string) (*models.Attachment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, err := s.indexOf(clinicID, attachmentID)
+	if err != nil || i < 0 {
+		return nil, err
+	}
+	return cloneDoc(s.attachments[i])
+}
+
+// FindByHash busca el adjunto más antiguo de la clínica con ese contenido.
+func (s *AttachmentStore) FindByHash(ctx context.Context, clinicID, hash string) (*models.Attachment, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic ID '%s': %w", clinicID, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, attachment := range s.attachments {
+		if attachment.ClinicID == clinicObjID && attachment.Hash == hash {
+			return cloneDoc(attachment)
+		}
+	}
+	return nil, nil
+}
+
+// List devuelve los adjuntos del filtro, del más reciente al más antiguo.
+func (s *AttachmentStore) List(ctx context.Context, clinicID string, filter storage.AttachmentFilter) ([]*models.Attachment, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic ID '%s': %w", clinicID, err)
+	}
+	var patientObjID primitive.ObjectID
+	if filter.PatientID != "" {
+		if patientObjID, err = primitive.ObjectIDFromHex(filter.PatientID); err != nil {
+			return nil, fmt.Errorf("invalid patient ID '%s': %w", filter.PatientID, err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attachments := []*models.Attachment{}
+	for _, attachment := range slices.Backward(s.attachments) {
+		if attachment.ClinicID != clinicObjID ||
+			(filter.PatientID != "" && (attachment.PatientID == nil || *attachment.PatientID != patientObjID)) ||
+			(filter.Category != "" && attachment.Category != filter.Category) {
+			continue
+		}
+		clone, err := cloneDoc(attachment)
+		if err != nil {
+			return nil, fmt.Errorf("error listing attachments: %w", err)
+		}
+		attachments = append(attachments, clone)
+		if filter.Limit > 0 && len(attachments) == filter.Limit {
+			break
+		}
+	}
+	return attachments, nil
+}
+
+// CountByHash cuenta los adjuntos de la clínica con ese contenido.
+func (s *AttachmentStore) CountByHash(ctx context.Context, clinicID, hash string) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clinic ID '%s': %w", clinicID, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var count int64
+	for _, attachment := range s.attachments {
+		if attachment.ClinicID == clinicObjID && attachment.Hash == hash {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Delete borra el adjunto y lo devuelve.
+func (s *AttachmentStore) Delete(ctx context.Context, clinicID, attachmentID string) (*models.Attachment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, err := s.indexOf(clinicID, attachmentID)
+	if err != nil || i < 0 {
+		return nil, err
+	}
+	deleted := s.attachments[i]
+	s.attachments = slices.Delete(s.attachments, i, i+1)
+	return deleted, nil
+}
+
+// DeleteByClinic borra todos los adjuntos de la clínica.
+func (s *AttachmentStore) DeleteByClinic(ctx context.Context, clinicID string) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clinic ID '%s': %w", clinicID, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before := len(s.attachments)
+	s.attachments = slices.DeleteFunc(s.attachments, func(a *models.Attachment) bool { return a.ClinicID == clinicObjID })
+	return int64(before - len(s.attachments)), nil
+}
+
+// indexOf es la posición del adjunto de la clínica, o -1 si no existe
+func (s *AttachmentStore) indexOf(clinicID, attachmentID string) (int, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return -1, fmt.Errorf("invalid clinic ID '%s': %w", clinicID, err)
+	}
+	objID, err := primitive.ObjectIDFromHex(attachmentID)
+	if err != nil {
+		return -1, fmt.Errorf("invalid attachment ID '%s': %w", attachmentID, err)
+	}
+	return slices.IndexFunc(s.attachments, func(a *models.Attachment) bool {
+		return a.ID == objID && a.ClinicID == clinicObjID
+	}), nil
+}
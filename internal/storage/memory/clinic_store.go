@@ -0,0 +1,532 @@
+// Package memory implementa los stores de storage en memoria, con la misma
+// semántica que los repositorios de MongoDB. Se usa en pruebas y en el modo
+// demo (STORAGE_DRIVER=memory); los datos se pierden al reiniciar.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/database"
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/search"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/dto"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ClinicStore implementa storage.ClinicStorer en memoria.
+type ClinicStore struct {
+	mu      sync.RWMutex
+	clinics []*models.Clinic // En orden de inserción, como el orden natural de Mongo
+}
+
+// NewClinicStore crea un ClinicStore vacío.
+func NewClinicStore() *ClinicStore {
+	return &ClinicStore{}
+}
+
+var _ storage.ClinicStorer = (*ClinicStore)(nil)
+
+// Create crea una nueva clínica con validación. Si clinic.ID viene vacío se genera uno.
+func (s *ClinicStore) Create(ctx context.Context, clinic *models.Clinic) error {
+	if err := clinic.IsValid(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Igual que los índices únicos: incluye las clínicas de la papelera
+	if err := s.checkUnique(clinic.Name, clinic.DisplayName, primitive.NilObjectID); err != nil {
+		return err
+	}
+
+	if clinic.ID.IsZero() {
+		clinic.ID = primitive.NewObjectID()
+	}
+	ts := now()
+	clinic.CreatedAt = ts
+	clinic.UpdatedAt = ts
+	clinic.IsActive = true
+	clinic.Version = 1
+	clinic.DeletedAt = nil
+	clinic.Search = clinic.SearchKeys()
+
+	if clinic.Palette.Primary == "" {
+		clinic.Palette = models.GetDefaultPalette()
+	}
+
+	stored, err := cloneClinic(clinic)
+	if err != nil {
+		return fmt.Errorf("failed to create clinic: %w", err)
+	}
+	s.clinics = append(s.clinics, stored)
+	onRollback(ctx, func() { s.remove(stored.ID) })
+	return nil
+}
+
+// remove borra una clínica insertada en una transacción que se revierte
+func (s *ClinicStore) remove(id primitive.ObjectID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clinics = slices.DeleteFunc(s.clinics, func(c *models.Clinic) bool { return c.ID == id })
+}
+
+// GetByID obtiene una clínica por ID (excluye eliminadas).
+func (s *ClinicStore) GetByID(ctx context.Context, id string) (*models.Clinic, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic ID '%s': %w", id, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	clinic := s.find(objID)
+	if clinic == nil || clinic.DeletedAt != nil {
+		return nil, fmt.Errorf("clinic with ID '%s' not found or deleted", id)
+	}
+	return cloneClinic(clinic)
+}
+
+// Update aplica un PATCH con los campos indicados (nombres de campo bson).
+func (s *ClinicStore) Update(ctx context.Context, id string, version int64, updateFields map[string]interface{}) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid clinic ID '%s': %w", id, err)
+	}
+	if len(updateFields) == 0 {
+		return fmt.Errorf("no fields provided for update")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clinic := s.find(objID)
+	if clinic == nil {
+		return fmt.Errorf("clinic with ID '%s' not found", id)
+	}
+	if version > 0 && clinic.Version != version {
+		return storage.ErrVersionConflict
+	}
+
+	updateFields["updatedAt"] = now()
+	updated, err := applySet(clinic, updateFields)
+	if err != nil {
+		return fmt.Errorf("failed to update clinic: %w", err)
+	}
+
+	if err := s.checkUnique(updated.Name, updated.DisplayName, objID); err != nil {
+		return err
+	}
+
+	updated.Search = updated.SearchKeys()
+	updated.Version++
+	*clinic = *updated
+	return nil
+}
+
+// Delete marca la clínica como eliminada (soft delete); se deshace si la
+// transacción falla.
+func (s *ClinicStore) Delete(ctx context.Context, id string, version int64) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid clinic ID '%s': %w", id, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clinic := s.find(objID)
+	if clinic == nil || clinic.DeletedAt != nil {
+		return fmt.Errorf("clinic with ID '%s' not found or already deleted", id)
+	}
+	if version > 0 && clinic.Version != version {
+		return storage.ErrVersionConflict
+	}
+
+	prevUpdatedAt := clinic.UpdatedAt
+	ts := now()
+	clinic.DeletedAt = &ts
+	clinic.UpdatedAt = ts
+	clinic.Version++
+	onRollback(ctx, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		clinic.DeletedAt = nil
+		clinic.UpdatedAt = prevUpdatedAt
+		clinic.Version--
+	})
+	return nil
+}
+
+// List lista clínicas no eliminadas con filtros, orden y paginación.
+func (s *ClinicStore) List(ctx context.Context, filters storage.ListFilters) ([]*models.Clinic, int64, error) {
+	return s.list(filters, false)
+}
+
+// SearchCandidates preselecciona las clínicas no eliminadas que comparten
+// trigramas con la consulta, igual que ClinicRepository.SearchCandidates.
+func (s *ClinicStore) SearchCandidates(ctx context.Context, grams []string, limit int) ([]*models.Clinic, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var active []*models.Clinic
+	for _, clinic := range s.clinics {
+		if clinic.DeletedAt == nil {
+			active = append(active, clinic)
+		}
+	}
+	return searchCandidates(active, func(c *models.Clinic) ([]string, primitive.ObjectID) { return c.Search.Grams, c.ID }, cloneClinic, grams, limit)
+}
+
+// GetByName busca una clínica por nombre sin distinguir mayúsculas (excluye eliminadas).
+func (s *ClinicStore) GetByName(ctx context.Context, name string) (*models.Clinic, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	return s.findActive(func(c *models.Clinic) bool { return strings.EqualFold(c.Name, name) })
+}
+
+// GetByDisplayName busca una clínica por display name sin distinguir mayúsculas (excluye eliminadas).
+func (s *ClinicStore) GetByDisplayName(ctx context.Context, displayName string) (*models.Clinic, error) {
+	if displayName == "" {
+		return nil, fmt.Errorf("display name cannot be empty")
+	}
+	return s.findActive(func(c *models.Clinic) bool { return strings.EqualFold(c.DisplayName, displayName) })
+}
+
+// Exists verifica si la clínica existe (excluye eliminadas).
+func (s *ClinicStore) Exists(ctx context.Context, id string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid clinic ID '%s': %w", id, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	clinic := s.find(objID)
+	return clinic != nil && clinic.DeletedAt == nil, nil
+}
+
+// ListDeleted lista las clínicas de la papelera.
+func (s *ClinicStore) ListDeleted(ctx context.Context, filters storage.ListFilters) ([]*models.Clinic, int64, error) {
+	return s.list(filters, true)
+}
+
+// StreamList recorre las clínicas del listado completo (excluye eliminadas).
+func (s *ClinicStore) StreamList(ctx context.Context, filters storage.ListFilters, each func(*models.Clinic) error) error {
+	return s.stream(filters, false, each)
+}
+
+// StreamDeleted recorre las clínicas de la papelera.
+func (s *ClinicStore) StreamDeleted(ctx context.Context, filters storage.ListFilters, each func(*models.Clinic) error) error {
+	return s.stream(filters, true, each)
+}
+
+// stream recorre el listado sin paginar. En memoria no hay cursor: el listado
+// ya está cargado, así que basta con filtrarlo y ordenarlo como list.
+func (s *ClinicStore) stream(filters storage.ListFilters, deleted bool, each func(*models.Clinic) error) error {
+	filters.Page, filters.Limit, filters.After, filters.SkipCount = 0, 0, nil, true
+	clinics, _, err := s.list(filters, deleted)
+	if err != nil {
+		return err
+	}
+	for _, clinic := range clinics {
+		if err := each(clinic); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetDeletedByID obtiene una clínica de la papelera por ID.
+func (s *ClinicStore) GetDeletedByID(ctx context.Context, id string) (*models.Clinic, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic ID '%s': %w", id, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	clinic := s.find(objID)
+	if clinic == nil || clinic.DeletedAt == nil {
+		return nil, fmt.Errorf("clinic with ID '%s' not found in trash", id)
+	}
+	return cloneClinic(clinic)
+}
+
+// ListDeletedBefore lista las clínicas eliminadas antes de la fecha indicada.
+func (s *ClinicStore) ListDeletedBefore(ctx context.Context, before time.Time) ([]*models.Clinic, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*models.Clinic
+	for _, clinic := range s.clinics {
+		if clinic.DeletedAt != nil && clinic.DeletedAt.Before(before) {
+			c, err := cloneClinic(clinic)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode results: %w", err)
+			}
+			result = append(result, c)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return result[i].DeletedAt.Before(*result[j].DeletedAt) })
+	return result, nil
+}
+
+// Restore saca una clínica de la papelera; se deshace si la transacción falla.
+func (s *ClinicStore) Restore(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid clinic ID '%s': %w", id, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clinic := s.find(objID)
+	if clinic == nil || clinic.DeletedAt == nil {
+		return fmt.Errorf("clinic with ID '%s' not found in trash", id)
+	}
+
+	prevDeletedAt, prevUpdatedAt := clinic.DeletedAt, clinic.UpdatedAt
+	clinic.DeletedAt = nil
+	clinic.UpdatedAt = now()
+	clinic.Version++
+	onRollback(ctx, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		clinic.DeletedAt = prevDeletedAt
+		clinic.UpdatedAt = prevUpdatedAt
+		clinic.Version--
+	})
+	return nil
+}
+
+// HardDelete borra definitivamente una clínica de la papelera.
+func (s *ClinicStore) HardDelete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid clinic ID '%s': %w", id, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, clinic := range s.clinics {
+		if clinic.ID == objID && clinic.DeletedAt != nil {
+			s.clinics = append(s.clinics[:i], s.clinics[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("clinic with ID '%s' not found in trash", id)
+}
+
+// Helpers (se llaman con el mutex tomado)
+
+func (s *ClinicStore) find(id primitive.ObjectID) *models.Clinic {
+	for _, clinic := range s.clinics {
+		if clinic.ID == id {
+			return clinic
+		}
+	}
+	return nil
+}
+
+func (s *ClinicStore) findActive(match func(c *models.Clinic) bool) (*models.Clinic, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, clinic := range s.clinics {
+		if clinic.DeletedAt == nil && match(clinic) {
+			return cloneClinic(clinic)
+		}
+	}
+	return nil, nil // No es error si no se encuentra
+}
+
+// checkUnique replica los índices únicos sin distinción de mayúsculas de name y displayName.
+func (s *ClinicStore) checkUnique(name, displayName string, except primitive.ObjectID) error {
+	for _, clinic := range s.clinics {
+		if clinic.ID == except {
+			continue
+		}
+		if strings.EqualFold(clinic.Name, name) {
+			return fmt.Errorf("%w: name %q", storage.ErrDuplicateClinicName, name)
+		}
+		if strings.EqualFold(clinic.DisplayName, displayName) {
+			return fmt.Errorf("%w: displayName %q", storage.ErrDuplicateClinicDisplayName, displayName)
+		}
+	}
+	return nil
+}
+
+// list replica buildFilter y buildFindOptions de ClinicRepository.
+func (s *ClinicStore) list(filters storage.ListFilters, deleted bool) ([]*models.Clinic, int64, error) {
+	terms := strings.Fields(search.Normalize(filters.Search))
+
+	type entry struct {
+		clinic *models.Clinic
+		score  float64
+		doc    bson.M
+	}
+
+	s.mu.RLock()
+	var matched []entry
+	for _, clinic := range s.clinics {
+		if (clinic.DeletedAt != nil) != deleted {
+			continue
+		}
+		var score float64
+		if len(terms) > 0 {
+			if score = clinicTextScore(clinic, terms); score == 0 {
+				continue
+			}
+		}
+		scored := *clinic
+		scored.Score = score
+		doc, err := toDoc(&scored)
+		if err != nil {
+			s.mu.RUnlock()
+			return nil, 0, fmt.Errorf("failed to execute query: %w", err)
+		}
+		if !matchFilters(doc, filters.Filters) {
+			continue
+		}
+		matched = append(matched, entry{clinic, score, doc})
+	}
+	s.mu.RUnlock()
+
+	// Orden estable por (campos, _id), igual que el repositorio de Mongo
+	order := filters.Sort
+	if len(order) == 0 {
+		order = []dto.SortField{{Field: "createdAt", Column: "createdAt"}}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return compareDocs(matched[i].doc, matched[j].doc, order) < 0
+	})
+
+	total := int64(len(matched))
+	if filters.SkipCount {
+		total = 0
+	}
+
+	if after := filters.After; after != nil {
+		var window []entry
+		for _, e := range matched {
+			if afterCursor(e.doc, order, after) {
+				window = append(window, e)
+			}
+		}
+		matched = window
+		if filters.Limit > 0 && len(matched) > filters.Limit {
+			if after.Backward {
+				matched = matched[len(matched)-filters.Limit:]
+			} else {
+				matched = matched[:filters.Limit]
+			}
+		}
+	} else if filters.Limit > 0 {
+		start := 0
+		if filters.Page > 1 {
+			start = (filters.Page - 1) * filters.Limit
+		}
+		if start > len(matched) {
+			start = len(matched)
+		}
+		end := start + filters.Limit
+		if end > len(matched) {
+			end = len(matched)
+		}
+		matched = matched[start:end]
+	}
+
+	result := make([]*models.Clinic, 0, len(matched))
+	for _, e := range matched {
+		c, err := cloneClinic(e.clinic)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to decode results: %w", err)
+		}
+		c.Score = e.score
+		result = append(result, c)
+	}
+	return result, total, nil
+}
+
+// clinicTextScore aproxima la relevancia del índice de texto de clínicas: suma
+// el peso de cada campo por cada término de la búsqueda que contiene (palabras
+// completas, sin distinguir mayúsculas ni acentos, como el índice de Mongo).
+func clinicTextScore(clinic *models.Clinic, terms []string) float64 {
+	fields := map[string]string{
+		"name":        clinic.Name,
+		"displayName": clinic.DisplayName,
+		"address":     clinic.Address,
+		"description": clinic.Description,
+	}
+
+	var score float64
+	for field, value := range fields {
+		fieldTerms := search.Terms(value)
+		for _, term := range terms {
+			if slices.Contains(fieldTerms, term) {
+				score += float64(database.ClinicTextWeights[field])
+			}
+		}
+	}
+	return score
+}
+
+// cloneClinic copia la clínica pasando por BSON, igual que un viaje de ida y
+// vuelta a Mongo (copia profunda y fechas truncadas a milisegundos).
+func cloneClinic(clinic *models.Clinic) (*models.Clinic, error) {
+	raw, err := bson.Marshal(clinic)
+	if err != nil {
+		return nil, err
+	}
+	var clone models.Clinic
+	if err := bson.Unmarshal(raw, &clone); err != nil {
+		return nil, err
+	}
+	return &clone, nil
+}
+
+// applySet aplica un $set con nombres de campo bson sobre una copia de la clínica.
+func applySet(clinic *models.Clinic, fields map[string]interface{}) (*models.Clinic, error) {
+	raw, err := bson.Marshal(clinic)
+	if err != nil {
+		return nil, err
+	}
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	for key, value := range fields {
+		doc[key] = value
+	}
+
+	raw, err = bson.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var updated models.Clinic
+	if err := bson.Unmarshal(raw, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// now devuelve la hora actual con la precisión con la que Mongo guarda las fechas.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+// IdempotencyStore implementa storage.IdempotencyStorer en memoria. En lugar
+// del índice TTL, cada reserva descarta los registros caducados.
+type IdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*models.IdempotencyRecord
+}
+
+// NewIdempotencyStore crea un IdempotencyStore vacío.
+func NewIdempotencyStore() *IdempotencyStore {
+	return &IdempotencyStore{records: make(map[string]*models.IdempotencyRecord)}
+}
+
+var _ storage.IdempotencyStorer = (*IdempotencyStore)(nil)
+
+// Reserve registra la petición en curso o devuelve el registro existente.
+func (s *IdempotencyStore) Reserve(ctx context.Context, record *models.IdempotencyRecord) (*models.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ts := now()
+	for id, r := range s.records {
+		if !r.ExpiresAt.After(ts) {
+			delete(s.records, id)
+		}
+	}
+
+	existing, ok := s.records[record.ID]
+	if ok {
+		abandoned := existing.Status == models.IdempotencyProcessing && existing.LockedUntil.Before(ts)
+		if !abandoned {
+			copied := *existing
+			return &copied, nil
+		}
+	}
+
+	stored := *record
+	s.records[record.ID] = &stored
+	return nil, nil
+}
+
+// Complete guarda la respuesta y marca el registro como completado.
+func (s *IdempotencyStore) Complete(ctx context.Context, id, owner string, response models.CachedResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[id]; ok && record.Owner == owner && record.Status == models.IdempotencyProcessing {
+		record.Status = models.IdempotencyCompleted
+		record.Response = &response
+	}
+	return nil
+}
+
+// Release borra una reserva en curso.
+func (s *IdempotencyStore) Release(ctx context.Context, id, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[id]; ok && record.Owner == owner && record.Status == models.IdempotencyProcessing {
+		delete(s.records, id)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ImportStore implementa storage.ImportStorer en memoria.
+type ImportStore struct {
+	mu   sync.Mutex
+	jobs []*models.ImportJob
+	rows map[primitive.ObjectID][]*models.ImportRow // Por trabajo, ordenadas por índice
+}
+
+// NewImportStore crea un ImportStore vacío.
+func NewImportStore() *ImportStore {
+	return &ImportStore{rows: make(map[primitive.ObjectID][]*models.ImportRow)}
+}
+
+var _ storage.ImportStorer = (*ImportStore)(nil)
+
+// CreateJob guarda el trabajo y sus filas.
+func (s *ImportStore) CreateJob(ctx context.Context, job *models.ImportJob, rows []*models.ImportRow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job.ID = primitive.NewObjectID()
+	ts := now()
+	job.CreatedAt = ts
+	job.UpdatedAt = ts
+
+	stored, err := cloneDoc(job)
+	if err != nil {
+		return fmt.Errorf("error creating import job: %w", err)
+	}
+	copies := make([]*models.ImportRow, len(rows))
+	for i, row := range rows {
+		row.ID = primitive.NewObjectID()
+		row.JobID = job.ID
+		if copies[i], err = cloneDoc(row); err != nil {
+			return fmt.Errorf("error creating import rows: %w", err)
+		}
+	}
+	slices.SortFunc(copies, func(a, b *models.ImportRow) int { return cmp.Compare(a.Index, b.Index) })
+
+	s.jobs = append(s.jobs, stored)
+	s.rows[job.ID] = copies
+	return nil
+}
+
+// FindJob busca un trabajo DENTRO de una clínica.
+func (s *ImportStore) FindJob(ctx context.Context, clinicID, jobID string) (*models.ImportJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.findJob(clinicID, jobID)
+	if err != nil || job == nil {
+		return nil, err
+	}
+	return cloneDoc(job)
+}
+
+// QueueJob pone en cola el trabajo si su estado es uno de from.
+func (s *ImportStore) QueueJob(ctx context.Context, clinicID, jobID string, from []string, mapping map[string]string, locale string) (*models.ImportJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.findJob(clinicID, jobID)
+	if err != nil || job == nil || !slices.Contains(from, job.Status) {
+		return nil, err
+	}
+	job.Status = models.ImportQueued
+	job.Mapping = maps.Clone(mapping)
+	job.Locale = locale
+	job.Error = ""
+	job.FinishedAt = nil
+	job.UpdatedAt = now()
+	return cloneDoc(job)
+}
+
+// ClaimJob asigna el trabajo en cola más antiguo o uno en curso abandonado.
+func (s *ImportStore) ClaimJob(ctx context.Context, staleBefore time.Time) (*models.ImportJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// s.jobs está en orden de creación
+	for _, job := range s.jobs {
+		stale := job.Status == models.ImportRunning && job.HeartbeatAt != nil && job.HeartbeatAt.Before(staleBefore)
+		if job.Status != models.ImportQueued && !stale {
+			continue
+		}
+		ts := now()
+		job.Status = models.ImportRunning
+		job.HeartbeatAt = &ts
+		job.UpdatedAt = ts
+		if job.StartedAt == nil {
+			job.StartedAt = &ts
+		}
+		return cloneDoc(job)
+	}
+	return nil, nil
+}
+
+// SaveProgress guarda el progreso y renueva el latido del ejecutor.
+func (s *ImportStore) SaveProgress(ctx context.Context, jobID string, stats models.ImportStats) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.jobByID(jobID)
+	if err != nil || job == nil || job.Status != models.ImportRunning {
+		return err
+	}
+	ts := now()
+	job.Stats = stats
+	job.HeartbeatAt = &ts
+	job.UpdatedAt = ts
+	return nil
+}
+
+// FinishJob cierra el trabajo.
+func (s *ImportStore) FinishJob(ctx context.Context, jobID string, status string, stats models.ImportStats, errMsg string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.jobByID(jobID)
+	if err != nil || job == nil {
+		return err
+	}
+	ts := now()
+	job.Status = status
+	job.Stats = stats
+	job.UpdatedAt = ts
+	job.FinishedAt = &ts
+	job.HeartbeatAt = nil
+	if errMsg != "" {
+		job.Error = errMsg
+	}
+	return nil
+}
+
+// ListRows devuelve las filas del trabajo con índice mayor que after, en orden.
+func (s *ImportStore) ListRows(ctx context.Context, jobID string, status string, after, limit int) ([]*models.ImportRow, error) {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid import job ID: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var rows []*models.ImportRow
+	for _, row := range s.rows[objID] {
+		if len(rows) == limit {
+			break
+		}
+		if row.Index <= after || (status != "" && row.Status != status) {
+			continue
+		}
+		clone, err := cloneDoc(row)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, clone)
+	}
+	return rows, nil
+}
+
+// SaveRow reemplaza la fila con su resultado.
+func (s *ImportStore) SaveRow(ctx context.Context, row *models.ImportRow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rows := s.rows[row.JobID]
+	i := slices.IndexFunc(rows, func(r *models.ImportRow) bool { return r.ID == row.ID })
+	if i < 0 {
+		return nil
+	}
+	stored, err := cloneDoc(row)
+	if err != nil {
+		return fmt.Errorf("error saving import row: %w", err)
+	}
+	rows[i] = stored
+	return nil
+}
+
+// RowStats cuenta las filas del trabajo por resultado.
+func (s *ImportStore) RowStats(ctx context.Context, jobID string) (models.ImportStats, error) {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return models.ImportStats{}, fmt.Errorf("invalid import job ID: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var stats models.ImportStats
+	for _, row := range s.rows[objID] {
+		stats.Total++
+		switch row.Status {
+		case models.ImportRowImported:
+			stats.Imported++
+		case models.ImportRowInvalid:
+			stats.Invalid++
+		}
+		if row.OwnerCreated {
+			stats.OwnersCreated++
+		}
+	}
+	return stats.WithTotals(), nil
+}
+
+// DeleteByClinic borra los trabajos de la clínica y sus filas.
+func (s *ImportStore) DeleteByClinic(ctx context.Context, clinicID string) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clinic ID: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	s.jobs = slices.DeleteFunc(s.jobs, func(job *models.ImportJob) bool {
+		if job.ClinicID != clinicObjID {
+			return false
+		}
+		delete(s.rows, job.ID)
+		deleted++
+		return true
+	})
+	return deleted, nil
+}
+
+func (s *ImportStore) findJob(clinicID, jobID string) (*models.ImportJob, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic ID: %w", err)
+	}
+	job, err := s.jobByID(jobID)
+	if err != nil || job == nil || job.ClinicID != clinicObjID {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (s *ImportStore) jobByID(jobID string) (*models.ImportJob, error) {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid import job ID: %w", err)
+	}
+	for _, job := range s.jobs {
+		if job.ID == objID {
+			return job, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OwnerStore implementa storage.OwnerStorer en memoria.
+type OwnerStore struct {
+	mu     sync.RWMutex
+	owners []*models.Owner
+}
+
+// NewOwnerStore crea un OwnerStore vacío.
+func NewOwnerStore() *OwnerStore {
+	return &OwnerStore{}
+}
+
+var _ storage.OwnerStorer = (*OwnerStore)(nil)
+
+// Create inserta un nuevo tutor. Si owner.ID viene vacío se genera uno.
+func (s *OwnerStore) Create(ctx context.Context, owner *models.Owner) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if owner.ID.IsZero() {
+		owner.ID = primitive.NewObjectID()
+	}
+	ts := now()
+	owner.CreatedAt = ts
+	owner.UpdatedAt = ts
+	owner.Search = owner.SearchKeys()
+	owner.Contacts = owner.ContactKeys()
+
+	stored, err := cloneDoc(owner)
+	if err != nil {
+		return fmt.Errorf("error al crear el tutor: %w", err)
+	}
+	s.owners = append(s.owners, stored)
+	return nil
+}
+
+// FindByID busca un tutor por su ID DENTRO de una clínica específica.
+func (s *OwnerStore) FindByID(ctx context.Context, clinicID, ownerID string) (*models.Owner, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+	ownerObjID, err := primitive.ObjectIDFromHex(ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de tutor inválido: %w", err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, owner := range s.owners {
+		if owner.ID == ownerObjID && owner.ClinicID == clinicObjID {
+			return cloneDoc(owner)
+		}
+	}
+	return nil, nil
+}
+
+// FindByContact busca el tutor más antiguo de la clínica con alguna de las claves de contacto.
+func (s *OwnerStore) FindByContact(ctx context.Context, clinicID string, keys []string) (*models.Owner, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var found *models.Owner
+	for _, owner := range s.owners {
+		if owner.ClinicID != clinicObjID || !slices.ContainsFunc(owner.Contacts, func(k string) bool { return slices.Contains(keys, k) }) {
+			continue
+		}
+		if found == nil || owner.ID.Hex() < found.ID.Hex() {
+			found = owner
+		}
+	}
+	if found == nil {
+		return nil, nil
+	}
+	return cloneDoc(found)
+}
+
+// SearchCandidates preselecciona los tutores de la clínica para la búsqueda tolerante a errores.
+func (s *OwnerStore) SearchCandidates(ctx context.Context, clinicID string, grams []string, limit int) ([]*models.Owner, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var inClinic []*models.Owner
+	for _, owner := range s.owners {
+		if owner.ClinicID == clinicObjID {
+			inClinic = append(inClinic, owner)
+		}
+	}
+	return searchCandidates(inClinic, func(o *models.Owner) ([]string, primitive.ObjectID) { return o.Search.Grams, o.ID }, cloneDoc[models.Owner], grams, limit)
+}
+
+// StreamByClinic recorre por orden de ID los tutores de una clínica. Copia la
+// selección antes de recorrerla para no retener el cerrojo mientras corre each.
+func (s *OwnerStore) StreamByClinic(ctx context.Context, clinicID string, each func(*models.Owner) error) error {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	s.mu.RLock()
+	var selected []*models.Owner
+	for _, doc := range s.owners {
+		if doc.ClinicID == clinicObjID {
+			clone, err := cloneDoc(doc)
+			if err != nil {
+				s.mu.RUnlock()
+				return err
+			}
+			selected = append(selected, clone)
+		}
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(selected, func(a, b *models.Owner) int { return bytes.Compare(a.ID[:], b.ID[:]) })
+	for _, doc := range selected {
+		if err := each(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HardDeleteByClinic borra definitivamente todos los tutores de una clínica.
+func (s *OwnerStore) HardDeleteByClinic(ctx context.Context, clinicID string) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before := len(s.owners)
+	s.owners = slices.DeleteFunc(s.owners, func(doc *models.Owner) bool { return doc.ClinicID == clinicObjID })
+	return int64(before - len(s.owners)), nil
+}
@@ -0,0 +1,134 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PatientStore implementa storage.PatientStorer en memoria.
+type PatientStore struct {
+	mu       sync.RWMutex
+	patients []*models.Patient
+}
+
+// NewPatientStore crea un PatientStore vacío.
+func NewPatientStore() *PatientStore {
+	return &PatientStore{}
+}
+
+var _ storage.PatientStorer = (*PatientStore)(nil)
+
+// Create inserta un nuevo paciente. Si patient.ID viene vacío se genera uno.
+func (s *PatientStore) Create(ctx context.Context, patient *models.Patient) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if patient.ID.IsZero() {
+		patient.ID = primitive.NewObjectID()
+	}
+	ts := now()
+	patient.CreatedAt = ts
+	patient.UpdatedAt = ts
+	patient.Search = patient.SearchKeys()
+
+	stored, err := cloneDoc(patient)
+	if err != nil {
+		return fmt.Errorf("error al crear el paciente: %w", err)
+	}
+	s.patients = append(s.patients, stored)
+	return nil
+}
+
+// FindByID busca un paciente por su ID DENTRO de una clínica específica.
+func (s *PatientStore) FindByID(ctx context.Context, clinicID, patientID string) (*models.Patient, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+	patientObjID, err := primitive.ObjectIDFromHex(patientID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de paciente inválido: %w", err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, patient := range s.patients {
+		if patient.ID == patientObjID && patient.ClinicID == clinicObjID {
+			return cloneDoc(patient)
+		}
+	}
+	return nil, nil
+}
+
+// SearchCandidates preselecciona los pacientes de la clínica para la búsqueda tolerante a errores.
+func (s *PatientStore) SearchCandidates(ctx context.Context, clinicID string, grams []string, limit int) ([]*models.Patient, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var inClinic []*models.Patient
+	for _, patient := range s.patients {
+		if patient.ClinicID == clinicObjID {
+			inClinic = append(inClinic, patient)
+		}
+	}
+	return searchCandidates(inClinic, func(p *models.Patient) ([]string, primitive.ObjectID) { return p.Search.Grams, p.ID }, cloneDoc[models.Patient], grams, limit)
+}
+
+// StreamByClinic recorre por orden de ID los pacientes de una clínica. Copia la
+// selección antes de recorrerla para no retener el cerrojo mientras corre each.
+func (s *PatientStore) StreamByClinic(ctx context.Context, clinicID string, each func(*models.Patient) error) error {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	s.mu.RLock()
+	var selected []*models.Patient
+	for _, doc := range s.patients {
+		if doc.ClinicID == clinicObjID {
+			clone, err := cloneDoc(doc)
+			if err != nil {
+				s.mu.RUnlock()
+				return err
+			}
+			selected = append(selected, clone)
+		}
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(selected, func(a, b *models.Patient) int { return bytes.Compare(a.ID[:], b.ID[:]) })
+	for _, doc := range selected {
+		if err := each(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HardDeleteByClinic borra definitivamente todos los pacientes de una clínica.
+func (s *PatientStore) HardDeleteByClinic(ctx context.Context, clinicID string) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before := len(s.patients)
+	s.patients = slices.DeleteFunc(s.patients, func(doc *models.Patient) bool { return doc.ClinicID == clinicObjID })
+	return int64(before - len(s.patients)), nil
+}
@@ -0,0 +1,191 @@
+package memory
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/pagination"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/dto"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Evaluación en memoria del lenguaje de consulta (dto.Filter, dto.SortField y
+// cursores keyset) sobre el documento BSON de cada elemento, con la semántica de
+// comparación de MongoDB para los tipos que usan los modelos.
+
+// toDoc convierte un modelo en su documento BSON, tal como lo vería Mongo.
+func toDoc(v interface{}) (bson.M, error) {
+	raw, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// matchFilters aplica los filtros como lo haría dto.FilterBSON en Mongo.
+func matchFilters(doc bson.M, filters []dto.Filter) bool {
+	for _, f := range filters {
+		if !matchFilter(doc, f) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchFilter(doc bson.M, f dto.Filter) bool {
+	value, present := doc[f.Column]
+	switch f.Op {
+	case dto.OpExists:
+		return present == f.Value.(bool)
+	case dto.OpEq:
+		return equalValues(value, f.Value)
+	case dto.OpNe:
+		// Como $ne: también coincide si el campo no existe
+		return !equalValues(value, f.Value)
+	case dto.OpIn:
+		for _, candidate := range f.Value.([]interface{}) {
+			if equalValues(value, candidate) {
+				return true
+			}
+		}
+		return false
+	case dto.OpGte:
+		cmp, ok := compareSameType(value, f.Value)
+		return ok && cmp >= 0
+	case dto.OpLte:
+		cmp, ok := compareSameType(value, f.Value)
+		return ok && cmp <= 0
+	default:
+		return false
+	}
+}
+
+func equalValues(a, b interface{}) bool {
+	cmp, ok := compareSameType(a, b)
+	return ok && cmp == 0
+}
+
+// compareSameType compara dos valores del mismo tipo BSON; ok es false si los
+// tipos difieren (Mongo no compara entre tipos en los filtros).
+func compareSameType(a, b interface{}) (int, bool) {
+	a, b = normalize(a), normalize(b)
+	if a == nil || b == nil || rank(a) != rank(b) {
+		return 0, false
+	}
+	return compareValues(a, b), true
+}
+
+// compareValues ordena dos valores cualesquiera siguiendo el orden de tipos de
+// BSON (null < números < cadenas < ObjectId < bool < fechas).
+func compareValues(a, b interface{}) int {
+	a, b = normalize(a), normalize(b)
+	if ra, rb := rank(a), rank(b); ra != rb {
+		return ra - rb
+	}
+
+	switch av := a.(type) {
+	case float64:
+		bv := b.(float64)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(av, b.(string))
+	case primitive.ObjectID:
+		bv := b.(primitive.ObjectID)
+		return bytes.Compare(av[:], bv[:])
+	case bool:
+		bv := b.(bool)
+		switch {
+		case av == bv:
+			return 0
+		case !av:
+			return -1
+		}
+		return 1
+	case time.Time:
+		return av.Compare(b.(time.Time))
+	default:
+		return 0
+	}
+}
+
+// normalize unifica las representaciones de un mismo tipo BSON.
+func normalize(v interface{}) interface{} {
+	switch t := v.(type) {
+	case int:
+		return float64(t)
+	case int32:
+		return float64(t)
+	case int64:
+		return float64(t)
+	case primitive.DateTime:
+		return t.Time().UTC()
+	case time.Time:
+		return t.UTC()
+	default:
+		return v
+	}
+}
+
+func rank(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return 0
+	case float64:
+		return 1
+	case string:
+		return 2
+	case primitive.ObjectID:
+		return 3
+	case bool:
+		return 4
+	case time.Time:
+		return 5
+	default:
+		return 6
+	}
+}
+
+// compareDocs ordena dos documentos por los campos pedidos y después por _id,
+// con la misma dirección de desempate que pagination.SortBSON.
+func compareDocs(a, b bson.M, sort []dto.SortField) int {
+	for _, s := range sort {
+		if cmp := compareValues(a[s.Column], b[s.Column]); cmp != 0 {
+			if s.Desc {
+				return -cmp
+			}
+			return cmp
+		}
+	}
+	cmp := compareValues(a["_id"], b["_id"])
+	if len(sort) > 0 && sort[len(sort)-1].Desc {
+		return -cmp
+	}
+	return cmp
+}
+
+// afterCursor indica si el documento queda después (o antes, si el cursor va
+// hacia atrás) de la posición del cursor; equivale a pagination.KeysetBSON.
+func afterCursor(doc bson.M, sort []dto.SortField, after *pagination.Cursor) bool {
+	position := bson.M{"_id": after.ID}
+	for i, s := range sort {
+		position[s.Column] = after.Values[i]
+	}
+
+	cmp := compareDocs(doc, position, sort)
+	if after.Backward {
+		return cmp < 0
+	}
+	return cmp > 0
+}
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+// RateLimitStore implementa storage.RateLimitStorer en memoria: sirve para
+// una sola instancia (cada réplica tendría sus propios cupos). En lugar del
+// índice TTL, los cubos caducados se descartan como mucho una vez por minuto.
+type RateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*rateLimitBucket
+	sweptAt time.Time
+}
+
+type rateLimitBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	expiresAt time.Time
+}
+
+// NewRateLimitStore crea un RateLimitStore vacío.
+func NewRateLimitStore() *RateLimitStore {
+	return &RateLimitStore{buckets: make(map[string]*rateLimitBucket)}
+}
+
+var _ storage.RateLimitStorer = (*RateLimitStore)(nil)
+
+// Take rellena el cubo según el tiempo transcurrido y consume un token.
+func (s *RateLimitStore) Take(ctx context.Context, key string, capacity, rate float64, now time.Time) (float64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.sweptAt) > time.Minute {
+		for k, b := range s.buckets {
+			if !b.expiresAt.After(now) {
+				delete(s.buckets, k)
+			}
+		}
+		s.sweptAt = now
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &rateLimitBucket{tokens: capacity, updatedAt: now}
+		s.buckets[key] = b
+	}
+	elapsed := max(0, now.Sub(b.updatedAt).Seconds())
+	b.tokens = min(capacity, b.tokens+elapsed*rate)
+	b.updatedAt = now
+	b.expiresAt = now.Add(storage.RateLimitTTL(capacity, rate))
+
+	if b.tokens < 1 {
+		return b.tokens, false, nil
+	}
+	b.tokens--
+	return b.tokens, true, nil
+}
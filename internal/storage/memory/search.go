@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// searchCandidates replica storage.findSearchCandidates: los documentos que más
+// trigramas comparten con la consulta, con el mismo orden y límite.
+func searchCandidates[T any](items []*T, keys func(*T) ([]string, primitive.ObjectID), clone func(*T) (*T, error), grams []string, limit int) ([]*T, error) {
+	if len(grams) == 0 {
+		return nil, nil
+	}
+	if limit <= 0 || limit > storage.MaxSearchCandidates {
+		limit = storage.MaxSearchCandidates
+	}
+
+	type candidate struct {
+		item    *T
+		id      primitive.ObjectID
+		overlap int
+	}
+	var candidates []candidate
+	for _, item := range items {
+		itemGrams, id := keys(item)
+		overlap := 0
+		for _, gram := range grams {
+			if slices.Contains(itemGrams, gram) {
+				overlap++
+			}
+		}
+		if overlap > 0 {
+			candidates = append(candidates, candidate{item, id, overlap})
+		}
+	}
+
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		if a.overlap != b.overlap {
+			return b.overlap - a.overlap
+		}
+		return bytes.Compare(a.id[:], b.id[:])
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	result := make([]*T, 0, len(candidates))
+	for _, c := range candidates {
+		cloned, err := clone(c.item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, cloned)
+	}
+	return result, nil
+}
+
+// cloneDoc copia un documento pasando por BSON, igual que cloneClinic.
+func cloneDoc[T any](doc *T) (*T, error) {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var clone T
+	if err := bson.Unmarshal(raw, &clone); err != nil {
+		return nil, err
+	}
+	return &clone, nil
+}
@@ -0,0 +1,20 @@
+package memory
+
+import "github.com/zabaletac3/go-vet-api/internal/storage"
+
+// NewStores crea un juego de stores en memoria vacíos.
+func NewStores() *storage.Stores {
+	return &storage.Stores{
+		Clinics:     NewClinicStore(),
+		Users:       NewUserStore(),
+		Owners:      NewOwnerStore(),
+		Patients:    NewPatientStore(),
+		Idempotency: NewIdempotencyStore(),
+		Imports:     NewImportStore(),
+		Transfers:   NewTransferStore(),
+		RateLimits:  NewRateLimitStore(),
+		Usage:       NewUsageStore(),
+		Attachments: NewAttachmentStore(),
+		Tx:          NewTransactor(),
+	}
+}
@@ -0,0 +1,69 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/storage/memory"
+	"github.com/zabaletac3/go-vet-api/internal/storage/storagetest"
+)
+
+func TestClinicStore(t *testing.T) {
+	storagetest.RunClinicStorerTests(t, func(t *testing.T) storage.ClinicStorer {
+		return memory.NewClinicStore()
+	})
+}
+
+func TestUserStore(t *testing.T) {
+	storagetest.RunUserStorerTests(t, func(t *testing.T) storage.UserStorer {
+		return memory.NewUserStore()
+	})
+}
+
+func TestIdempotencyStore(t *testing.T) {
+	storagetest.RunIdempotencyStorerTests(t, func(t *testing.T) storage.IdempotencyStorer {
+		return memory.NewIdempotencyStore()
+	})
+}
+
+func TestImportStore(t *testing.T) {
+	storagetest.RunImportStorerTests(t, func(t *testing.T) storage.ImportStorer {
+		return memory.NewImportStore()
+	})
+}
+
+func TestTransferStore(t *testing.T) {
+	storagetest.RunTransferStorerTests(t, func(t *testing.T) storage.TransferStorer {
+		return memory.NewTransferStore()
+	})
+}
+
+func TestRateLimitStore(t *testing.T) {
+	storagetest.RunRateLimitStorerTests(t, func(t *testing.T) storage.RateLimitStorer {
+		return memory.NewRateLimitStore()
+	})
+}
+
+func TestUsageStore(t *testing.T) {
+	storagetest.RunUsageStorerTests(t, func(t *testing.T) storage.UsageStorer {
+		return memory.NewUsageStore()
+	})
+}
+
+func TestAttachmentStore(t *testing.T) {
+	storagetest.RunAttachmentStorerTests(t, func(t *testing.T) storage.AttachmentStorer {
+		return memory.NewAttachmentStore()
+	})
+}
+
+func TestOwnerStore(t *testing.T) {
+	storagetest.RunOwnerStorerTests(t, func(t *testing.T) storage.OwnerStorer {
+		return memory.NewOwnerStore()
+	})
+}
+
+func TestPatientStore(t *testing.T) {
+	storagetest.RunPatientStorerTests(t, func(t *testing.T) storage.PatientStorer {
+		return memory.NewPatientStore()
+	})
+}
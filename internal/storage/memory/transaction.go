@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+// Transactor implementa storage.Transactor en memoria con un registro de
+// deshacer: las inserciones hechas con el ctx de la transacción se revierten
+// si fn falla. Las transacciones se ejecutan de una en una; las escrituras de
+// otras peticiones no se aíslan (es un backend de demo).
+type Transactor struct {
+	mu sync.Mutex
+}
+
+// NewTransactor crea un Transactor en memoria.
+func NewTransactor() *Transactor {
+	return &Transactor{}
+}
+
+var _ storage.Transactor = (*Transactor)(nil)
+
+type undoKey struct{}
+
+// undoLog acumula las funciones que revierten las escrituras de una transacción
+type undoLog struct {
+	undo []func()
+}
+
+// WithTransaction ejecuta fn y, si falla, deshace sus escrituras en orden inverso.
+func (t *Transactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	log := &undoLog{}
+	txCtx, commit := storage.WithCommitHooks(context.WithValue(ctx, undoKey{}, log))
+	if err := fn(txCtx); err != nil {
+		for i := len(log.undo) - 1; i >= 0; i-- {
+			log.undo[i]()
+		}
+		return err
+	}
+	commit()
+	return nil
+}
+
+// onRollback registra cómo deshacer una escritura si ctx pertenece a una transacción
+func onRollback(ctx context.Context, undo func()) {
+	if log, ok := ctx.Value(undoKey{}).(*undoLog); ok {
+		log.undo = append(log.undo, undo)
+	}
+}
@@ -0,0 +1,216 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TransferStore implementa storage.TransferStorer en memoria.
+type TransferStore struct {
+	mu   sync.Mutex
+	jobs []*models.TransferJob
+}
+
+// NewTransferStore crea un TransferStore vacío.
+func NewTransferStore() *TransferStore {
+	return &TransferStore{}
+}
+
+var _ storage.TransferStorer = (*TransferStore)(nil)
+
+// CreateJob guarda el trabajo. Si job.ID viene vacío se genera uno.
+func (s *TransferStore) CreateJob(ctx context.Context, job *models.TransferJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if job.ID.IsZero() {
+		job.ID = primitive.NewObjectID()
+	}
+	ts := now()
+	job.CreatedAt = ts
+	job.UpdatedAt = ts
+
+	stored, err := cloneDoc(job)
+	if err != nil {
+		return fmt.Errorf("error creating transfer job: %w", err)
+	}
+	s.jobs = append(s.jobs, stored)
+	return nil
+}
+
+// FindJob busca un trabajo por ID.
+func (s *TransferStore) FindJob(ctx context.Context, jobID string) (*models.TransferJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.jobByID(jobID)
+	if err != nil || job == nil {
+		return nil, err
+	}
+	return cloneDoc(job)
+}
+
+// QueueJob vuelve a poner en cola el trabajo si su estado es uno de from.
+func (s *TransferStore) QueueJob(ctx context.Context, jobID string, from []string) (*models.TransferJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.jobByID(jobID)
+	if err != nil || job == nil || !slices.Contains(from, job.Status) {
+		return nil, err
+	}
+	job.Status = models.TransferQueued
+	job.Error = ""
+	job.FinishedAt = nil
+	job.ExpiresAt = nil
+	job.UpdatedAt = now()
+	return cloneDoc(job)
+}
+
+// ClaimJob asigna el trabajo en cola más antiguo o uno en curso abandonado.
+func (s *TransferStore) ClaimJob(ctx context.Context, staleBefore time.Time) (*models.TransferJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// s.jobs está en orden de creación
+	for _, job := range s.jobs {
+		stale := job.Status == models.TransferRunning && job.HeartbeatAt != nil && job.HeartbeatAt.Before(staleBefore)
+		if job.Status != models.TransferQueued && !stale {
+			continue
+		}
+		ts := now()
+		job.Status = models.TransferRunning
+		job.HeartbeatAt = &ts
+		job.UpdatedAt = ts
+		if job.StartedAt == nil {
+			job.StartedAt = &ts
+		}
+		return cloneDoc(job)
+	}
+	return nil, nil
+}
+
+// SaveProgress guarda los contadores y renueva el latido del ejecutor.
+func (s *TransferStore) SaveProgress(ctx context.Context, jobID string, counts models.TransferCounts) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.jobByID(jobID)
+	if err != nil || job == nil || job.Status != models.TransferRunning {
+		return err
+	}
+	ts := now()
+	job.Counts = counts
+	job.HeartbeatAt = &ts
+	job.UpdatedAt = ts
+	return nil
+}
+
+// FinishJob cierra el trabajo.
+func (s *TransferStore) FinishJob(ctx context.Context, jobID string, status string, counts models.TransferCounts, size int64, errMsg string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.jobByID(jobID)
+	if err != nil || job == nil {
+		return err
+	}
+	ts := now()
+	job.Status = status
+	job.Counts = counts
+	job.UpdatedAt = ts
+	job.FinishedAt = &ts
+	job.HeartbeatAt = nil
+	expiresAt := ts.Add(models.TransferArchiveTTL)
+	job.ExpiresAt = &expiresAt
+	if size > 0 {
+		job.Size = size
+	}
+	if errMsg != "" {
+		job.Error = errMsg
+	}
+	return nil
+}
+
+// ExpiredJobs devuelve los trabajos caducados, los más antiguos primero.
+func (s *TransferStore) ExpiredJobs(ctx context.Context, before time.Time, limit int) ([]*models.TransferJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expired []*models.TransferJob
+	for _, job := range s.jobs {
+		if job.ExpiresAt != nil && !job.ExpiresAt.After(before) {
+			expired = append(expired, job)
+		}
+	}
+	slices.SortStableFunc(expired, func(a, b *models.TransferJob) int { return a.ExpiresAt.Compare(*b.ExpiresAt) })
+	if len(expired) > limit {
+		expired = expired[:limit]
+	}
+	return cloneJobs(expired)
+}
+
+// FindJobsByClinic devuelve los trabajos de una clínica.
+func (s *TransferStore) FindJobsByClinic(ctx context.Context, clinicID string) ([]*models.TransferJob, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic ID: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var jobs []*models.TransferJob
+	for _, job := range s.jobs {
+		if job.ClinicID == clinicObjID {
+			jobs = append(jobs, job)
+		}
+	}
+	return cloneJobs(jobs)
+}
+
+// DeleteJob borra el trabajo.
+func (s *TransferStore) DeleteJob(ctx context.Context, jobID string) error {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return fmt.Errorf("invalid transfer job ID: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs = slices.DeleteFunc(s.jobs, func(job *models.TransferJob) bool { return job.ID == objID })
+	return nil
+}
+
+func cloneJobs(jobs []*models.TransferJob) ([]*models.TransferJob, error) {
+	out := make([]*models.TransferJob, 0, len(jobs))
+	for _, job := range jobs {
+		clone, err := cloneDoc(job)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, clone)
+	}
+	return out, nil
+}
+
+func (s *TransferStore) jobByID(jobID string) (*models.TransferJob, error) {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transfer job ID: %w", err)
+	}
+	for _, job := range s.jobs {
+		if job.ID == objID {
+			return job, nil
+		}
+	}
+	return nil, nil
+}
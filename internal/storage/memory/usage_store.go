@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UsageStore implementa storage.UsageStorer en memoria.
+type UsageStore struct {
+	mu    sync.Mutex
+	usage map[primitive.ObjectID]*models.ClinicUsage
+}
+
+// NewUsageStore crea un UsageStore vacío.
+func NewUsageStore() *UsageStore {
+	return &UsageStore{usage: make(map[primitive.ObjectID]*models.ClinicUsage)}
+}
+
+var _ storage.UsageStorer = (*UsageStore)(nil)
+
+// Get devuelve una copia del uso de la clínica, a cero si no tiene contadores.
+func (s *UsageStore) Get(ctx context.Context, clinicID string) (*models.ClinicUsage, error) {
+	objID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic ID '%s': %w", clinicID, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if usage, ok := s.usage[objID]; ok {
+		clone := *usage
+		return &clone, nil
+	}
+	return &models.ClinicUsage{ClinicID: objID}, nil
+}
+
+// Add suma n al contador si cabe en el límite; se deshace si la transacción falla.
+func (s *UsageStore) Add(ctx context.Context, clinicID, resource string, n, limit int64) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return false, fmt.Errorf("invalid clinic ID '%s': %w", clinicID, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usage, ok := s.usage[objID]
+	if !ok {
+		usage = &models.ClinicUsage{ClinicID: objID}
+	}
+	counter := usageCounter(usage, resource)
+	if counter == nil {
+		return false, fmt.Errorf("unknown usage resource %q", resource)
+	}
+	if n > 0 && limit > 0 && *counter+n > limit {
+		return false, nil
+	}
+
+	old := *counter
+	*counter = max(0, old+n)
+	usage.UpdatedAt = now()
+	s.usage[objID] = usage
+
+	delta := *counter - old
+	onRollback(ctx, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if usage, ok := s.usage[objID]; ok {
+			*usageCounter(usage, resource) -= delta
+		}
+	})
+	return true, nil
+}
+
+// Set sustituye los contadores de la clínica.
+func (s *UsageStore) Set(ctx context.Context, usage *models.ClinicUsage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usage.UpdatedAt = now()
+	stored := *usage
+	s.usage[usage.ClinicID] = &stored
+	return nil
+}
+
+// Delete borra los contadores de la clínica.
+func (s *UsageStore) Delete(ctx context.Context, clinicID string) error {
+	objID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return fmt.Errorf("invalid clinic ID '%s': %w", clinicID, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.usage, objID)
+	return nil
+}
+
+// usageCounter devuelve el campo del recurso, o nil si no existe
+func usageCounter(usage *models.ClinicUsage, resource string) *int64 {
+	switch resource {
+	case models.UsageUsers:
+		return &usage.Users
+	case models.UsagePatients:
+		return &usage.Patients
+	case models.UsageStorage:
+		return &usage.StorageBytes
+	}
+	return nil
+}
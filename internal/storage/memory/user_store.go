@@ -0,0 +1,261 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UserStore implementa storage.UserStorer en memoria.
+type UserStore struct {
+	mu    sync.RWMutex
+	users []*models.User
+}
+
+// NewUserStore crea un UserStore vacío.
+func NewUserStore() *UserStore {
+	return &UserStore{}
+}
+
+var _ storage.UserStorer = (*UserStore)(nil)
+
+// Create inserta un nuevo usuario. Si user.ID viene vacío se genera uno.
+func (s *UserStore) Create(ctx context.Context, user *models.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Igual que el índice único {clinicId, email}: incluye los usuarios eliminados
+	for _, existing := range s.users {
+		if existing.ClinicID == user.ClinicID && existing.Email == user.Email {
+			return fmt.Errorf("%w: %s", storage.ErrDuplicateUserEmail, user.Email)
+		}
+	}
+
+	if user.ID.IsZero() {
+		user.ID = primitive.NewObjectID()
+	}
+	ts := now()
+	user.CreatedAt = ts
+	user.UpdatedAt = ts
+
+	stored, err := cloneUser(user)
+	if err != nil {
+		return fmt.Errorf("error al crear el usuario: %w", err)
+	}
+	s.users = append(s.users, stored)
+	onRollback(ctx, func() { s.remove(stored.ID) })
+	return nil
+}
+
+// remove borra un usuario insertado en una transacción que se revierte
+func (s *UserStore) remove(id primitive.ObjectID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users = slices.DeleteFunc(s.users, func(u *models.User) bool { return u.ID == id })
+}
+
+// FindByEmail busca un usuario por su email DENTRO de una clínica específica.
+func (s *UserStore) FindByEmail(ctx context.Context, clinicID, email string) (*models.User, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, user := range s.users {
+		if user.ClinicID == clinicObjID && user.Email == email && user.DeletedAt == nil {
+			return cloneUser(user)
+		}
+	}
+	return nil, nil // No es un error si no se encuentra.
+}
+
+// FindByID busca un usuario por su ID DENTRO de una clínica específica.
+func (s *UserStore) FindByID(ctx context.Context, clinicID, userID string) (*models.User, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de usuario inválido: %w", err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, user := range s.users {
+		if user.ID == userObjID && user.ClinicID == clinicObjID && user.DeletedAt == nil {
+			return cloneUser(user)
+		}
+	}
+	// El repositorio de Mongo también trata "no encontrado" como error aquí.
+	return nil, fmt.Errorf("error al buscar usuario por ID: usuario %s no encontrado", userID)
+}
+
+// CountByClinic cuenta los usuarios activos de una clínica.
+func (s *UserStore) CountByClinic(ctx context.Context, clinicID string) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var count int64
+	for _, user := range s.users {
+		if user.ClinicID == clinicObjID && user.DeletedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// SoftDeleteByClinic marca como eliminados los usuarios activos de una clínica;
+// se deshace si la transacción falla.
+func (s *UserStore) SoftDeleteByClinic(ctx context.Context, clinicID string, deletedAt time.Time) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	deletedAt = deletedAt.UTC().Truncate(time.Millisecond)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var count int64
+	prevUpdatedAt := make(map[*models.User]time.Time)
+	for _, user := range s.users {
+		if user.ClinicID == clinicObjID && user.DeletedAt == nil {
+			prevUpdatedAt[user] = user.UpdatedAt
+			ts := deletedAt
+			user.DeletedAt = &ts
+			user.UpdatedAt = deletedAt
+			count++
+		}
+	}
+	onRollback(ctx, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for user, updatedAt := range prevUpdatedAt {
+			user.DeletedAt = nil
+			user.UpdatedAt = updatedAt
+		}
+	})
+	return count, nil
+}
+
+// RestoreByClinic restaura los usuarios eliminados en cascada con la clínica;
+// se deshace si la transacción falla.
+func (s *UserStore) RestoreByClinic(ctx context.Context, clinicID string, deletedAt time.Time) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	deletedAt = deletedAt.UTC().Truncate(time.Millisecond)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var count int64
+	prevUpdatedAt := make(map[*models.User]time.Time)
+	for _, user := range s.users {
+		if user.ClinicID == clinicObjID && user.DeletedAt != nil && user.DeletedAt.Equal(deletedAt) {
+			prevUpdatedAt[user] = user.UpdatedAt
+			user.DeletedAt = nil
+			user.UpdatedAt = now()
+			count++
+		}
+	}
+	onRollback(ctx, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for user, updatedAt := range prevUpdatedAt {
+			ts := deletedAt
+			user.DeletedAt = &ts
+			user.UpdatedAt = updatedAt
+		}
+	})
+	return count, nil
+}
+
+// HardDeleteByClinic borra definitivamente todos los usuarios de una clínica.
+func (s *UserStore) HardDeleteByClinic(ctx context.Context, clinicID string) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.users[:0]
+	var count int64
+	for _, user := range s.users {
+		if user.ClinicID == clinicObjID {
+			count++
+			continue
+		}
+		kept = append(kept, user)
+	}
+	s.users = kept
+	return count, nil
+}
+
+// cloneUser copia el usuario pasando por BSON, igual que cloneClinic.
+func cloneUser(user *models.User) (*models.User, error) {
+	raw, err := bson.Marshal(user)
+	if err != nil {
+		return nil, err
+	}
+	var clone models.User
+	if err := bson.Unmarshal(raw, &clone); err != nil {
+		return nil, err
+	}
+	return &clone, nil
+}
+
+// StreamByClinic recorre por orden de ID los usuarios activos de una clínica.
+// Copia la selección antes de recorrerla para no retener el cerrojo mientras
+// corre each.
+func (s *UserStore) StreamByClinic(ctx context.Context, clinicID string, each func(*models.User) error) error {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	s.mu.RLock()
+	var selected []*models.User
+	for _, doc := range s.users {
+		if doc.ClinicID == clinicObjID && doc.DeletedAt == nil {
+			clone, err := cloneUser(doc)
+			if err != nil {
+				s.mu.RUnlock()
+				return err
+			}
+			selected = append(selected, clone)
+		}
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(selected, func(a, b *models.User) int { return bytes.Compare(a.ID[:], b.ID[:]) })
+	for _, doc := range selected {
+		if err := each(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// OwnerRepository implementa OwnerStorer sobre MongoDB.
+type OwnerRepository struct {
+	collection *mongo.Collection
+}
+
+// NewOwnerRepository crea una nueva instancia del repositorio de tutores.
+func NewOwnerRepository(db *mongo.Database) *OwnerRepository {
+	return &OwnerRepository{
+		collection: db.Collection("owners"),
+	}
+}
+
+// Create inserta un nuevo tutor. Si owner.ID viene vacío se genera uno.
+func (r *OwnerRepository) Create(ctx context.Context, owner *models.Owner) error {
+	if owner.ID.IsZero() {
+		owner.ID = primitive.NewObjectID()
+	}
+	now := time.Now().UTC()
+	owner.CreatedAt = now
+	owner.UpdatedAt = now
+	owner.Search = owner.SearchKeys()
+	owner.Contacts = owner.ContactKeys()
+
+	if _, err := r.collection.InsertOne(ctx, owner); err != nil {
+		return fmt.Errorf("error al crear el tutor: %w", err)
+	}
+	return nil
+}
+
+// FindByID busca un tutor por su ID DENTRO de una clínica específica.
+func (r *OwnerRepository) FindByID(ctx context.Context, clinicID, ownerID string) (*models.Owner, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+	ownerObjID, err := primitive.ObjectIDFromHex(ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de tutor inválido: %w", err)
+	}
+
+	var owner models.Owner
+	if err := r.collection.FindOne(ctx, bson.M{"_id": ownerObjID, "clinicId": clinicObjID}).Decode(&owner); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error al buscar tutor por ID: %w", err)
+	}
+	return &owner, nil
+}
+
+// FindByContact busca el tutor más antiguo de la clínica con alguna de las claves de contacto.
+func (r *OwnerRepository) FindByContact(ctx context.Context, clinicID string, keys []string) (*models.Owner, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	filter := bson.M{"clinicId": clinicObjID, "contacts": bson.M{"$in": keys}}
+	opts := options.FindOne().SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	var owner models.Owner
+	if err := r.collection.FindOne(ctx, filter, opts).Decode(&owner); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error al buscar tutor por contacto: %w", err)
+	}
+	return &owner, nil
+}
+
+// SearchCandidates preselecciona los tutores de la clínica para la búsqueda tolerante a errores.
+func (r *OwnerRepository) SearchCandidates(ctx context.Context, clinicID string, grams []string, limit int) ([]*models.Owner, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+	return findSearchCandidates[models.Owner](ctx, r.collection, bson.M{"clinicId": clinicObjID}, grams, limit)
+}
+
+// StreamByClinic recorre por orden de ID los tutores de una clínica.
+func (r *OwnerRepository) StreamByClinic(ctx context.Context, clinicID string, each func(*models.Owner) error) error {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+	return streamDocs(ctx, r.collection, bson.M{"clinicId": clinicObjID}, each)
+}
+
+// HardDeleteByClinic borra definitivamente todos los tutores de una clínica.
+func (r *OwnerRepository) HardDeleteByClinic(ctx context.Context, clinicID string) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	result, err := r.collection.DeleteMany(ctx, bson.M{"clinicId": clinicObjID})
+	if err != nil {
+		return 0, fmt.Errorf("error al borrar tutores de la clínica: %w", err)
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// OwnerStorer define las operaciones de la colección de tutores.
+// Igual que UserStorer, cada método está acotado a una clínica.
+type OwnerStorer interface {
+	Create(ctx context.Context, owner *models.Owner) error
+	FindByID(ctx context.Context, clinicID, ownerID string) (*models.Owner, error)
+	// FindByContact busca el tutor más antiguo de la clínica que comparta alguna
+	// de las claves de contacto (ver models.Owner.ContactKeys). nil si no hay.
+	FindByContact(ctx context.Context, clinicID string, keys []string) (*models.Owner, error)
+	// SearchCandidates preselecciona para la búsqueda tolerante a errores los
+	// tutores de la clínica que comparten trigramas con la consulta.
+	SearchCandidates(ctx context.Context, clinicID string, grams []string, limit int) ([]*models.Owner, error)
+	// StreamByClinic recorre por orden de ID los tutores de una clínica sin
+	// cargarlos en memoria. Si each devuelve un error el recorrido se detiene.
+	StreamByClinic(ctx context.Context, clinicID string, each func(*models.Owner) error) error
+	// HardDeleteByClinic borra definitivamente todos los tutores de una clínica.
+	HardDeleteByClinic(ctx context.Context, clinicID string) (int64, error)
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// PatientRepository implementa PatientStorer sobre MongoDB.
+type PatientRepository struct {
+	collection *mongo.Collection
+}
+
+// NewPatientRepository crea una nueva instancia del repositorio de pacientes.
+func NewPatientRepository(db *mongo.Database) *PatientRepository {
+	return &PatientRepository{
+		collection: db.Collection("patients"),
+	}
+}
+
+// Create inserta un nuevo paciente. Si patient.ID viene vacío se genera uno.
+func (r *PatientRepository) Create(ctx context.Context, patient *models.Patient) error {
+	if patient.ID.IsZero() {
+		patient.ID = primitive.NewObjectID()
+	}
+	now := time.Now().UTC()
+	patient.CreatedAt = now
+	patient.UpdatedAt = now
+	patient.Search = patient.SearchKeys()
+
+	if _, err := r.collection.InsertOne(ctx, patient); err != nil {
+		return fmt.Errorf("error al crear el paciente: %w", err)
+	}
+	return nil
+}
+
+// FindByID busca un paciente por su ID DENTRO de una clínica específica.
+func (r *PatientRepository) FindByID(ctx context.Context, clinicID, patientID string) (*models.Patient, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+	patientObjID, err := primitive.ObjectIDFromHex(patientID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de paciente inválido: %w", err)
+	}
+
+	var patient models.Patient
+	if err := r.collection.FindOne(ctx, bson.M{"_id": patientObjID, "clinicId": clinicObjID}).Decode(&patient); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error al buscar paciente por ID: %w", err)
+	}
+	return &patient, nil
+}
+
+// SearchCandidates preselecciona los pacientes de la clínica para la búsqueda tolerante a errores.
+func (r *PatientRepository) SearchCandidates(ctx context.Context, clinicID string, grams []string, limit int) ([]*models.Patient, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+	return findSearchCandidates[models.Patient](ctx, r.collection, bson.M{"clinicId": clinicObjID}, grams, limit)
+}
+
+// StreamByClinic recorre por orden de ID los pacientes de una clínica.
+func (r *PatientRepository) StreamByClinic(ctx context.Context, clinicID string, each func(*models.Patient) error) error {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+	return streamDocs(ctx, r.collection, bson.M{"clinicId": clinicObjID}, each)
+}
+
+// HardDeleteByClinic borra definitivamente todos los pacientes de una clínica.
+func (r *PatientRepository) HardDeleteByClinic(ctx context.Context, clinicID string) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	result, err := r.collection.DeleteMany(ctx, bson.M{"clinicId": clinicObjID})
+	if err != nil {
+		return 0, fmt.Errorf("error al borrar pacientes de la clínica: %w", err)
+	}
+	return result.DeletedCount, nil
+}
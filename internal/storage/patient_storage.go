@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// PatientStorer define las operaciones de la colección de pacientes.
+// Igual que UserStorer, cada método está acotado a una clínica.
+type PatientStorer interface {
+	Create(ctx context.Context, patient *models.Patient) error
+	FindByID(ctx context.Context, clinicID, patientID string) (*models.Patient, error)
+	// SearchCandidates preselecciona para la búsqueda tolerante a errores los
+	// pacientes de la clínica que comparten trigramas con la consulta.
+	SearchCandidates(ctx context.Context, clinicID string, grams []string, limit int) ([]*models.Patient, error)
+	// StreamByClinic recorre por orden de ID los pacientes de una clínica sin
+	// cargarlos en memoria. Si each devuelve un error el recorrido se detiene.
+	StreamByClinic(ctx context.Context, clinicID string, each func(*models.Patient) error) error
+	// HardDeleteByClinic borra definitivamente todos los pacientes de una clínica.
+	HardDeleteByClinic(ctx context.Context, clinicID string) (int64, error)
+}
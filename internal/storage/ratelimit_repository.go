@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RateLimitRepository implementa RateLimitStorer sobre MongoDB, para que
+// varias réplicas compartan los cupos. Los cubos caducan con un índice TTL
+// sobre expiresAt.
+type RateLimitRepository struct {
+	collection *mongo.Collection
+}
+
+// NewRateLimitRepository crea el repositorio de cubos de tokens.
+func NewRateLimitRepository(db *mongo.Database) *RateLimitRepository {
+	return &RateLimitRepository{
+		collection: db.Collection("rate_limits"),
+	}
+}
+
+// rateLimitBucket es el cubo tal como queda tras la actualización
+type rateLimitBucket struct {
+	Tokens  float64 `bson:"tokens"`
+	Allowed bool    `bson:"allowed"`
+}
+
+// Take rellena y consume el cubo en una sola actualización con pipeline, así
+// que dos réplicas no pueden gastar el mismo token.
+func (r *RateLimitRepository) Take(ctx context.Context, key string, capacity, rate float64, now time.Time) (float64, bool, error) {
+	now = now.UTC()
+	// Milisegundos desde el último uso (nunca negativos si los relojes difieren)
+	elapsed := bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{now, bson.M{"$ifNull": bson.A{"$updatedAt", now}}}}}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"tokens": bson.M{"$min": bson.A{capacity, bson.M{"$add": bson.A{
+				bson.M{"$ifNull": bson.A{"$tokens", capacity}},
+				bson.M{"$multiply": bson.A{bson.M{"$divide": bson.A{elapsed, 1000}}, rate}},
+			}}}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"allowed":   bson.M{"$gte": bson.A{"$tokens", 1}},
+			"tokens":    bson.M{"$cond": bson.A{bson.M{"$gte": bson.A{"$tokens", 1}}, bson.M{"$subtract": bson.A{"$tokens", 1}}, "$tokens"}},
+			"updatedAt": now,
+			"expiresAt": now.Add(RateLimitTTL(capacity, rate)),
+		}}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var bucket rateLimitBucket
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, pipeline, opts).Decode(&bucket)
+	if mongo.IsDuplicateKeyError(err) {
+		// Dos peticiones crearon el cubo a la vez: ahora ya existe
+		err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, pipeline, opts).Decode(&bucket)
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("error taking rate limit token: %w", err)
+	}
+	return bucket.Tokens, bucket.Allowed, nil
+}
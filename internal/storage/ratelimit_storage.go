@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimitStorer guarda los cubos de tokens de la limitación de peticiones.
+type RateLimitStorer interface {
+	// Take descuenta un token del cubo key si le queda alguno y devuelve los
+	// tokens que quedan (con decimales) y si se concedió. El cubo admite hasta
+	// capacity tokens y se rellena a rate tokens por segundo desde la última
+	// vez que se usó; un cubo nuevo empieza lleno. Es atómico aunque varias
+	// réplicas compartan el cubo.
+	Take(ctx context.Context, key string, capacity, rate float64, now time.Time) (remaining float64, allowed bool, err error)
+}
+
+// RateLimitTTL es cuánto se guarda un cubo sin usar: al terminar se habrá
+// rellenado del todo y equivale a uno nuevo.
+func RateLimitTTL(capacity, rate float64) time.Duration {
+	return time.Duration(capacity / rate * float64(time.Second))
+}
@@ -0,0 +1,128 @@
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/database"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/storage/storagetest"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Las pruebas de los repositorios necesitan un MongoDB real: se saltan si no
+// se define MONGO_URI (p. ej. MONGO_URI=mongodb://localhost:27017 go test ./...).
+
+var (
+	testClient *mongo.Client
+	testDBSeq  atomic.Int64
+)
+
+func TestMain(m *testing.M) {
+	uri := os.Getenv("MONGO_URI")
+	if uri == "" {
+		os.Exit(m.Run())
+	}
+
+	client, cleanup, err := database.Connect(uri, testLogger())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	testClient = client
+	code := m.Run()
+	cleanup()
+	os.Exit(code)
+}
+
+// newTestDB crea una base de datos vacía con las migraciones aplicadas (los
+// índices únicos forman parte de la semántica) y la borra al acabar la prueba.
+func newTestDB(t *testing.T) *mongo.Database {
+	t.Helper()
+	if testClient == nil {
+		t.Skip("MONGO_URI no definido: se omiten las pruebas contra MongoDB")
+	}
+
+	name := fmt.Sprintf("vetsify_test_%d_%d", time.Now().UnixNano(), testDBSeq.Add(1))
+	db := testClient.Database(name)
+	t.Cleanup(func() {
+		if err := db.Drop(context.Background()); err != nil {
+			t.Logf("no se pudo borrar la base de datos %s: %v", name, err)
+		}
+	})
+
+	if _, err := database.NewMigrator(db, testLogger(), database.Migrations).Up(context.Background()); err != nil {
+		t.Fatalf("migraciones: %v", err)
+	}
+	return db
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestClinicRepository(t *testing.T) {
+	storagetest.RunClinicStorerTests(t, func(t *testing.T) storage.ClinicStorer {
+		return storage.NewClinicRepository(newTestDB(t))
+	})
+}
+
+func TestUserRepository(t *testing.T) {
+	storagetest.RunUserStorerTests(t, func(t *testing.T) storage.UserStorer {
+		return storage.NewUserRepository(newTestDB(t))
+	})
+}
+
+func TestIdempotencyRepository(t *testing.T) {
+	storagetest.RunIdempotencyStorerTests(t, func(t *testing.T) storage.IdempotencyStorer {
+		return storage.NewIdempotencyRepository(newTestDB(t))
+	})
+}
+
+func TestImportRepository(t *testing.T) {
+	storagetest.RunImportStorerTests(t, func(t *testing.T) storage.ImportStorer {
+		return storage.NewImportRepository(newTestDB(t))
+	})
+}
+
+func TestTransferRepository(t *testing.T) {
+	storagetest.RunTransferStorerTests(t, func(t *testing.T) storage.TransferStorer {
+		return storage.NewTransferRepository(newTestDB(t))
+	})
+}
+
+func TestRateLimitRepository(t *testing.T) {
+	storagetest.RunRateLimitStorerTests(t, func(t *testing.T) storage.RateLimitStorer {
+		return storage.NewRateLimitRepository(newTestDB(t))
+	})
+}
+
+func TestUsageRepository(t *testing.T) {
+	storagetest.RunUsageStorerTests(t, func(t *testing.T) storage.UsageStorer {
+		return storage.NewUsageRepository(newTestDB(t))
+	})
+}
+
+func TestAttachmentRepository(t *testing.T) {
+	storagetest.RunAttachmentStorerTests(t, func(t *testing.T) storage.AttachmentStorer {
+		return storage.NewAttachmentRepository(newTestDB(t))
+	})
+}
+
+func TestOwnerRepository(t *testing.T) {
+	storagetest.RunOwnerStorerTests(t, func(t *testing.T) storage.OwnerStorer {
+		return storage.NewOwnerRepository(newTestDB(t))
+	})
+}
+
+func TestPatientRepository(t *testing.T) {
+	storagetest.RunPatientStorerTests(t, func(t *testing.T) storage.PatientStorer {
+		return storage.NewPatientRepository(newTestDB(t))
+	})
+}
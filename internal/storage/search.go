@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MaxSearchCandidates limita cuántos documentos preselecciona cada colección
+// para la búsqueda tolerante a errores.
+const MaxSearchCandidates = 200
+
+// findSearchCandidates devuelve los documentos que más trigramas comparten con
+// la consulta (índice multikey sobre search.grams). Solo preselecciona: la
+// puntuación final la calcula el paquete search.
+func findSearchCandidates[T any](ctx context.Context, coll *mongo.Collection, match bson.M, grams []string, limit int) ([]*T, error) {
+	if len(grams) == 0 {
+		return nil, nil
+	}
+	if limit <= 0 || limit > MaxSearchCandidates {
+		limit = MaxSearchCandidates
+	}
+
+	match["search.grams"] = bson.M{"$in": grams}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$addFields", Value: bson.M{"_overlap": bson.M{"$size": bson.M{"$setIntersection": bson.A{"$search.grams", grams}}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_overlap", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: int64(limit)}},
+		{{Key: "$project", Value: bson.M{"_overlap": 0}}},
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %s: %w", coll.Name(), err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []*T
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("failed to decode %s search results: %w", coll.Name(), err)
+	}
+	return docs, nil
+}
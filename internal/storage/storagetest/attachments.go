@@ -0,0 +1,140 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RunAttachmentStorerTests ejecuta la batería de conformidad de storage.AttachmentStorer.
+func RunAttachmentStorerTests(t *testing.T, newStore func(t *testing.T) storage.AttachmentStorer) {
+	t.Helper()
+	ctx := context.Background()
+
+	create := func(t *testing.T, store storage.AttachmentStorer, clinicID primitive.ObjectID, patientID *primitive.ObjectID, category, hash string) *models.Attachment {
+		t.Helper()
+		attachment := &models.Attachment{
+			ClinicID:  clinicID,
+			PatientID: patientID,
+			Category:  category,
+			Filename:  hash + ".pdf",
+			Size:      10,
+			Hash:      hash,
+			Key:       "clinics/" + clinicID.Hex() + "/" + hash,
+			Scan:      models.AttachmentScan{Status: models.ScanUnscanned},
+		}
+		if err := store.Create(ctx, attachment); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		return attachment
+	}
+
+	t.Run("CreateAndFindWithinClinic", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		attachment := create(t, store, clinicID, nil, models.AttachmentDocument, "aa11")
+		if attachment.ID.IsZero() || attachment.CreatedAt.IsZero() {
+			t.Fatalf("esperado ID y fecha de creación, obtenido %+v", attachment)
+		}
+
+		found, err := store.FindByID(ctx, clinicID.Hex(), attachment.ID.Hex())
+		if err != nil || found == nil || found.Hash != "aa11" || found.Key != attachment.Key || found.Scan.Status != models.ScanUnscanned {
+			t.Fatalf("esperado el adjunto guardado, obtenido %v, %+v", err, found)
+		}
+		if other, err := store.FindByID(ctx, primitive.NewObjectID().Hex(), attachment.ID.Hex()); err != nil || other != nil {
+			t.Fatalf("esperado nil desde otra clínica, obtenido %v, %+v", err, other)
+		}
+		if _, err := store.FindByID(ctx, clinicID.Hex(), "no-es-un-id"); err == nil {
+			t.Fatal("esperado error con un ID inválido")
+		}
+	})
+
+	t.Run("FindAndCountByHash", func(t *testing.T) {
+		store := newStore(t)
+		clinicID, otherClinic := primitive.NewObjectID(), primitive.NewObjectID()
+		first := create(t, store, clinicID, nil, models.AttachmentDocument, "bb22")
+		create(t, store, clinicID, nil, models.AttachmentLabResult, "bb22")
+		create(t, store, otherClinic, nil, models.AttachmentDocument, "bb22")
+
+		found, err := store.FindByHash(ctx, clinicID.Hex(), "bb22")
+		if err != nil || found == nil || found.ID != first.ID {
+			t.Fatalf("esperado el primer adjunto con el hash, obtenido %v, %+v", err, found)
+		}
+		if count, err := store.CountByHash(ctx, clinicID.Hex(), "bb22"); err != nil || count != 2 {
+			t.Fatalf("esperados 2 adjuntos con el hash en la clínica, obtenido %d (%v)", count, err)
+		}
+		if missing, err := store.FindByHash(ctx, clinicID.Hex(), "cc33"); err != nil || missing != nil {
+			t.Fatalf("esperado nil con un hash sin adjuntos, obtenido %v, %+v", err, missing)
+		}
+	})
+
+	t.Run("ListFiltersNewestFirst", func(t *testing.T) {
+		store := newStore(t)
+		clinicID, patientID := primitive.NewObjectID(), primitive.NewObjectID()
+		a := create(t, store, clinicID, &patientID, models.AttachmentLabResult, "01")
+		b := create(t, store, clinicID, nil, models.AttachmentDocument, "02")
+		c := create(t, store, clinicID, &patientID, models.AttachmentImaging, "03")
+		create(t, store, primitive.NewObjectID(), &patientID, models.AttachmentImaging, "04")
+
+		all, err := store.List(ctx, clinicID.Hex(), storage.AttachmentFilter{})
+		if err != nil || len(all) != 3 || all[0].ID != c.ID || all[2].ID != a.ID {
+			t.Fatalf("esperados 3 adjuntos del más reciente al más antiguo, obtenido %v, %d", err, len(all))
+		}
+		byPatient, err := store.List(ctx, clinicID.Hex(), storage.AttachmentFilter{PatientID: patientID.Hex()})
+		if err != nil || len(byPatient) != 2 || byPatient[0].ID != c.ID || byPatient[1].ID != a.ID {
+			t.Fatalf("esperados los 2 adjuntos del paciente, obtenido %v, %d", err, len(byPatient))
+		}
+		byCategory, err := store.List(ctx, clinicID.Hex(), storage.AttachmentFilter{Category: models.AttachmentDocument})
+		if err != nil || len(byCategory) != 1 || byCategory[0].ID != b.ID {
+			t.Fatalf("esperado el documento, obtenido %v, %d", err, len(byCategory))
+		}
+		limited, err := store.List(ctx, clinicID.Hex(), storage.AttachmentFilter{Limit: 1})
+		if err != nil || len(limited) != 1 || limited[0].ID != c.ID {
+			t.Fatalf("esperado solo el más reciente, obtenido %v, %d", err, len(limited))
+		}
+		if empty, err := store.List(ctx, primitive.NewObjectID().Hex(), storage.AttachmentFilter{}); err != nil || empty == nil || len(empty) != 0 {
+			t.Fatalf("esperada una lista vacía (no nil), obtenido %v, %v", err, empty)
+		}
+	})
+
+	t.Run("DeleteReturnsAttachment", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		attachment := create(t, store, clinicID, nil, models.AttachmentDocument, "dd44")
+
+		if deleted, err := store.Delete(ctx, primitive.NewObjectID().Hex(), attachment.ID.Hex()); err != nil || deleted != nil {
+			t.Fatalf("esperado nil al borrar desde otra clínica, obtenido %v, %+v", err, deleted)
+		}
+		deleted, err := store.Delete(ctx, clinicID.Hex(), attachment.ID.Hex())
+		if err != nil || deleted == nil || deleted.Hash != "dd44" {
+			t.Fatalf("esperado el adjunto borrado, obtenido %v, %+v", err, deleted)
+		}
+		if again, err := store.Delete(ctx, clinicID.Hex(), attachment.ID.Hex()); err != nil || again != nil {
+			t.Fatalf("esperado nil al borrar dos veces, obtenido %v, %+v", err, again)
+		}
+		if count, err := store.CountByHash(ctx, clinicID.Hex(), "dd44"); err != nil || count != 0 {
+			t.Fatalf("esperados 0 adjuntos tras borrar, obtenido %d (%v)", count, err)
+		}
+	})
+	t.Run("DeleteByClinic", func(t *testing.T) {
+		store := newStore(t)
+		clinicID, otherClinic := primitive.NewObjectID(), primitive.NewObjectID()
+		create(t, store, clinicID, nil, models.AttachmentDocument, "ee55")
+		create(t, store, clinicID, nil, models.AttachmentLabResult, "ff66")
+		other := create(t, store, otherClinic, nil, models.AttachmentDocument, "ee55")
+
+		deleted, err := store.DeleteByClinic(ctx, clinicID.Hex())
+		if err != nil || deleted != 2 {
+			t.Fatalf("esperados 2 adjuntos borrados, obtenido %d (%v)", deleted, err)
+		}
+		if left, err := store.List(ctx, clinicID.Hex(), storage.AttachmentFilter{}); err != nil || len(left) != 0 {
+			t.Fatalf("no deben quedar adjuntos de la clínica, obtenido %v, %d", err, len(left))
+		}
+		if found, err := store.FindByID(ctx, otherClinic.Hex(), other.ID.Hex()); err != nil || found == nil {
+			t.Fatalf("los adjuntos de otras clínicas no se tocan: %v, %+v", err, found)
+		}
+	})
+}
@@ -0,0 +1,575 @@
+// Package storagetest contiene las pruebas de conformidad que toda
+// implementación de los Storer de storage debe pasar (Mongo y memoria), para
+// garantizar que los backends son intercambiables.
+//
+// Se usa desde el _test.go de cada backend:
+//
+//	func TestClinicStore(t *testing.T) {
+//		storagetest.RunClinicStorerTests(t, func(t *testing.T) storage.ClinicStorer {
+//			return memory.NewClinicStore()
+//		})
+//	}
+//
+// newStore debe devolver un store vacío en cada llamada. Para Mongo eso implica
+// una base de datos nueva por prueba con las migraciones aplicadas (los índices
+// únicos forman parte de la semántica).
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/pagination"
+	"github.com/zabaletac3/go-vet-api/internal/search"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/dto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RunClinicStorerTests ejecuta la batería de conformidad de storage.ClinicStorer.
+func RunClinicStorerTests(t *testing.T, newStore func(t *testing.T) storage.ClinicStorer) {
+	t.Helper()
+
+	t.Run("CreateSetsDefaults", func(t *testing.T) {
+		store := newStore(t)
+		clinic := &models.Clinic{Name: "vet-a", DisplayName: "Vet A"}
+
+		if err := store.Create(context.Background(), clinic); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if clinic.ID.IsZero() {
+			t.Fatal("Create no asignó ID")
+		}
+		if !clinic.IsActive || clinic.DeletedAt != nil || clinic.Version != 1 {
+			t.Fatalf("Create debe dejar la clínica activa, sin eliminar y en la versión 1: %+v", clinic)
+		}
+		if clinic.CreatedAt.IsZero() || clinic.UpdatedAt.IsZero() {
+			t.Fatal("Create no asignó timestamps")
+		}
+
+		got := mustGetClinic(t, store, clinic.ID.Hex())
+		if got.Palette != models.GetDefaultPalette() {
+			t.Fatalf("paleta por defecto esperada, obtenida %+v", got.Palette)
+		}
+	})
+
+	t.Run("CreateValidates", func(t *testing.T) {
+		store := newStore(t)
+		err := store.Create(context.Background(), &models.Clinic{Name: "x", DisplayName: "Vet"})
+		if !errors.Is(err, models.ErrInvalidClinicNameLength) {
+			t.Fatalf("esperado ErrInvalidClinicNameLength, obtenido %v", err)
+		}
+	})
+
+	t.Run("CreateRejectsDuplicatesCaseInsensitive", func(t *testing.T) {
+		store := newStore(t)
+		mustCreateClinic(t, store, "vet-a", "Vet A")
+
+		err := store.Create(context.Background(), &models.Clinic{Name: "VET-A", DisplayName: "Other"})
+		if !errors.Is(err, storage.ErrDuplicateClinicName) {
+			t.Fatalf("esperado ErrDuplicateClinicName, obtenido %v", err)
+		}
+		err = store.Create(context.Background(), &models.Clinic{Name: "vet-b", DisplayName: "vet a"})
+		if !errors.Is(err, storage.ErrDuplicateClinicDisplayName) {
+			t.Fatalf("esperado ErrDuplicateClinicDisplayName, obtenido %v", err)
+		}
+	})
+
+	t.Run("DeletedNamesStayReserved", func(t *testing.T) {
+		store := newStore(t)
+		clinic := mustCreateClinic(t, store, "vet-a", "Vet A")
+		mustDeleteClinic(t, store, clinic.ID.Hex())
+
+		err := store.Create(context.Background(), &models.Clinic{Name: "vet-a", DisplayName: "Vet A2"})
+		if !errors.Is(err, storage.ErrDuplicateClinicName) {
+			t.Fatalf("el nombre de una clínica en la papelera debe seguir reservado, obtenido %v", err)
+		}
+	})
+
+	t.Run("GetByIDErrors", func(t *testing.T) {
+		store := newStore(t)
+
+		_, err := store.GetByID(context.Background(), "not-an-id")
+		if err == nil || !strings.Contains(err.Error(), "invalid") {
+			t.Fatalf("esperado error 'invalid', obtenido %v", err)
+		}
+		_, err = store.GetByID(context.Background(), primitive.NewObjectID().Hex())
+		if err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Fatalf("esperado error 'not found', obtenido %v", err)
+		}
+	})
+
+	t.Run("GetByNameIsCaseInsensitiveAndExcludesDeleted", func(t *testing.T) {
+		store := newStore(t)
+		clinic := mustCreateClinic(t, store, "vet-a", "Vet A")
+
+		got, err := store.GetByName(context.Background(), "VET-A")
+		if err != nil || got == nil || got.ID != clinic.ID {
+			t.Fatalf("GetByName: %v, %+v", err, got)
+		}
+		got, err = store.GetByDisplayName(context.Background(), "vet a")
+		if err != nil || got == nil || got.ID != clinic.ID {
+			t.Fatalf("GetByDisplayName: %v, %+v", err, got)
+		}
+		got, err = store.GetByName(context.Background(), "missing")
+		if err != nil || got != nil {
+			t.Fatalf("GetByName de una clínica inexistente debe devolver nil, nil: %v, %+v", err, got)
+		}
+
+		mustDeleteClinic(t, store, clinic.ID.Hex())
+		got, err = store.GetByName(context.Background(), "vet-a")
+		if err != nil || got != nil {
+			t.Fatalf("GetByName no debe devolver clínicas eliminadas: %v, %+v", err, got)
+		}
+	})
+
+	t.Run("GetByNameIsLiteral", func(t *testing.T) {
+		store := newStore(t)
+		mustCreateClinic(t, store, "abc", "Vet (a+)+")
+
+		for _, name := range []string{"a.c", "a.*", "^abc$", "(a+)+$"} {
+			got, err := store.GetByName(context.Background(), name)
+			if err != nil || got != nil {
+				t.Fatalf("GetByName(%q) debe comparar literalmente: %v, %+v", name, err, got)
+			}
+		}
+		got, err := store.GetByDisplayName(context.Background(), "VET (A+)+")
+		if err != nil || got == nil || got.Name != "abc" {
+			t.Fatalf("GetByDisplayName con metacaracteres: %v, %+v", err, got)
+		}
+	})
+
+	t.Run("SearchIsLiteralAndRanked", func(t *testing.T) {
+		store := newStore(t)
+		mustCreateClinic(t, store, "centro-canino", "Centro Canino")
+		mustCreateClinic(t, store, "clinica-felina", "Clínica Felina")
+		other := &models.Clinic{Name: "patitas", DisplayName: "Patitas", Description: "Atención canina y felina"}
+		if err := store.Create(context.Background(), other); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		find := func(term string) []*models.Clinic {
+			t.Helper()
+			page, _, err := store.List(context.Background(), storage.ListFilters{
+				Limit: 10, Search: term, Sort: []dto.SortField{dto.Relevance, {Field: "name", Column: "name"}},
+			})
+			if err != nil {
+				t.Fatalf("List(search=%q): %v", term, err)
+			}
+			return page
+		}
+
+		// Palabras completas, sin distinguir mayúsculas ni acentos
+		assertNames(t, find("CLINICA"), "clinica-felina")
+		// Coincidir en el nombre pesa más que en la descripción
+		assertNames(t, find("felina"), "clinica-felina", "patitas")
+		// Los metacaracteres no se interpretan
+		assertNames(t, find("(a+)+$"))
+		assertNames(t, find(".*"), "centro-canino", "clinica-felina", "patitas")
+	})
+
+	t.Run("SearchCandidatesShareTrigrams", func(t *testing.T) {
+		store := newStore(t)
+		patitas := mustCreateClinic(t, store, "patitas", "Veterinaria Patitas")
+		mustCreateClinic(t, store, "bigotes", "Bigotes")
+		deleted := mustCreateClinic(t, store, "patas", "Patas")
+		mustDeleteClinic(t, store, deleted.ID.Hex())
+
+		// "patiats": una transposición
+		got, err := store.SearchCandidates(context.Background(), search.Grams([]string{"patiats"}), 10)
+		if err != nil {
+			t.Fatalf("SearchCandidates: %v", err)
+		}
+		if len(got) != 1 || got[0].ID != patitas.ID {
+			t.Fatalf("se esperaba solo la clínica activa patitas, obtenidas %v", got)
+		}
+		if len(got[0].Search.Terms) == 0 {
+			t.Fatal("las claves de búsqueda deben guardarse al crear")
+		}
+
+		// Renombrar recalcula las claves
+		if err := store.Update(context.Background(), patitas.ID.Hex(), 0, map[string]interface{}{"name": "huellitas", "displayName": "Huellitas"}); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		got, err = store.SearchCandidates(context.Background(), search.Grams([]string{"huellitas"}), 10)
+		if err != nil || len(got) == 0 || got[0].ID != patitas.ID {
+			t.Fatalf("SearchCandidates tras renombrar: %v, %v", err, got)
+		}
+		if strings.Join(got[0].Search.Terms, " ") != "huellitas" {
+			t.Fatalf("las claves de búsqueda deben recalcularse al renombrar, obtenidas %v", got[0].Search.Terms)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		store := newStore(t)
+		clinic := mustCreateClinic(t, store, "vet-a", "Vet A")
+		mustCreateClinic(t, store, "vet-b", "Vet B")
+		id := clinic.ID.Hex()
+
+		time.Sleep(2 * time.Millisecond)
+		if err := store.Update(context.Background(), id, 0, map[string]interface{}{"address": "Calle 1 # 2-3"}); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		got := mustGetClinic(t, store, id)
+		if got.Address != "Calle 1 # 2-3" || got.Name != "vet-a" {
+			t.Fatalf("Update debe cambiar solo los campos enviados: %+v", got)
+		}
+		if !got.UpdatedAt.After(got.CreatedAt) {
+			t.Fatal("Update no actualizó updatedAt")
+		}
+
+		err := store.Update(context.Background(), id, 0, map[string]interface{}{"name": "VET-B"})
+		if !errors.Is(err, storage.ErrDuplicateClinicName) {
+			t.Fatalf("esperado ErrDuplicateClinicName, obtenido %v", err)
+		}
+		err = store.Update(context.Background(), id, 0, map[string]interface{}{})
+		if err == nil {
+			t.Fatal("Update sin campos debe fallar")
+		}
+		err = store.Update(context.Background(), primitive.NewObjectID().Hex(), 0, map[string]interface{}{"phone": "1234567"})
+		if err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Fatalf("esperado error 'not found', obtenido %v", err)
+		}
+	})
+
+	t.Run("OptimisticConcurrency", func(t *testing.T) {
+		store := newStore(t)
+		clinic := mustCreateClinic(t, store, "vet-a", "Vet A")
+		id := clinic.ID.Hex()
+
+		if err := store.Update(context.Background(), id, 1, map[string]interface{}{"phone": "1234567"}); err != nil {
+			t.Fatalf("Update con la versión actual: %v", err)
+		}
+		if got := mustGetClinic(t, store, id); got.Version != 2 {
+			t.Fatalf("Update debe incrementar la versión, obtenida %d", got.Version)
+		}
+
+		err := store.Update(context.Background(), id, 1, map[string]interface{}{"phone": "7654321"})
+		if !errors.Is(err, storage.ErrVersionConflict) {
+			t.Fatalf("esperado ErrVersionConflict, obtenido %v", err)
+		}
+		if got := mustGetClinic(t, store, id); got.Phone != "1234567" {
+			t.Fatalf("una escritura con versión vieja no debe aplicarse: %+v", got)
+		}
+		err = store.Update(context.Background(), primitive.NewObjectID().Hex(), 1, map[string]interface{}{"phone": "7654321"})
+		if err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Fatalf("una clínica inexistente es 'not found', no un conflicto: %v", err)
+		}
+
+		if err := store.Delete(context.Background(), id, 1); !errors.Is(err, storage.ErrVersionConflict) {
+			t.Fatalf("esperado ErrVersionConflict al eliminar, obtenido %v", err)
+		}
+		if err := store.Delete(context.Background(), id, 2); err != nil {
+			t.Fatalf("Delete con la versión actual: %v", err)
+		}
+		if err := store.Restore(context.Background(), id); err != nil {
+			t.Fatalf("Restore: %v", err)
+		}
+		if got := mustGetClinic(t, store, id); got.Version != 4 {
+			t.Fatalf("Delete y Restore deben incrementar la versión, obtenida %d", got.Version)
+		}
+	})
+
+	t.Run("SoftDeleteAndRestore", func(t *testing.T) {
+		store := newStore(t)
+		clinic := mustCreateClinic(t, store, "vet-a", "Vet A")
+		id := clinic.ID.Hex()
+		mustDeleteClinic(t, store, id)
+
+		if _, err := store.GetByID(context.Background(), id); err == nil {
+			t.Fatal("GetByID no debe devolver clínicas eliminadas")
+		}
+		if exists, err := store.Exists(context.Background(), id); err != nil || exists {
+			t.Fatalf("Exists debe ser false tras eliminar: %v, %v", exists, err)
+		}
+		if err := store.Delete(context.Background(), id, 0); err == nil {
+			t.Fatal("eliminar dos veces debe fallar")
+		}
+
+		deleted, err := store.GetDeletedByID(context.Background(), id)
+		if err != nil || deleted.DeletedAt == nil {
+			t.Fatalf("GetDeletedByID: %v, %+v", err, deleted)
+		}
+
+		if err := store.Restore(context.Background(), id); err != nil {
+			t.Fatalf("Restore: %v", err)
+		}
+		if got := mustGetClinic(t, store, id); got.DeletedAt != nil {
+			t.Fatalf("Restore no quitó deletedAt: %+v", got)
+		}
+		if err := store.Restore(context.Background(), id); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Fatalf("restaurar una clínica activa debe fallar con 'not found', obtenido %v", err)
+		}
+	})
+
+	t.Run("HardDeleteOnlyFromTrash", func(t *testing.T) {
+		store := newStore(t)
+		clinic := mustCreateClinic(t, store, "vet-a", "Vet A")
+		id := clinic.ID.Hex()
+
+		if err := store.HardDelete(context.Background(), id); err == nil {
+			t.Fatal("HardDelete de una clínica activa debe fallar")
+		}
+		mustDeleteClinic(t, store, id)
+		if err := store.HardDelete(context.Background(), id); err != nil {
+			t.Fatalf("HardDelete: %v", err)
+		}
+		if _, err := store.GetDeletedByID(context.Background(), id); err == nil {
+			t.Fatal("la clínica sigue en la papelera tras HardDelete")
+		}
+	})
+
+	t.Run("ListDeletedBefore", func(t *testing.T) {
+		store := newStore(t)
+		clinic := mustCreateClinic(t, store, "vet-a", "Vet A")
+		mustCreateClinic(t, store, "vet-b", "Vet B")
+		mustDeleteClinic(t, store, clinic.ID.Hex())
+
+		old, err := store.ListDeletedBefore(context.Background(), time.Now().Add(-time.Hour))
+		if err != nil || len(old) != 0 {
+			t.Fatalf("no debe haber clínicas eliminadas hace más de una hora: %v, %d", err, len(old))
+		}
+		recent, err := store.ListDeletedBefore(context.Background(), time.Now().Add(time.Hour))
+		if err != nil || len(recent) != 1 || recent[0].ID != clinic.ID {
+			t.Fatalf("ListDeletedBefore: %v, %+v", err, recent)
+		}
+	})
+
+	t.Run("ListFiltersSortsAndPaginates", func(t *testing.T) {
+		store := newStore(t)
+		for _, name := range []string{"charlie", "alpha", "bravo", "delta"} {
+			mustCreateClinic(t, store, name, "Vet "+name)
+		}
+		deleted := mustCreateClinic(t, store, "echo", "Vet echo")
+		mustDeleteClinic(t, store, deleted.ID.Hex())
+		inactive := false
+		if err := store.Update(context.Background(), mustGetByName(t, store, "delta").ID.Hex(), 0, map[string]interface{}{"isActive": inactive}); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+
+		clinics, total, err := store.List(context.Background(), storage.ListFilters{Page: 1, Limit: 10, Sort: byName})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		assertNames(t, clinics, "alpha", "bravo", "charlie", "delta")
+		if total != 4 {
+			t.Fatalf("total esperado 4, obtenido %d", total)
+		}
+
+		clinics, total, err = store.List(context.Background(), storage.ListFilters{Page: 2, Limit: 3, Sort: byNameDesc})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		assertNames(t, clinics, "alpha")
+		if total != 4 {
+			t.Fatalf("total esperado 4 en página 2, obtenido %d", total)
+		}
+
+		clinics, _, err = store.List(context.Background(), storage.ListFilters{Page: 1, Limit: 10, Sort: byName, Search: "BRAVO"})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		assertNames(t, clinics, "bravo")
+
+		clinics, _, err = store.List(context.Background(), storage.ListFilters{Page: 1, Limit: 10, Sort: byName, Filters: []dto.Filter{
+			{Field: "isActive", Column: "isActive", Op: dto.OpEq, Value: inactive},
+		}})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		assertNames(t, clinics, "delta")
+
+		clinics, total, err = store.ListDeleted(context.Background(), storage.ListFilters{Page: 1, Limit: 10, Sort: []dto.SortField{{Field: "deletedAt", Column: "deletedAt"}}})
+		if err != nil {
+			t.Fatalf("ListDeleted: %v", err)
+		}
+		assertNames(t, clinics, "echo")
+		if total != 1 {
+			t.Fatalf("total de papelera esperado 1, obtenido %d", total)
+		}
+	})
+
+	t.Run("StreamIgnoresPaginationAndStops", func(t *testing.T) {
+		store := newStore(t)
+		for _, name := range []string{"charlie", "alpha", "bravo"} {
+			mustCreateClinic(t, store, name, "Vet "+name)
+		}
+		deleted := mustCreateClinic(t, store, "delta", "Vet delta")
+		mustDeleteClinic(t, store, deleted.ID.Hex())
+
+		var streamed []*models.Clinic
+		collect := func(c *models.Clinic) error {
+			streamed = append(streamed, c)
+			return nil
+		}
+		if err := store.StreamList(context.Background(), storage.ListFilters{Page: 2, Limit: 1, Sort: byNameDesc}, collect); err != nil {
+			t.Fatalf("StreamList: %v", err)
+		}
+		assertNames(t, streamed, "charlie", "bravo", "alpha")
+
+		streamed = nil
+		if err := store.StreamDeleted(context.Background(), storage.ListFilters{Sort: byName}, collect); err != nil {
+			t.Fatalf("StreamDeleted: %v", err)
+		}
+		assertNames(t, streamed, "delta")
+
+		stop := errors.New("stop")
+		streamed = nil
+		err := store.StreamList(context.Background(), storage.ListFilters{Sort: byName}, func(c *models.Clinic) error {
+			streamed = append(streamed, c)
+			return stop
+		})
+		if !errors.Is(err, stop) {
+			t.Fatalf("StreamList debe devolver el error de each, obtenido %v", err)
+		}
+		assertNames(t, streamed, "alpha")
+	})
+
+	t.Run("ListKeyset", func(t *testing.T) {
+		store := newStore(t)
+		clinics := map[string]*models.Clinic{}
+		for _, name := range []string{"charlie", "alpha", "echo", "bravo", "delta"} {
+			clinics[name] = mustCreateClinic(t, store, name, "Vet "+name)
+		}
+		after := func(name string, sort []dto.SortField, backward bool) *pagination.Cursor {
+			return &pagination.Cursor{Sort: dto.SortString(sort), Values: []interface{}{name}, ID: clinics[name].ID, Backward: backward}
+		}
+
+		page, total, err := store.List(context.Background(), storage.ListFilters{Limit: 2, Sort: byName, After: after("bravo", byName, false)})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		assertNames(t, page, "charlie", "delta")
+		if total != 5 {
+			t.Fatalf("el total no depende del cursor: esperado 5, obtenido %d", total)
+		}
+
+		page, _, err = store.List(context.Background(), storage.ListFilters{Limit: 2, Sort: byName, After: after("delta", byName, true)})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		assertNames(t, page, "bravo", "charlie")
+
+		page, total, err = store.List(context.Background(), storage.ListFilters{Limit: 5, Sort: byNameDesc, After: after("charlie", byNameDesc, false), SkipCount: true})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		assertNames(t, page, "bravo", "alpha")
+		if total != 0 {
+			t.Fatalf("con SkipCount el total debe ser 0, obtenido %d", total)
+		}
+	})
+
+	t.Run("ListQueryFiltersAndMultiSort", func(t *testing.T) {
+		store := newStore(t)
+		clinics := map[string]*models.Clinic{}
+		for _, name := range []string{"alpha", "bravo", "charlie", "delta", "echo"} {
+			clinics[name] = mustCreateClinic(t, store, name, "Vet "+name)
+		}
+		for _, name := range []string{"bravo", "delta"} {
+			if err := store.Update(context.Background(), clinics[name].ID.Hex(), 0, map[string]interface{}{"isActive": false}); err != nil {
+				t.Fatalf("Update: %v", err)
+			}
+		}
+		if err := store.Update(context.Background(), clinics["echo"].ID.Hex(), 0, map[string]interface{}{"website": "https://echo.vet"}); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+
+		list := func(filters storage.ListFilters) []*models.Clinic {
+			t.Helper()
+			filters.Limit = 10
+			if filters.Sort == nil {
+				filters.Sort = byName
+			}
+			page, _, err := store.List(context.Background(), filters)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			return page
+		}
+		filter := func(column, op string, value interface{}) []dto.Filter {
+			return []dto.Filter{{Field: column, Column: column, Op: op, Value: value}}
+		}
+
+		assertNames(t, list(storage.ListFilters{Filters: filter("name", dto.OpIn, []interface{}{"alpha", "bravo", "zulu"})}), "alpha", "bravo")
+		assertNames(t, list(storage.ListFilters{Filters: filter("name", dto.OpNe, "alpha")}), "bravo", "charlie", "delta", "echo")
+		assertNames(t, list(storage.ListFilters{Filters: filter("website", dto.OpExists, true)}), "echo")
+		assertNames(t, list(storage.ListFilters{Filters: filter("website", dto.OpNe, "https://echo.vet")}), "alpha", "bravo", "charlie", "delta")
+		assertNames(t, list(storage.ListFilters{Filters: filter("version", dto.OpGte, int64(2))}), "bravo", "delta", "echo")
+		assertNames(t, list(storage.ListFilters{Filters: append(
+			filter("createdAt", dto.OpGte, clinics["alpha"].CreatedAt),
+			filter("isActive", dto.OpEq, true)...,
+		)}), "alpha", "charlie", "echo")
+
+		activeFirst := []dto.SortField{{Field: "isActive", Column: "isActive", Desc: true}, {Field: "name", Column: "name"}}
+		assertNames(t, list(storage.ListFilters{Sort: activeFirst}), "alpha", "charlie", "echo", "bravo", "delta")
+
+		at := func(name string, active, backward bool) *pagination.Cursor {
+			return &pagination.Cursor{Sort: dto.SortString(activeFirst), Values: []interface{}{active, name}, ID: clinics[name].ID, Backward: backward}
+		}
+		page, _, err := store.List(context.Background(), storage.ListFilters{Limit: 2, Sort: activeFirst, After: at("charlie", true, false)})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		assertNames(t, page, "echo", "bravo")
+		page, _, err = store.List(context.Background(), storage.ListFilters{Limit: 2, Sort: activeFirst, After: at("bravo", false, true)})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		assertNames(t, page, "charlie", "echo")
+	})
+}
+
+var (
+	byName     = []dto.SortField{{Field: "name", Column: "name"}}
+	byNameDesc = []dto.SortField{{Field: "name", Column: "name", Desc: true}}
+)
+
+func mustCreateClinic(t *testing.T, store storage.ClinicStorer, name, displayName string) *models.Clinic {
+	t.Helper()
+	clinic := &models.Clinic{Name: name, DisplayName: displayName}
+	if err := store.Create(context.Background(), clinic); err != nil {
+		t.Fatalf("Create(%s): %v", name, err)
+	}
+	return clinic
+}
+
+func mustGetClinic(t *testing.T, store storage.ClinicStorer, id string) *models.Clinic {
+	t.Helper()
+	clinic, err := store.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetByID(%s): %v", id, err)
+	}
+	return clinic
+}
+
+func mustGetByName(t *testing.T, store storage.ClinicStorer, name string) *models.Clinic {
+	t.Helper()
+	clinic, err := store.GetByName(context.Background(), name)
+	if err != nil || clinic == nil {
+		t.Fatalf("GetByName(%s): %v, %+v", name, err, clinic)
+	}
+	return clinic
+}
+
+func mustDeleteClinic(t *testing.T, store storage.ClinicStorer, id string) {
+	t.Helper()
+	if err := store.Delete(context.Background(), id, 0); err != nil {
+		t.Fatalf("Delete(%s): %v", id, err)
+	}
+}
+
+func assertNames(t *testing.T, clinics []*models.Clinic, want ...string) {
+	t.Helper()
+	got := make([]string, len(clinics))
+	for i, clinic := range clinics {
+		got[i] = clinic.Name
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("clínicas esperadas %v, obtenidas %v", want, got)
+	}
+}
@@ -0,0 +1,134 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+// RunIdempotencyStorerTests ejecuta la batería de conformidad de storage.IdempotencyStorer.
+func RunIdempotencyStorerTests(t *testing.T, newStore func(t *testing.T) storage.IdempotencyStorer) {
+	t.Helper()
+	ctx := context.Background()
+
+	newRecord := func(id, fingerprint string, lock time.Duration) *models.IdempotencyRecord {
+		ts := time.Now().UTC().Truncate(time.Millisecond)
+		return &models.IdempotencyRecord{
+			ID:          id,
+			Fingerprint: fingerprint,
+			Status:      models.IdempotencyProcessing,
+			Owner:       fingerprint,
+			LockedUntil: ts.Add(lock),
+			CreatedAt:   ts,
+			ExpiresAt:   ts.Add(time.Hour),
+		}
+	}
+
+	t.Run("ReserveCompleteReplay", func(t *testing.T) {
+		store := newStore(t)
+		id := storage.IdempotencyID("POST", "/api/v1/clinics", "k1")
+
+		existing, err := store.Reserve(ctx, newRecord(id, "fp", time.Minute))
+		if err != nil || existing != nil {
+			t.Fatalf("primera reserva: %v, %+v", err, existing)
+		}
+
+		existing, err = store.Reserve(ctx, newRecord(id, "fp", time.Minute))
+		if err != nil || existing == nil || existing.Status != models.IdempotencyProcessing {
+			t.Fatalf("esperado registro en curso, obtenido %v, %+v", err, existing)
+		}
+
+		resp := models.CachedResponse{Status: 201, Header: map[string]string{"ETag": `"1"`}, Body: []byte(`{"ok":true}`)}
+		if err := store.Complete(ctx, id, "fp", resp); err != nil {
+			t.Fatalf("Complete: %v", err)
+		}
+
+		existing, err = store.Reserve(ctx, newRecord(id, "other", time.Minute))
+		if err != nil || existing == nil || existing.Status != models.IdempotencyCompleted {
+			t.Fatalf("esperado registro completado, obtenido %v, %+v", err, existing)
+		}
+		if existing.Fingerprint != "fp" || existing.Response == nil || existing.Response.Status != 201 ||
+			string(existing.Response.Body) != `{"ok":true}` || existing.Response.Header["ETag"] != `"1"` {
+			t.Fatalf("registro no conserva la petición original: %+v", existing)
+		}
+	})
+
+	t.Run("ReleaseAllowsRetry", func(t *testing.T) {
+		store := newStore(t)
+		id := storage.IdempotencyID("POST", "/api/v1/clinics", "k2")
+
+		if _, err := store.Reserve(ctx, newRecord(id, "fp", time.Minute)); err != nil {
+			t.Fatalf("Reserve: %v", err)
+		}
+		if err := store.Release(ctx, id, "fp"); err != nil {
+			t.Fatalf("Release: %v", err)
+		}
+		existing, err := store.Reserve(ctx, newRecord(id, "fp", time.Minute))
+		if err != nil || existing != nil {
+			t.Fatalf("esperada nueva reserva tras Release, obtenido %v, %+v", err, existing)
+		}
+	})
+
+	t.Run("AbandonedReservationIsTakenOver", func(t *testing.T) {
+		store := newStore(t)
+		id := storage.IdempotencyID("POST", "/api/v1/clinics", "k3")
+
+		if _, err := store.Reserve(ctx, newRecord(id, "fp", -time.Second)); err != nil {
+			t.Fatalf("Reserve: %v", err)
+		}
+		existing, err := store.Reserve(ctx, newRecord(id, "fp2", time.Minute))
+		if err != nil || existing != nil {
+			t.Fatalf("esperada toma de la reserva abandonada, obtenido %v, %+v", err, existing)
+		}
+		existing, _ = store.Reserve(ctx, newRecord(id, "fp3", time.Minute))
+		if existing == nil || existing.Fingerprint != "fp2" {
+			t.Fatalf("la reserva tomada debe ser la nueva: %+v", existing)
+		}
+	})
+
+	t.Run("StaleOwnerCannotCompleteOrRelease", func(t *testing.T) {
+		store := newStore(t)
+		id := storage.IdempotencyID("POST", "/api/v1/clinics", "k4")
+
+		if _, err := store.Reserve(ctx, newRecord(id, "fp", -time.Second)); err != nil {
+			t.Fatalf("Reserve: %v", err)
+		}
+		if existing, err := store.Reserve(ctx, newRecord(id, "fp2", time.Minute)); err != nil || existing != nil {
+			t.Fatalf("esperada toma de la reserva abandonada, obtenido %v, %+v", err, existing)
+		}
+
+		// La petición original termina tarde: no debe tocar la reserva nueva
+		if err := store.Complete(ctx, id, "fp", models.CachedResponse{Status: 201}); err != nil {
+			t.Fatalf("Complete: %v", err)
+		}
+		if err := store.Release(ctx, id, "fp"); err != nil {
+			t.Fatalf("Release: %v", err)
+		}
+		existing, err := store.Reserve(ctx, newRecord(id, "fp3", time.Minute))
+		if err != nil || existing == nil || existing.Status != models.IdempotencyProcessing || existing.Owner != "fp2" {
+			t.Fatalf("la reserva del nuevo owner debe seguir en curso: %v, %+v", err, existing)
+		}
+
+		if err := store.Complete(ctx, id, "fp2", models.CachedResponse{Status: 201}); err != nil {
+			t.Fatalf("Complete: %v", err)
+		}
+		existing, _ = store.Reserve(ctx, newRecord(id, "fp3", time.Minute))
+		if existing == nil || existing.Status != models.IdempotencyCompleted {
+			t.Fatalf("el owner actual debe poder completar la reserva: %+v", existing)
+		}
+	})
+
+	t.Run("KeysAreScopedToRoute", func(t *testing.T) {
+		store := newStore(t)
+		if _, err := store.Reserve(ctx, newRecord(storage.IdempotencyID("POST", "/api/v1/clinics", "k"), "a", time.Minute)); err != nil {
+			t.Fatalf("Reserve: %v", err)
+		}
+		existing, err := store.Reserve(ctx, newRecord(storage.IdempotencyID("POST", "/api/v1/users/register", "k"), "b", time.Minute))
+		if err != nil || existing != nil {
+			t.Fatalf("la misma clave en otra ruta debe reservarse aparte: %v, %+v", err, existing)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RunImportStorerTests ejecuta la batería de conformidad de storage.ImportStorer.
+func RunImportStorerTests(t *testing.T, newStore func(t *testing.T) storage.ImportStorer) {
+	t.Helper()
+	ctx := context.Background()
+
+	createJob := func(t *testing.T, store storage.ImportStorer, clinicID primitive.ObjectID, rows int) *models.ImportJob {
+		t.Helper()
+		job := &models.ImportJob{
+			ClinicID: clinicID,
+			FileName: "pets.csv",
+			Format:   "csv",
+			Columns:  []string{"Pet"},
+			Mapping:  map[string]string{},
+			Status:   models.ImportUploaded,
+			Stats:    models.ImportStats{Total: rows},
+		}
+		list := make([]*models.ImportRow, rows)
+		for i := range list {
+			list[i] = &models.ImportRow{Index: i, Values: []string{"pet"}, Status: models.ImportRowPending}
+		}
+		if err := store.CreateJob(ctx, job, list); err != nil {
+			t.Fatalf("CreateJob: %v", err)
+		}
+		return job
+	}
+
+	t.Run("FindJobIsScopedToClinic", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		job := createJob(t, store, clinicID, 1)
+
+		found, err := store.FindJob(ctx, clinicID.Hex(), job.ID.Hex())
+		if err != nil || found == nil || found.FileName != "pets.csv" {
+			t.Fatalf("FindJob: %v, %+v", err, found)
+		}
+		found, err = store.FindJob(ctx, primitive.NewObjectID().Hex(), job.ID.Hex())
+		if err != nil || found != nil {
+			t.Fatalf("esperado nil en otra clínica, obtenido %v, %+v", err, found)
+		}
+	})
+
+	t.Run("QueueOnlyFromAllowedStates", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		job := createJob(t, store, clinicID, 1)
+		mapping := map[string]string{"patient.name": "Pet"}
+
+		queued, err := store.QueueJob(ctx, clinicID.Hex(), job.ID.Hex(), []string{models.ImportUploaded}, mapping, "es")
+		if err != nil || queued == nil || queued.Status != models.ImportQueued || queued.Mapping["patient.name"] != "Pet" || queued.Locale != "es" {
+			t.Fatalf("QueueJob: %v, %+v", err, queued)
+		}
+		again, err := store.QueueJob(ctx, clinicID.Hex(), job.ID.Hex(), []string{models.ImportUploaded}, mapping, "es")
+		if err != nil || again != nil {
+			t.Fatalf("esperado nil al volver a confirmar, obtenido %v, %+v", err, again)
+		}
+	})
+
+	t.Run("ClaimQueuedAndStaleJobs", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		job := createJob(t, store, clinicID, 1)
+
+		claimed, err := store.ClaimJob(ctx, time.Now().UTC().Add(-time.Minute))
+		if err != nil || claimed != nil {
+			t.Fatalf("un trabajo sin confirmar no se asigna: %v, %+v", err, claimed)
+		}
+
+		if _, err := store.QueueJob(ctx, clinicID.Hex(), job.ID.Hex(), []string{models.ImportUploaded}, nil, "en"); err != nil {
+			t.Fatalf("QueueJob: %v", err)
+		}
+		claimed, err = store.ClaimJob(ctx, time.Now().UTC().Add(-time.Minute))
+		if err != nil || claimed == nil || claimed.Status != models.ImportRunning || claimed.StartedAt == nil {
+			t.Fatalf("ClaimJob: %v, %+v", err, claimed)
+		}
+
+		// Con latido reciente nadie más lo toma; con uno caducado, sí
+		claimed, err = store.ClaimJob(ctx, time.Now().UTC().Add(-time.Minute))
+		if err != nil || claimed != nil {
+			t.Fatalf("un trabajo con latido reciente no se reasigna: %v, %+v", err, claimed)
+		}
+		claimed, err = store.ClaimJob(ctx, time.Now().UTC().Add(time.Minute))
+		if err != nil || claimed == nil || claimed.ID != job.ID {
+			t.Fatalf("esperado el trabajo abandonado, obtenido %v, %+v", err, claimed)
+		}
+	})
+
+	t.Run("RowsAndStats", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		job := createJob(t, store, clinicID, 3)
+		jobID := job.ID.Hex()
+
+		rows, err := store.ListRows(ctx, jobID, models.ImportRowPending, -1, 2)
+		if err != nil || len(rows) != 2 || rows[0].Index != 0 || rows[1].Index != 1 {
+			t.Fatalf("ListRows: %v, %+v", err, rows)
+		}
+
+		rows[0].Status = models.ImportRowImported
+		rows[0].OwnerCreated = true
+		rows[1].Status = models.ImportRowInvalid
+		rows[1].Errors = []models.ImportRowError{{Field: "patient.species", Message: "Invalid"}}
+		for _, row := range rows {
+			if err := store.SaveRow(ctx, row); err != nil {
+				t.Fatalf("SaveRow: %v", err)
+			}
+		}
+
+		pending, err := store.ListRows(ctx, jobID, models.ImportRowPending, -1, 10)
+		if err != nil || len(pending) != 1 || pending[0].Index != 2 {
+			t.Fatalf("esperada solo la fila 2 pendiente, obtenido %v, %+v", err, pending)
+		}
+		invalid, err := store.ListRows(ctx, jobID, models.ImportRowInvalid, -1, 10)
+		if err != nil || len(invalid) != 1 || len(invalid[0].Errors) != 1 {
+			t.Fatalf("filas inválidas: %v, %+v", err, invalid)
+		}
+
+		stats, err := store.RowStats(ctx, jobID)
+		want := models.ImportStats{Total: 3, Processed: 2, Imported: 1, Invalid: 1, OwnersCreated: 1}
+		if err != nil || stats != want {
+			t.Fatalf("RowStats: %v, %+v (esperado %+v)", err, stats, want)
+		}
+	})
+
+	t.Run("FinishJob", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		job := createJob(t, store, clinicID, 1)
+
+		stats := models.ImportStats{Total: 1, Processed: 1, Imported: 1}
+		if err := store.FinishJob(ctx, job.ID.Hex(), models.ImportFailed, stats, "boom"); err != nil {
+			t.Fatalf("FinishJob: %v", err)
+		}
+		found, err := store.FindJob(ctx, clinicID.Hex(), job.ID.Hex())
+		if err != nil || found.Status != models.ImportFailed || found.Error != "boom" || found.FinishedAt == nil || found.Stats != stats {
+			t.Fatalf("trabajo cerrado: %v, %+v", err, found)
+		}
+	})
+	t.Run("DeleteByClinic", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		job := createJob(t, store, clinicID, 2)
+		createJob(t, store, clinicID, 1)
+		otherClinic := primitive.NewObjectID()
+		other := createJob(t, store, otherClinic, 1)
+
+		deleted, err := store.DeleteByClinic(ctx, clinicID.Hex())
+		if err != nil || deleted != 2 {
+			t.Fatalf("DeleteByClinic: %v, %d", err, deleted)
+		}
+		if found, err := store.FindJob(ctx, clinicID.Hex(), job.ID.Hex()); err != nil || found != nil {
+			t.Fatalf("el trabajo de la clínica debe borrarse: %v, %+v", err, found)
+		}
+		if rows, err := store.ListRows(ctx, job.ID.Hex(), "", -1, 10); err != nil || len(rows) != 0 {
+			t.Fatalf("las filas del trabajo deben borrarse: %v, %+v", err, rows)
+		}
+		if found, err := store.FindJob(ctx, otherClinic.Hex(), other.ID.Hex()); err != nil || found == nil {
+			t.Fatalf("los trabajos de otras clínicas no se tocan: %v, %+v", err, found)
+		}
+		if rows, err := store.ListRows(ctx, other.ID.Hex(), "", -1, 10); err != nil || len(rows) != 1 {
+			t.Fatalf("las filas de otras clínicas no se tocan: %v, %+v", err, rows)
+		}
+	})
+}
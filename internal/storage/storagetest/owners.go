@@ -0,0 +1,154 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/search"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RunOwnerStorerTests ejecuta la batería de conformidad de storage.OwnerStorer.
+func RunOwnerStorerTests(t *testing.T, newStore func(t *testing.T) storage.OwnerStorer) {
+	t.Helper()
+	ctx := context.Background()
+
+	createOwner := func(t *testing.T, store storage.OwnerStorer, clinicID primitive.ObjectID, name, email, phone string) *models.Owner {
+		t.Helper()
+		owner := &models.Owner{ClinicID: clinicID, FullName: name, Email: email, Phone: phone}
+		if err := store.Create(ctx, owner); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		return owner
+	}
+	contactKeys := func(email, phone string) []string {
+		return (&models.Owner{Email: email, Phone: phone}).ContactKeys()
+	}
+
+	t.Run("FindByIDIsScopedToClinic", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		owner := createOwner(t, store, clinicID, "Ana Gómez", "ana@example.com", "")
+
+		found, err := store.FindByID(ctx, clinicID.Hex(), owner.ID.Hex())
+		if err != nil || found == nil || found.FullName != "Ana Gómez" {
+			t.Fatalf("FindByID: %v, %+v", err, found)
+		}
+		found, err = store.FindByID(ctx, primitive.NewObjectID().Hex(), owner.ID.Hex())
+		if err != nil || found != nil {
+			t.Fatalf("esperado nil en otra clínica, obtenido %v, %+v", err, found)
+		}
+	})
+
+	t.Run("FindByContact", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		byEmail := createOwner(t, store, clinicID, "Ana Gómez", "Ana.Gomez@Example.com", "")
+		byPhone := createOwner(t, store, clinicID, "Luis Pérez", "", "+57 300 123-4567")
+		createOwner(t, store, primitive.NewObjectID(), "Otra clínica", "otra@example.com", "")
+
+		tests := []struct {
+			name string
+			keys []string
+			want *models.Owner
+		}{
+			{"EmailOnly", contactKeys("Ana.Gomez@Example.com", ""), byEmail},
+			{"EmailDifferentCase", contactKeys("  ANA.GOMEZ@example.COM", ""), byEmail},
+			{"PhoneOnly", contactKeys("", "+573001234567"), byPhone},
+			{"AnyKeyMatches", contactKeys("nadie@example.com", "+57 (300) 123 4567"), byPhone},
+			{"NoMatch", contactKeys("nadie@example.com", "+57 311 000 0000"), nil},
+			{"OtherClinic", contactKeys("otra@example.com", ""), nil},
+			{"NoKeys", nil, nil},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				found, err := store.FindByContact(ctx, clinicID.Hex(), tt.keys)
+				if err != nil {
+					t.Fatalf("FindByContact: %v", err)
+				}
+				switch {
+				case tt.want == nil && found != nil:
+					t.Fatalf("esperado nil, obtenido %+v", found)
+				case tt.want != nil && (found == nil || found.ID != tt.want.ID):
+					t.Fatalf("esperado el tutor %s, obtenido %+v", tt.want.ID.Hex(), found)
+				}
+			})
+		}
+	})
+
+	t.Run("FindByContactReturnsOldest", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		first := createOwner(t, store, clinicID, "Ana Gómez", "ana@example.com", "")
+		createOwner(t, store, clinicID, "Ana G.", "ANA@example.com", "")
+
+		found, err := store.FindByContact(ctx, clinicID.Hex(), contactKeys("ana@example.com", ""))
+		if err != nil || found == nil || found.ID != first.ID {
+			t.Fatalf("esperado el tutor más antiguo, obtenido %v, %+v", err, found)
+		}
+	})
+
+	t.Run("SearchCandidatesIsScopedToClinic", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		owner := createOwner(t, store, clinicID, "Ana Gómez", "", "")
+		createOwner(t, store, clinicID, "Marta Silva", "", "")
+		createOwner(t, store, primitive.NewObjectID(), "Ana Gómez", "", "")
+
+		found, err := store.SearchCandidates(ctx, clinicID.Hex(), search.Grams(search.Terms("gomez")), 10)
+		if err != nil || len(found) != 1 || found[0].ID != owner.ID {
+			t.Fatalf("SearchCandidates: %v, %+v", err, found)
+		}
+	})
+
+	t.Run("StreamByClinicInIDOrder", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		var want []primitive.ObjectID
+		for _, name := range []string{"Ana", "Luis", "Marta"} {
+			want = append(want, createOwner(t, store, clinicID, name, "", "").ID)
+		}
+		createOwner(t, store, primitive.NewObjectID(), "Otra", "", "")
+
+		var got []primitive.ObjectID
+		err := store.StreamByClinic(ctx, clinicID.Hex(), func(o *models.Owner) error {
+			got = append(got, o.ID)
+			return nil
+		})
+		if err != nil || len(got) != len(want) {
+			t.Fatalf("StreamByClinic: %v, %v", err, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("orden inesperado: %v, esperado %v", got, want)
+			}
+		}
+	})
+
+	t.Run("HardDeleteByClinic", func(t *testing.T) {
+		store := newStore(t)
+		clinicID, otherClinicID := primitive.NewObjectID(), primitive.NewObjectID()
+		owner := createOwner(t, store, clinicID, "Ana Gómez", "ana@example.com", "")
+		createOwner(t, store, clinicID, "Luis Pérez", "", "")
+		other := createOwner(t, store, otherClinicID, "Otra", "", "")
+
+		n, err := store.HardDeleteByClinic(ctx, clinicID.Hex())
+		if err != nil || n != 2 {
+			t.Fatalf("HardDeleteByClinic = %d, %v; esperado 2", n, err)
+		}
+		if found, err := store.FindByID(ctx, clinicID.Hex(), owner.ID.Hex()); err != nil || found != nil {
+			t.Fatalf("el tutor debe haberse borrado: %v, %+v", err, found)
+		}
+		if found, err := store.FindByContact(ctx, clinicID.Hex(), contactKeys("ana@example.com", "")); err != nil || found != nil {
+			t.Fatalf("el tutor borrado no debe reconocerse por contacto: %v, %+v", err, found)
+		}
+		if found, err := store.FindByID(ctx, otherClinicID.Hex(), other.ID.Hex()); err != nil || found == nil {
+			t.Fatalf("no debe borrar tutores de otra clínica: %v, %+v", err, found)
+		}
+		if n, err := store.HardDeleteByClinic(ctx, clinicID.Hex()); err != nil || n != 0 {
+			t.Fatalf("repetir HardDeleteByClinic = %d, %v; esperado 0", n, err)
+		}
+	})
+}
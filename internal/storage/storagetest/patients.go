@@ -0,0 +1,100 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/search"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RunPatientStorerTests ejecuta la batería de conformidad de storage.PatientStorer.
+func RunPatientStorerTests(t *testing.T, newStore func(t *testing.T) storage.PatientStorer) {
+	t.Helper()
+	ctx := context.Background()
+
+	createPatient := func(t *testing.T, store storage.PatientStorer, clinicID primitive.ObjectID, name string) *models.Patient {
+		t.Helper()
+		patient := &models.Patient{ClinicID: clinicID, OwnerID: primitive.NewObjectID(), Name: name, Species: "dog", Breed: "Beagle"}
+		if err := store.Create(ctx, patient); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		return patient
+	}
+
+	t.Run("FindByIDIsScopedToClinic", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		patient := createPatient(t, store, clinicID, "Luna")
+
+		found, err := store.FindByID(ctx, clinicID.Hex(), patient.ID.Hex())
+		if err != nil || found == nil || found.Name != "Luna" || found.OwnerID != patient.OwnerID {
+			t.Fatalf("FindByID: %v, %+v", err, found)
+		}
+		found, err = store.FindByID(ctx, primitive.NewObjectID().Hex(), patient.ID.Hex())
+		if err != nil || found != nil {
+			t.Fatalf("esperado nil en otra clínica, obtenido %v, %+v", err, found)
+		}
+	})
+
+	t.Run("SearchCandidatesIsScopedToClinic", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		patient := createPatient(t, store, clinicID, "Luna")
+		createPatient(t, store, clinicID, "Toby")
+		createPatient(t, store, primitive.NewObjectID(), "Luna")
+
+		found, err := store.SearchCandidates(ctx, clinicID.Hex(), search.Grams(search.Terms("luna")), 10)
+		if err != nil || len(found) != 1 || found[0].ID != patient.ID {
+			t.Fatalf("SearchCandidates: %v, %+v", err, found)
+		}
+	})
+
+	t.Run("StreamByClinicInIDOrder", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		var want []primitive.ObjectID
+		for _, name := range []string{"Luna", "Toby", "Misu"} {
+			want = append(want, createPatient(t, store, clinicID, name).ID)
+		}
+		createPatient(t, store, primitive.NewObjectID(), "Otra")
+
+		var got []primitive.ObjectID
+		err := store.StreamByClinic(ctx, clinicID.Hex(), func(p *models.Patient) error {
+			got = append(got, p.ID)
+			return nil
+		})
+		if err != nil || len(got) != len(want) {
+			t.Fatalf("StreamByClinic: %v, %v", err, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("orden inesperado: %v, esperado %v", got, want)
+			}
+		}
+	})
+
+	t.Run("HardDeleteByClinic", func(t *testing.T) {
+		store := newStore(t)
+		clinicID, otherClinicID := primitive.NewObjectID(), primitive.NewObjectID()
+		patient := createPatient(t, store, clinicID, "Luna")
+		createPatient(t, store, clinicID, "Toby")
+		other := createPatient(t, store, otherClinicID, "Otra")
+
+		n, err := store.HardDeleteByClinic(ctx, clinicID.Hex())
+		if err != nil || n != 2 {
+			t.Fatalf("HardDeleteByClinic = %d, %v; esperado 2", n, err)
+		}
+		if found, err := store.FindByID(ctx, clinicID.Hex(), patient.ID.Hex()); err != nil || found != nil {
+			t.Fatalf("el paciente debe haberse borrado: %v, %+v", err, found)
+		}
+		if found, err := store.FindByID(ctx, otherClinicID.Hex(), other.ID.Hex()); err != nil || found == nil {
+			t.Fatalf("no debe borrar pacientes de otra clínica: %v, %+v", err, found)
+		}
+		if n, err := store.HardDeleteByClinic(ctx, clinicID.Hex()); err != nil || n != 0 {
+			t.Fatalf("repetir HardDeleteByClinic = %d, %v; esperado 0", n, err)
+		}
+	})
+}
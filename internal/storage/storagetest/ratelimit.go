@@ -0,0 +1,68 @@
+package storagetest
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+// RunRateLimitStorerTests ejecuta la batería de conformidad de storage.RateLimitStorer.
+func RunRateLimitStorerTests(t *testing.T, newStore func(t *testing.T) storage.RateLimitStorer) {
+	t.Helper()
+	ctx := context.Background()
+	start := time.Now().UTC().Truncate(time.Millisecond)
+
+	near := func(a, b float64) bool { return math.Abs(a-b) < 0.01 }
+
+	t.Run("NewBucketStartsFull", func(t *testing.T) {
+		store := newStore(t)
+		for i := 3; i > 0; i-- {
+			remaining, allowed, err := store.Take(ctx, "k", 3, 1, start)
+			if err != nil || !allowed || !near(remaining, float64(i-1)) {
+				t.Fatalf("token %d: %v, allowed=%v, remaining=%v", 4-i, err, allowed, remaining)
+			}
+		}
+		remaining, allowed, err := store.Take(ctx, "k", 3, 1, start)
+		if err != nil || allowed || !near(remaining, 0) {
+			t.Fatalf("cubo vacío debe denegar: %v, allowed=%v, remaining=%v", err, allowed, remaining)
+		}
+	})
+
+	t.Run("RefillsOverTime", func(t *testing.T) {
+		store := newStore(t)
+		for i := 0; i < 2; i++ {
+			store.Take(ctx, "k", 2, 0.5, start)
+		}
+		// Medio segundo son 0,25 tokens: no basta
+		if _, allowed, _ := store.Take(ctx, "k", 2, 0.5, start.Add(500*time.Millisecond)); allowed {
+			t.Fatal("esperado denegado antes de rellenar un token")
+		}
+		remaining, allowed, err := store.Take(ctx, "k", 2, 0.5, start.Add(2*time.Second))
+		if err != nil || !allowed || !near(remaining, 0) {
+			t.Fatalf("esperado un token tras 2s: %v, allowed=%v, remaining=%v", err, allowed, remaining)
+		}
+	})
+
+	t.Run("NeverExceedsCapacity", func(t *testing.T) {
+		store := newStore(t)
+		store.Take(ctx, "k", 5, 1, start)
+		remaining, allowed, err := store.Take(ctx, "k", 5, 1, start.Add(time.Hour))
+		if err != nil || !allowed || !near(remaining, 4) {
+			t.Fatalf("el cubo no debe pasar de su capacidad: %v, allowed=%v, remaining=%v", err, allowed, remaining)
+		}
+	})
+
+	t.Run("KeysAreIndependent", func(t *testing.T) {
+		store := newStore(t)
+		store.Take(ctx, "a", 1, 1, start)
+		if _, allowed, _ := store.Take(ctx, "a", 1, 1, start); allowed {
+			t.Fatal("esperado a agotado")
+		}
+		if _, allowed, err := store.Take(ctx, "b", 1, 1, start); err != nil || !allowed {
+			t.Fatalf("b no debe compartir el cubo de a: %v", err)
+		}
+	})
+}
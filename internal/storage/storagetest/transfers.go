@@ -0,0 +1,186 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RunTransferStorerTests ejecuta la batería de conformidad de storage.TransferStorer.
+func RunTransferStorerTests(t *testing.T, newStore func(t *testing.T) storage.TransferStorer) {
+	t.Helper()
+	ctx := context.Background()
+
+	createJob := func(t *testing.T, store storage.TransferStorer, job *models.TransferJob) *models.TransferJob {
+		t.Helper()
+		if err := store.CreateJob(ctx, job); err != nil {
+			t.Fatalf("CreateJob: %v", err)
+		}
+		return job
+	}
+
+	t.Run("CreateKeepsPresetIDAndFinds", func(t *testing.T) {
+		store := newStore(t)
+		id := primitive.NewObjectID()
+		job := createJob(t, store, &models.TransferJob{ID: id, Kind: models.TransferImport, ClinicID: primitive.NewObjectID(), Status: models.TransferQueued})
+		if job.ID != id || job.CreatedAt.IsZero() {
+			t.Fatalf("CreateJob: %+v", job)
+		}
+
+		found, err := store.FindJob(ctx, id.Hex())
+		if err != nil || found == nil || found.Kind != models.TransferImport || found.Status != models.TransferQueued {
+			t.Fatalf("FindJob: %v, %+v", err, found)
+		}
+		missing, err := store.FindJob(ctx, primitive.NewObjectID().Hex())
+		if err != nil || missing != nil {
+			t.Fatalf("FindJob de un trabajo inexistente: %v, %+v", err, missing)
+		}
+		if _, err := store.FindJob(ctx, "not-an-id"); err == nil {
+			t.Fatalf("FindJob con un ID inválido debe fallar")
+		}
+	})
+
+	t.Run("ClaimQueuedAndStaleJobs", func(t *testing.T) {
+		store := newStore(t)
+		first := createJob(t, store, &models.TransferJob{Kind: models.TransferExport, ClinicID: primitive.NewObjectID(), Status: models.TransferQueued})
+		createJob(t, store, &models.TransferJob{Kind: models.TransferExport, ClinicID: primitive.NewObjectID(), Status: models.TransferCompleted})
+
+		claimed, err := store.ClaimJob(ctx, time.Now().UTC().Add(-time.Minute))
+		if err != nil || claimed == nil || claimed.ID != first.ID || claimed.Status != models.TransferRunning || claimed.StartedAt == nil {
+			t.Fatalf("ClaimJob: %v, %+v", err, claimed)
+		}
+
+		// Con latido reciente nadie más lo toma; con uno caducado, sí
+		claimed, err = store.ClaimJob(ctx, time.Now().UTC().Add(-time.Minute))
+		if err != nil || claimed != nil {
+			t.Fatalf("un trabajo con latido reciente no se reasigna: %v, %+v", err, claimed)
+		}
+		claimed, err = store.ClaimJob(ctx, time.Now().UTC().Add(time.Minute))
+		if err != nil || claimed == nil || claimed.ID != first.ID {
+			t.Fatalf("esperado el trabajo abandonado, obtenido %v, %+v", err, claimed)
+		}
+	})
+
+	t.Run("ProgressAndFinish", func(t *testing.T) {
+		store := newStore(t)
+		job := createJob(t, store, &models.TransferJob{Kind: models.TransferExport, ClinicID: primitive.NewObjectID(), Status: models.TransferQueued})
+		if _, err := store.ClaimJob(ctx, time.Now().UTC()); err != nil {
+			t.Fatalf("ClaimJob: %v", err)
+		}
+
+		counts := models.TransferCounts{Users: 2, Owners: 3, Patients: 4}
+		if err := store.SaveProgress(ctx, job.ID.Hex(), counts); err != nil {
+			t.Fatalf("SaveProgress: %v", err)
+		}
+		found, err := store.FindJob(ctx, job.ID.Hex())
+		if err != nil || found.Counts != counts || found.HeartbeatAt == nil {
+			t.Fatalf("progreso: %v, %+v", err, found)
+		}
+
+		if err := store.FinishJob(ctx, job.ID.Hex(), models.TransferCompleted, counts, 1024, ""); err != nil {
+			t.Fatalf("FinishJob: %v", err)
+		}
+		found, err = store.FindJob(ctx, job.ID.Hex())
+		if err != nil || found.Status != models.TransferCompleted || found.Size != 1024 || found.FinishedAt == nil || found.HeartbeatAt != nil {
+			t.Fatalf("trabajo cerrado: %v, %+v", err, found)
+		}
+		if found.ExpiresAt == nil || !found.ExpiresAt.Equal(found.FinishedAt.Add(models.TransferArchiveTTL)) {
+			t.Fatalf("un trabajo cerrado caduca a los %s: %+v", models.TransferArchiveTTL, found)
+		}
+	})
+
+	t.Run("QueueOnlyFromAllowedStates", func(t *testing.T) {
+		store := newStore(t)
+		job := createJob(t, store, &models.TransferJob{Kind: models.TransferImport, ClinicID: primitive.NewObjectID(), Status: models.TransferQueued})
+		if err := store.FinishJob(ctx, job.ID.Hex(), models.TransferFailed, models.TransferCounts{}, 0, "boom"); err != nil {
+			t.Fatalf("FinishJob: %v", err)
+		}
+
+		queued, err := store.QueueJob(ctx, job.ID.Hex(), []string{models.TransferFailed})
+		if err != nil || queued == nil || queued.Status != models.TransferQueued || queued.Error != "" || queued.FinishedAt != nil || queued.ExpiresAt != nil {
+			t.Fatalf("QueueJob: %v, %+v", err, queued)
+		}
+		again, err := store.QueueJob(ctx, job.ID.Hex(), []string{models.TransferFailed})
+		if err != nil || again != nil {
+			t.Fatalf("esperado nil al volver a encolar, obtenido %v, %+v", err, again)
+		}
+	})
+
+	t.Run("ExpiredJobs", func(t *testing.T) {
+		store := newStore(t)
+		finish := func(job *models.TransferJob) {
+			t.Helper()
+			if err := store.FinishJob(ctx, job.ID.Hex(), models.TransferCompleted, models.TransferCounts{}, 0, ""); err != nil {
+				t.Fatalf("FinishJob: %v", err)
+			}
+		}
+		first := createJob(t, store, &models.TransferJob{Kind: models.TransferExport, ClinicID: primitive.NewObjectID(), Status: models.TransferQueued})
+		finish(first)
+		time.Sleep(2 * time.Millisecond)
+		second := createJob(t, store, &models.TransferJob{Kind: models.TransferImport, ClinicID: primitive.NewObjectID(), Status: models.TransferQueued})
+		finish(second)
+		createJob(t, store, &models.TransferJob{Kind: models.TransferExport, ClinicID: primitive.NewObjectID(), Status: models.TransferQueued})
+
+		expired, err := store.ExpiredJobs(ctx, time.Now().UTC(), 10)
+		if err != nil || len(expired) != 0 {
+			t.Fatalf("nada caduca antes de su plazo: %v, %+v", err, expired)
+		}
+
+		later := time.Now().UTC().Add(models.TransferArchiveTTL + time.Minute)
+		expired, err = store.ExpiredJobs(ctx, later, 10)
+		if err != nil || len(expired) != 2 || expired[0].ID != first.ID || expired[1].ID != second.ID {
+			t.Fatalf("esperados los dos trabajos cerrados, el más antiguo primero: %v, %+v", err, expired)
+		}
+		expired, err = store.ExpiredJobs(ctx, later, 1)
+		if err != nil || len(expired) != 1 || expired[0].ID != first.ID {
+			t.Fatalf("ExpiredJobs con límite: %v, %+v", err, expired)
+		}
+
+		if err := store.DeleteJob(ctx, first.ID.Hex()); err != nil {
+			t.Fatalf("DeleteJob: %v", err)
+		}
+		if err := store.DeleteJob(ctx, first.ID.Hex()); err != nil {
+			t.Fatalf("borrar un trabajo que ya no existe no es un error: %v", err)
+		}
+		if found, err := store.FindJob(ctx, first.ID.Hex()); err != nil || found != nil {
+			t.Fatalf("el trabajo debe haberse borrado: %v, %+v", err, found)
+		}
+		expired, err = store.ExpiredJobs(ctx, later, 10)
+		if err != nil || len(expired) != 1 || expired[0].ID != second.ID {
+			t.Fatalf("ExpiredJobs tras borrar: %v, %+v", err, expired)
+		}
+	})
+
+	t.Run("PurgeClinicRemovesItsJobs", func(t *testing.T) {
+		store := newStore(t)
+		clinicID, otherClinicID := primitive.NewObjectID(), primitive.NewObjectID()
+		export := createJob(t, store, &models.TransferJob{Kind: models.TransferExport, ClinicID: clinicID, Status: models.TransferCompleted})
+		imported := createJob(t, store, &models.TransferJob{Kind: models.TransferImport, ClinicID: clinicID, Status: models.TransferFailed})
+		other := createJob(t, store, &models.TransferJob{Kind: models.TransferExport, ClinicID: otherClinicID, Status: models.TransferCompleted})
+
+		jobs, err := store.FindJobsByClinic(ctx, clinicID.Hex())
+		if err != nil || len(jobs) != 2 || jobs[0].ID != export.ID || jobs[1].ID != imported.ID {
+			t.Fatalf("FindJobsByClinic: %v, %+v", err, jobs)
+		}
+		for _, job := range jobs {
+			if err := store.DeleteJob(ctx, job.ID.Hex()); err != nil {
+				t.Fatalf("DeleteJob: %v", err)
+			}
+		}
+
+		jobs, err = store.FindJobsByClinic(ctx, clinicID.Hex())
+		if err != nil || len(jobs) != 0 {
+			t.Fatalf("la clínica purgada no debe conservar traspasos: %v, %+v", err, jobs)
+		}
+		if found, err := store.FindJob(ctx, other.ID.Hex()); err != nil || found == nil {
+			t.Fatalf("no debe borrar traspasos de otra clínica: %v, %+v", err, found)
+		}
+		if _, err := store.FindJobsByClinic(ctx, "not-an-id"); err == nil {
+			t.Fatalf("FindJobsByClinic con un ID inválido debe fallar")
+		}
+	})
+}
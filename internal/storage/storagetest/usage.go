@@ -0,0 +1,112 @@
+package storagetest
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RunUsageStorerTests ejecuta la batería de conformidad de storage.UsageStorer.
+func RunUsageStorerTests(t *testing.T, newStore func(t *testing.T) storage.UsageStorer) {
+	t.Helper()
+	ctx := context.Background()
+
+	t.Run("GetWithoutCountersIsZero", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		usage, err := store.Get(ctx, clinicID.Hex())
+		if err != nil || usage.ClinicID != clinicID || usage.Users != 0 || usage.Patients != 0 || usage.StorageBytes != 0 {
+			t.Fatalf("esperado uso a cero, obtenido %v, %+v", err, usage)
+		}
+	})
+
+	t.Run("AddRespectsLimit", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID().Hex()
+		for i := 0; i < 3; i++ {
+			if ok, err := store.Add(ctx, clinicID, models.UsageUsers, 1, 3); err != nil || !ok {
+				t.Fatalf("alta %d: %v, ok=%v", i+1, err, ok)
+			}
+		}
+		if ok, err := store.Add(ctx, clinicID, models.UsageUsers, 1, 3); err != nil || ok {
+			t.Fatalf("esperado límite alcanzado: %v, ok=%v", err, ok)
+		}
+		if ok, err := store.Add(ctx, clinicID, models.UsagePatients, 5, 4); err != nil || ok {
+			t.Fatalf("esperado rechazo de un lote mayor que el límite: %v, ok=%v", err, ok)
+		}
+		usage, err := store.Get(ctx, clinicID)
+		if err != nil || usage.Users != 3 || usage.Patients != 0 {
+			t.Fatalf("esperado 3 usuarios y 0 pacientes, obtenido %v, %+v", err, usage)
+		}
+	})
+
+	t.Run("UnlimitedAndRelease", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID().Hex()
+		if ok, err := store.Add(ctx, clinicID, models.UsageStorage, 1<<40, 0); err != nil || !ok {
+			t.Fatalf("sin límite: %v, ok=%v", err, ok)
+		}
+		if ok, err := store.Add(ctx, clinicID, models.UsageStorage, -(1 << 39), 1); err != nil || !ok {
+			t.Fatalf("restar nunca se rechaza: %v, ok=%v", err, ok)
+		}
+		if _, err := store.Add(ctx, clinicID, models.UsagePatients, -2, 0); err != nil {
+			t.Fatalf("restar sin contador: %v", err)
+		}
+		usage, err := store.Get(ctx, clinicID)
+		if err != nil || usage.StorageBytes != 1<<39 || usage.Patients != 0 {
+			t.Fatalf("esperado 2^39 bytes y 0 pacientes, obtenido %v, %+v", err, usage)
+		}
+	})
+
+	t.Run("UnknownResource", func(t *testing.T) {
+		store := newStore(t)
+		if _, err := store.Add(ctx, primitive.NewObjectID().Hex(), "owners", 1, 0); err == nil {
+			t.Fatal("esperado error con un recurso desconocido")
+		}
+	})
+
+	t.Run("ConcurrentAddsNeverExceedLimit", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID().Hex()
+		var granted atomic.Int64
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if ok, err := store.Add(ctx, clinicID, models.UsageUsers, 1, 5); err == nil && ok {
+					granted.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		usage, err := store.Get(ctx, clinicID)
+		if err != nil || granted.Load() != 5 || usage.Users != 5 {
+			t.Fatalf("esperado 5 altas concedidas, obtenido %d (%v, %+v)", granted.Load(), err, usage)
+		}
+	})
+
+	t.Run("SetAndDelete", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		if err := store.Set(ctx, &models.ClinicUsage{ClinicID: clinicID, Users: 4, Patients: 10}); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+		usage, err := store.Get(ctx, clinicID.Hex())
+		if err != nil || usage.Users != 4 || usage.Patients != 10 {
+			t.Fatalf("esperado el uso guardado, obtenido %v, %+v", err, usage)
+		}
+		if err := store.Delete(ctx, clinicID.Hex()); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		usage, err = store.Get(ctx, clinicID.Hex())
+		if err != nil || usage.Users != 0 {
+			t.Fatalf("esperado uso a cero tras borrar, obtenido %v, %+v", err, usage)
+		}
+	})
+}
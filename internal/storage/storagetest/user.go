@@ -0,0 +1,197 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RunUserStorerTests ejecuta la batería de conformidad de storage.UserStorer.
+func RunUserStorerTests(t *testing.T, newStore func(t *testing.T) storage.UserStorer) {
+	t.Helper()
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		user := mustCreateUser(t, store, clinicID, "ana@example.com")
+
+		if user.ID.IsZero() || user.CreatedAt.IsZero() {
+			t.Fatalf("Create no asignó ID o timestamps: %+v", user)
+		}
+
+		got, err := store.FindByEmail(context.Background(), clinicID.Hex(), "ana@example.com")
+		if err != nil || got == nil || got.ID != user.ID {
+			t.Fatalf("FindByEmail: %v, %+v", err, got)
+		}
+		got, err = store.FindByID(context.Background(), clinicID.Hex(), user.ID.Hex())
+		if err != nil || got == nil || got.Email != "ana@example.com" {
+			t.Fatalf("FindByID: %v, %+v", err, got)
+		}
+	})
+
+	t.Run("TenantIsolation", func(t *testing.T) {
+		store := newStore(t)
+		clinicA, clinicB := primitive.NewObjectID(), primitive.NewObjectID()
+		user := mustCreateUser(t, store, clinicA, "ana@example.com")
+
+		got, err := store.FindByEmail(context.Background(), clinicB.Hex(), "ana@example.com")
+		if err != nil || got != nil {
+			t.Fatalf("FindByEmail no debe cruzar clínicas: %v, %+v", err, got)
+		}
+		if _, err := store.FindByID(context.Background(), clinicB.Hex(), user.ID.Hex()); err == nil {
+			t.Fatal("FindByID no debe cruzar clínicas")
+		}
+
+		// El mismo email puede existir en otra clínica
+		mustCreateUser(t, store, clinicB, "ana@example.com")
+	})
+
+	t.Run("CreateRejectsDuplicateEmailInClinic", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		mustCreateUser(t, store, clinicID, "ana@example.com")
+
+		err := store.Create(context.Background(), &models.User{ClinicID: clinicID, Email: "ana@example.com", FullName: "Otra"})
+		if !errors.Is(err, storage.ErrDuplicateUserEmail) {
+			t.Fatalf("esperado ErrDuplicateUserEmail, obtenido %v", err)
+		}
+	})
+
+	t.Run("FindMissing", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID().Hex()
+
+		got, err := store.FindByEmail(context.Background(), clinicID, "nadie@example.com")
+		if err != nil || got != nil {
+			t.Fatalf("FindByEmail de un usuario inexistente debe devolver nil, nil: %v, %+v", err, got)
+		}
+		if _, err := store.FindByEmail(context.Background(), "not-an-id", "nadie@example.com"); err == nil {
+			t.Fatal("FindByEmail con un ID de clínica inválido debe fallar")
+		}
+	})
+
+	t.Run("CascadeSoftDeleteAndRestore", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		other := primitive.NewObjectID()
+		mustCreateUser(t, store, clinicID, "ana@example.com")
+		mustCreateUser(t, store, clinicID, "juan@example.com")
+		mustCreateUser(t, store, other, "ana@example.com")
+
+		assertUserCount(t, store, clinicID, 2)
+
+		deletedAt := time.Now().UTC().Truncate(time.Millisecond)
+		n, err := store.SoftDeleteByClinic(context.Background(), clinicID.Hex(), deletedAt)
+		if err != nil || n != 2 {
+			t.Fatalf("SoftDeleteByClinic: %v, %d", err, n)
+		}
+		assertUserCount(t, store, clinicID, 0)
+		assertUserCount(t, store, other, 1)
+
+		got, err := store.FindByEmail(context.Background(), clinicID.Hex(), "ana@example.com")
+		if err != nil || got != nil {
+			t.Fatalf("FindByEmail no debe devolver usuarios eliminados: %v, %+v", err, got)
+		}
+
+		// Solo se restauran los eliminados con la misma marca de tiempo
+		n, err = store.RestoreByClinic(context.Background(), clinicID.Hex(), deletedAt.Add(time.Second))
+		if err != nil || n != 0 {
+			t.Fatalf("RestoreByClinic con otra fecha no debe restaurar: %v, %d", err, n)
+		}
+		n, err = store.RestoreByClinic(context.Background(), clinicID.Hex(), deletedAt)
+		if err != nil || n != 2 {
+			t.Fatalf("RestoreByClinic: %v, %d", err, n)
+		}
+		assertUserCount(t, store, clinicID, 2)
+	})
+
+	t.Run("HardDeleteByClinic", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		other := primitive.NewObjectID()
+		mustCreateUser(t, store, clinicID, "ana@example.com")
+		mustCreateUser(t, store, other, "juan@example.com")
+
+		n, err := store.HardDeleteByClinic(context.Background(), clinicID.Hex())
+		if err != nil || n != 1 {
+			t.Fatalf("HardDeleteByClinic: %v, %d", err, n)
+		}
+		assertUserCount(t, store, clinicID, 0)
+		assertUserCount(t, store, other, 1)
+
+		// Tras el borrado definitivo el email queda libre
+		mustCreateUser(t, store, clinicID, "ana@example.com")
+	})
+
+	t.Run("CreateKeepsPresetID", func(t *testing.T) {
+		store := newStore(t)
+		clinicID := primitive.NewObjectID()
+		id := primitive.NewObjectID()
+		user := &models.User{ID: id, ClinicID: clinicID, FullName: "Ana", Email: "ana@example.com", HashedPassword: "x", Role: "admin"}
+		if err := store.Create(context.Background(), user); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if user.ID != id {
+			t.Fatalf("Create reemplazó el ID asignado: %s", user.ID.Hex())
+		}
+	})
+
+	t.Run("StreamByClinicSkipsDeletedAndOtherClinics", func(t *testing.T) {
+		store := newStore(t)
+		clinicID, other := primitive.NewObjectID(), primitive.NewObjectID()
+		ana := mustCreateUser(t, store, clinicID, "ana@example.com")
+		juan := mustCreateUser(t, store, clinicID, "juan@example.com")
+		mustCreateUser(t, store, other, "eva@example.com")
+
+		var got []primitive.ObjectID
+		err := store.StreamByClinic(context.Background(), clinicID.Hex(), func(u *models.User) error {
+			if u.HashedPassword == "" {
+				t.Errorf("StreamByClinic debe incluir el hash de la contraseña")
+			}
+			got = append(got, u.ID)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("StreamByClinic: %v", err)
+		}
+		if len(got) != 2 || got[0] != ana.ID || got[1] != juan.ID {
+			t.Fatalf("StreamByClinic esperado [ana juan] por ID, obtenido %v", got)
+		}
+
+		if _, err := store.SoftDeleteByClinic(context.Background(), clinicID.Hex(), time.Now().UTC()); err != nil {
+			t.Fatalf("SoftDeleteByClinic: %v", err)
+		}
+		got = nil
+		if err := store.StreamByClinic(context.Background(), clinicID.Hex(), func(u *models.User) error {
+			got = append(got, u.ID)
+			return nil
+		}); err != nil || len(got) != 0 {
+			t.Fatalf("StreamByClinic tras el borrado: %v, %v", err, got)
+		}
+	})
+}
+
+func mustCreateUser(t *testing.T, store storage.UserStorer, clinicID primitive.ObjectID, email string) *models.User {
+	t.Helper()
+	user := &models.User{ClinicID: clinicID, FullName: "Test User", Email: email, HashedPassword: "x", Role: "admin"}
+	if err := store.Create(context.Background(), user); err != nil {
+		t.Fatalf("Create(%s): %v", email, err)
+	}
+	return user
+}
+
+func assertUserCount(t *testing.T, store storage.UserStorer, clinicID primitive.ObjectID, want int64) {
+	t.Helper()
+	got, err := store.CountByClinic(context.Background(), clinicID.Hex())
+	if err != nil {
+		t.Fatalf("CountByClinic: %v", err)
+	}
+	if got != want {
+		t.Fatalf("CountByClinic esperado %d, obtenido %d", want, got)
+	}
+}
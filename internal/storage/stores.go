@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"github.com/zabaletac3/go-vet-api/internal/blob"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Stores agrupa las implementaciones de storage que usan los módulos HTTP.
+// Permite cambiar de backend (Mongo o memoria) sin tocar servicios ni handlers.
+type Stores struct {
+	Clinics  ClinicStorer
+	Users    UserStorer
+	Owners   OwnerStorer
+	Patients PatientStorer
+
+	// Idempotency guarda las respuestas de las peticiones con Idempotency-Key
+	Idempotency IdempotencyStorer
+
+	// Imports guarda los trabajos de importación de pacientes y tutores
+	Imports ImportStorer
+
+	// Transfers guarda los trabajos de exportación e importación de clínicas completas
+	Transfers TransferStorer
+
+	// RateLimits guarda los cubos de tokens de la limitación de peticiones
+	RateLimits RateLimitStorer
+
+	// Usage guarda los contadores de uso de cada clínica frente a su plan
+	Usage UsageStorer
+
+	// Attachments guarda los metadatos de los ficheros adjuntos
+	Attachments AttachmentStorer
+
+	// Blobs guarda el contenido de los ficheros adjuntos y de las imágenes
+	// de marca. No depende del backend de datos: lo asigna main según BLOB_DRIVER.
+	Blobs blob.Store
+
+	// Tx agrupa escrituras de varios stores en una transacción
+	Tx Transactor
+}
+
+// NewMongoStores crea los stores respaldados por MongoDB.
+func NewMongoStores(db *mongo.Database) *Stores {
+	return &Stores{
+		Clinics:     NewClinicRepository(db),
+		Users:       NewUserRepository(db),
+		Owners:      NewOwnerRepository(db),
+		Patients:    NewPatientRepository(db),
+		Idempotency: NewIdempotencyRepository(db),
+		Imports:     NewImportRepository(db),
+		Transfers:   NewTransferRepository(db),
+		RateLimits:  NewRateLimitRepository(db),
+		Usage:       NewUsageRepository(db),
+		Attachments: NewAttachmentRepository(db),
+		Tx:          NewMongoTransactor(db.Client()),
+	}
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// streamDocs recorre por orden de ID los documentos de filter decodificándolos
+// de uno en uno a medida que llegan los lotes del cursor (sin cursor.All).
+func streamDocs[T any](ctx context.Context, coll *mongo.Collection, filter bson.M, each func(*T) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return fmt.Errorf("error al recorrer %s: %w", coll.Name(), err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc T
+		if err := cursor.Decode(&doc); err != nil {
+			return fmt.Errorf("error al decodificar %s: %w", coll.Name(), err)
+		}
+		if err := each(&doc); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrTransactionsUnsupported lo devuelve WithTransaction cuando el despliegue
+// de Mongo no admite transacciones (un servidor standalone, sin replica set).
+var ErrTransactionsUnsupported = errors.New("transactions require a MongoDB replica set")
+
+// Transactor ejecuta varias operaciones de los stores como una unidad: si fn
+// devuelve error no queda ninguna escritura. Los stores participan en la
+// transacción a través del ctx que recibe fn.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// MongoTransactor implementa Transactor con sesiones de MongoDB.
+type MongoTransactor struct {
+	client *mongo.Client
+}
+
+// NewMongoTransactor crea un Transactor sobre el cliente de la base de datos.
+func NewMongoTransactor(client *mongo.Client) *MongoTransactor {
+	return &MongoTransactor{client: client}
+}
+
+// WithTransaction ejecuta fn en una transacción. El driver reintenta fn ante
+// errores transitorios, así que fn no debe acumular estado entre intentos.
+func (t *MongoTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := t.client.StartSession()
+	if err != nil {
+		return fmt.Errorf("error starting session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	var commit func()
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		// Cada intento registra sus propios AfterCommit
+		var txCtx context.Context
+		txCtx, commit = WithCommitHooks(sc)
+		return nil, fn(txCtx)
+	})
+	if transactionsUnsupported(err) {
+		return ErrTransactionsUnsupported
+	}
+	if err == nil {
+		commit()
+	}
+	return err
+}
+
+type commitHooksKey struct{}
+
+// commitHooks acumula lo que debe ejecutarse al confirmar una transacción
+type commitHooks struct {
+	fns []func()
+}
+
+// WithCommitHooks prepara ctx para que AfterCommit acumule funciones en vez de
+// ejecutarlas; commit las ejecuta en orden. Lo usan las implementaciones de
+// Transactor: commit solo se llama si la transacción se confirma.
+func WithCommitHooks(ctx context.Context) (context.Context, func()) {
+	hooks := &commitHooks{}
+	commit := func() {
+		for _, fn := range hooks.fns {
+			fn()
+		}
+	}
+	return context.WithValue(ctx, commitHooksKey{}, hooks), commit
+}
+
+// AfterCommit ejecuta fn cuando se confirme la transacción de ctx, o en el acto
+// si ctx no pertenece a ninguna. Es para efectos que no se pueden deshacer
+// (p. ej. métricas): en un lote atómico revertido no deben contarse.
+func AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := ctx.Value(commitHooksKey{}).(*commitHooks); ok {
+		hooks.fns = append(hooks.fns, fn)
+		return
+	}
+	fn()
+}
+
+// transactionsUnsupported detecta el IllegalOperation (20) de un servidor standalone
+func transactionsUnsupported(err error) bool {
+	var serverErr mongo.ServerError
+	return errors.As(err, &serverErr) && serverErr.HasErrorCode(20)
+}
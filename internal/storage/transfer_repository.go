@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TransferRepository implementa TransferStorer sobre la colección transfer_jobs.
+type TransferRepository struct {
+	jobs *mongo.Collection
+}
+
+// NewTransferRepository crea el repositorio de traspasos.
+func NewTransferRepository(db *mongo.Database) *TransferRepository {
+	return &TransferRepository{jobs: db.Collection("transfer_jobs")}
+}
+
+// CreateJob inserta el trabajo.
+func (r *TransferRepository) CreateJob(ctx context.Context, job *models.TransferJob) error {
+	if job.ID.IsZero() {
+		job.ID = primitive.NewObjectID()
+	}
+	now := time.Now().UTC()
+	job.CreatedAt = now
+	job.UpdatedAt = now
+
+	if _, err := r.jobs.InsertOne(ctx, job); err != nil {
+		return fmt.Errorf("error creating transfer job: %w", err)
+	}
+	return nil
+}
+
+// FindJob busca un trabajo por ID.
+func (r *TransferRepository) FindJob(ctx context.Context, jobID string) (*models.TransferJob, error) {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transfer job ID: %w", err)
+	}
+
+	var job models.TransferJob
+	if err := r.jobs.FindOne(ctx, bson.M{"_id": objID}).Decode(&job); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error finding transfer job: %w", err)
+	}
+	return &job, nil
+}
+
+// QueueJob vuelve a poner en cola el trabajo si su estado es uno de from.
+func (r *TransferRepository) QueueJob(ctx context.Context, jobID string, from []string) (*models.TransferJob, error) {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transfer job ID: %w", err)
+	}
+
+	update := bson.M{
+		"$set":   bson.M{"status": models.TransferQueued, "updatedAt": time.Now().UTC()},
+		"$unset": bson.M{"error": "", "finishedAt": "", "expiresAt": ""},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var job models.TransferJob
+	if err := r.jobs.FindOneAndUpdate(ctx, bson.M{"_id": objID, "status": bson.M{"$in": from}}, update, opts).Decode(&job); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error queueing transfer job: %w", err)
+	}
+	return &job, nil
+}
+
+// ClaimJob asigna un trabajo pendiente con una actualización atómica, así dos
+// réplicas nunca procesan el mismo trabajo a la vez.
+func (r *TransferRepository) ClaimJob(ctx context.Context, staleBefore time.Time) (*models.TransferJob, error) {
+	now := time.Now().UTC()
+	filter := bson.M{"$or": []bson.M{
+		{"status": models.TransferQueued},
+		{"status": models.TransferRunning, "heartbeatAt": bson.M{"$lt": staleBefore}},
+	}}
+	update := bson.M{
+		"$set": bson.M{"status": models.TransferRunning, "heartbeatAt": now, "updatedAt": now},
+		"$min": bson.M{"startedAt": now}, // Solo la primera vez
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "createdAt", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var job models.TransferJob
+	if err := r.jobs.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error claiming transfer job: %w", err)
+	}
+	return &job, nil
+}
+
+// SaveProgress guarda los contadores y renueva el latido del ejecutor.
+func (r *TransferRepository) SaveProgress(ctx context.Context, jobID string, counts models.TransferCounts) error {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return fmt.Errorf("invalid transfer job ID: %w", err)
+	}
+	now := time.Now().UTC()
+	_, err = r.jobs.UpdateOne(ctx,
+		bson.M{"_id": objID, "status": models.TransferRunning},
+		bson.M{"$set": bson.M{"counts": counts, "heartbeatAt": now, "updatedAt": now}},
+	)
+	if err != nil {
+		return fmt.Errorf("error saving transfer progress: %w", err)
+	}
+	return nil
+}
+
+// FinishJob cierra el trabajo.
+func (r *TransferRepository) FinishJob(ctx context.Context, jobID string, status string, counts models.TransferCounts, size int64, errMsg string) error {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return fmt.Errorf("invalid transfer job ID: %w", err)
+	}
+	now := time.Now().UTC()
+	set := bson.M{"status": status, "counts": counts, "updatedAt": now, "finishedAt": now, "expiresAt": now.Add(models.TransferArchiveTTL)}
+	if size > 0 {
+		set["size"] = size
+	}
+	if errMsg != "" {
+		set["error"] = errMsg
+	}
+	if _, err := r.jobs.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": set, "$unset": bson.M{"heartbeatAt": ""}}); err != nil {
+		return fmt.Errorf("error finishing transfer job: %w", err)
+	}
+	return nil
+}
+
+// ExpiredJobs devuelve los trabajos caducados, los más antiguos primero.
+func (r *TransferRepository) ExpiredJobs(ctx context.Context, before time.Time, limit int) ([]*models.TransferJob, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "expiresAt", Value: 1}}).SetLimit(int64(limit))
+	return r.find(ctx, bson.M{"expiresAt": bson.M{"$lte": before}}, opts)
+}
+
+// FindJobsByClinic devuelve los trabajos de una clínica.
+func (r *TransferRepository) FindJobsByClinic(ctx context.Context, clinicID string) ([]*models.TransferJob, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic ID: %w", err)
+	}
+	return r.find(ctx, bson.M{"clinicId": clinicObjID}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+}
+
+// DeleteJob borra el trabajo.
+func (r *TransferRepository) DeleteJob(ctx context.Context, jobID string) error {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return fmt.Errorf("invalid transfer job ID: %w", err)
+	}
+	if _, err := r.jobs.DeleteOne(ctx, bson.M{"_id": objID}); err != nil {
+		return fmt.Errorf("error deleting transfer job: %w", err)
+	}
+	return nil
+}
+
+func (r *TransferRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*models.TransferJob, error) {
+	cursor, err := r.jobs.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error finding transfer jobs: %w", err)
+	}
+	var jobs []*models.TransferJob
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, fmt.Errorf("error decoding transfer jobs: %w", err)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// TransferStorer guarda los trabajos de exportación e importación de clínicas
+// completas. Los archivos no se guardan aquí: solo el estado de cada trabajo.
+type TransferStorer interface {
+	// CreateJob guarda el trabajo. Si job.ID viene vacío se genera uno.
+	CreateJob(ctx context.Context, job *models.TransferJob) error
+	// FindJob busca un trabajo por ID. nil si no existe.
+	FindJob(ctx context.Context, jobID string) (*models.TransferJob, error)
+	// QueueJob vuelve a poner en cola el trabajo si su estado es uno de from.
+	// Devuelve nil si no existe o está en otro estado.
+	QueueJob(ctx context.Context, jobID string, from []string) (*models.TransferJob, error)
+	// ClaimJob asigna al ejecutor el trabajo en cola más antiguo, o uno en curso
+	// cuyo ejecutor no da señales desde antes de staleBefore. nil si no hay ninguno.
+	ClaimJob(ctx context.Context, staleBefore time.Time) (*models.TransferJob, error)
+	// SaveProgress guarda los contadores y renueva el latido del ejecutor.
+	SaveProgress(ctx context.Context, jobID string, counts models.TransferCounts) error
+	// FinishJob cierra el trabajo como completed o failed (errMsg explica el
+	// fallo) y lo hace caducar dentro de models.TransferArchiveTTL.
+	FinishJob(ctx context.Context, jobID string, status string, counts models.TransferCounts, size int64, errMsg string) error
+	// ExpiredJobs devuelve hasta limit trabajos caducados antes de before, los
+	// más antiguos primero.
+	ExpiredJobs(ctx context.Context, before time.Time, limit int) ([]*models.TransferJob, error)
+	// FindJobsByClinic devuelve los trabajos de una clínica (exportaciones de
+	// ella o importaciones que la crean).
+	FindJobsByClinic(ctx context.Context, clinicID string) ([]*models.TransferJob, error)
+	// DeleteJob borra el trabajo. No es un error si ya no existe.
+	DeleteJob(ctx context.Context, jobID string) error
+}
+
+// TransferHeartbeatTimeout es cuánto puede pasar sin latido antes de que otro
+// ejecutor retome un traspaso en curso (p. ej. si el proceso se reinició).
+const TransferHeartbeatTimeout = 2 * time.Minute
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UsageRepository implementa UsageStorer sobre MongoDB: un documento por
+// clínica, con el ID de la clínica como _id.
+type UsageRepository struct {
+	collection *mongo.Collection
+}
+
+// NewUsageRepository crea el repositorio de contadores de uso.
+func NewUsageRepository(db *mongo.Database) *UsageRepository {
+	return &UsageRepository{
+		collection: db.Collection("clinic_usage"),
+	}
+}
+
+// Get devuelve el uso de la clínica, o uno a cero si aún no tiene contadores.
+func (r *UsageRepository) Get(ctx context.Context, clinicID string) (*models.ClinicUsage, error) {
+	objID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic ID '%s': %w", clinicID, err)
+	}
+
+	var usage models.ClinicUsage
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&usage)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &models.ClinicUsage{ClinicID: objID}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find clinic usage: %w", err)
+	}
+	return &usage, nil
+}
+
+// Add suma n al contador. Con límite primero se asegura de que el documento
+// existe y después incrementa solo si el contador deja sitio: un filtro que no
+// coincide es un límite alcanzado, sin errores de clave duplicada que
+// abortarían la transacción en curso.
+func (r *UsageRepository) Add(ctx context.Context, clinicID, resource string, n, limit int64) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return false, fmt.Errorf("invalid clinic ID '%s': %w", clinicID, err)
+	}
+	if err := checkUsageResource(resource); err != nil {
+		return false, err
+	}
+	now := time.Now().UTC()
+
+	if n < 0 {
+		// Pipeline para no bajar de cero si los contadores se desajustaron
+		_, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{
+				resource:    bson.M{"$max": bson.A{0, bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$" + resource, 0}}, n}}}},
+				"updatedAt": now,
+			}}},
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to update clinic usage: %w", err)
+		}
+		return true, nil
+	}
+
+	inc := bson.M{"$inc": bson.M{resource: n}, "$set": bson.M{"updatedAt": now}}
+	if limit <= 0 {
+		if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, inc, options.Update().SetUpsert(true)); err != nil {
+			return false, fmt.Errorf("failed to update clinic usage: %w", err)
+		}
+		return true, nil
+	}
+	if n > limit {
+		return false, nil
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$setOnInsert": bson.M{
+		models.UsageUsers: int64(0), models.UsagePatients: int64(0), models.UsageStorage: int64(0), "updatedAt": now,
+	}}, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, fmt.Errorf("failed to create clinic usage: %w", err)
+	}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID, resource: bson.M{"$lte": limit - n}}, inc)
+	if err != nil {
+		return false, fmt.Errorf("failed to update clinic usage: %w", err)
+	}
+	return result.MatchedCount == 1, nil
+}
+
+// Set sustituye los contadores de la clínica.
+func (r *UsageRepository) Set(ctx context.Context, usage *models.ClinicUsage) error {
+	usage.UpdatedAt = time.Now().UTC()
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": usage.ClinicID}, usage, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save clinic usage: %w", err)
+	}
+	return nil
+}
+
+// Delete borra los contadores de la clínica.
+func (r *UsageRepository) Delete(ctx context.Context, clinicID string) error {
+	objID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return fmt.Errorf("invalid clinic ID '%s': %w", clinicID, err)
+	}
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID}); err != nil {
+		return fmt.Errorf("failed to delete clinic usage: %w", err)
+	}
+	return nil
+}
+
+// checkUsageResource rechaza los recursos que no son un contador de ClinicUsage
+func checkUsageResource(resource string) error {
+	switch resource {
+	case models.UsageUsers, models.UsagePatients, models.UsageStorage:
+		return nil
+	}
+	return fmt.Errorf("unknown usage resource %q", resource)
+}
@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// UsageStorer guarda los contadores de uso de cada clínica (ver models.ClinicUsage).
+type UsageStorer interface {
+	// Get devuelve el uso de la clínica; una clínica sin contadores tiene uso cero.
+	Get(ctx context.Context, clinicID string) (*models.ClinicUsage, error)
+	// Add suma n (negativo para restar) al recurso si el resultado no supera
+	// limit, y devuelve si lo sumó. limit <= 0 es ilimitado, y restar nunca se
+	// rechaza ni deja el contador por debajo de cero. Es atómico: dos
+	// escrituras simultáneas no pueden pasar a la vez del límite.
+	Add(ctx context.Context, clinicID, resource string, n, limit int64) (bool, error)
+	// Set sustituye los contadores de la clínica (p. ej. al recontarlos).
+	Set(ctx context.Context, usage *models.ClinicUsage) error
+	// Delete borra los contadores de la clínica.
+	Delete(ctx context.Context, clinicID string) error
+}
@@ -25,15 +25,20 @@ func NewUserRepository(db *mongo.Database) *UserRepository {
 	}
 }
 
-// Create inserta un nuevo usuario en la base de datos.
+// Create inserta un nuevo usuario en la base de datos. Si user.ID viene vacío se genera uno.
 func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
-	user.ID = primitive.NewObjectID()
+	if user.ID.IsZero() {
+		user.ID = primitive.NewObjectID()
+	}
 	now := time.Now().UTC()
 	user.CreatedAt = now
 	user.UpdatedAt = now
 
 	_, err := r.collection.InsertOne(ctx, user)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("%w: %v", ErrDuplicateUserEmail, err)
+		}
 		return fmt.Errorf("error al crear el usuario: %w", err)
 	}
 	return nil
@@ -46,7 +51,7 @@ func (r *UserRepository) FindByEmail(ctx context.Context, clinicID, email string
 		return nil, fmt.Errorf("ID de clínica inválido: %w", err)
 	}
 
-	filter := bson.M{"clinicId": clinicObjID, "email": email}
+	filter := bson.M{"clinicId": clinicObjID, "email": email, "deletedAt": bson.M{"$exists": false}}
 
 	var user models.User
 	if err := r.collection.FindOne(ctx, filter).Decode(&user); err != nil {
@@ -69,11 +74,131 @@ func (r *UserRepository) FindByID(ctx context.Context, clinicID, userID string)
 		return nil, fmt.Errorf("ID de usuario inválido: %w", err)
 	}
 
-	filter := bson.M{"_id": userObjID, "clinicId": clinicObjID}
+	filter := bson.M{"_id": userObjID, "clinicId": clinicObjID, "deletedAt": bson.M{"$exists": false}}
 
 	var user models.User
 	if err := r.collection.FindOne(ctx, filter).Decode(&user); err != nil {
 		return nil, fmt.Errorf("error al buscar usuario por ID: %w", err)
 	}
 	return &user, nil
-}
\ No newline at end of file
+}
+
+// CountByClinic cuenta los usuarios activos (no eliminados) de una clínica.
+func (r *UserRepository) CountByClinic(ctx context.Context, clinicID string) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{
+		"clinicId":  clinicObjID,
+		"deletedAt": bson.M{"$exists": false},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error al contar usuarios de la clínica: %w", err)
+	}
+	return count, nil
+}
+
+// SoftDeleteByClinic marca como eliminados todos los usuarios activos de una clínica.
+// Se usa la misma marca de tiempo que la clínica para poder restaurarlos juntos.
+func (r *UserRepository) SoftDeleteByClinic(ctx context.Context, clinicID string, deletedAt time.Time) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	result, err := r.collection.UpdateMany(ctx, bson.M{
+		"clinicId":  clinicObjID,
+		"deletedAt": bson.M{"$exists": false},
+	}, bson.M{
+		"$set": bson.M{"deletedAt": deletedAt, "updatedAt": deletedAt},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error al eliminar usuarios de la clínica: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
+
+// RestoreByClinic restaura los usuarios eliminados en cascada junto con su clínica.
+// Solo se restauran los que comparten la marca de tiempo de la eliminación de la clínica,
+// de modo que los usuarios eliminados por otros motivos siguen eliminados.
+func (r *UserRepository) RestoreByClinic(ctx context.Context, clinicID string, deletedAt time.Time) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	result, err := r.collection.UpdateMany(ctx, bson.M{
+		"clinicId":  clinicObjID,
+		"deletedAt": deletedAt,
+	}, bson.M{
+		"$unset": bson.M{"deletedAt": ""},
+		"$set":   bson.M{"updatedAt": time.Now().UTC()},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error al restaurar usuarios de la clínica: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
+
+// HardDeleteByClinic borra definitivamente todos los usuarios de una clínica.
+func (r *UserRepository) HardDeleteByClinic(ctx context.Context, clinicID string) (int64, error) {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return 0, fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+
+	result, err := r.collection.DeleteMany(ctx, bson.M{"clinicId": clinicObjID})
+	if err != nil {
+		return 0, fmt.Errorf("error al borrar usuarios de la clínica: %w", err)
+	}
+	return result.DeletedCount, nil
+}
+
+// ForEach recorre todos los usuarios (de todas las clínicas) sin cargarlos en memoria.
+// Es una operación administrativa: no forma parte de UserStorer.
+func (r *UserRepository) ForEach(ctx context.Context, fn func(user *models.User) error) error {
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return fmt.Errorf("error al recorrer usuarios: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var user models.User
+		if err := cursor.Decode(&user); err != nil {
+			return fmt.Errorf("error al decodificar usuario: %w", err)
+		}
+		if err := fn(&user); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// MarkPasswordNeedsRehash marca los usuarios indicados para regenerar su hash de contraseña.
+func (r *UserRepository) MarkPasswordNeedsRehash(ctx context.Context, userIDs []primitive.ObjectID) (int64, error) {
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+
+	result, err := r.collection.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": userIDs}},
+		bson.M{"$set": bson.M{"passwordNeedsRehash": true, "updatedAt": time.Now().UTC()}},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("error al marcar usuarios para rehash: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
+
+// StreamByClinic recorre por orden de ID los usuarios activos de una clínica.
+func (r *UserRepository) StreamByClinic(ctx context.Context, clinicID string, each func(*models.User) error) error {
+	clinicObjID, err := primitive.ObjectIDFromHex(clinicID)
+	if err != nil {
+		return fmt.Errorf("ID de clínica inválido: %w", err)
+	}
+	filter := bson.M{"clinicId": clinicObjID, "deletedAt": bson.M{"$exists": false}}
+	return streamDocs(ctx, r.collection, filter, each)
+}
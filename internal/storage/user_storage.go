@@ -2,14 +2,29 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/zabaletac3/go-vet-api/internal/models"
 )
 
+// ErrDuplicateUserEmail se devuelve cuando el índice único {clinicId, email} rechaza la escritura.
+var ErrDuplicateUserEmail = errors.New("user with that email already exists in this clinic")
+
 // UserStorer define la interfaz para las operaciones de la colección de usuarios.
 // Cada método recibe el `clinicId` para asegurar el aislamiento de los datos.
 type UserStorer interface {
 	Create(ctx context.Context, user *models.User) error
 	FindByEmail(ctx context.Context, clinicID, email string) (*models.User, error)
 	FindByID(ctx context.Context, clinicID, userID string) (*models.User, error)
-}
\ No newline at end of file
+
+	// Operaciones en cascada sobre todos los usuarios de una clínica.
+	CountByClinic(ctx context.Context, clinicID string) (int64, error)
+	SoftDeleteByClinic(ctx context.Context, clinicID string, deletedAt time.Time) (int64, error)
+	RestoreByClinic(ctx context.Context, clinicID string, deletedAt time.Time) (int64, error)
+	HardDeleteByClinic(ctx context.Context, clinicID string) (int64, error)
+
+	// StreamByClinic recorre por orden de ID los usuarios activos de una clínica
+	// sin cargarlos en memoria. Si each devuelve un error el recorrido se detiene.
+	StreamByClinic(ctx context.Context, clinicID string, each func(*models.User) error) error
+}
@@ -0,0 +1,212 @@
+// Package tenant define el archivo de portabilidad de una clínica completa: un
+// zip con un NDJSON por colección y un manifest.json con la versión del formato
+// y la suma SHA-256 y el número de registros de cada fichero. Los registros son
+// tipos propios (no los modelos) para que el formato no cambie con el esquema
+// de la base de datos.
+package tenant
+
+import (
+	"crypto/sha256"
+	"errors"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FormatVersion es la versión del formato del archivo. Se incrementa con
+// cualquier cambio incompatible; Open rechaza versiones que no conoce.
+const FormatVersion = 1
+
+// ManifestName es el nombre del manifiesto dentro del zip
+const ManifestName = "manifest.json"
+
+// Colecciones del archivo, en el orden en que se escriben y se importan (cada
+// una solo referencia a las anteriores)
+const (
+	CollectionClinic   = "clinic"
+	CollectionUsers    = "users"
+	CollectionOwners   = "owners"
+	CollectionPatients = "patients"
+)
+
+// Collections son todas las colecciones del archivo, en orden
+var Collections = []string{CollectionClinic, CollectionUsers, CollectionOwners, CollectionPatients}
+
+// Errores de un archivo que no se puede importar
+var (
+	ErrInvalidArchive     = errors.New("invalid tenant archive")
+	ErrUnsupportedVersion = errors.New("unsupported tenant archive version")
+	ErrChecksumMismatch   = errors.New("tenant archive checksum mismatch")
+)
+
+// Manifest describe el contenido del archivo
+type Manifest struct {
+	FormatVersion int       `json:"formatVersion"`
+	ExportedAt    time.Time `json:"exportedAt"`
+	ClinicID      string    `json:"clinicId"` // ID de la clínica en el entorno de origen
+	Files         []File    `json:"files"`
+}
+
+// File es un fichero NDJSON del archivo
+type File struct {
+	Name       string `json:"name"`
+	Collection string `json:"collection"`
+	Records    int    `json:"records"`
+	SHA256     string `json:"sha256"` // Hexadecimal
+}
+
+// file devuelve la entrada de la colección, o nil si no está en el manifiesto
+func (m *Manifest) file(collection string) *File {
+	for i := range m.Files {
+		if m.Files[i].Collection == collection {
+			return &m.Files[i]
+		}
+	}
+	return nil
+}
+
+// Clinic es el registro de la clínica
+type Clinic struct {
+	ID          primitive.ObjectID  `json:"id"`
+	Name        string              `json:"name"`
+	DisplayName string              `json:"displayName"`
+	Address     string              `json:"address,omitempty"`
+	Phone       string              `json:"phone,omitempty"`
+	Email       string              `json:"email,omitempty"`
+	Website     string              `json:"website,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Palette     models.ColorPalette `json:"palette"`
+	IsActive    bool                `json:"isActive"`
+	Locale      string              `json:"locale,omitempty"`
+	Plan        string              `json:"plan,omitempty"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	UpdatedAt   time.Time           `json:"updatedAt"`
+}
+
+// User es el registro de un usuario. Incluye el hash de la contraseña para que
+// los usuarios puedan iniciar sesión en el entorno de destino.
+type User struct {
+	ID           primitive.ObjectID `json:"id"`
+	FullName     string             `json:"fullName"`
+	Email        string             `json:"email"`
+	PasswordHash string             `json:"passwordHash"`
+	Role         string             `json:"role"`
+	CreatedAt    time.Time          `json:"createdAt"`
+	UpdatedAt    time.Time          `json:"updatedAt"`
+}
+
+// Owner es el registro de un tutor
+type Owner struct {
+	ID         primitive.ObjectID `json:"id"`
+	FullName   string             `json:"fullName"`
+	Email      string             `json:"email,omitempty"`
+	Phone      string             `json:"phone,omitempty"`
+	DocumentID string             `json:"documentId,omitempty"`
+	Address    string             `json:"address,omitempty"`
+	CreatedAt  time.Time          `json:"createdAt"`
+	UpdatedAt  time.Time          `json:"updatedAt"`
+}
+
+// Patient es el registro de un paciente; OwnerID referencia a un Owner del archivo
+type Patient struct {
+	ID        primitive.ObjectID `json:"id"`
+	OwnerID   primitive.ObjectID `json:"ownerId"`
+	Name      string             `json:"name"`
+	Species   string             `json:"species"`
+	Breed     string             `json:"breed,omitempty"`
+	Sex       string             `json:"sex,omitempty"`
+	BirthDate *time.Time         `json:"birthDate,omitempty"`
+	WeightKg  float64            `json:"weightKg,omitempty"`
+	Microchip string             `json:"microchip,omitempty"`
+	Notes     string             `json:"notes,omitempty"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+// NewClinic crea el registro de una clínica
+func NewClinic(c *models.Clinic) Clinic {
+	return Clinic{
+		ID: c.ID, Name: c.Name, DisplayName: c.DisplayName, Address: c.Address, Phone: c.Phone,
+		Email: c.Email, Website: c.Website, Description: c.Description, Palette: c.Palette,
+		IsActive: c.IsActive, Locale: c.Locale, Plan: c.Plan, CreatedAt: c.CreatedAt, UpdatedAt: c.UpdatedAt,
+	}
+}
+
+// NewUser crea el registro de un usuario
+func NewUser(u *models.User) User {
+	return User{
+		ID: u.ID, FullName: u.FullName, Email: u.Email, PasswordHash: u.HashedPassword,
+		Role: u.Role, CreatedAt: u.CreatedAt, UpdatedAt: u.UpdatedAt,
+	}
+}
+
+// NewOwner crea el registro de un tutor
+func NewOwner(o *models.Owner) Owner {
+	return Owner{
+		ID: o.ID, FullName: o.FullName, Email: o.Email, Phone: o.Phone, DocumentID: o.DocumentID,
+		Address: o.Address, CreatedAt: o.CreatedAt, UpdatedAt: o.UpdatedAt,
+	}
+}
+
+// NewPatient crea el registro de un paciente
+func NewPatient(p *models.Patient) Patient {
+	return Patient{
+		ID: p.ID, OwnerID: p.OwnerID, Name: p.Name, Species: p.Species, Breed: p.Breed, Sex: p.Sex,
+		BirthDate: p.BirthDate, WeightKg: p.WeightKg, Microchip: p.Microchip, Notes: p.Notes,
+		CreatedAt: p.CreatedAt, UpdatedAt: p.UpdatedAt,
+	}
+}
+
+// Model crea la clínica a importar con su ID nuevo
+func (c Clinic) Model(ids Remapper) *models.Clinic {
+	return &models.Clinic{
+		ID: ids.ID(c.ID), Name: c.Name, DisplayName: c.DisplayName, Address: c.Address, Phone: c.Phone,
+		Email: c.Email, Website: c.Website, Description: c.Description, Palette: c.Palette, Locale: c.Locale,
+		Plan: c.Plan,
+	}
+}
+
+// Model crea el usuario a importar con su ID nuevo en la clínica nueva
+func (u User) Model(ids Remapper, clinicID primitive.ObjectID) *models.User {
+	return &models.User{
+		ID: ids.ID(u.ID), ClinicID: clinicID, FullName: u.FullName, Email: u.Email,
+		HashedPassword: u.PasswordHash, Role: u.Role,
+	}
+}
+
+// Model crea el tutor a importar con su ID nuevo en la clínica nueva
+func (o Owner) Model(ids Remapper, clinicID primitive.ObjectID) *models.Owner {
+	return &models.Owner{
+		ID: ids.ID(o.ID), ClinicID: clinicID, FullName: o.FullName, Email: o.Email, Phone: o.Phone,
+		DocumentID: o.DocumentID, Address: o.Address,
+	}
+}
+
+// Model crea el paciente a importar con su ID nuevo en la clínica nueva; el
+// tutor se remapea igual que al importarlo, así que la referencia se conserva
+func (p Patient) Model(ids Remapper, clinicID primitive.ObjectID) *models.Patient {
+	return &models.Patient{
+		ID: ids.ID(p.ID), ClinicID: clinicID, OwnerID: ids.ID(p.OwnerID), Name: p.Name, Species: p.Species,
+		Breed: p.Breed, Sex: p.Sex, BirthDate: p.BirthDate, WeightKg: p.WeightKg, Microchip: p.Microchip,
+		Notes: p.Notes,
+	}
+}
+
+// Remapper asigna los IDs nuevos de una importación. Cada ID se deriva del ID
+// original y de la semilla (el ID del trabajo): las referencias entre registros
+// se conservan sin guardar una tabla de correspondencias, y reanudar la
+// importación produce los mismos IDs, así que lo ya creado se reconoce.
+type Remapper struct {
+	Seed primitive.ObjectID
+}
+
+// ID devuelve el ID nuevo de old. Conserva los 4 bytes de la marca de tiempo
+// del original para que el orden por _id (y por fecha de creación) se mantenga.
+func (m Remapper) ID(old primitive.ObjectID) primitive.ObjectID {
+	sum := sha256.Sum256(append(m.Seed[:], old[:]...))
+	var id primitive.ObjectID
+	copy(id[:4], old[:4])
+	copy(id[4:], sum[:8])
+	return id
+}
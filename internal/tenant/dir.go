@@ -0,0 +1,45 @@
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// dir es donde se guardan los archivos de los traspasos (exportados y subidos
+// para importar), uno por trabajo. Con varias réplicas debe ser compartido.
+var dir = filepath.Join(os.TempDir(), "go-vet-api-transfers")
+
+// SetDir cambia el directorio de los archivos y lo crea si no existe. Vacío
+// mantiene el predeterminado, dentro del directorio temporal del sistema.
+func SetDir(path string) error {
+	if path == "" {
+		path = dir
+	}
+	if err := os.MkdirAll(path, 0o750); err != nil {
+		return fmt.Errorf("failed to create transfer directory: %w", err)
+	}
+	dir = path
+	return nil
+}
+
+// Path es la ruta del archivo del trabajo jobID
+func Path(jobID primitive.ObjectID) string {
+	return filepath.Join(dir, jobID.Hex()+".zip")
+}
+
+// Remove borra el archivo del trabajo jobID. No es un error si no existe.
+func Remove(jobID primitive.ObjectID) error {
+	if err := os.Remove(Path(jobID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove transfer archive: %w", err)
+	}
+	return nil
+}
+
+// Dir es el directorio de los archivos de los traspasos
+func Dir() string {
+	return dir
+}
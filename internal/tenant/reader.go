@@ -0,0 +1,222 @@
+package tenant
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Archive es un archivo de portabilidad abierto para leer
+type Archive struct {
+	Manifest Manifest
+
+	zr    *zip.ReadCloser
+	files map[string]*zip.File
+}
+
+// Open abre el archivo de path y lee su manifiesto. No comprueba el contenido
+// de las colecciones: para eso está Verify.
+func Open(path string) (*Archive, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	a := &Archive{zr: zr, files: make(map[string]*zip.File, len(zr.File))}
+	for _, f := range zr.File {
+		a.files[f.Name] = f
+	}
+
+	if err := a.readManifest(); err != nil {
+		zr.Close()
+		return nil, err
+	}
+	return a, nil
+}
+
+// Close cierra el archivo
+func (a *Archive) Close() error {
+	return a.zr.Close()
+}
+
+// ClinicID es el ID de la clínica en el entorno de origen
+func (a *Archive) ClinicID() primitive.ObjectID {
+	id, _ := primitive.ObjectIDFromHex(a.Manifest.ClinicID)
+	return id
+}
+
+func (a *Archive) readManifest() error {
+	f, ok := a.files[ManifestName]
+	if !ok {
+		return fmt.Errorf("%w: missing %s", ErrInvalidArchive, ManifestName)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	defer rc.Close()
+
+	if err := json.NewDecoder(rc).Decode(&a.Manifest); err != nil {
+		return fmt.Errorf("%w: unreadable manifest: %v", ErrInvalidArchive, err)
+	}
+	if a.Manifest.FormatVersion != FormatVersion {
+		return fmt.Errorf("%w: %d (supported: %d)", ErrUnsupportedVersion, a.Manifest.FormatVersion, FormatVersion)
+	}
+	if _, err := primitive.ObjectIDFromHex(a.Manifest.ClinicID); err != nil {
+		return fmt.Errorf("%w: invalid clinicId in manifest", ErrInvalidArchive)
+	}
+	for _, collection := range Collections {
+		entry := a.Manifest.file(collection)
+		if entry == nil {
+			return fmt.Errorf("%w: collection %s missing from manifest", ErrInvalidArchive, collection)
+		}
+		if _, ok := a.files[entry.Name]; !ok {
+			return fmt.Errorf("%w: missing %s", ErrInvalidArchive, entry.Name)
+		}
+	}
+	return nil
+}
+
+// Verify lee el archivo completo y comprueba la suma SHA-256 y el número de
+// registros de cada colección, que los IDs no se repitan y que cada paciente
+// referencie a un tutor del archivo. Así una importación no se queda a medias
+// por un archivo dañado o manipulado.
+func (a *Archive) Verify() error {
+	var clinics int
+	err := a.verify(CollectionClinic, func(dec *json.Decoder) error {
+		var c Clinic
+		if err := dec.Decode(&c); err != nil {
+			return err
+		}
+		clinics++
+		if c.ID.Hex() != a.Manifest.ClinicID {
+			return fmt.Errorf("clinic id does not match the manifest")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if clinics != 1 {
+		return fmt.Errorf("%w: expected 1 clinic record, found %d", ErrInvalidArchive, clinics)
+	}
+
+	users, emails := ids{}, map[string]bool{}
+	err = a.verify(CollectionUsers, func(dec *json.Decoder) error {
+		var u User
+		if err := dec.Decode(&u); err != nil {
+			return err
+		}
+		if emails[u.Email] {
+			return fmt.Errorf("duplicate user email %s", u.Email)
+		}
+		emails[u.Email] = true
+		return users.add(u.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	owners := ids{}
+	err = a.verify(CollectionOwners, func(dec *json.Decoder) error {
+		var o Owner
+		if err := dec.Decode(&o); err != nil {
+			return err
+		}
+		return owners.add(o.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	patients := ids{}
+	return a.verify(CollectionPatients, func(dec *json.Decoder) error {
+		var p Patient
+		if err := dec.Decode(&p); err != nil {
+			return err
+		}
+		if !owners[p.OwnerID] {
+			return fmt.Errorf("patient %s references unknown owner %s", p.ID.Hex(), p.OwnerID.Hex())
+		}
+		return patients.add(p.ID)
+	})
+}
+
+// verify recorre los registros de collection con decode, uno por llamada, y
+// compara la suma y el recuento con el manifiesto
+func (a *Archive) verify(collection string, decode func(dec *json.Decoder) error) error {
+	entry := a.Manifest.file(collection)
+	rc, err := a.files[entry.Name].Open()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	defer rc.Close()
+
+	sum := sha256.New()
+	dec := json.NewDecoder(io.TeeReader(rc, sum))
+	records := 0
+	for dec.More() {
+		if err := decode(dec); err != nil {
+			return fmt.Errorf("%w: %s record %d: %v", ErrInvalidArchive, collection, records+1, err)
+		}
+		records++
+	}
+	// El decoder puede no haber leído el final del fichero: también cuenta en la suma
+	if _, err := io.Copy(sum, rc); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+
+	if records != entry.Records {
+		return fmt.Errorf("%w: %s has %d records, manifest says %d", ErrChecksumMismatch, collection, records, entry.Records)
+	}
+	if got := hex.EncodeToString(sum.Sum(nil)); got != entry.SHA256 {
+		return fmt.Errorf("%w: %s", ErrChecksumMismatch, collection)
+	}
+	return nil
+}
+
+// Each llama a fn con cada registro de collection, en el orden del archivo
+func Each[T any](a *Archive, collection string, fn func(*T) error) error {
+	entry := a.Manifest.file(collection)
+	if entry == nil {
+		return fmt.Errorf("%w: collection %s missing from manifest", ErrInvalidArchive, collection)
+	}
+	rc, err := a.files[entry.Name].Open()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	defer rc.Close()
+
+	dec := json.NewDecoder(rc)
+	for {
+		var record T
+		if err := dec.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("%w: %s: %v", ErrInvalidArchive, collection, err)
+		}
+		if err := fn(&record); err != nil {
+			return err
+		}
+	}
+}
+
+// ids detecta IDs repetidos dentro de una colección
+type ids map[primitive.ObjectID]bool
+
+func (s ids) add(id primitive.ObjectID) error {
+	if id.IsZero() {
+		return fmt.Errorf("missing id")
+	}
+	if s[id] {
+		return fmt.Errorf("duplicate id %s", id.Hex())
+	}
+	s[id] = true
+	return nil
+}
@@ -0,0 +1,101 @@
+package tenant
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Writer escribe un archivo de portabilidad. Las colecciones se escriben una
+// tras otra (Begin y después Add con cada registro); Close añade el manifiesto.
+type Writer struct {
+	zw       *zip.Writer
+	manifest Manifest
+
+	current *File
+	out     io.Writer
+	sum     hash.Hash
+}
+
+// NewWriter empieza un archivo de la clínica clinicID sobre w
+func NewWriter(w io.Writer, clinicID primitive.ObjectID) *Writer {
+	return &Writer{
+		zw: zip.NewWriter(w),
+		manifest: Manifest{
+			FormatVersion: FormatVersion,
+			ExportedAt:    time.Now().UTC(),
+			ClinicID:      clinicID.Hex(),
+		},
+	}
+}
+
+// Begin cierra la colección en curso y empieza el fichero de collection
+func (w *Writer) Begin(collection string) error {
+	w.finish()
+
+	entry, err := w.zw.CreateHeader(&zip.FileHeader{
+		Name:     collection + ".ndjson",
+		Method:   zip.Deflate,
+		Modified: w.manifest.ExportedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", collection, err)
+	}
+	w.current = &File{Name: collection + ".ndjson", Collection: collection}
+	w.sum = sha256.New()
+	w.out = io.MultiWriter(entry, w.sum)
+	return nil
+}
+
+// Add escribe un registro en la colección en curso
+func (w *Writer) Add(record any) error {
+	if w.current == nil {
+		return fmt.Errorf("no collection started in archive")
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s record: %w", w.current.Collection, err)
+	}
+	if _, err := w.out.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write %s record: %w", w.current.Collection, err)
+	}
+	w.current.Records++
+	return nil
+}
+
+// Close cierra la última colección, escribe el manifiesto y termina el zip
+func (w *Writer) Close() error {
+	w.finish()
+
+	entry, err := w.zw.CreateHeader(&zip.FileHeader{
+		Name:     ManifestName,
+		Method:   zip.Deflate,
+		Modified: w.manifest.ExportedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add manifest to archive: %w", err)
+	}
+	enc := json.NewEncoder(entry)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(w.manifest); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return w.zw.Close()
+}
+
+// finish registra en el manifiesto la colección en curso
+func (w *Writer) finish() {
+	if w.current == nil {
+		return
+	}
+	w.current.SHA256 = hex.EncodeToString(w.sum.Sum(nil))
+	w.manifest.Files = append(w.manifest.Files, *w.current)
+	w.current = nil
+}
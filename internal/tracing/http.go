@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/recorder"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/requestid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware crea el span de servidor de cada petición, como hijo del
+// traceparent entrante si lo hay, y devuelve traceparent en la respuesta. Va
+// por dentro de requestid.Middleware (el ID queda en el span) y por fuera de
+// metrics.Middleware y del mux: el nombre del span es el
+// patrón de ruta que el mux deja en la petición (p. ej. "GET /api/v1/clinics/{id}").
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		propagator := otel.GetTextMapPropagator()
+		ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLPath(r.URL.Path),
+				attribute.String("http.request_id", requestid.FromContext(r.Context())),
+			),
+		)
+		defer span.End()
+
+		propagator.Inject(ctx, propagation.HeaderCarrier(w.Header()))
+
+		rec := recorder.Wrap(w)
+		r = r.WithContext(ctx)
+		next.ServeHTTP(rec, r)
+
+		if r.Pattern != "" {
+			span.SetName(r.Pattern)
+			// El patrón puede llevar método y host delante de la ruta
+			route := r.Pattern[strings.Index(r.Pattern, "/"):]
+			span.SetAttributes(semconv.HTTPRoute(route))
+		}
+		status := rec.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		// En un span de servidor solo los 5xx son errores
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	})
+}
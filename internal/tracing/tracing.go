@@ -0,0 +1,137 @@
+// Package tracing configura las trazas distribuidas con OpenTelemetry: el
+// proveedor y su exportador, la propagación W3C (traceparent) y los helpers
+// para crear spans en las capas de la API.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName identifica los spans creados por la API
+const InstrumentationName = "github.com/zabaletac3/go-vet-api"
+
+// Exportadores admitidos en Options.Exporter
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterNone   = "none"
+)
+
+// ErrUnknownExporter se devuelve con un Options.Exporter no admitido
+var ErrUnknownExporter = errors.New("unknown tracing exporter")
+
+// Options configura Setup
+type Options struct {
+	Exporter    string // otlp, stdout o none
+	Endpoint    string // URL del colector OTLP/HTTP; vacío: OTEL_EXPORTER_OTLP_ENDPOINT o localhost:4318
+	ServiceName string
+	SampleRatio float64 // Fracción de trazas nuevas que se guardan (0 a 1)
+
+	// SpanExporter sustituye a Exporter (p. ej. tracetest.NewInMemoryExporter
+	// para comprobar los spans en pruebas)
+	SpanExporter sdktrace.SpanExporter
+}
+
+// Setup instala el proveedor de trazas global y la propagación W3C. La
+// propagación se instala siempre, también con el exportador none: así el
+// trace_id de un traceparent entrante llega a los logs aunque aquí no se
+// guarden spans. shutdown envía los spans pendientes; se llama al apagar.
+func Setup(ctx context.Context, opts Options) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter := opts.SpanExporter
+	if exporter == nil {
+		exporter, err = newExporter(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(opts.ServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// newExporter crea el exportador de opts.Exporter; nil con none
+func newExporter(ctx context.Context, opts Options) (sdktrace.SpanExporter, error) {
+	switch opts.Exporter {
+	case ExporterOTLP:
+		var clientOpts []otlptracehttp.Option
+		if opts.Endpoint != "" {
+			clientOpts = append(clientOpts, otlptracehttp.WithEndpointURL(opts.Endpoint))
+		}
+		exporter, err := otlptracehttp.New(ctx, clientOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		}
+		return exporter, nil
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+		return exporter, nil
+	case ExporterNone, "":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%w %q (use otlp, stdout or none)", ErrUnknownExporter, opts.Exporter)
+	}
+}
+
+// Tracer devuelve el tracer de la API. Se pide al proveedor global en cada
+// llamada para que los spans usen el instalado por Setup.
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// Start crea un span hijo del de ctx
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
+
+// Fail registra err en el span y lo marca como fallido
+func Fail(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// End registra err en el span (si no es nil) y lo cierra
+func End(span trace.Span, err error) {
+	if err != nil {
+		Fail(span, err)
+	}
+	span.End()
+}
@@ -0,0 +1,186 @@
+// Package apierror es el registro central que traduce los errores de los
+// servicios a respuestas problem+json con un código estable. Los handlers no
+// deciden el status de cada error: llaman a Write y el registro lo resuelve.
+package apierror
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/zabaletac3/go-vet-api/internal/blob"
+	"github.com/zabaletac3/go-vet-api/internal/branding"
+	"github.com/zabaletac3/go-vet-api/internal/importer"
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/palette"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/tenant"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/logging"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+)
+
+// Códigos de dominio. El nombre es parte del contrato de la API: no se renombra.
+var (
+	CodeClinicNameRequired        = response.Code{Name: "CLINIC_NAME_REQUIRED", Status: http.StatusBadRequest, Title: "Clinic Name Required"}
+	CodeInvalidClinicName         = response.Code{Name: "INVALID_CLINIC_NAME", Status: http.StatusBadRequest, Title: "Invalid Clinic Name"}
+	CodeDisplayNameRequired       = response.Code{Name: "DISPLAY_NAME_REQUIRED", Status: http.StatusBadRequest, Title: "Display Name Required"}
+	CodeInvalidDisplayName        = response.Code{Name: "INVALID_DISPLAY_NAME", Status: http.StatusBadRequest, Title: "Invalid Display Name"}
+	CodeInvalidPaletteColor       = response.Code{Name: "INVALID_PALETTE_COLOR", Status: http.StatusBadRequest, Title: "Invalid Palette Color"}
+	CodeClinicNameTaken           = response.Code{Name: "CLINIC_NAME_TAKEN", Status: http.StatusConflict, Title: "Clinic Name Taken"}
+	CodeClinicDisplayNameTaken    = response.Code{Name: "CLINIC_DISPLAY_NAME_TAKEN", Status: http.StatusConflict, Title: "Clinic Display Name Taken"}
+	CodeClinicNotFound            = response.Code{Name: "CLINIC_NOT_FOUND", Status: http.StatusNotFound, Title: "Clinic Not Found"}
+	CodeInvalidClinicID           = response.Code{Name: "INVALID_CLINIC_ID", Status: http.StatusBadRequest, Title: "Invalid Clinic ID"}
+	CodeClinicHasUsers            = response.Code{Name: "CLINIC_HAS_USERS", Status: http.StatusConflict, Title: "Clinic Has Users"}
+	CodeClinicVersionConflict     = response.Code{Name: "CLINIC_VERSION_CONFLICT", Status: http.StatusPreconditionFailed, Title: "Clinic Version Conflict"}
+	CodeInvalidCursor             = response.Code{Name: "INVALID_CURSOR", Status: http.StatusBadRequest, Title: "Invalid Cursor"}
+	CodeUserEmailTaken            = response.Code{Name: "USER_EMAIL_TAKEN", Status: http.StatusConflict, Title: "User Email Taken"}
+	CodePasswordTooShort          = response.Code{Name: "PASSWORD_TOO_SHORT", Status: http.StatusBadRequest, Title: "Password Too Short"}
+	CodeSearchQueryRequired       = response.Code{Name: "SEARCH_QUERY_REQUIRED", Status: http.StatusBadRequest, Title: "Search Query Required"}
+	CodeSearchClinicRequired      = response.Code{Name: "SEARCH_CLINIC_REQUIRED", Status: http.StatusBadRequest, Title: "Search Clinic Required"}
+	CodeTransactionsUnsupported   = response.Code{Name: "TRANSACTIONS_UNSUPPORTED", Status: http.StatusNotImplemented, Title: "Transactions Unsupported"}
+	CodeInvalidSearchType         = response.Code{Name: "INVALID_SEARCH_TYPE", Status: http.StatusBadRequest, Title: "Invalid Search Type"}
+	CodeImportNotFound            = response.Code{Name: "IMPORT_NOT_FOUND", Status: http.StatusNotFound, Title: "Import Not Found"}
+	CodeInvalidImportID           = response.Code{Name: "INVALID_IMPORT_ID", Status: http.StatusBadRequest, Title: "Invalid Import ID"}
+	CodeImportStateConflict       = response.Code{Name: "IMPORT_STATE_CONFLICT", Status: http.StatusConflict, Title: "Import State Conflict"}
+	CodeUnsupportedImportFormat   = response.Code{Name: "UNSUPPORTED_IMPORT_FORMAT", Status: http.StatusUnsupportedMediaType, Title: "Unsupported Import Format"}
+	CodeEmptyImportFile           = response.Code{Name: "EMPTY_IMPORT_FILE", Status: http.StatusBadRequest, Title: "Empty Import File"}
+	CodeImportTooManyRows         = response.Code{Name: "IMPORT_TOO_MANY_ROWS", Status: http.StatusRequestEntityTooLarge, Title: "Import Too Many Rows"}
+	CodeUnreadableImportFile      = response.Code{Name: "UNREADABLE_IMPORT_FILE", Status: http.StatusBadRequest, Title: "Unreadable Import File"}
+	CodeTransferNotFound          = response.Code{Name: "TRANSFER_NOT_FOUND", Status: http.StatusNotFound, Title: "Transfer Not Found"}
+	CodeInvalidTransferID         = response.Code{Name: "INVALID_TRANSFER_ID", Status: http.StatusBadRequest, Title: "Invalid Transfer ID"}
+	CodeTransferStateConflict     = response.Code{Name: "TRANSFER_STATE_CONFLICT", Status: http.StatusConflict, Title: "Transfer State Conflict"}
+	CodeInvalidTenantArchive      = response.Code{Name: "INVALID_TENANT_ARCHIVE", Status: http.StatusBadRequest, Title: "Invalid Tenant Archive"}
+	CodeUnsupportedArchive        = response.Code{Name: "UNSUPPORTED_ARCHIVE_VERSION", Status: http.StatusUnprocessableEntity, Title: "Unsupported Archive Version"}
+	CodeArchiveChecksumMismatch   = response.Code{Name: "ARCHIVE_CHECKSUM_MISMATCH", Status: http.StatusUnprocessableEntity, Title: "Archive Checksum Mismatch"}
+	CodeInvalidPlan               = response.Code{Name: "INVALID_PLAN", Status: http.StatusBadRequest, Title: "Invalid Plan"}
+	CodePlanLimitExceeded         = response.Code{Name: "PLAN_LIMIT_EXCEEDED", Status: http.StatusPaymentRequired, Title: "Plan Limit Exceeded"}
+	CodeInvalidFeatureKey         = response.Code{Name: "INVALID_FEATURE_KEY", Status: http.StatusBadRequest, Title: "Invalid Feature Key"}
+	CodeInvalidRollout            = response.Code{Name: "INVALID_ROLLOUT", Status: http.StatusBadRequest, Title: "Invalid Rollout"}
+	CodeModuleDisabled            = response.Code{Name: "MODULE_DISABLED", Status: http.StatusNotFound, Title: "Module Disabled"}
+	CodeInvalidAssetKind          = response.Code{Name: "INVALID_ASSET_KIND", Status: http.StatusBadRequest, Title: "Invalid Image Kind"}
+	CodeAssetNotFound             = response.Code{Name: "ASSET_NOT_FOUND", Status: http.StatusNotFound, Title: "Image Not Found"}
+	CodeUnsupportedImage          = response.Code{Name: "UNSUPPORTED_IMAGE", Status: http.StatusUnsupportedMediaType, Title: "Unsupported Image"}
+	CodeImageTooLarge             = response.Code{Name: "IMAGE_TOO_LARGE", Status: http.StatusRequestEntityTooLarge, Title: "Image Too Large"}
+	CodeInvalidImageDimensions    = response.Code{Name: "INVALID_IMAGE_DIMENSIONS", Status: http.StatusBadRequest, Title: "Invalid Image Dimensions"}
+	CodeUnreadableImage           = response.Code{Name: "UNREADABLE_IMAGE", Status: http.StatusBadRequest, Title: "Unreadable Image"}
+	CodeInvalidColor              = response.Code{Name: "INVALID_COLOR", Status: http.StatusBadRequest, Title: "Invalid Colour"}
+	CodeNoImageColors             = response.Code{Name: "NO_IMAGE_COLORS", Status: http.StatusBadRequest, Title: "No Colours In Image"}
+	CodeInsufficientContrast      = response.Code{Name: "INSUFFICIENT_CONTRAST", Status: http.StatusBadRequest, Title: "Insufficient Contrast"}
+	CodeAttachmentNotFound        = response.Code{Name: "ATTACHMENT_NOT_FOUND", Status: http.StatusNotFound, Title: "Attachment Not Found"}
+	CodeInvalidAttachmentID       = response.Code{Name: "INVALID_ATTACHMENT_ID", Status: http.StatusBadRequest, Title: "Invalid Attachment ID"}
+	CodeInvalidAttachmentCategory = response.Code{Name: "INVALID_ATTACHMENT_CATEGORY", Status: http.StatusBadRequest, Title: "Invalid Attachment Category"}
+	CodeEmptyAttachment           = response.Code{Name: "EMPTY_ATTACHMENT", Status: http.StatusBadRequest, Title: "Empty Attachment"}
+	CodeAttachmentTooLarge        = response.Code{Name: "ATTACHMENT_TOO_LARGE", Status: http.StatusRequestEntityTooLarge, Title: "Attachment Too Large"}
+	CodeInfectedFile              = response.Code{Name: "INFECTED_FILE", Status: http.StatusUnprocessableEntity, Title: "Infected File"}
+	CodeScanUnavailable           = response.Code{Name: "SCAN_UNAVAILABLE", Status: http.StatusServiceUnavailable, Title: "Antivirus Unavailable"}
+	CodePatientNotFound           = response.Code{Name: "PATIENT_NOT_FOUND", Status: http.StatusNotFound, Title: "Patient Not Found"}
+	CodeInvalidPatientID          = response.Code{Name: "INVALID_PATIENT_ID", Status: http.StatusBadRequest, Title: "Invalid Patient ID"}
+	CodeFileNotFound              = response.Code{Name: "FILE_NOT_FOUND", Status: http.StatusNotFound, Title: "File Not Found"}
+	CodeInvalidDownloadSignature  = response.Code{Name: "INVALID_DOWNLOAD_SIGNATURE", Status: http.StatusForbidden, Title: "Invalid Download Signature"}
+	CodeDownloadURLExpired        = response.Code{Name: "DOWNLOAD_URL_EXPIRED", Status: http.StatusForbidden, Title: "Download URL Expired"}
+)
+
+// entry asocia un error centinela con su código y su detalle. El detalle es un
+// texto fijo (no err.Error()) para que tenga traducción en el catálogo de i18n.
+type entry struct {
+	err    error
+	code   response.Code
+	detail string
+}
+
+// registry se recorre en orden con errors.Is, así que los errores envueltos
+// también se resuelven.
+var registry = []entry{
+	{services.ErrClinicNameRequired, CodeClinicNameRequired, "Clinic name is required"},
+	{models.ErrInvalidClinicName, CodeClinicNameRequired, "Clinic name is required"},
+	{models.ErrInvalidClinicNameLength, CodeInvalidClinicName, "Clinic name must be between 2 and 100 characters"},
+	{models.ErrInvalidDisplayName, CodeDisplayNameRequired, "Display name is required"},
+	{models.ErrInvalidDisplayNameLength, CodeInvalidDisplayName, "Display name must be between 2 and 150 characters"},
+	{models.ErrInvalidPrimaryColor, CodeInvalidPaletteColor, "Palette colors must be hex colors like #1A2B3C"},
+	{models.ErrInvalidSecondaryColor, CodeInvalidPaletteColor, "Palette colors must be hex colors like #1A2B3C"},
+	{models.ErrInvalidTertiaryColor, CodeInvalidPaletteColor, "Palette colors must be hex colors like #1A2B3C"},
+	{models.ErrInvalidQuaternaryColor, CodeInvalidPaletteColor, "Palette colors must be hex colors like #1A2B3C"},
+	{models.ErrInvalidBackgroundColor, CodeInvalidPaletteColor, "Palette colors must be hex colors like #1A2B3C"},
+	{services.ErrClinicNameExists, CodeClinicNameTaken, "A clinic with that name already exists"},
+	{services.ErrDisplayNameExists, CodeClinicDisplayNameTaken, "A clinic with that display name already exists"},
+	{services.ErrClinicNotFound, CodeClinicNotFound, "Clinic not found"},
+	{services.ErrInvalidClinicID, CodeInvalidClinicID, "Invalid clinic ID"},
+	{services.ErrClinicHasUsers, CodeClinicHasUsers, "Clinic still has users; delete with cascade=true to remove them"},
+	{services.ErrClinicVersionConflict, CodeClinicVersionConflict, "Clinic was modified; fetch it again and retry"},
+	{services.ErrInvalidCursor, CodeInvalidCursor, "Invalid or expired cursor for this listing"},
+	{services.ErrUserAlreadyExists, CodeUserEmailTaken, "A user with that email already exists in this clinic"},
+	{services.ErrPasswordTooShort, CodePasswordTooShort, "Password must be at least 8 characters long"},
+	{services.ErrSearchQueryRequired, CodeSearchQueryRequired, "Search query must contain at least one letter or digit"},
+	{services.ErrSearchClinicRequired, CodeSearchClinicRequired, "clinicId is required to search owners and pets"},
+	{storage.ErrTransactionsUnsupported, CodeTransactionsUnsupported, "Atomic batches need a MongoDB replica set; use mode best_effort"},
+	{services.ErrInvalidSearchType, CodeInvalidSearchType, "Invalid search type; use clinics, owners or pets"},
+	{services.ErrImportNotFound, CodeImportNotFound, "Import not found"},
+	{services.ErrInvalidImportID, CodeInvalidImportID, "Invalid import ID"},
+	{services.ErrImportState, CodeImportStateConflict, "Only uploaded imports can be committed and only failed imports can be resumed"},
+	{importer.ErrUnsupportedFormat, CodeUnsupportedImportFormat, "Upload a .csv or .xlsx file"},
+	{importer.ErrEmptySheet, CodeEmptyImportFile, "The file has no header row"},
+	{importer.ErrTooManyRows, CodeImportTooManyRows, "The file has more than 10000 rows"},
+	{importer.ErrUnreadableFile, CodeUnreadableImportFile, "The file could not be read as CSV or XLSX"},
+	{services.ErrTransferNotFound, CodeTransferNotFound, "Transfer not found"},
+	{services.ErrInvalidTransferID, CodeInvalidTransferID, "Invalid transfer ID"},
+	{services.ErrTransferState, CodeTransferStateConflict, "Only failed transfers can be retried and only completed exports can be downloaded"},
+	{tenant.ErrUnsupportedVersion, CodeUnsupportedArchive, "The archive was exported with an unsupported format version"},
+	{tenant.ErrChecksumMismatch, CodeArchiveChecksumMismatch, "The archive is corrupted: its contents do not match the manifest"},
+	{tenant.ErrInvalidArchive, CodeInvalidTenantArchive, "The file is not a valid clinic archive"},
+	{services.ErrInvalidPlan, CodeInvalidPlan, "Unknown plan; use free, standard or premium"},
+	{services.ErrUserLimitReached, CodePlanLimitExceeded, "The clinic reached the user limit of its plan; upgrade the plan to add more users"},
+	{services.ErrPatientLimitReached, CodePlanLimitExceeded, "The clinic reached the patient limit of its plan; upgrade the plan to add more patients"},
+	{services.ErrStorageLimitReached, CodePlanLimitExceeded, "The clinic reached the storage limit of its plan; upgrade the plan or free up space"},
+	{services.ErrInvalidFeatureKey, CodeInvalidFeatureKey, "Feature keys are 2-50 lowercase letters, digits or underscores"},
+	{services.ErrInvalidRollout, CodeInvalidRollout, "Rollout must be between 0 and 100"},
+	{services.ErrModuleDisabled, CodeModuleDisabled, "This module is not enabled for the clinic"},
+	{services.ErrInvalidAssetKind, CodeInvalidAssetKind, "Unknown image kind; use logo, favicon or emailHeader"},
+	{services.ErrAssetNotFound, CodeAssetNotFound, "Branding image not found"},
+	{branding.ErrUnsupportedImage, CodeUnsupportedImage, "Images must be PNG, JPEG, GIF or WebP"},
+	{branding.ErrImageTooLarge, CodeImageTooLarge, "Images must be at most 2 MiB (favicons 1 MiB)"},
+	{branding.ErrImageDimensions, CodeInvalidImageDimensions, "Images must be at most 4096 pixels per side and at least 64 (favicons 32, email headers 300 wide)"},
+	{branding.ErrUnreadableImage, CodeUnreadableImage, "The image could not be read"},
+	{palette.ErrInvalidColor, CodeInvalidColor, "Brand colour must be a #RRGGBB hex colour"},
+	{palette.ErrNoColors, CodeNoImageColors, "The image has no opaque pixels to take colours from"},
+	{palette.ErrInsufficientContrast, CodeInsufficientContrast, "Palette colours do not reach the required contrast with the background"},
+	{services.ErrAttachmentNotFound, CodeAttachmentNotFound, "Attachment not found"},
+	{services.ErrInvalidAttachmentID, CodeInvalidAttachmentID, "Invalid attachment ID"},
+	{services.ErrInvalidAttachmentCategory, CodeInvalidAttachmentCategory, "Unknown attachment category; use medicalRecord, labResult, imaging or document"},
+	{services.ErrEmptyAttachment, CodeEmptyAttachment, "The file is empty"},
+	{blob.ErrTooLarge, CodeAttachmentTooLarge, "Attachments must be at most 25 MiB"},
+	{blob.ErrInfected, CodeInfectedFile, "The file contains a threat and was rejected"},
+	{services.ErrScanUnavailable, CodeScanUnavailable, "The antivirus is not available; try again later"},
+	{services.ErrPatientNotFound, CodePatientNotFound, "Patient not found"},
+	{services.ErrInvalidPatientID, CodeInvalidPatientID, "Invalid patient ID"},
+	{blob.ErrNotFound, CodeFileNotFound, "File not found"},
+	{blob.ErrInvalidSignature, CodeInvalidDownloadSignature, "The download URL signature is not valid"},
+	{blob.ErrURLExpired, CodeDownloadURLExpired, "The download URL has expired"},
+}
+
+// Lookup devuelve el código y el detalle registrados para err
+func Lookup(err error) (response.Code, string, bool) {
+	for _, e := range registry {
+		if errors.Is(err, e.err) {
+			return e.code, e.detail, true
+		}
+	}
+	return response.Code{}, "", false
+}
+
+// Write responde con el problema registrado para err, traducido al idioma de la petición. Los errores que no están
+// en el registro son fallos internos: se registran con msg y args (el contexto
+// del handler) y el cliente recibe un INTERNAL_ERROR sin detalles.
+func Write(w http.ResponseWriter, r *http.Request, logger *slog.Logger, err error, msg string, args ...any) {
+	if code, detail, ok := Lookup(err); ok {
+		response.Error(w, r, code, detail)
+		return
+	}
+
+	if logger == nil {
+		logger = logging.FromContext(r.Context())
+	}
+	args = append(args, "error", err)
+	logger.ErrorContext(r.Context(), msg, args...)
+	response.Error(w, r, response.CodeInternal, "An unexpected error occurred")
+}
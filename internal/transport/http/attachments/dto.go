@@ -0,0 +1,59 @@
+package attachments
+
+import (
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// AttachmentResponse son los metadatos de un adjunto.
+type AttachmentResponse struct {
+	ID          string       `json:"id"`
+	ClinicID    string       `json:"clinicId"`
+	PatientID   string       `json:"patientId,omitempty"`
+	Category    string       `json:"category" example:"labResult"`
+	Filename    string       `json:"filename" example:"hemograma.pdf"`
+	ContentType string       `json:"contentType" example:"application/pdf"` // Detectado a partir del contenido
+	Size        int64        `json:"size" example:"48213"`
+	Hash        string       `json:"hash"` // SHA-256 del contenido
+	Scan        ScanResponse `json:"scan"`
+	CreatedAt   time.Time    `json:"createdAt"`
+
+	// URL firmada de descarga; solo al pedir un adjunto
+	DownloadURL       string     `json:"downloadUrl,omitempty"`
+	DownloadExpiresAt *time.Time `json:"downloadExpiresAt,omitempty"`
+}
+
+// ScanResponse es el resultado del análisis antivirus.
+type ScanResponse struct {
+	Status    string     `json:"status" example:"clean"` // clean o unscanned
+	Engine    string     `json:"engine,omitempty"`
+	ScannedAt *time.Time `json:"scannedAt,omitempty"`
+}
+
+// AttachmentsResponse es un listado de adjuntos.
+type AttachmentsResponse struct {
+	Data []AttachmentResponse `json:"data"`
+}
+
+func toAttachmentResponse(a *models.Attachment) AttachmentResponse {
+	res := AttachmentResponse{
+		ID:          a.ID.Hex(),
+		ClinicID:    a.ClinicID.Hex(),
+		Category:    a.Category,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Hash:        a.Hash,
+		Scan: ScanResponse{
+			Status:    a.Scan.Status,
+			Engine:    a.Scan.Engine,
+			ScannedAt: a.Scan.ScannedAt,
+		},
+		CreatedAt: a.CreatedAt,
+	}
+	if a.PatientID != nil {
+		res.PatientID = a.PatientID.Hex()
+	}
+	return res
+}
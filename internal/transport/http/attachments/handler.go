@@ -0,0 +1,259 @@
+package attachments
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/blob"
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/apierror"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+)
+
+// MaxUploadSize limita el cuerpo multipart: el fichero y los campos del formulario.
+const MaxUploadSize = services.MaxAttachmentSize + 64<<10
+
+// Límites del listado
+const (
+	defaultListLimit = 100
+	maxListLimit     = 500
+)
+
+// fileTooLarge es el detalle de los ficheros que superan el límite
+const fileTooLarge = "Attachments must be at most 25 MiB"
+
+// Handler contiene las dependencias de los adjuntos.
+type Handler struct {
+	service services.AttachmentService
+	logger  *slog.Logger
+}
+
+// NewHandler es el constructor del handler de adjuntos.
+func NewHandler(svc services.AttachmentService, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: svc,
+		logger:  logger.With("handler", "attachments"),
+	}
+}
+
+// Upload sube un fichero adjunto
+// @Summary      Upload an attachment
+// @Description  Stores a file (medical record, lab result, imaging or any other clinic document) of up to 25 MiB. The content type is detected from the content and the file is scanned by the antivirus when one is configured; infected files are rejected. If the clinic already has a file with the same content (SHA-256) the new attachment shares it and does not count again towards the plan's storage.
+// @Tags         Attachments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id         path      string  true   "Clinic ID"
+// @Param        file       formData  file    true   "File"
+// @Param        category   formData  string  true   "Category"  Enums(medicalRecord, labResult, imaging, document)
+// @Param        patientId  formData  string  false  "Patient the file belongs to"
+// @Success      201        {object}  AttachmentResponse
+// @Failure      400        {object}  response.Problem "Invalid ID or category, missing or empty file"
+// @Failure      402        {object}  response.Problem "Plan storage limit reached"
+// @Failure      404        {object}  response.Problem "Clinic or patient not found"
+// @Failure      413        {object}  response.Problem "File too large"
+// @Failure      422        {object}  response.Problem "Infected file"
+// @Failure      500        {object}  response.Problem "Internal server error"
+// @Failure      503        {object}  response.Problem "Antivirus not available"
+// @Router       /api/v1/clinics/{id}/attachments [post]
+func (h *Handler) Upload(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxUploadSize)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.Error(w, r, response.CodePayloadTooLarge, fileTooLarge)
+			return
+		}
+		response.ValidationErrorRes(w, r, "Request body has invalid fields", []response.ValidationError{
+			{Field: "file", Message: i18n.Translate(i18n.FromContext(r.Context()), "This field is required")},
+		})
+		return
+	}
+	defer file.Close()
+
+	params := services.UploadAttachmentParams{
+		ClinicID:  r.PathValue("id"),
+		PatientID: r.FormValue("patientId"),
+		Category:  r.FormValue("category"),
+		Filename:  header.Filename,
+		Content:   file,
+	}
+	attachment, err := h.service.Upload(r.Context(), params)
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error uploading attachment", "clinic_id", params.ClinicID, "category", params.Category)
+		return
+	}
+	response.JSON(w, http.StatusCreated, toAttachmentResponse(attachment))
+}
+
+// List lista los adjuntos de una clínica
+// @Summary      List attachments
+// @Description  Lists the clinic's attachments, newest first, optionally only those of a patient or a category.
+// @Tags         Attachments
+// @Produce      json
+// @Param        id         path      string  true   "Clinic ID"
+// @Param        patientId  query     string  false  "Only this patient's attachments"
+// @Param        category   query     string  false  "Only this category"  Enums(medicalRecord, labResult, imaging, document)
+// @Param        limit      query     int     false  "Max results (default: 100, max: 500)"
+// @Success      200        {object}  AttachmentsResponse
+// @Failure      400        {object}  response.Problem "Invalid parameters"
+// @Failure      404        {object}  response.Problem "Clinic not found"
+// @Failure      500        {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{id}/attachments [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := storage.AttachmentFilter{
+		PatientID: query.Get("patientId"),
+		Category:  query.Get("category"),
+		Limit:     defaultListLimit,
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxListLimit {
+			response.ValidationErrorRes(w, r, "Invalid query parameters", []response.ValidationError{
+				{Field: "limit", Message: i18n.Translate(i18n.FromContext(r.Context()), "limit must be an integer between 1 and %s", strconv.Itoa(maxListLimit)), Value: limitStr},
+			})
+			return
+		}
+		filter.Limit = limit
+	}
+
+	clinicID := r.PathValue("id")
+	attachments, err := h.service.List(r.Context(), clinicID, filter)
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error listing attachments", "clinic_id", clinicID)
+		return
+	}
+
+	res := AttachmentsResponse{Data: make([]AttachmentResponse, len(attachments))}
+	for i, attachment := range attachments {
+		res.Data[i] = toAttachmentResponse(attachment)
+	}
+	response.JSON(w, http.StatusOK, res)
+}
+
+// Get devuelve un adjunto con su URL de descarga
+// @Summary      Get an attachment
+// @Description  Returns the attachment's metadata and a signed download URL valid for 15 minutes.
+// @Tags         Attachments
+// @Produce      json
+// @Param        id            path      string  true  "Clinic ID"
+// @Param        attachmentId  path      string  true  "Attachment ID"
+// @Success      200           {object}  AttachmentResponse
+// @Failure      400           {object}  response.Problem "Invalid ID"
+// @Failure      404           {object}  response.Problem "Attachment not found"
+// @Failure      500           {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{id}/attachments/{attachmentId} [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	clinicID, attachmentID := r.PathValue("id"), r.PathValue("attachmentId")
+	download, err := h.service.Download(r.Context(), clinicID, attachmentID)
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error getting attachment", "clinic_id", clinicID, "attachment_id", attachmentID)
+		return
+	}
+
+	res := toAttachmentResponse(download.Attachment)
+	res.DownloadURL, res.DownloadExpiresAt = download.URL, &download.ExpiresAt
+	w.Header().Set("Cache-Control", "private, no-store")
+	response.JSON(w, http.StatusOK, res)
+}
+
+// Download redirige a la URL firmada del adjunto
+// @Summary      Download an attachment
+// @Description  Redirects to a signed download URL valid for 15 minutes (the storage service with S3, or GET /api/v1/files/{key} with local storage).
+// @Tags         Attachments
+// @Param        id            path  string  true  "Clinic ID"
+// @Param        attachmentId  path  string  true  "Attachment ID"
+// @Success      302           "Redirect to the signed URL"
+// @Failure      400           {object}  response.Problem "Invalid ID"
+// @Failure      404           {object}  response.Problem "Attachment not found"
+// @Failure      500           {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{id}/attachments/{attachmentId}/download [get]
+func (h *Handler) Download(w http.ResponseWriter, r *http.Request) {
+	clinicID, attachmentID := r.PathValue("id"), r.PathValue("attachmentId")
+	download, err := h.service.Download(r.Context(), clinicID, attachmentID)
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error getting attachment", "clinic_id", clinicID, "attachment_id", attachmentID)
+		return
+	}
+	w.Header().Set("Cache-Control", "private, no-store")
+	http.Redirect(w, r, download.URL, http.StatusFound)
+}
+
+// Delete borra un adjunto
+// @Summary      Delete an attachment
+// @Description  Deletes the attachment. The file is deleted, and its storage freed, when no other attachment of the clinic shares its content.
+// @Tags         Attachments
+// @Param        id            path  string  true  "Clinic ID"
+// @Param        attachmentId  path  string  true  "Attachment ID"
+// @Success      204           "Deleted"
+// @Failure      400           {object}  response.Problem "Invalid ID"
+// @Failure      404           {object}  response.Problem "Attachment not found"
+// @Failure      500           {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{id}/attachments/{attachmentId} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	clinicID, attachmentID := r.PathValue("id"), r.PathValue("attachmentId")
+	if err := h.service.Delete(r.Context(), clinicID, attachmentID); err != nil {
+		apierror.Write(w, r, h.logger, err, "Error deleting attachment", "clinic_id", clinicID, "attachment_id", attachmentID)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// FileHandler sirve las URLs firmadas del almacén local.
+type FileHandler struct {
+	store  *blob.LocalStore
+	logger *slog.Logger
+}
+
+// NewFileHandler es el constructor del handler de descargas firmadas.
+func NewFileHandler(store *blob.LocalStore, logger *slog.Logger) *FileHandler {
+	return &FileHandler{store: store, logger: logger.With("handler", "files")}
+}
+
+// Serve sirve un fichero con una URL firmada
+// @Summary      Download a file with a signed URL
+// @Description  Serves a stored file when the signature and expiry of the URL are valid. Only with local storage (BLOB_DRIVER=local); the URLs come from the attachment endpoints. Always served as a download. Supports Range requests.
+// @Tags         Attachments
+// @Produce      octet-stream
+// @Param        key        path   string  true  "File key"
+// @Param        expires    query  int     true  "Expiry (Unix seconds)"
+// @Param        signature  query  string  true  "Signature"
+// @Success      200  {file}    file
+// @Failure      403  {object}  response.Problem "Invalid signature or expired URL"
+// @Failure      404  {object}  response.Problem "File not found"
+// @Failure      500  {object}  response.Problem "Internal server error"
+// @Router       /api/v1/files/{key} [get]
+func (h *FileHandler) Serve(w http.ResponseWriter, r *http.Request) {
+	key, query := r.PathValue("key"), r.URL.Query()
+	if err := h.store.Verify(key, query.Get("expires"), query.Get("signature"), time.Now()); err != nil {
+		apierror.Write(w, r, h.logger, err, "Error verifying download URL", "key", key)
+		return
+	}
+
+	content, info, err := h.store.Get(r.Context(), key)
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error opening file", "key", key)
+		return
+	}
+	defer content.Close()
+
+	// El contenido lo sube cualquier usuario: nunca se muestra en el navegador
+	// con el origen de la API
+	w.Header().Set("Content-Type", info.ContentType)
+	w.Header().Set("Content-Disposition", "attachment")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	if seeker, ok := content.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, "", info.ModTime, seeker)
+		return
+	}
+	w.Header().Set("Content-Length", strconv.FormatInt(info.Size, 10))
+	io.Copy(w, content)
+}
@@ -0,0 +1,29 @@
+package attachments
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/zabaletac3/go-vet-api/internal/blob"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+// RegisterRoutes registra los adjuntos de cada clínica y, con el almacén
+// local, las descargas con URL firmada (con S3 las sirve el propio servicio).
+func RegisterRoutes(mux *http.ServeMux, stores *storage.Stores, logger *slog.Logger) {
+	handler := NewHandler(services.NewAttachmentService(stores, logger), logger)
+
+	mux.HandleFunc("POST /api/v1/clinics/{id}/attachments", handler.Upload)
+	mux.HandleFunc("GET /api/v1/clinics/{id}/attachments", handler.List)
+	mux.HandleFunc("GET /api/v1/clinics/{id}/attachments/{attachmentId}", handler.Get)
+	mux.HandleFunc("GET /api/v1/clinics/{id}/attachments/{attachmentId}/download", handler.Download)
+	mux.HandleFunc("DELETE /api/v1/clinics/{id}/attachments/{attachmentId}", handler.Delete)
+
+	if local, ok := stores.Blobs.(*blob.LocalStore); ok {
+		files := NewFileHandler(local, logger)
+		mux.HandleFunc("GET "+blob.DownloadPath+"{key...}", files.Serve)
+	}
+
+	logger.Info("Attachment routes registered successfully")
+}
@@ -0,0 +1,166 @@
+// Package batch implementa los endpoints de creación por lotes (clinics:batch,
+// users:batch): cada elemento se valida con las mismas reglas que el endpoint
+// individual y la respuesta trae el resultado de cada uno en su posición.
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/middleware"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/apierror"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+)
+
+// Estados de cada elemento en la respuesta
+const (
+	StatusCreated    = "created"
+	StatusInvalid    = "invalid"     // Datos inválidos (errors) u otro error 4xx (code)
+	StatusConflict   = "conflict"    // Ya existe (nombre, email...)
+	StatusError      = "error"       // Error interno
+	StatusSkipped    = "skipped"     // No se ejecutó porque el lote atómico falló
+	StatusRolledBack = "rolled_back" // Se creó, pero el lote atómico se revirtió
+)
+
+// Request es el cuerpo de un lote
+type Request struct {
+	Mode  services.BatchMode `json:"mode" enums:"atomic,best_effort" example:"atomic"` // Por defecto atomic
+	Items []json.RawMessage  `json:"items" swaggertype:"array,object"`
+}
+
+// ItemResult es el resultado de un elemento del lote
+type ItemResult struct {
+	Index  int                        `json:"index"`
+	Status string                     `json:"status" enums:"created,invalid,conflict,error,skipped,rolled_back"`
+	Code   string                     `json:"code,omitempty"`   // Código estable del error (como en problem+json)
+	Detail string                     `json:"detail,omitempty"` // Traducido al idioma de la petición
+	Errors []response.ValidationError `json:"errors,omitempty"` // Errores por campo (status invalid)
+	Data   any                        `json:"data,omitempty"`   // Recurso creado (status created)
+}
+
+// Summary resume el lote
+type Summary struct {
+	Total   int `json:"total"`
+	Created int `json:"created"`
+	Failed  int `json:"failed"`
+}
+
+// Response es la respuesta de un lote: 201 si se crearon todos, 207 si no
+type Response struct {
+	Mode      services.BatchMode `json:"mode"`
+	Committed bool               `json:"committed"` // atomic: si se creó todo el lote; best_effort: siempre true
+	Summary   Summary            `json:"summary"`
+	Items     []ItemResult       `json:"items"`
+}
+
+// Handler construye el http.HandlerFunc de un lote de T (el DTO con las reglas
+// de validación), convertido a los parámetros P del servicio con toParams y
+// creado con run. toData convierte el recurso creado a su DTO de respuesta.
+func Handler[T, P, R any](tx storage.Transactor, logger *slog.Logger, toParams func(T) P, run func(context.Context, P) (R, error), toData func(R) any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			response.Error(w, r, response.CodeMalformedJSON, "Request body is not valid JSON")
+			return
+		}
+		if req.Mode == "" {
+			req.Mode = services.BatchAtomic
+		}
+
+		var errs []response.ValidationError
+		if req.Mode != services.BatchAtomic && req.Mode != services.BatchBestEffort {
+			errs = append(errs, response.ValidationError{Field: "mode", Message: translate(r, "Must be one of: %s", "atomic, best_effort"), Value: string(req.Mode)})
+		}
+		if len(req.Items) == 0 || len(req.Items) > services.MaxBatchItems {
+			errs = append(errs, response.ValidationError{Field: "items", Message: translate(r, "Must contain between 1 and %s items", strconv.Itoa(services.MaxBatchItems))})
+		}
+		if len(errs) > 0 {
+			response.ValidationErrorRes(w, r, "Invalid batch request", errs)
+			return
+		}
+
+		// Validar cada elemento con las reglas del endpoint individual
+		results := make([]ItemResult, len(req.Items))
+		params := make([]P, len(req.Items))
+		skip := make([]bool, len(req.Items))
+		for i, raw := range req.Items {
+			results[i].Index = i
+			var item T
+			decoder := json.NewDecoder(bytes.NewReader(raw))
+			if err := decoder.Decode(&item); err != nil {
+				results[i].Status, results[i].Code = StatusInvalid, response.CodeMalformedJSON.Name
+				results[i].Detail = translate(r, "Item is not a valid JSON object")
+				skip[i] = true
+				continue
+			}
+			if fieldErrs := middleware.ValidationErrors(r, item); fieldErrs != nil {
+				results[i].Status, results[i].Code = StatusInvalid, response.CodeValidationFailed.Name
+				results[i].Detail = translate(r, "Item has invalid fields")
+				results[i].Errors = fieldErrs
+				skip[i] = true
+				continue
+			}
+			params[i] = toParams(item)
+		}
+
+		outcomes, err := services.RunBatch(r.Context(), tx, req.Mode, params, skip, run)
+		if err != nil {
+			apierror.Write(w, r, logger, err, "Error running batch", "mode", req.Mode, "items", len(req.Items))
+			return
+		}
+
+		resp := Response{Mode: req.Mode, Summary: Summary{Total: len(req.Items)}, Items: results}
+		for i, outcome := range outcomes {
+			item := &resp.Items[i]
+			switch outcome.Status {
+			case services.BatchDone:
+				item.Status, item.Data = StatusCreated, toData(outcome.Result)
+				resp.Summary.Created++
+			case services.BatchFailed:
+				describe(r, logger, item, outcome.Err)
+			case services.BatchRolledBack:
+				item.Status = StatusRolledBack
+			case services.BatchSkipped:
+				if item.Status == "" {
+					item.Status = StatusSkipped
+				}
+			}
+			if item.Status == StatusInvalid || item.Status == StatusConflict || item.Status == StatusError {
+				resp.Summary.Failed++
+			}
+		}
+		resp.Committed = req.Mode == services.BatchBestEffort || resp.Summary.Created == resp.Summary.Total
+
+		status := http.StatusCreated
+		if resp.Summary.Created < resp.Summary.Total {
+			status = http.StatusMultiStatus
+		}
+		response.JSON(w, status, resp)
+	}
+}
+
+// describe traduce el error de un elemento con el registro central de errores
+func describe(r *http.Request, logger *slog.Logger, item *ItemResult, err error) {
+	code, detail, ok := apierror.Lookup(err)
+	switch {
+	case !ok:
+		logger.ErrorContext(r.Context(), "Error creating batch item", "error", err, "index", item.Index)
+		item.Status, code, detail = StatusError, response.CodeInternal, "An unexpected error occurred"
+	case code.Status == http.StatusConflict:
+		item.Status = StatusConflict
+	default:
+		item.Status = StatusInvalid
+	}
+	item.Code, item.Detail = code.Name, translate(r, detail)
+}
+
+func translate(r *http.Request, msg string, args ...any) string {
+	return i18n.Translate(i18n.FromContext(r.Context()), msg, args...)
+}
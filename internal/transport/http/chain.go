@@ -0,0 +1,15 @@
+package http
+
+import "net/http"
+
+// Middleware envuelve un handler con un comportamiento común a las rutas.
+type Middleware func(http.Handler) http.Handler
+
+// Chain aplica los middlewares a h en el orden dado: el primero es el más
+// externo y el último el que recibe la petición justo antes que h.
+func Chain(h http.Handler, middlewares ...Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
@@ -0,0 +1,72 @@
+package clinics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/palette"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+)
+
+// PaletteWarning es un color de la paleta sin el contraste exigido con el
+// fondo (solo con PALETTE_CONTRAST=warn; con error la paleta se rechaza).
+type PaletteWarning struct {
+	Field    string  `json:"field" example:"palette.primary"`
+	Ratio    float64 `json:"ratio" example:"2.15"`
+	Required float64 `json:"required" example:"3"`
+	Message  string  `json:"message"`
+}
+
+// contrastMessage describe un color sin contraste suficiente
+const contrastMessage = "Contrast with the background is %s:1; at least %s:1 is required"
+
+// paletteWarnings son los avisos de contraste de la paleta guardada
+func paletteWarnings(r *http.Request, p models.ColorPalette) []PaletteWarning {
+	issues := palette.Warnings(p)
+	if len(issues) == 0 {
+		return nil
+	}
+	locale := i18n.FromContext(r.Context())
+	warnings := make([]PaletteWarning, len(issues))
+	for i, issue := range issues {
+		warnings[i] = PaletteWarning{
+			Field:    "palette." + issue.Color,
+			Ratio:    roundRatio(issue.Ratio),
+			Required: issue.Required,
+			Message:  i18n.Translate(locale, contrastMessage, formatRatio(issue.Ratio), formatRatio(issue.Required)),
+		}
+	}
+	return warnings
+}
+
+// writeContrastError responde 400 con un error por color si err es de
+// contraste (PALETTE_CONTRAST=error). Devuelve false si no lo es.
+func writeContrastError(w http.ResponseWriter, r *http.Request, err error) bool {
+	var contrastErr *palette.ContrastError
+	if !errors.As(err, &contrastErr) {
+		return false
+	}
+	locale := i18n.FromContext(r.Context())
+	fields := make([]response.ValidationError, len(contrastErr.Issues))
+	for i, issue := range contrastErr.Issues {
+		fields[i] = response.ValidationError{
+			Field:   "palette." + issue.Color,
+			Message: i18n.Translate(locale, contrastMessage, formatRatio(issue.Ratio), formatRatio(issue.Required)),
+			Value:   issue.Foreground,
+		}
+	}
+	response.ValidationErrorRes(w, r, "Palette colours do not reach the required contrast with the background", fields)
+	return true
+}
+
+// roundRatio redondea hacia abajo a dos decimales: 2.999 no se muestra como 3
+func roundRatio(ratio float64) float64 {
+	return float64(int(ratio*100)) / 100
+}
+
+func formatRatio(ratio float64) string {
+	return strconv.FormatFloat(roundRatio(ratio), 'f', -1, 64)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/services"
 	"github.com/zabaletac3/go-vet-api/internal/transport/http/dto"
 )
 
@@ -19,6 +20,8 @@ type CreateClinicRequest struct {
     Website     string             `json:"website" validate:"omitempty,url"`
     Description string             `json:"description" validate:"omitempty,max=500"`
     Palette     *ColorPaletteDTO   `json:"palette,omitempty"`
+    Locale      string             `json:"locale" validate:"omitempty,oneof=es en pt" example:"es"` // Idioma de los mensajes si el cliente no envía Accept-Language
+    Plan        string             `json:"plan" validate:"omitempty,oneof=free standard premium" example:"free"` // Plan de suscripción; por defecto free
 }
 
 // UpdateClinicRequest - DTO para actualizar clínica
@@ -32,6 +35,35 @@ type UpdateClinicRequest struct {
     Description *string            `json:"description" validate:"omitempty,max=500"`
     Palette     *ColorPaletteDTO   `json:"palette,omitempty"`
     IsActive    *bool              `json:"isActive"`
+    Locale      *string            `json:"locale" validate:"omitempty,oneof=es en pt"`
+    Plan        *string            `json:"plan" validate:"omitempty,oneof=free standard premium"`
+}
+
+// CreateClinicsBatchRequest - Lote de clínicas (para Swagger; ver batch.Request)
+type CreateClinicsBatchRequest struct {
+    Mode  services.BatchMode    `json:"mode" enums:"atomic,best_effort" example:"atomic"` // Por defecto atomic
+    Items []CreateClinicRequest `json:"items"`
+}
+
+// ToParams convierte el DTO a los parámetros del servicio (paleta por defecto si no se envía)
+func (r CreateClinicRequest) ToParams() services.CreateClinicParams {
+    params := services.CreateClinicParams{
+        Name:        r.Name,
+        DisplayName: r.DisplayName,
+        Address:     r.Address,
+        Phone:       r.Phone,
+        Email:       r.Email,
+        Website:     r.Website,
+        Description: r.Description,
+        Locale:      r.Locale,
+        Plan:        r.Plan,
+    }
+    if r.Palette != nil {
+        params.Palette = r.Palette.ToModel()
+    } else {
+        params.Palette = models.GetDefaultPalette()
+    }
+    return params
 }
 
 // ColorPaletteDTO - DTO para paleta de colores
@@ -55,6 +87,11 @@ type ClinicResponse struct {
     Description string                `json:"description,omitempty"`
     Palette     ColorPaletteResponse  `json:"palette"`
     IsActive    bool                  `json:"isActive"`
+    Locale      string                `json:"locale,omitempty"`
+    Plan        string                `json:"plan"`
+    Version     int64                 `json:"version"` // También en la cabecera ETag
+    PaletteWarnings []PaletteWarning  `json:"paletteWarnings,omitempty"` // Solo al crear o cambiar la paleta
+    DeletedAt   *time.Time            `json:"deletedAt,omitempty"`
     CreatedAt   time.Time             `json:"createdAt"`
     UpdatedAt   time.Time             `json:"updatedAt"`
 }
@@ -68,16 +105,11 @@ type ColorPaletteResponse struct {
     Background string `json:"background,omitempty"`
 }
 
-// ListClinicsRequest - DTO para listar clínicas (extiende paginación)
-type ListClinicsRequest struct {
-    dto.PaginationRequest            // Embedding de paginación reutilizable
-    IsActive              *bool      `json:"is_active" form:"is_active"`
-}
-
 // ListClinicsResponse - Respuesta específica para listado de clínicas (para Swagger)
 type ListClinicsResponse struct {
-    Data       []ClinicResponse       `json:"data"`
-    Pagination dto.PaginationResponse `json:"pagination"`
+    Data       []ClinicResponse              `json:"data"`
+    Pagination *dto.PaginationResponse       `json:"pagination,omitempty"` // Solo en modo página
+    Cursor     *dto.CursorPaginationResponse `json:"cursor"`               // nextCursor/prevCursor
 }
 
 // Métodos de conversión
@@ -124,6 +156,10 @@ func FromModel(clinic *models.Clinic) ClinicResponse {
             Background: clinic.Palette.Background,
         },
         IsActive:  clinic.IsActive,
+        Locale:    clinic.Locale,
+        Plan:      clinic.GetPlan().ID,
+        Version:   clinic.Version,
+        DeletedAt: clinic.DeletedAt,
         CreatedAt: clinic.CreatedAt,
         UpdatedAt: clinic.UpdatedAt,
     }
@@ -169,23 +205,12 @@ func (r *UpdateClinicRequest) ToUpdateFields() map[string]interface{} {
     if r.Palette != nil {
         fields["palette"] = r.Palette.ToModel()
     }
+    if r.Locale != nil {
+        fields["locale"] = *r.Locale
+    }
+    if r.Plan != nil {
+        fields["plan"] = *r.Plan
+    }
 
     return fields
 }
-
-// SetDefaults establece valores por defecto específicos de clínicas
-func (r *ListClinicsRequest) SetDefaults() {
-    r.PaginationRequest.SetDefaults()
-    
-    // Validar campo de ordenamiento específico de clínicas
-    validSortFields := map[string]bool{
-        "name":         true,
-        "display_name": true,
-        "created_at":   true,
-        "updated_at":   true,
-    }
-    
-    if r.SortBy == "" || !validSortFields[r.SortBy] {
-        r.SortBy = "created_at"
-    }
-}
\ No newline at end of file
@@ -0,0 +1,83 @@
+package clinics
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/apierror"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+)
+
+// clinicETag es el entity tag (fuerte) de una clínica: su versión entre comillas.
+// Cada escritura incrementa la versión, así que identifica el estado exacto.
+func clinicETag(clinic *models.Clinic) string {
+	return `"` + strconv.FormatInt(clinic.Version, 10) + `"`
+}
+
+// setETag añade la cabecera ETag de la clínica a la respuesta
+func setETag(w http.ResponseWriter, clinic *models.Clinic) {
+	w.Header().Set("ETag", clinicETag(clinic))
+}
+
+// etagMatches evalúa una lista de entity tags (If-Match / If-None-Match) contra
+// etag. Con weak=false se usa la comparación fuerte de If-Match (RFC 9110 8.8.3.2):
+// las etiquetas W/ nunca coinciden.
+func etagMatches(header, etag string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if strings.HasPrefix(tag, "W/") {
+			if !weak {
+				continue
+			}
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// notModified responde 304 si If-None-Match coincide con la versión actual
+func notModified(w http.ResponseWriter, r *http.Request, clinic *models.Clinic) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" || !etagMatches(header, clinicETag(clinic), true) {
+		return false
+	}
+	setETag(w, clinic)
+	response.NotModified(w)
+	return true
+}
+
+// checkIfMatch exige If-Match en las escrituras y lo compara con la versión
+// actual de la clínica. Devuelve la versión que el servicio debe exigir al
+// persistir (cierra la carrera entre esta lectura y la escritura), o false si
+// ya respondió con el error.
+func (h *Handler) checkIfMatch(w http.ResponseWriter, r *http.Request, id string, logger *slog.Logger) (int64, bool) {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		response.Error(w, r, response.CodePreconditionRequired, "If-Match header with the clinic ETag is required")
+		return 0, false
+	}
+
+	clinic, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		apierror.Write(w, r, logger, err, "Error getting clinic", "id", id)
+		return 0, false
+	}
+
+	if !etagMatches(header, clinicETag(clinic), false) {
+		setETag(w, clinic)
+		apierror.Write(w, r, logger, services.ErrClinicVersionConflict, "Clinic version conflict")
+		return 0, false
+	}
+
+	return clinic.Version, true
+}
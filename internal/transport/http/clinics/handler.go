@@ -8,7 +8,14 @@ import (
 
 	"github.com/zabaletac3/go-vet-api/internal/middleware"
 	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/pagination"
 	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/apierror"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/batch"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/dto"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/export"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/logging"
 	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -27,56 +34,36 @@ func NewHandler(svc services.ClinicService, logger *slog.Logger) *Handler {
 
 // createClinic maneja la creación de clínicas
 // @Summary      Create a new clinic
-// @Description  Register a new clinic (tenant) in the system with color palette
+// @Description  Register a new clinic (tenant) in the system with color palette.
+// @Description  Primary and secondary must contrast with the background (WCAG 2.1, level set by PALETTE_CONTRAST_LEVEL): depending on PALETTE_CONTRAST the clinic is saved with paletteWarnings or rejected with a 400 per colour.
 // @Tags         Clinics
 // @Accept       json
 // @Produce      json
 // @Param        clinic  body      CreateClinicRequest  true  "Clinic data"
+// @Param        Idempotency-Key  header  string  false  "Unique key per creation attempt; retries with the same key replay the first response (24h)"
 // @Success      201      {object}  ClinicResponse
-// @Failure      400      {object}  response.ValidationErrorResponse "Invalid data"
-// @Failure      409      {object}  response.ErrorResponse "Name already exists"
-// @Failure      500      {object}  response.ErrorResponse "Internal server error"
+// @Header       201      {string}  ETag  "Clinic version"
+// @Failure      400      {object}  response.Problem "Invalid data"
+// @Failure      409      {object}  response.Problem "Name already exists, or Idempotency-Key reused with another body or still in progress"
+// @Failure      500      {object}  response.Problem "Internal server error"
 // @Router       /api/v1/clinics [post]
 func (h *Handler) createClinic(w http.ResponseWriter, r *http.Request, req CreateClinicRequest, db *mongo.Database, logger *slog.Logger) {
     // Convertir a parámetros de servicio
-    params := services.CreateClinicParams{
-        Name:        req.Name,
-        DisplayName: req.DisplayName,
-        Address:     req.Address,
-        Phone:       req.Phone,
-        Email:       req.Email,
-        Website:     req.Website,
-        Description: req.Description,
-    }
-
-    // Establecer paleta de colores
-    if req.Palette != nil {
-        params.Palette = req.Palette.ToModel()
-    } else {
-        params.Palette = models.GetDefaultPalette()
-    }
+    params := req.ToParams()
 
     clinic, err := h.service.Create(r.Context(), params)
     if err != nil {
-        switch err {
-        case services.ErrClinicNameRequired:
-            response.Error(w, http.StatusBadRequest, "Bad Request", "Clinic name is required")
-            return
-        case services.ErrClinicNameExists:
-            response.Error(w, http.StatusConflict, "Conflict", "A clinic with that name already exists")
-            return
-        case services.ErrDisplayNameExists:
-            response.Error(w, http.StatusConflict, "Conflict", "A clinic with that display name already exists")
-            return
-        default:
-            logger.Error("Error creating clinic", "error", err, "params", params)
-            response.Error(w, http.StatusInternalServerError, "Internal Server Error", "Failed to create clinic")
+        if writeContrastError(w, r, err) {
             return
         }
+        apierror.Write(w, r, logger, err, "Error creating clinic", "params", params)
+        return
     }
 
     // Convertir a DTO de respuesta
     clinicResponse := FromModel(clinic)
+    clinicResponse.PaletteWarnings = paletteWarnings(r, clinic.Palette)
+    setETag(w, clinic)
     response.JSON(w, http.StatusCreated, response.SuccessResponse{
         Success: true,
         Message: "Clinic created successfully",
@@ -89,47 +76,64 @@ func (h *Handler) CreateClinic(db *mongo.Database, logger *slog.Logger) http.Han
     return middleware.ValidateRequestWithDeps(h.createClinic, db, logger)
 }
 
+// CreateClinicsBatch crea varias clínicas en un solo lote
+// @Summary      Create clinics in batch
+// @Description  Create up to 200 clinics. Each item is validated like POST /api/v1/clinics. In atomic mode (default) the batch runs in a transaction and nothing is created if any item fails; in best_effort mode each item is created independently. The response has one result per item, in request order.
+// @Tags         Clinics
+// @Accept       json
+// @Produce      json
+// @Param        batch            body    CreateClinicsBatchRequest  true   "Clinics to create"
+// @Param        Idempotency-Key  header  string                     false  "Unique key per batch attempt; retries with the same key replay the first response (24h)"
+// @Success      201  {object}  batch.Response  "All clinics created"
+// @Success      207  {object}  batch.Response  "Some items failed (see items[].status)"
+// @Failure      400  {object}  response.Problem "Malformed body, invalid mode or item count"
+// @Failure      409  {object}  response.Problem "Idempotency-Key reused with another body or still in progress"
+// @Failure      501  {object}  response.Problem "Atomic mode needs a MongoDB replica set"
+// @Failure      500  {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics:batch [post]
+func (h *Handler) CreateClinicsBatch(tx storage.Transactor, logger *slog.Logger) http.HandlerFunc {
+    return batch.Handler(tx, logger, CreateClinicRequest.ToParams, h.service.Create, func(clinic *models.Clinic) any {
+        return FromModel(clinic)
+    })
+}
+
 // GetClinicByID obtiene una clínica por ID
 // @Summary      Get clinic by ID
 // @Description  Retrieve a specific clinic using its ID
 // @Tags         Clinics
 // @Produce      json
-// @Param        id   path      string  true  "Clinic ID"
+// @Param        id             path      string  true   "Clinic ID"
+// @Param        If-None-Match  header    string  false  "ETag from a previous response; 304 if unchanged"
 // @Success      200  {object}  ClinicResponse
-// @Failure      400  {object}  response.ErrorResponse "Invalid ID"
-// @Failure      404  {object}  response.ErrorResponse "Clinic not found"
-// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Header       200  {string}  ETag  "Clinic version"
+// @Success      304  "Not modified"
+// @Failure      400  {object}  response.Problem "Invalid ID"
+// @Failure      404  {object}  response.Problem "Clinic not found"
+// @Failure      500  {object}  response.Problem "Internal server error"
 // @Router       /api/v1/clinics/{id} [get]
 func (h *Handler) GetClinicByID(w http.ResponseWriter, r *http.Request) {
-    logger, ok := r.Context().Value("logger").(*slog.Logger)
-    if !ok {
-        logger = slog.Default()
-    }
+    logger := logging.FromContext(r.Context())
 
     id := r.PathValue("id")
     if id == "" {
-        response.Error(w, http.StatusBadRequest, "Bad Request", "Clinic ID is required")
+        response.Error(w, r, response.CodeBadRequest, "Clinic ID is required")
         return
     }
 
     clinic, err := h.service.GetByID(r.Context(), id)
     if err != nil {
-        switch err {
-        case services.ErrClinicNotFound:
-            response.Error(w, http.StatusNotFound, "Not Found", "Clinic not found")
-            return
-        case services.ErrInvalidClinicID:
-            response.Error(w, http.StatusBadRequest, "Bad Request", "Invalid clinic ID")
-            return
-        default:
-            logger.Error("Error getting clinic", "error", err, "id", id)
-            response.Error(w, http.StatusInternalServerError, "Internal Server Error", "Failed to get clinic")
-            return
-        }
+        apierror.Write(w, r, logger, err, "Error getting clinic", "id", id)
+        return
+    }
+
+    // GET condicional: si el cliente ya tiene esta versión no se reenvía
+    if notModified(w, r, clinic) {
+        return
     }
 
     // Convertir a DTO de respuesta
     clinicResponse := FromModel(clinic)
+    setETag(w, clinic)
     response.JSON(w, http.StatusOK, response.SuccessResponse{
         Success: true,
         Message: "Clinic found",
@@ -139,22 +143,31 @@ func (h *Handler) GetClinicByID(w http.ResponseWriter, r *http.Request) {
 
 // updateClinic maneja la actualización parcial de clínicas (PATCH)
 // @Summary      Update clinic (partial)
-// @Description  Partially update an existing clinic's data (only provided fields)
+// @Description  Partially update an existing clinic's data (only provided fields). A new palette is checked for contrast like on creation.
 // @Tags         Clinics
 // @Accept       json
 // @Produce      json
-// @Param        id      path      string                true  "Clinic ID"
-// @Param        clinic  body      UpdateClinicRequest   true  "Fields to update (partial)"
+// @Param        id        path      string                true  "Clinic ID"
+// @Param        If-Match  header    string                true  "ETag of the version being edited"
+// @Param        clinic    body      UpdateClinicRequest   true  "Fields to update (partial)"
 // @Success      200      {object}  ClinicResponse
-// @Failure      400      {object}  response.ValidationErrorResponse "Invalid data"
-// @Failure      404      {object}  response.ErrorResponse "Clinic not found"
-// @Failure      409      {object}  response.ErrorResponse "Name already exists"
-// @Failure      500      {object}  response.ErrorResponse "Internal server error"
+// @Header       200      {string}  ETag  "New clinic version"
+// @Failure      400      {object}  response.Problem "Invalid data"
+// @Failure      404      {object}  response.Problem "Clinic not found"
+// @Failure      409      {object}  response.Problem "Name already exists"
+// @Failure      412      {object}  response.Problem "Clinic was modified (ETag mismatch)"
+// @Failure      428      {object}  response.Problem "If-Match header missing"
+// @Failure      500      {object}  response.Problem "Internal server error"
 // @Router       /api/v1/clinics/{id} [patch]
 func (h *Handler) updateClinic(w http.ResponseWriter, r *http.Request, req UpdateClinicRequest, db *mongo.Database, logger *slog.Logger) {
     id := r.PathValue("id")
     if id == "" {
-        response.Error(w, http.StatusBadRequest, "Bad Request", "Clinic ID is required")
+        response.Error(w, r, response.CodeBadRequest, "Clinic ID is required")
+        return
+    }
+
+    version, ok := h.checkIfMatch(w, r, id, logger)
+    if !ok {
         return
     }
 
@@ -168,6 +181,9 @@ func (h *Handler) updateClinic(w http.ResponseWriter, r *http.Request, req Updat
         Website:     req.Website,
         Description: req.Description,
         IsActive:    req.IsActive,
+        Locale:      req.Locale,
+        Plan:        req.Plan,
+        Version:     version,
     }
 
     // Convertir paleta si se proporciona
@@ -178,28 +194,19 @@ func (h *Handler) updateClinic(w http.ResponseWriter, r *http.Request, req Updat
 
     clinic, err := h.service.Update(r.Context(), id, params)
     if err != nil {
-        switch err {
-        case services.ErrClinicNotFound:
-            response.Error(w, http.StatusNotFound, "Not Found", "Clinic not found")
-            return
-        case services.ErrClinicNameExists:
-            response.Error(w, http.StatusConflict, "Conflict", "A clinic with that name already exists")
-            return
-        case services.ErrDisplayNameExists:
-            response.Error(w, http.StatusConflict, "Conflict", "A clinic with that display name already exists")
-            return
-        case services.ErrInvalidClinicID:
-            response.Error(w, http.StatusBadRequest, "Bad Request", "Invalid clinic ID")
-            return
-        default:
-            logger.Error("Error updating clinic", "error", err, "id", id, "params", params)
-            response.Error(w, http.StatusInternalServerError, "Internal Server Error", "Failed to update clinic")
+        if writeContrastError(w, r, err) {
             return
         }
+        apierror.Write(w, r, logger, err, "Error updating clinic", "id", id, "params", params)
+        return
     }
 
     // Convertir a DTO de respuesta
     clinicResponse := FromModel(clinic)
+    if req.Palette != nil {
+        clinicResponse.PaletteWarnings = paletteWarnings(r, clinic.Palette)
+    }
+    setETag(w, clinic)
     response.JSON(w, http.StatusOK, response.SuccessResponse{
         Success: true,
         Message: "Clinic updated successfully",
@@ -214,41 +221,49 @@ func (h *Handler) UpdateClinic(db *mongo.Database, logger *slog.Logger) http.Han
 
 // DeleteClinic elimina una clínica (soft delete)
 // @Summary      Delete clinic
-// @Description  Delete a clinic from the system (soft delete - marks as inactive)
+// @Description  Delete a clinic from the system (soft delete - moves it to the trash).
+// @Description  A clinic with users can only be deleted with cascade=true, which also deletes its users.
 // @Tags         Clinics
 // @Produce      json
-// @Param        id   path      string  true  "Clinic ID"
+// @Param        id        path      string  true   "Clinic ID"
+// @Param        If-Match  header    string  true   "ETag of the version being deleted"
+// @Param        cascade   query     bool    false  "Also delete the clinic's users"
 // @Success      200  {object}  response.SuccessResponse "Clinic deleted successfully"
-// @Failure      400  {object}  response.ErrorResponse "Invalid ID"
-// @Failure      404  {object}  response.ErrorResponse "Clinic not found"
-// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Failure      400  {object}  response.Problem "Invalid ID"
+// @Failure      404  {object}  response.Problem "Clinic not found"
+// @Failure      409  {object}  response.Problem "Clinic has dependencies"
+// @Failure      412  {object}  response.Problem "Clinic was modified (ETag mismatch)"
+// @Failure      428  {object}  response.Problem "If-Match header missing"
+// @Failure      500  {object}  response.Problem "Internal server error"
 // @Router       /api/v1/clinics/{id} [delete]
 func (h *Handler) DeleteClinic(w http.ResponseWriter, r *http.Request) {
-    logger, ok := r.Context().Value("logger").(*slog.Logger)
-    if !ok {
-        logger = slog.Default()
-    }
+    logger := logging.FromContext(r.Context())
 
     id := r.PathValue("id")
     if id == "" {
-        response.Error(w, http.StatusBadRequest, "Bad Request", "Clinic ID is required")
+        response.Error(w, r, response.CodeBadRequest, "Clinic ID is required")
         return
     }
 
-    err := h.service.Delete(r.Context(), id)
-    if err != nil {
-        switch err {
-        case services.ErrClinicNotFound:
-            response.Error(w, http.StatusNotFound, "Not Found", "Clinic not found")
-            return
-        case services.ErrInvalidClinicID:
-            response.Error(w, http.StatusBadRequest, "Bad Request", "Invalid clinic ID")
-            return
-        default:
-            logger.Error("Error deleting clinic", "error", err, "id", id)
-            response.Error(w, http.StatusInternalServerError, "Internal Server Error", "Failed to delete clinic")
+    version, ok := h.checkIfMatch(w, r, id, logger)
+    if !ok {
+        return
+    }
+
+    params := services.DeleteClinicParams{Version: version}
+    if cascadeStr := r.URL.Query().Get("cascade"); cascadeStr != "" {
+        cascade, err := strconv.ParseBool(cascadeStr)
+        if err != nil {
+            response.Error(w, r, response.CodeBadRequest, "Invalid cascade value")
             return
         }
+        params.Cascade = cascade
+    }
+
+    err := h.service.Delete(r.Context(), id, params)
+    if err != nil {
+        apierror.Write(w, r, logger, err, "Error deleting clinic", "id", id)
+        return
     }
 
     response.JSON(w, http.StatusOK, response.SuccessResponse{
@@ -260,91 +275,192 @@ func (h *Handler) DeleteClinic(w http.ResponseWriter, r *http.Request) {
 
 // GetAllClinics obtiene todas las clínicas con paginación
 // @Summary      Get all clinics
-// @Description  Retrieve a paginated list of all clinics
+// @Description  Retrieve a paginated list of all clinics. With Accept: text/csv, application/x-ndjson or the XLSX media type the whole filtered list is downloaded instead (page, limit and cursor are ignored; fields selects the columns)
 // @Tags         Clinics
-// @Produce      json
+// @Produce      json,text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        Accept     header   string  false  "application/json (default), text/csv, application/x-ndjson or application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
 // @Param        page       query    int     false  "Page number (default: 1)"
 // @Param        limit      query    int     false  "Items per page (default: 10, max: 100)"
-// @Param        search     query    string  false  "Search term"
-// @Param        is_active  query    bool    false  "Filter by active status"
-// @Param        sort_by    query    string  false  "Sort field (name, display_name, created_at, updated_at)"
-// @Param        sort_desc  query    bool    false  "Sort descending"
+// @Param        search     query    string  false  "Full-text search on name, display name, address and description (whole words, ignores case and accents). Results are ranked by relevance unless sort is given"
+// @Param        filter     query    string  false  "filter[field][op]=value; op: eq (default), ne, in (comma-separated), gte, lte, exists. Fields: id, name, displayName, email, phone, website, address, isActive, version, createdAt, updatedAt"
+// @Param        sort       query    string  false  "Up to 3 comma-separated fields, '-' for descending (name, displayName, createdAt, updatedAt). Default: relevance with search, otherwise createdAt"
+// @Param        fields     query    string  false  "Comma-separated response fields (id is always included)"
+// @Param        is_active  query    bool    false  "Deprecated: use filter[isActive]"
+// @Param        sort_by    query    string  false  "Deprecated: use sort"
+// @Param        sort_desc  query    bool    false  "Deprecated: use sort"
+// @Param        cursor     query    string  false  "nextCursor/prevCursor from a previous response (keyset mode, page is ignored)"
+// @Param        count      query    bool    false  "Include the total in keyset mode (default: true)"
 // @Success      200        {object}  ListClinicsResponse
-// @Failure      400        {object}  response.ErrorResponse "Invalid parameters"
-// @Failure      500        {object}  response.ErrorResponse "Internal server error"
+// @Failure      400        {object}  response.Problem "Invalid parameters or cursor"
+// @Failure      500        {object}  response.Problem "Internal server error"
 // @Router       /api/v1/clinics [get]
 func (h *Handler) GetAllClinics(w http.ResponseWriter, r *http.Request) {
-    logger, ok := r.Context().Value("logger").(*slog.Logger)
-    if !ok {
-        logger = slog.Default()
+    logger := logging.FromContext(r.Context())
+
+    query, errs := dto.ParseListQuery(r.URL.Query(), clinicQuerySpec)
+    if len(errs) > 0 {
+        response.ValidationErrorRes(w, r, "Invalid query parameters", errs)
+        return
     }
 
-    // Parsear parámetros de query
-    req := ListClinicsRequest{}
-    
-    // Page
-    if pageStr := r.URL.Query().Get("page"); pageStr != "" {
-        if page, err := strconv.Atoi(pageStr); err == nil {
-            req.Page = page
-        }
+    // Ejecutar servicio
+    params := listParams(query)
+    if format, ok := export.Negotiate(r.Header.Get("Accept")); ok {
+        writeExport(w, r, format, "clinics", query.Fields, logger, func(each func(*models.Clinic) error) error {
+            return h.service.Export(r.Context(), params, each)
+        })
+        return
     }
-    
-    // Limit
-    if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-        if limit, err := strconv.Atoi(limitStr); err == nil {
-            req.Limit = limit
-        }
+
+    result, err := h.service.List(r.Context(), params)
+    if err != nil {
+        apierror.Write(w, r, logger, err, "Error listing clinics", "params", params)
+        return
     }
-    
-    // Search
-    req.Search = r.URL.Query().Get("search")
-    
-    // IsActive
-    if isActiveStr := r.URL.Query().Get("is_active"); isActiveStr != "" {
-        if isActive, err := strconv.ParseBool(isActiveStr); err == nil {
-            req.IsActive = &isActive
-        }
+
+    writeList(w, r, result, query.Fields, logger)
+}
+
+// GetDeletedClinics lista las clínicas en la papelera
+// @Summary      List deleted clinics
+// @Description  Retrieve a paginated list of soft-deleted clinics (trash). They are purged after the retention period. Supports the same CSV/NDJSON/XLSX downloads as GET /clinics.
+// @Tags         Clinics
+// @Produce      json,text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        Accept     header   string  false  "application/json (default), text/csv, application/x-ndjson or application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+// @Param        page       query    int     false  "Page number (default: 1)"
+// @Param        limit      query    int     false  "Items per page (default: 10, max: 100)"
+// @Param        search     query    string  false  "Full-text search, as in GET /clinics"
+// @Param        filter     query    string  false  "filter[field][op]=value, as in GET /clinics, plus deletedAt (gte, lte)"
+// @Param        sort       query    string  false  "Up to 3 comma-separated fields, '-' for descending (deletedAt, name, displayName, createdAt, updatedAt). Default: relevance with search, otherwise deletedAt"
+// @Param        fields     query    string  false  "Comma-separated response fields (id is always included)"
+// @Param        sort_by    query    string  false  "Deprecated: use sort"
+// @Param        sort_desc  query    bool    false  "Deprecated: use sort"
+// @Param        cursor     query    string  false  "nextCursor/prevCursor from a previous response (keyset mode, page is ignored)"
+// @Param        count      query    bool    false  "Include the total in keyset mode (default: true)"
+// @Success      200        {object}  ListClinicsResponse
+// @Failure      400        {object}  response.Problem "Invalid parameters or cursor"
+// @Failure      500        {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/trash [get]
+func (h *Handler) GetDeletedClinics(w http.ResponseWriter, r *http.Request) {
+    logger := logging.FromContext(r.Context())
+
+    query, errs := dto.ParseListQuery(r.URL.Query(), trashQuerySpec)
+    if len(errs) > 0 {
+        response.ValidationErrorRes(w, r, "Invalid query parameters", errs)
+        return
     }
-    
-    // SortBy
-    req.SortBy = r.URL.Query().Get("sort_by")
-    
-    // SortDesc
-    if sortDescStr := r.URL.Query().Get("sort_desc"); sortDescStr != "" {
-        if sortDesc, err := strconv.ParseBool(sortDescStr); err == nil {
-            req.SortDesc = sortDesc
-        }
+
+    params := listParams(query)
+    if format, ok := export.Negotiate(r.Header.Get("Accept")); ok {
+        writeExport(w, r, format, "clinics-trash", query.Fields, logger, func(each func(*models.Clinic) error) error {
+            return h.service.ExportDeleted(r.Context(), params, each)
+        })
+        return
     }
 
-    // Establecer valores por defecto
-    req.SetDefaults()
+    result, err := h.service.ListDeleted(r.Context(), params)
+    if err != nil {
+        apierror.Write(w, r, logger, err, "Error listing deleted clinics", "params", params)
+        return
+    }
 
-    // Convertir a parámetros de servicio
-    params := services.ListClinicsParams{
-        Page:     req.Page,
-        Limit:    req.Limit,
-        Search:   req.Search,
-        IsActive: req.IsActive,
-        SortBy:   req.SortBy,
-        SortDesc: req.SortDesc,
+    writeList(w, r, result, query.Fields, logger)
+}
+
+// RestoreClinic saca una clínica de la papelera
+// @Summary      Restore clinic
+// @Description  Restore a soft-deleted clinic (and the users deleted with it). Fails if its name or display name was taken meanwhile.
+// @Tags         Clinics
+// @Produce      json
+// @Param        id   path      string  true  "Clinic ID"
+// @Success      200  {object}  ClinicResponse
+// @Header       200  {string}  ETag  "Clinic version"
+// @Failure      400  {object}  response.Problem "Invalid ID"
+// @Failure      404  {object}  response.Problem "Clinic not found in trash"
+// @Failure      409  {object}  response.Problem "Name already exists"
+// @Failure      500  {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{id}/restore [post]
+func (h *Handler) RestoreClinic(w http.ResponseWriter, r *http.Request) {
+    logger := logging.FromContext(r.Context())
+
+    id := r.PathValue("id")
+    if id == "" {
+        response.Error(w, r, response.CodeBadRequest, "Clinic ID is required")
+        return
     }
 
-    // Ejecutar servicio
-    clinics, pagination, err := h.service.List(r.Context(), params)
+    clinic, err := h.service.Restore(r.Context(), id)
     if err != nil {
-        logger.Error("Error listing clinics", "error", err, "params", params)
-        response.Error(w, http.StatusInternalServerError, "Internal Server Error", "Failed to list clinics")
+        apierror.Write(w, r, logger, err, "Error restoring clinic", "id", id)
         return
     }
 
-    // Convertir a DTOs de respuesta
-    clinicResponses := FromModels(clinics)
+    setETag(w, clinic)
+    response.JSON(w, http.StatusOK, response.SuccessResponse{
+        Success: true,
+        Message: "Clinic restored successfully",
+        Data:    FromModel(clinic),
+    })
+}
 
-    // Crear respuesta paginada específica (para Swagger)
-    listResponse := ListClinicsResponse{
-        Data:       clinicResponses,
-        Pagination: pagination,
+// listParams convierte la consulta ya validada en parámetros de servicio
+func listParams(query dto.ListQuery) services.ListClinicsParams {
+    return services.ListClinicsParams{
+        Page:      query.Page,
+        Limit:     query.Limit,
+        Search:    query.Search,
+        Filters:   query.Filters,
+        Sort:      query.Sort,
+        Cursor:    query.Cursor,
+        SkipCount: query.SkipCount,
+    }
+}
+
+// writeList responde un listado completo o, con fields=, solo los campos pedidos
+func writeList(w http.ResponseWriter, r *http.Request, result *pagination.Result[*models.Clinic], fields []string, logger *slog.Logger) {
+    clinics := FromModels(result.Items)
+    if len(fields) == 0 {
+        response.JSON(w, http.StatusOK, ListClinicsResponse{
+            Data:       clinics,
+            Pagination: result.Pagination,
+            Cursor:     result.Cursor,
+        })
+        return
     }
 
-    response.JSON(w, http.StatusOK, listResponse)
-}
\ No newline at end of file
+    data, err := dto.SelectFields(clinics, fields)
+    if err != nil {
+        logger.ErrorContext(r.Context(), "Error selecting clinic fields", "error", err, "fields", fields)
+        response.Error(w, r, response.CodeInternal, "Failed to list clinics")
+        return
+    }
+    response.JSON(w, http.StatusOK, dto.SparseListResponse{
+        Data:       data,
+        Pagination: result.Pagination,
+        Cursor:     result.Cursor,
+    })
+}
+
+// writeExport descarga el listado completo en el formato negociado (CSV, NDJSON
+// o XLSX), con los mismos filtros, orden y campos que la respuesta JSON. Un error
+// antes del primer byte se responde como problem+json; después la descarga ya
+// está en curso y solo se puede registrar (el cliente recibe un fichero cortado).
+func writeExport(w http.ResponseWriter, r *http.Request, format export.Format, name string, fields []string, logger *slog.Logger, run func(each func(*models.Clinic) error) error) {
+    out := export.New[ClinicResponse](w, format, name, fields)
+    err := run(func(clinic *models.Clinic) error {
+        return out.Write(FromModel(clinic))
+    })
+    if err == nil {
+        err = out.Close()
+    }
+    if err == nil {
+        return
+    }
+
+    if !out.Started() {
+        out.Discard()
+        apierror.Write(w, r, logger, err, "Error exporting clinics", "format", format.Extension)
+        return
+    }
+    logger.ErrorContext(r.Context(), "Error streaming clinic export", "error", err, "format", format.Extension)
+}
@@ -0,0 +1,43 @@
+package clinics
+
+import (
+	"maps"
+
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/dto"
+)
+
+var (
+	stringOps = []string{dto.OpEq, dto.OpNe, dto.OpIn, dto.OpExists}
+	rangeOps  = []string{dto.OpGte, dto.OpLte}
+)
+
+// clinicQuerySpec es la allowlist de filtros, orden y campos de GET /clinics
+var clinicQuerySpec = dto.QuerySpec{
+	Fields: map[string]dto.QueryField{
+		"id":          {Column: "_id", Type: dto.ObjectIDField, Ops: []string{dto.OpEq, dto.OpNe, dto.OpIn}},
+		"name":        {Column: "name", Ops: stringOps, Sortable: true},
+		"displayName": {Column: "displayName", Ops: stringOps, Sortable: true},
+		"email":       {Column: "email", Ops: stringOps},
+		"phone":       {Column: "phone", Ops: stringOps},
+		"website":     {Column: "website", Ops: stringOps},
+		"address":     {Column: "address", Ops: stringOps},
+		"isActive":    {Column: "isActive", Type: dto.BoolField, Ops: []string{dto.OpEq, dto.OpNe}},
+		"version":     {Column: "version", Type: dto.IntField, Ops: []string{dto.OpEq, dto.OpGte, dto.OpLte}},
+		"createdAt":   {Column: "createdAt", Type: dto.TimeField, Ops: rangeOps, Sortable: true},
+		"updatedAt":   {Column: "updatedAt", Type: dto.TimeField, Ops: rangeOps, Sortable: true},
+	},
+	Selectable: []string{
+		"id", "name", "displayName", "address", "phone", "email", "website",
+		"description", "palette", "isActive", "version", "createdAt", "updatedAt",
+	},
+	Shorthands: map[string]string{"is_active": "isActive"},
+}
+
+// trashQuerySpec añade la fecha de eliminación, que solo existe en la papelera
+var trashQuerySpec = func() dto.QuerySpec {
+	spec := clinicQuerySpec
+	spec.Fields = maps.Clone(clinicQuerySpec.Fields)
+	spec.Fields["deletedAt"] = dto.QueryField{Column: "deletedAt", Type: dto.TimeField, Ops: rangeOps, Sortable: true}
+	spec.Selectable = append(spec.Selectable[:len(spec.Selectable):len(spec.Selectable)], "deletedAt")
+	return spec
+}()
@@ -6,42 +6,51 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/middleware"
 	"github.com/zabaletac3/go-vet-api/internal/services"
 	"github.com/zabaletac3/go-vet-api/internal/storage"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // contextMiddleware agrega dependencias al contexto
-func contextMiddleware(db *mongo.Database, logger *slog.Logger) func(http.Handler) http.Handler {
+func contextMiddleware(db *mongo.Database) func(http.Handler) http.Handler {
     return func(next http.Handler) http.Handler {
         return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            // Los errores salen en el idioma de la clínica si el cliente no pide uno
+            i18n.UseClinic(r.Context(), r.PathValue("id"))
+
             ctx := context.WithValue(r.Context(), "db", db)
-            ctx = context.WithValue(ctx, "logger", logger)
             next.ServeHTTP(w, r.WithContext(ctx))
         })
     }
 }
 
 // RegisterRoutes registra todas las rutas de clinics
-func RegisterRoutes(mux *http.ServeMux, db *mongo.Database, logger *slog.Logger) {
-    // Crear el repository específico del módulo (implementa ClinicStorer)
-    clinicRepo := storage.NewClinicRepository(db)
-    
-    // Crear el service específico del módulo
-    clinicService := services.NewClinicService(clinicRepo, logger)
+func RegisterRoutes(mux *http.ServeMux, stores *storage.Stores, db *mongo.Database, logger *slog.Logger) {
+    // Crear el service específico del módulo (los stores implementan ClinicStorer y UserStorer)
+    clinicService := services.NewClinicService(stores, logger)
     
     // Crear el handler específico del módulo
     handler := NewHandler(clinicService, logger)
     
     // Middleware para agregar dependencias al contexto
-    middleware := contextMiddleware(db, logger)
+    withDeps := contextMiddleware(db)
+
+    // Los reintentos con el mismo Idempotency-Key no crean dos clínicas
+    idempotent := middleware.Idempotency(stores.Idempotency, logger)
     
     // Rutas CRUD de clinics
-    mux.Handle("POST /api/v1/clinics", middleware(handler.CreateClinic(db, logger)))
-    mux.Handle("GET /api/v1/clinics/{id}", middleware(http.HandlerFunc(handler.GetClinicByID)))
-    mux.Handle("PATCH /api/v1/clinics/{id}", middleware(handler.UpdateClinic(db, logger))) // PATCH instead of PUT
-    mux.Handle("DELETE /api/v1/clinics/{id}", middleware(http.HandlerFunc(handler.DeleteClinic)))
-    mux.Handle("GET /api/v1/clinics", middleware(http.HandlerFunc(handler.GetAllClinics)))
+    mux.Handle("POST /api/v1/clinics", withDeps(idempotent(handler.CreateClinic(db, logger))))
+    mux.Handle("POST /api/v1/clinics:batch", withDeps(idempotent(handler.CreateClinicsBatch(stores.Tx, logger))))
+    mux.Handle("GET /api/v1/clinics/{id}", withDeps(http.HandlerFunc(handler.GetClinicByID)))
+    mux.Handle("PATCH /api/v1/clinics/{id}", withDeps(handler.UpdateClinic(db, logger))) // PATCH instead of PUT
+    mux.Handle("DELETE /api/v1/clinics/{id}", withDeps(http.HandlerFunc(handler.DeleteClinic)))
+    mux.Handle("GET /api/v1/clinics", withDeps(http.HandlerFunc(handler.GetAllClinics)))
+
+    // Papelera
+    mux.Handle("GET /api/v1/clinics/trash", withDeps(http.HandlerFunc(handler.GetDeletedClinics)))
+    mux.Handle("POST /api/v1/clinics/{id}/restore", withDeps(http.HandlerFunc(handler.RestoreClinic)))
 
     logger.Info("Clinic routes registered successfully")
 }
\ No newline at end of file
@@ -1,7 +1,10 @@
 // internal/transport/http/dto/pagination.go
 package dto
 
-import "errors"
+import (
+    "encoding/json"
+    "errors"
+)
 
 // PaginationRequest - DTO reutilizable para requests paginados
 type PaginationRequest struct {
@@ -10,6 +13,10 @@ type PaginationRequest struct {
     Search   string `json:"search" form:"search"`
     SortBy   string `json:"sort_by" form:"sort_by"`
     SortDesc bool   `json:"sort_desc" form:"sort_desc"`
+
+    // Paginación por cursor: si Cursor no está vacío se ignora Page
+    Cursor    string `json:"cursor" form:"cursor"`
+    SkipCount bool   `json:"-"` // count=false: no calcular el total (solo modo cursor)
 }
 
 // PaginationResponse - DTO reutilizable para respuestas paginadas
@@ -22,6 +29,16 @@ type PaginationResponse struct {
     HasPrev     bool  `json:"hasPrev"`
 }
 
+// CursorPaginationResponse - DTO reutilizable para paginación por cursor (keyset)
+type CursorPaginationResponse struct {
+    PerPage    int    `json:"perPage"`
+    NextCursor string `json:"nextCursor,omitempty"`
+    PrevCursor string `json:"prevCursor,omitempty"`
+    HasNext    bool   `json:"hasNext"`
+    HasPrev    bool   `json:"hasPrev"`
+    Total      *int64 `json:"total,omitempty"` // Omitido con count=false
+}
+
 // PaginatedResponse - Wrapper genérico para respuestas paginadas
 type PaginatedResponse[T any] struct {
     Data       []T                `json:"data"`
@@ -50,4 +67,11 @@ func (p *PaginationRequest) Validate() error {
         return errors.New("limit cannot be greater than 100")
     }
     return nil
-}
\ No newline at end of file
+}
+
+// SparseListResponse - Listado con fields=: cada elemento solo trae los campos pedidos
+type SparseListResponse struct {
+    Data       []map[string]json.RawMessage `json:"data"`
+    Pagination *PaginationResponse          `json:"pagination,omitempty"`
+    Cursor     *CursorPaginationResponse    `json:"cursor"`
+}
@@ -0,0 +1,445 @@
+// internal/transport/http/dto/query.go
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Lenguaje de consulta común a los listados:
+//
+//	filter[field][op]=value   op: eq (por defecto), ne, in, gte, lte, exists
+//	sort=-updatedAt,name      "-" para descendente
+//	fields=id,name,palette    campos de la respuesta (sparse fieldsets)
+//
+// Solo se aceptan los campos declarados en el QuerySpec del recurso, y los
+// valores se convierten al tipo del campo antes de llegar a la base de datos,
+// así que nunca se interpola nada del cliente como operador o nombre de campo.
+
+// Operadores de filtro
+const (
+	OpEq     = "eq"
+	OpNe     = "ne"
+	OpIn     = "in"
+	OpGte    = "gte"
+	OpLte    = "lte"
+	OpExists = "exists"
+)
+
+// FieldType es el tipo al que se convierten los valores de un filtro
+type FieldType int
+
+const (
+	StringField FieldType = iota
+	BoolField
+	IntField
+	TimeField
+	ObjectIDField
+)
+
+const (
+	maxSortFields = 3
+	maxInValues   = 50
+)
+
+// QueryField describe un campo consultable de un recurso
+type QueryField struct {
+	Column   string    // Campo del documento (bson)
+	Type     FieldType // Tipo de los valores
+	Ops      []string  // Operadores admitidos en filter[]; vacío si no es filtrable
+	Sortable bool      // Admitido en sort= (solo campos siempre presentes)
+}
+
+// QuerySpec es la allowlist de un recurso
+type QuerySpec struct {
+	Fields     map[string]QueryField // Por nombre de la API (el del JSON)
+	Selectable []string              // Campos admitidos en fields=
+	Shorthands map[string]string     // Parámetros heredados: param=v equivale a filter[campo][eq]=v
+}
+
+// Filter es una condición ya validada y tipada
+type Filter struct {
+	Field  string
+	Column string
+	Op     string
+	Value  interface{} // []interface{} para "in", bool para "exists"
+}
+
+// SortField es un criterio de orden ya validado
+type SortField struct {
+	Field  string
+	Column string
+	Desc   bool
+}
+
+// Relevance es el orden por defecto de un listado con search: la puntuación de
+// la búsqueda de texto, de mayor a menor. No se admite en sort=.
+var Relevance = SortField{Field: "relevance", Column: "score", Desc: true}
+
+// ListQuery es el resultado de interpretar la query string de un listado
+type ListQuery struct {
+	Page      int
+	Limit     int
+	Search    string
+	Cursor    string
+	SkipCount bool
+	Filters   []Filter
+	Sort      []SortField // Vacío: orden por defecto del recurso
+	Fields    []string    // Vacío: todos los campos
+}
+
+var filterParam = regexp.MustCompile(`^filter\[([A-Za-z][A-Za-z0-9_.]*)\](?:\[([a-z]+)\])?$`)
+
+// ParseListQuery interpreta la query string de un listado según la allowlist
+// del recurso. Devuelve un error por cada parámetro inválido.
+func ParseListQuery(values url.Values, spec QuerySpec) (ListQuery, []response.ValidationError) {
+	q := ListQuery{Page: 1, Limit: 10}
+	var errs []response.ValidationError
+	fail := func(field, value, format string, args ...interface{}) {
+		errs = append(errs, response.ValidationError{Field: field, Message: fmt.Sprintf(format, args...), Value: value})
+	}
+
+	if v, ok := single(values, "page", fail); ok {
+		page, err := strconv.Atoi(v)
+		if err != nil || page < 1 {
+			fail("page", v, "page must be a positive integer")
+		} else {
+			q.Page = page
+		}
+	}
+	if v, ok := single(values, "limit", fail); ok {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > 100 {
+			fail("limit", v, "limit must be an integer between 1 and 100")
+		} else {
+			q.Limit = limit
+		}
+	}
+	if v, ok := single(values, "search", fail); ok {
+		q.Search = strings.TrimSpace(v)
+	}
+	if v, ok := single(values, "cursor", fail); ok {
+		q.Cursor = v
+	}
+	if v, ok := single(values, "count", fail); ok {
+		count, err := strconv.ParseBool(v)
+		if err != nil {
+			fail("count", v, "count must be true or false")
+		} else {
+			q.SkipCount = !count
+		}
+	}
+
+	q.Sort = parseSort(values, spec, fail)
+	q.Fields = parseFields(values, spec, fail)
+	q.Filters = parseFilters(values, spec, fail)
+
+	return q, errs
+}
+
+type failFunc func(field, value, format string, args ...interface{})
+
+// single devuelve el valor de un parámetro que no admite repetirse
+func single(values url.Values, key string, fail failFunc) (string, bool) {
+	vs, ok := values[key]
+	if !ok {
+		return "", false
+	}
+	if len(vs) > 1 {
+		fail(key, strings.Join(vs, ","), "%s must not be repeated", key)
+		return "", false
+	}
+	return vs[0], true
+}
+
+func parseSort(values url.Values, spec QuerySpec, fail failFunc) []SortField {
+	raw, ok := single(values, "sort", fail)
+	param := "sort"
+	if !ok {
+		// Compatibilidad: sort_by=updated_at&sort_desc=true
+		by, hasBy := single(values, "sort_by", fail)
+		if !hasBy {
+			return nil
+		}
+		raw = snakeToCamel(by)
+		param = "sort_by"
+		if desc, hasDesc := single(values, "sort_desc", fail); hasDesc {
+			d, err := strconv.ParseBool(desc)
+			if err != nil {
+				fail("sort_desc", desc, "sort_desc must be true or false")
+			} else if d {
+				raw = "-" + raw
+			}
+		}
+	}
+
+	var sort []SortField
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		name := strings.TrimPrefix(part, "-")
+
+		field, known := spec.Fields[name]
+		switch {
+		case name == "":
+			fail(param, raw, "empty sort field")
+		case !known || !field.Sortable:
+			fail(param, part, "cannot sort by %q; allowed: %s", name, strings.Join(spec.sortable(), ", "))
+		case slices.ContainsFunc(sort, func(s SortField) bool { return s.Field == name }):
+			fail(param, part, "sort field %q is repeated", name)
+		default:
+			sort = append(sort, SortField{Field: name, Column: field.Column, Desc: desc})
+		}
+	}
+	if len(sort) > maxSortFields {
+		fail(param, raw, "at most %d sort fields are allowed", maxSortFields)
+	}
+	return sort
+}
+
+func parseFields(values url.Values, spec QuerySpec, fail failFunc) []string {
+	raw, ok := single(values, "fields", fail)
+	if !ok {
+		return nil
+	}
+
+	fields := []string{"id"} // El id siempre se incluye
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case !slices.Contains(spec.Selectable, name):
+			fail("fields", name, "unknown field %q; allowed: %s", name, strings.Join(spec.Selectable, ", "))
+		case !slices.Contains(fields, name):
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+func parseFilters(values url.Values, spec QuerySpec, fail failFunc) []Filter {
+	type rawFilter struct{ param, field, op string }
+	var raws []rawFilter
+
+	for key := range values {
+		if field, ok := spec.Shorthands[key]; ok {
+			raws = append(raws, rawFilter{key, field, OpEq})
+			continue
+		}
+		if !strings.HasPrefix(key, "filter") {
+			continue
+		}
+		m := filterParam.FindStringSubmatch(key)
+		if m == nil {
+			fail(key, "", "malformed filter; use filter[field][op]=value")
+			continue
+		}
+		op := m[2]
+		if op == "" {
+			op = OpEq
+		}
+		raws = append(raws, rawFilter{key, m[1], op})
+	}
+	// Orden determinista (errores y consultas reproducibles)
+	slices.SortFunc(raws, func(a, b rawFilter) int { return strings.Compare(a.param, b.param) })
+
+	var filters []Filter
+	for _, rf := range raws {
+		raw, ok := single(values, rf.param, fail)
+		if !ok {
+			continue
+		}
+		field, known := spec.Fields[rf.field]
+		if !known || len(field.Ops) == 0 {
+			fail(rf.param, raw, "cannot filter by %q; allowed: %s", rf.field, strings.Join(spec.filterable(), ", "))
+			continue
+		}
+		if !slices.Contains(field.Ops, rf.op) {
+			fail(rf.param, raw, "operator %q is not allowed for %q; allowed: %s", rf.op, rf.field, strings.Join(field.Ops, ", "))
+			continue
+		}
+
+		if slices.ContainsFunc(filters, func(f Filter) bool { return f.Column == field.Column && f.Op == rf.op }) {
+			fail(rf.param, raw, "filter %q with operator %q is repeated", rf.field, rf.op)
+			continue
+		}
+
+		value, err := parseFilterValue(field.Type, rf.op, raw)
+		if err != nil {
+			fail(rf.param, raw, "%v", err)
+			continue
+		}
+		filters = append(filters, Filter{Field: rf.field, Column: field.Column, Op: rf.op, Value: value})
+	}
+	return filters
+}
+
+func parseFilterValue(t FieldType, op, raw string) (interface{}, error) {
+	switch op {
+	case OpExists:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("exists expects true or false")
+		}
+		return b, nil
+	case OpIn:
+		parts := strings.Split(raw, ",")
+		if len(parts) > maxInValues {
+			return nil, fmt.Errorf("in accepts at most %d values", maxInValues)
+		}
+		list := make([]interface{}, 0, len(parts))
+		for _, part := range parts {
+			v, err := parseScalar(t, strings.TrimSpace(part))
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	default:
+		return parseScalar(t, raw)
+	}
+}
+
+func parseScalar(t FieldType, raw string) (interface{}, error) {
+	switch t {
+	case BoolField:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("expected true or false")
+		}
+		return b, nil
+	case IntField:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer")
+		}
+		return n, nil
+	case TimeField:
+		if ts, err := time.Parse(time.RFC3339, raw); err == nil {
+			return ts.UTC(), nil
+		}
+		if day, err := time.Parse(time.DateOnly, raw); err == nil {
+			return day, nil
+		}
+		return nil, fmt.Errorf("expected an RFC 3339 timestamp or a YYYY-MM-DD date")
+	case ObjectIDField:
+		id, err := primitive.ObjectIDFromHex(raw)
+		if err != nil {
+			return nil, fmt.Errorf("expected a valid ID")
+		}
+		return id, nil
+	default:
+		return raw, nil
+	}
+}
+
+func (s QuerySpec) sortable() []string {
+	var names []string
+	for name, f := range s.Fields {
+		if f.Sortable {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (s QuerySpec) filterable() []string {
+	var names []string
+	for name, f := range s.Fields {
+		if len(f.Ops) > 0 {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// snakeToCamel convierte los nombres heredados (updated_at) a los de la API (updatedAt)
+func snakeToCamel(s string) string {
+	parts := strings.Split(s, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// SortString es la forma canónica de un orden ("-updatedAt,name"); los cursores
+// la guardan para rechazarse si se usan con otro orden.
+func SortString(sort []SortField) string {
+	parts := make([]string, len(sort))
+	for i, s := range sort {
+		parts[i] = s.Field
+		if s.Desc {
+			parts[i] = "-" + s.Field
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// FilterBSON traduce filtros validados a un filtro de MongoDB
+func FilterBSON(filters []Filter) bson.M {
+	conds := bson.M{}
+	for _, f := range filters {
+		ops, _ := conds[f.Column].(bson.M)
+		if ops == nil {
+			ops = bson.M{}
+			conds[f.Column] = ops
+		}
+		ops["$"+f.Op] = f.Value
+	}
+	return conds
+}
+
+// SortBSON traduce un orden a MongoDB, invirtiéndolo si reverse (página anterior por cursor)
+func SortBSON(sort []SortField, reverse bool) bson.D {
+	doc := make(bson.D, 0, len(sort))
+	for _, s := range sort {
+		doc = append(doc, bson.E{Key: s.Column, Value: direction(s.Desc != reverse)})
+	}
+	return doc
+}
+
+func direction(desc bool) int {
+	if desc {
+		return -1
+	}
+	return 1
+}
+
+// SelectFields reduce cada elemento a los campos pedidos en fields= (por su
+// nombre JSON). Con fields vacío no se llama: se responde el DTO completo.
+func SelectFields[T any](items []T, fields []string) ([]map[string]json.RawMessage, error) {
+	out := make([]map[string]json.RawMessage, len(items))
+	for i, item := range items {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &all); err != nil {
+			return nil, err
+		}
+
+		selected := make(map[string]json.RawMessage, len(fields))
+		for _, f := range fields {
+			if v, ok := all[f]; ok {
+				selected[f] = v
+			}
+		}
+		out[i] = selected
+	}
+	return out, nil
+}
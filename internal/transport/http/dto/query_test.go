@@ -0,0 +1,138 @@
+package dto
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var testSpec = QuerySpec{
+	Fields: map[string]QueryField{
+		"name":      {Column: "name", Type: StringField, Ops: []string{OpEq, OpNe, OpIn}, Sortable: true},
+		"active":    {Column: "active", Type: BoolField, Ops: []string{OpEq, OpExists}},
+		"visits":    {Column: "visits", Type: IntField, Ops: []string{OpGte, OpLte, OpIn}},
+		"createdAt": {Column: "createdAt", Type: TimeField, Ops: []string{OpGte, OpLte}, Sortable: true},
+		"ownerId":   {Column: "ownerId", Type: ObjectIDField, Ops: []string{OpEq, OpIn}},
+		"notes":     {Column: "notes", Type: StringField},
+	},
+	Selectable: []string{"id", "name", "active"},
+	Shorthands: map[string]string{"owner_id": "ownerId"},
+}
+
+func TestParseListQueryRejectsInvalidParams(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantField string
+	}{
+		{"FieldNotAllowed", "filter[notes]=x", "filter[notes]"},
+		{"UnknownField", "filter[password][eq]=x", "filter[password][eq]"},
+		{"UnknownOperator", "filter[name][regex]=^a", "filter[name][regex]"},
+		{"OperatorNotAllowedForField", "filter[createdAt][eq]=2024-01-01", "filter[createdAt][eq]"},
+		{"MalformedFilterKey", "filter[name]x=1", "filter[name]x"},
+		{"MalformedInList", "filter[visits][in]=1,dos,3", "filter[visits][in]"},
+		{"MalformedInObjectIDs", "filter[ownerId][in]=abc,def", "filter[ownerId][in]"},
+		{"ExistsNotBoolean", "filter[active][exists]=maybe", "filter[active][exists]"},
+		{"InvalidShorthand", "owner_id=nope", "owner_id"},
+		{"SortNotSortable", "sort=notes", "sort"},
+		{"SortUnknown", "sort=-password", "sort"},
+		{"SortRepeated", "sort=name,-name", "sort"},
+		{"LegacySortNotSortable", "sort_by=visits", "sort_by"},
+		{"FieldsUnknown", "fields=name,passwordHash", "fields"},
+		{"PageNotPositive", "page=0", "page"},
+		{"LimitTooLarge", "limit=101", "limit"},
+		{"RepeatedParam", "limit=10&limit=20", "limit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("query inválida en la prueba: %v", err)
+			}
+			_, errs := ParseListQuery(values, testSpec)
+			if len(errs) != 1 {
+				t.Fatalf("esperado un error, obtenidos %+v", errs)
+			}
+			if errs[0].Field != tt.wantField {
+				t.Fatalf("esperado el campo %q, obtenido %q (%s)", tt.wantField, errs[0].Field, errs[0].Message)
+			}
+		})
+	}
+}
+
+func TestParseListQueryBuildsBSON(t *testing.T) {
+	ownerA, ownerB := primitive.NewObjectID(), primitive.NewObjectID()
+	values := url.Values{
+		"filter[name][in]":       {"Luna, Toby"},
+		"filter[active][exists]": {"true"},
+		"filter[visits][gte]":    {"2"},
+		"filter[visits][lte]":    {"10"},
+		"filter[createdAt][gte]": {"2024-03-01"},
+		"filter[createdAt][lte]": {"2024-03-31T23:59:59-05:00"},
+		"filter[ownerId][in]":    {ownerA.Hex() + "," + ownerB.Hex()},
+		"sort":                   {"-createdAt,name"},
+		"fields":                 {"name,active,name"},
+		"page":                   {"2"},
+		"limit":                  {"25"},
+		"count":                  {"false"},
+	}
+
+	q, errs := ParseListQuery(values, testSpec)
+	if len(errs) != 0 {
+		t.Fatalf("errores inesperados: %+v", errs)
+	}
+	if q.Page != 2 || q.Limit != 25 || !q.SkipCount {
+		t.Fatalf("paginación inesperada: page=%d limit=%d skipCount=%v", q.Page, q.Limit, q.SkipCount)
+	}
+
+	wantFilter := bson.M{
+		"name":      bson.M{"$in": []interface{}{"Luna", "Toby"}},
+		"active":    bson.M{"$exists": true},
+		"visits":    bson.M{"$gte": int64(2), "$lte": int64(10)},
+		"createdAt": bson.M{"$gte": time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "$lte": time.Date(2024, 4, 1, 4, 59, 59, 0, time.UTC)},
+		"ownerId":   bson.M{"$in": []interface{}{ownerA, ownerB}},
+	}
+	if got := FilterBSON(q.Filters); !reflect.DeepEqual(got, wantFilter) {
+		t.Fatalf("filtro inesperado:\n got %#v\nwant %#v", got, wantFilter)
+	}
+
+	wantSort := bson.D{{Key: "createdAt", Value: -1}, {Key: "name", Value: 1}}
+	if got := SortBSON(q.Sort, false); !reflect.DeepEqual(got, wantSort) {
+		t.Fatalf("orden inesperado: %#v", got)
+	}
+	wantReverse := bson.D{{Key: "createdAt", Value: 1}, {Key: "name", Value: -1}}
+	if got := SortBSON(q.Sort, true); !reflect.DeepEqual(got, wantReverse) {
+		t.Fatalf("orden invertido inesperado: %#v", got)
+	}
+	if got := SortString(q.Sort); got != "-createdAt,name" {
+		t.Fatalf("SortString = %q", got)
+	}
+
+	if want := []string{"id", "name", "active"}; !reflect.DeepEqual(q.Fields, want) {
+		t.Fatalf("fields = %v, esperado %v", q.Fields, want)
+	}
+}
+
+func TestParseListQueryLegacyParams(t *testing.T) {
+	ownerID := primitive.NewObjectID()
+	values := url.Values{
+		"owner_id":  {ownerID.Hex()},
+		"sort_by":   {"created_at"},
+		"sort_desc": {"true"},
+	}
+
+	q, errs := ParseListQuery(values, testSpec)
+	if len(errs) != 0 {
+		t.Fatalf("errores inesperados: %+v", errs)
+	}
+	if want := (bson.M{"ownerId": bson.M{"$eq": ownerID}}); !reflect.DeepEqual(FilterBSON(q.Filters), want) {
+		t.Fatalf("filtro inesperado: %#v", FilterBSON(q.Filters))
+	}
+	if got := SortString(q.Sort); got != "-createdAt" {
+		t.Fatalf("SortString = %q", got)
+	}
+}
@@ -0,0 +1,70 @@
+// Package export escribe un listado como descarga en CSV, NDJSON o XLSX. Los
+// handlers de listado negocian el formato con la cabecera Accept y, si el
+// cliente pide uno de exportación, escriben cada elemento a medida que lo lee
+// el store en lugar de construir la respuesta JSON paginada.
+package export
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+)
+
+// Format es un formato de exportación
+type Format struct {
+	MediaType string
+	Extension string
+}
+
+// Formatos de exportación soportados
+var (
+	CSV    = Format{MediaType: "text/csv", Extension: "csv"}
+	NDJSON = Format{MediaType: "application/x-ndjson", Extension: "ndjson"}
+	XLSX   = Format{MediaType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Extension: "xlsx"}
+)
+
+var formats = []Format{CSV, NDJSON, XLSX}
+
+// Negotiate elige el formato de la cabecera Accept según sus valores q. Devuelve
+// false si el cliente prefiere JSON o no pide ningún formato de exportación: en
+// ese caso el listado responde como siempre. Un tipo concreto gana a un comodín
+// con el mismo q y, entre tipos concretos con el mismo q, gana el primero.
+func Negotiate(accept string) (Format, bool) {
+	var best Format
+	bestQ, found := 0.0, false
+	jsonQ, wildcardQ := 0.0, 0.0
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil || q < 0 || q > 1 {
+				continue
+			}
+		}
+
+		switch mediaType {
+		case "application/json":
+			// Solo cuenta si llega antes que un formato con el mismo q
+			if !found || q > bestQ {
+				jsonQ = max(jsonQ, q)
+			}
+		case "*/*", "application/*", "text/*":
+			wildcardQ = max(wildcardQ, q)
+		default:
+			for _, f := range formats {
+				if f.MediaType == mediaType && q > bestQ && q > jsonQ {
+					best, bestQ, found = f, q, true
+				}
+			}
+		}
+	}
+
+	if !found || bestQ <= jsonQ || bestQ < wildcardQ {
+		return Format{}, false
+	}
+	return best, true
+}
@@ -0,0 +1,360 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// flushEvery es cada cuántas filas se envía al cliente lo escrito en CSV/NDJSON
+const flushEvery = 100
+
+// Writer escribe los elementos de un listado en el formato negociado. Las
+// columnas salen de los nombres JSON de T: los structs anidados se aplanan con
+// puntos (palette.primary) y fields, si no está vacío, limita y ordena las
+// columnas por su nombre de primer nivel, igual que en la respuesta JSON.
+type Writer[T any] struct {
+	format  Format
+	name    string
+	columns []string
+	fields  []string
+
+	rw      http.ResponseWriter
+	out     *startWriter
+	csv     *csv.Writer
+	xlsx    *excelize.File
+	sheet   *excelize.StreamWriter
+	rows    int
+	started bool // Cabeceras (y fila de títulos) ya preparadas
+}
+
+// New crea un Writer para la descarga name (sin extensión ni fecha). No escribe
+// nada en rw hasta el primer Write o el Close.
+func New[T any](rw http.ResponseWriter, format Format, name string, fields []string) *Writer[T] {
+	return &Writer[T]{
+		format:  format,
+		name:    name,
+		columns: Columns(reflect.TypeFor[T](), fields),
+		fields:  fields,
+		rw:      rw,
+		out:     &startWriter{w: rw},
+	}
+}
+
+// Started indica si ya se envió algún byte al cliente. Hasta entonces un error
+// todavía se puede responder como problem+json; después solo se puede registrar.
+func (w *Writer[T]) Started() bool {
+	return w.out.started
+}
+
+// Discard quita las cabeceras de la descarga para responder un error en su
+// lugar. Solo tiene efecto si todavía no se envió nada (Started es false).
+func (w *Writer[T]) Discard() {
+	if w.out.started {
+		return
+	}
+	h := w.rw.Header()
+	for _, key := range []string{"Content-Type", "Content-Disposition", "Cache-Control"} {
+		h.Del(key)
+	}
+	if w.xlsx != nil {
+		w.xlsx.Close()
+	}
+}
+
+// Write añade un elemento
+func (w *Writer[T]) Write(item T) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	w.rows++
+	if w.format == NDJSON {
+		if err := w.writeNDJSON(item); err != nil {
+			return err
+		}
+		return w.maybeFlush()
+	}
+
+	values, err := flatten(item)
+	if err != nil {
+		return fmt.Errorf("failed to encode export row: %w", err)
+	}
+	switch w.format {
+	case CSV:
+		record := make([]string, len(w.columns))
+		for i, col := range w.columns {
+			record[i] = csvCell(values[col])
+		}
+		if err := w.csv.Write(record); err != nil {
+			return err
+		}
+	case XLSX:
+		row := make([]any, len(w.columns))
+		for i, col := range w.columns {
+			row[i] = xlsxCell(values[col])
+		}
+		cell, err := excelize.CoordinatesToCellName(1, w.rows+1)
+		if err != nil {
+			return err
+		}
+		return w.sheet.SetRow(cell, row)
+	}
+
+	return w.maybeFlush()
+}
+
+// maybeFlush envía lo escrito cada flushEvery filas
+func (w *Writer[T]) maybeFlush() error {
+	if w.rows%flushEvery == 0 {
+		return w.flush()
+	}
+	return nil
+}
+
+// Close termina la descarga. En XLSX es cuando se envía el libro completo (el
+// StreamWriter de excelize lo va volcando a un fichero temporal, no a memoria).
+func (w *Writer[T]) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	if w.format == XLSX {
+		defer w.xlsx.Close()
+		if err := w.sheet.Flush(); err != nil {
+			return err
+		}
+		if _, err := w.xlsx.WriteTo(w.out); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if err := w.flush(); err != nil {
+		return err
+	}
+	if !w.out.started {
+		// Listado vacío en NDJSON: sin cuerpo, pero con las cabeceras de la descarga
+		w.rw.WriteHeader(http.StatusOK)
+		w.out.started = true
+	}
+	return nil
+}
+
+// start prepara las cabeceras HTTP y la fila de títulos
+func (w *Writer[T]) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	filename := fmt.Sprintf("%s-%s.%s", w.name, time.Now().UTC().Format("20060102"), w.format.Extension)
+	h := w.rw.Header()
+	h.Set("Content-Type", w.format.MediaType)
+	h.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	h.Set("Cache-Control", "no-store")
+	h.Set("X-Content-Type-Options", "nosniff")
+
+	switch w.format {
+	case CSV:
+		h.Set("Content-Type", w.format.MediaType+"; charset=utf-8")
+		w.csv = csv.NewWriter(w.out)
+		return w.csv.Write(w.columns)
+	case XLSX:
+		w.xlsx = excelize.NewFile()
+		sheet, err := w.xlsx.NewStreamWriter("Sheet1")
+		if err != nil {
+			return err
+		}
+		w.sheet = sheet
+		header := make([]any, len(w.columns))
+		for i, col := range w.columns {
+			header[i] = col
+		}
+		return w.sheet.SetRow("A1", header)
+	}
+	return nil
+}
+
+// writeNDJSON escribe el elemento como una línea JSON con los campos pedidos
+func (w *Writer[T]) writeNDJSON(item T) error {
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("failed to encode export row: %w", err)
+	}
+	if len(w.fields) > 0 {
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &all); err != nil {
+			return fmt.Errorf("failed to encode export row: %w", err)
+		}
+		selected := make(map[string]json.RawMessage, len(w.fields))
+		for _, f := range w.fields {
+			if v, ok := all[f]; ok {
+				selected[f] = v
+			}
+		}
+		if raw, err = json.Marshal(selected); err != nil {
+			return fmt.Errorf("failed to encode export row: %w", err)
+		}
+	}
+	_, err = w.out.Write(append(raw, '\n'))
+	return err
+}
+
+// flush envía al cliente lo que quede en los buffers
+func (w *Writer[T]) flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if f, ok := w.rw.(http.Flusher); ok && w.out.started {
+		f.Flush()
+	}
+	return nil
+}
+
+// startWriter registra si ya se escribió algún byte en la respuesta
+type startWriter struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (s *startWriter) Write(p []byte) (int, error) {
+	s.started = true
+	return s.w.Write(p)
+}
+
+// Columns devuelve las columnas de t (un struct o un puntero a struct)
+func Columns(t reflect.Type, fields []string) []string {
+	all := columns(t, "")
+	if len(fields) == 0 {
+		return all
+	}
+
+	var selected []string
+	for _, f := range fields {
+		for _, col := range all {
+			if col == f || strings.HasPrefix(col, f+".") {
+				selected = append(selected, col)
+			}
+		}
+	}
+	return selected
+}
+
+var (
+	timeType      = reflect.TypeFor[time.Time]()
+	marshalerType = reflect.TypeFor[json.Marshaler]()
+)
+
+func columns(t reflect.Type, prefix string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var cols []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		ft := field.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && ft != timeType && !reflect.PointerTo(ft).Implements(marshalerType) {
+			cols = append(cols, columns(ft, prefix+name+".")...)
+			continue
+		}
+		cols = append(cols, prefix+name)
+	}
+	return cols
+}
+
+// flatten codifica item como JSON y lo aplana con las mismas claves que Columns
+func flatten(item any) (map[string]any, error) {
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var doc map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]any, len(doc))
+	var walk func(prefix string, m map[string]any)
+	walk = func(prefix string, m map[string]any) {
+		for k, v := range m {
+			if nested, ok := v.(map[string]any); ok {
+				walk(prefix+k+".", nested)
+				continue
+			}
+			values[prefix+k] = v
+		}
+	}
+	walk("", doc)
+	return values, nil
+}
+
+// csvCell convierte un valor JSON en texto. Los textos que una hoja de cálculo
+// interpretaría como fórmula se escapan con un apóstrofo (inyección CSV).
+func csvCell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+			return "'" + v
+		}
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	default:
+		raw, _ := json.Marshal(v)
+		return string(raw)
+	}
+}
+
+// xlsxCell convierte un valor JSON en una celda: los números y booleanos
+// conservan su tipo; los textos nunca se interpretan como fórmula.
+func xlsxCell(v any) any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case string, bool:
+		return v
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	default:
+		raw, _ := json.Marshal(v)
+		return string(raw)
+	}
+}
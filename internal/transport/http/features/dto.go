@@ -0,0 +1,41 @@
+package features
+
+import (
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+)
+
+// FeatureResponse es un flag de la clínica ya evaluado.
+type FeatureResponse struct {
+	Key     string `json:"key" example:"inventory"`
+	Module  bool   `json:"module"`                // true: activa un grupo de rutas de la API
+	Enabled bool   `json:"enabled"`               // Resultado para quien hace la petición
+	Rollout *int   `json:"rollout,omitempty"`     // Porcentaje de sujetos con el flag encendido
+	Source  string `json:"source" example:"plan"` // plan o clinic
+}
+
+// FeaturesResponse son los flags de una clínica.
+type FeaturesResponse struct {
+	ClinicID string            `json:"clinicId"`
+	Features []FeatureResponse `json:"features"`
+}
+
+// SetFeatureRequest es el valor propio de la clínica para un flag.
+type SetFeatureRequest struct {
+	Enabled bool `json:"enabled"`
+	Rollout *int `json:"rollout" validate:"omitempty,min=0,max=100" example:"25"` // Opcional: encendido para ese porcentaje
+}
+
+func (r SetFeatureRequest) toModel() *models.FeatureFlag {
+	return &models.FeatureFlag{Enabled: r.Enabled, Rollout: r.Rollout}
+}
+
+func toFeatureResponse(state services.FeatureState) FeatureResponse {
+	return FeatureResponse{
+		Key:     state.Key,
+		Module:  state.Module,
+		Enabled: state.Enabled,
+		Rollout: state.Flag.Rollout,
+		Source:  state.Source,
+	}
+}
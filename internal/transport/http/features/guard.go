@@ -0,0 +1,85 @@
+package features
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/zabaletac3/go-vet-api/internal/auth"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/apierror"
+)
+
+// ClinicFunc extrae de la petición la clínica cuyo flag decide; "" deja
+// pasar la petición (la ruta no es de ninguna clínica).
+type ClinicFunc func(r *http.Request) string
+
+// PathClinic toma la clínica del parámetro {id} de la ruta.
+func PathClinic(r *http.Request) string {
+	return r.PathValue("id")
+}
+
+// QueryClinic toma la clínica del parámetro clinicId de la URL.
+func QueryClinic(r *http.Request) string {
+	return r.URL.Query().Get("clinicId")
+}
+
+// Guard protege rutas con el flag de un módulo: si está apagado para la
+// clínica la ruta responde 404 (MODULE_DISABLED), como si no existiera.
+type Guard struct {
+	service services.FeatureService
+	logger  *slog.Logger
+}
+
+// NewGuard crea el guard de módulos.
+func NewGuard(svc services.FeatureService, logger *slog.Logger) *Guard {
+	return &Guard{service: svc, logger: logger.With("middleware", "feature_guard")}
+}
+
+// Require envuelve next para que solo responda si el módulo está encendido.
+// Si la clínica no existe la petición sigue: su handler ya responde 404.
+func (g *Guard) Require(module string, clinic ClinicFunc, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clinicID := clinic(r)
+		if clinicID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		enabled, err := g.service.Enabled(r.Context(), clinicID, module, auth.PrincipalFromContext(r.Context()))
+		switch {
+		case errors.Is(err, services.ErrClinicNotFound), errors.Is(err, services.ErrInvalidClinicID):
+			next.ServeHTTP(w, r)
+		case err != nil:
+			apierror.Write(w, r, g.logger, err, "Error checking module flag", "module", module, "clinic_id", clinicID)
+		case !enabled:
+			apierror.Write(w, r, g.logger, services.ErrModuleDisabled, "")
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// Group registra un grupo de rutas protegido por el mismo módulo. Los grupos
+// de hospitalización, laboratorio e inventario se registran así al añadirlos.
+type Group struct {
+	mux    *http.ServeMux
+	guard  *Guard
+	module string
+	clinic ClinicFunc
+}
+
+// Group devuelve un registrador de rutas del módulo sobre mux.
+func (g *Guard) Group(mux *http.ServeMux, module string, clinic ClinicFunc) *Group {
+	return &Group{mux: mux, guard: g, module: module, clinic: clinic}
+}
+
+// Handle registra handler protegido por el módulo del grupo.
+func (g *Group) Handle(pattern string, handler http.Handler) {
+	g.mux.Handle(pattern, g.guard.Require(g.module, g.clinic, handler))
+}
+
+// HandleFunc registra handler protegido por el módulo del grupo.
+func (g *Group) HandleFunc(pattern string, handler http.HandlerFunc) {
+	g.Handle(pattern, handler)
+}
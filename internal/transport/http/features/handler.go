@@ -0,0 +1,108 @@
+package features
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/zabaletac3/go-vet-api/internal/auth"
+	"github.com/zabaletac3/go-vet-api/internal/middleware"
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/apierror"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+)
+
+// Handler contiene las dependencias de los flags.
+type Handler struct {
+	service services.FeatureService
+	logger  *slog.Logger
+}
+
+// NewHandler es el constructor del handler de flags.
+func NewHandler(svc services.FeatureService, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: svc,
+		logger:  logger.With("handler", "features"),
+	}
+}
+
+// List devuelve los flags de la clínica evaluados para quien hace la petición
+// @Summary      List clinic features
+// @Description  Returns every module and feature flag of the clinic evaluated for the caller, so the frontend can hide menu items. Modules are enabled by the clinic's plan unless the clinic overrides them; a disabled module answers 404 (MODULE_DISABLED) on its routes. Percentage rollouts are stable per user (or per clinic for anonymous requests).
+// @Tags         Features
+// @Produce      json
+// @Param        id   path      string  true  "Clinic ID"
+// @Success      200  {object}  FeaturesResponse
+// @Failure      400  {object}  response.Problem "Invalid ID"
+// @Failure      404  {object}  response.Problem "Clinic not found"
+// @Failure      500  {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{id}/features [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	clinicID := r.PathValue("id")
+	states, err := h.service.List(r.Context(), clinicID, auth.PrincipalFromContext(r.Context()))
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error listing clinic features", "clinic_id", clinicID)
+		return
+	}
+
+	res := FeaturesResponse{ClinicID: clinicID, Features: make([]FeatureResponse, len(states))}
+	for i, state := range states {
+		res.Features[i] = toFeatureResponse(state)
+	}
+	w.Header().Set("Cache-Control", "private, no-cache")
+	response.JSON(w, http.StatusOK, res)
+}
+
+// Set sustituye el valor del plan por uno propio de la clínica
+// @Summary      Override a clinic feature
+// @Description  Sets the clinic's own value for a module or feature flag, replacing the plan default. Use rollout to enable it for a stable percentage of users.
+// @Tags         Features
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string             true  "Clinic ID"
+// @Param        key   path      string             true  "Flag key, e.g. inventory"
+// @Param        flag  body      SetFeatureRequest  true  "Flag value"
+// @Success      200   {object}  FeatureResponse
+// @Failure      400   {object}  response.Problem "Invalid ID, key or rollout"
+// @Failure      404   {object}  response.Problem "Clinic not found"
+// @Failure      500   {object}  response.Problem "Internal server error"
+// @Router       /api/v1/admin/clinics/{id}/features/{key} [put]
+func (h *Handler) Set(w http.ResponseWriter, r *http.Request) {
+	var req SetFeatureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, r, response.CodeMalformedJSON, "Request body is not valid JSON")
+		return
+	}
+	if errs := middleware.ValidationErrors(r, &req); len(errs) > 0 {
+		response.ValidationErrorRes(w, r, "Request body has invalid fields", errs)
+		return
+	}
+	h.save(w, r, req.toModel())
+}
+
+// Reset vuelve al valor del plan
+// @Summary      Reset a clinic feature to its plan default
+// @Description  Removes the clinic's own value for the flag, so the plan default applies again.
+// @Tags         Features
+// @Produce      json
+// @Param        id   path      string  true  "Clinic ID"
+// @Param        key  path      string  true  "Flag key, e.g. inventory"
+// @Success      200  {object}  FeatureResponse
+// @Failure      400  {object}  response.Problem "Invalid ID or key"
+// @Failure      404  {object}  response.Problem "Clinic not found"
+// @Failure      500  {object}  response.Problem "Internal server error"
+// @Router       /api/v1/admin/clinics/{id}/features/{key} [delete]
+func (h *Handler) Reset(w http.ResponseWriter, r *http.Request) {
+	h.save(w, r, nil)
+}
+
+func (h *Handler) save(w http.ResponseWriter, r *http.Request, flag *models.FeatureFlag) {
+	clinicID, key := r.PathValue("id"), r.PathValue("key")
+	state, err := h.service.Set(r.Context(), clinicID, key, flag)
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error saving clinic feature", "clinic_id", clinicID, "feature", key)
+		return
+	}
+	response.JSON(w, http.StatusOK, toFeatureResponse(*state))
+}
@@ -0,0 +1,20 @@
+package features
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+// RegisterRoutes registra los flags de cada clínica.
+func RegisterRoutes(mux *http.ServeMux, stores *storage.Stores, logger *slog.Logger) {
+	handler := NewHandler(services.NewFeatureService(stores, logger), logger)
+
+	mux.HandleFunc("GET /api/v1/clinics/{id}/features", handler.List)
+	mux.HandleFunc("PUT /api/v1/admin/clinics/{id}/features/{key}", handler.Set)
+	mux.HandleFunc("DELETE /api/v1/admin/clinics/{id}/features/{key}", handler.Reset)
+
+	logger.Info("Feature routes registered successfully")
+}
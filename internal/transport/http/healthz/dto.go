@@ -0,0 +1,43 @@
+package healthz
+
+import (
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/health"
+)
+
+// LivenessResponse indica que el proceso atiende peticiones.
+type LivenessResponse struct {
+	Status    string    `json:"status" example:"ok"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ReadinessResponse es el resultado de los checks de dependencias.
+type ReadinessResponse struct {
+	Status    string                   `json:"status" example:"ok"` // ok, fail o draining
+	Timestamp time.Time                `json:"timestamp"`
+	Checks    map[string]CheckResponse `json:"checks"`
+}
+
+// CheckResponse es el resultado de un check.
+type CheckResponse struct {
+	Status    string  `json:"status" example:"ok"` // ok o fail
+	LatencyMs float64 `json:"latencyMs" example:"1.25"`
+	Error     string  `json:"error,omitempty"`
+}
+
+func toReadinessResponse(report health.Report) ReadinessResponse {
+	res := ReadinessResponse{
+		Status:    report.Status,
+		Timestamp: time.Now().UTC(),
+		Checks:    make(map[string]CheckResponse, len(report.Checks)),
+	}
+	for name, result := range report.Checks {
+		res.Checks[name] = CheckResponse{
+			Status:    result.Status,
+			LatencyMs: float64(result.Latency.Microseconds()) / 1000,
+			Error:     result.Error,
+		}
+	}
+	return res
+}
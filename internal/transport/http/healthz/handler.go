@@ -0,0 +1,50 @@
+package healthz
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/health"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+)
+
+// Handler sirve las sondas de salud.
+type Handler struct {
+	checks *health.Registry
+}
+
+// NewHandler es el constructor del handler de salud.
+func NewHandler(checks *health.Registry) *Handler {
+	return &Handler{checks: checks}
+}
+
+// Live indica si el proceso está vivo
+// @Summary      Liveness probe
+// @Description  Returns 200 while the process is able to serve requests. It does not check dependencies: a failing database must not restart the process.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  LivenessResponse
+// @Router       /healthz/live [get]
+func (h *Handler) Live(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	response.JSON(w, http.StatusOK, LivenessResponse{Status: health.StatusOK, Timestamp: time.Now().UTC()})
+}
+
+// Ready indica si la instancia puede recibir tráfico
+// @Summary      Readiness probe
+// @Description  Runs the dependency checks (MongoDB ping, pending migrations, background worker heartbeats, free disk space for uploads) and reports the status and latency of each one. Returns 503 when any check fails and while the instance is shutting down (status draining), so load balancers stop sending it traffic. GET /health is an alias.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  ReadinessResponse
+// @Failure      503  {object}  ReadinessResponse "A check failed or the instance is draining"
+// @Router       /healthz/ready [get]
+func (h *Handler) Ready(w http.ResponseWriter, r *http.Request) {
+	report := h.checks.Run(r.Context())
+
+	status := http.StatusOK
+	if report.Status != health.StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	response.JSON(w, status, toReadinessResponse(report))
+}
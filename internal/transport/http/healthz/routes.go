@@ -0,0 +1,21 @@
+package healthz
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/zabaletac3/go-vet-api/internal/health"
+)
+
+// RegisterRoutes registra las sondas de liveness y readiness. Van fuera de
+// /api/v1, como /metrics: son para el orquestador y los balanceadores.
+func RegisterRoutes(mux *http.ServeMux, checks *health.Registry, logger *slog.Logger) {
+	handler := NewHandler(checks)
+
+	mux.HandleFunc("GET /healthz/live", handler.Live)
+	mux.HandleFunc("GET /healthz/ready", handler.Ready)
+	// Ruta antigua: ahora responde como la readiness
+	mux.HandleFunc("GET /health", handler.Ready)
+
+	logger.Info("Health routes registered successfully")
+}
@@ -0,0 +1,122 @@
+package imports
+
+import (
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/importer"
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+	"github.com/zabaletac3/go-vet-api/internal/validators"
+)
+
+// MappingRequest es el cuerpo de preview y commit. Sin mapping se usa el
+// guardado en la importación (al subir el archivo, el propuesto).
+type MappingRequest struct {
+	// Campo de importación -> nombre de la columna del archivo
+	Mapping map[string]string `json:"mapping,omitempty" example:"patient.name:Mascota,patient.species:Especie,owner.fullName:Tutor"`
+}
+
+// FieldResponse describe un campo de importación.
+type FieldResponse struct {
+	Key      string `json:"key" example:"patient.species"`
+	Required bool   `json:"required"`
+}
+
+// ImportResponse es una importación con su progreso.
+type ImportResponse struct {
+	ID         string             `json:"id"`
+	ClinicID   string             `json:"clinicId"`
+	FileName   string             `json:"fileName" example:"pacientes.xlsx"`
+	Format     string             `json:"format" example:"xlsx"`
+	Columns    []string           `json:"columns"`
+	Mapping    map[string]string  `json:"mapping"`
+	Fields     []FieldResponse    `json:"fields"`
+	Status     string             `json:"status" example:"running"` // uploaded, queued, running, completed o failed
+	Stats      models.ImportStats `json:"stats"`
+	Error      string             `json:"error,omitempty"`
+	CreatedAt  time.Time          `json:"createdAt"`
+	UpdatedAt  time.Time          `json:"updatedAt"`
+	StartedAt  *time.Time         `json:"startedAt,omitempty"`
+	FinishedAt *time.Time         `json:"finishedAt,omitempty"`
+}
+
+// RowErrorsResponse son los errores de validación de una fila. Row es el
+// número de fila de datos, empezando en 1 después de la cabecera.
+type RowErrorsResponse struct {
+	Row    int                        `json:"row" example:"3"`
+	Errors []response.ValidationError `json:"errors"`
+}
+
+// PreviewResponse es el resultado del dry-run.
+type PreviewResponse struct {
+	Total   int                 `json:"total"`
+	Valid   int                 `json:"valid"`
+	Invalid int                 `json:"invalid"`
+	Rows    []RowErrorsResponse `json:"rows"`   // Primeras filas inválidas (máx. 100)
+	Sample  []map[string]string `json:"sample"` // Primeras filas válidas ya mapeadas
+}
+
+// InvalidRowsResponse es una página de filas que no se importaron.
+type InvalidRowsResponse struct {
+	Data []RowErrorsResponse `json:"data"`
+	// NextAfter se pasa como after para pedir la siguiente página (ausente en la última)
+	NextAfter *int `json:"nextAfter,omitempty"`
+}
+
+func toImportResponse(job *models.ImportJob) ImportResponse {
+	fields := make([]FieldResponse, len(importer.Fields))
+	for i, f := range importer.Fields {
+		fields[i] = FieldResponse{Key: f.Key, Required: f.Required}
+	}
+	return ImportResponse{
+		ID:         job.ID.Hex(),
+		ClinicID:   job.ClinicID.Hex(),
+		FileName:   job.FileName,
+		Format:     job.Format,
+		Columns:    job.Columns,
+		Mapping:    job.Mapping,
+		Fields:     fields,
+		Status:     job.Status,
+		Stats:      job.Stats,
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		UpdatedAt:  job.UpdatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+	}
+}
+
+func toPreviewResponse(preview *services.ImportPreview) PreviewResponse {
+	rows := make([]RowErrorsResponse, len(preview.Rows))
+	for i, row := range preview.Rows {
+		rows[i] = RowErrorsResponse{Row: row.Index + 1, Errors: toValidationErrors(row.Errors)}
+	}
+	sample := preview.Sample
+	if sample == nil {
+		sample = []map[string]string{}
+	}
+	return PreviewResponse{
+		Total:   preview.Total,
+		Valid:   preview.Valid,
+		Invalid: preview.Invalid,
+		Rows:    rows,
+		Sample:  sample,
+	}
+}
+
+func toRowErrorsResponse(row *models.ImportRow) RowErrorsResponse {
+	errs := make([]response.ValidationError, len(row.Errors))
+	for i, e := range row.Errors {
+		errs[i] = response.ValidationError{Field: e.Field, Message: e.Message, Value: e.Value}
+	}
+	return RowErrorsResponse{Row: row.Index + 1, Errors: errs}
+}
+
+func toValidationErrors(fieldErrors []validators.FieldError) []response.ValidationError {
+	errs := make([]response.ValidationError, len(fieldErrors))
+	for i, e := range fieldErrors {
+		errs[i] = response.ValidationError{Field: e.Field, Message: e.Message, Value: e.Value}
+	}
+	return errs
+}
@@ -0,0 +1,261 @@
+package imports
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/apierror"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+)
+
+// MaxUploadSize limita el tamaño del archivo subido.
+const MaxUploadSize = 10 << 20
+
+// Límites de la paginación de filas inválidas
+const (
+	defaultRowsLimit = 50
+	maxRowsLimit     = 500
+)
+
+// Handler contiene las dependencias de las importaciones.
+type Handler struct {
+	service services.ImportService
+	logger  *slog.Logger
+}
+
+// NewHandler es el constructor del handler de importaciones.
+func NewHandler(svc services.ImportService, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: svc,
+		logger:  logger.With("handler", "imports"),
+	}
+}
+
+// Upload sube un archivo de pacientes y tutores
+// @Summary      Upload a patients and owners file
+// @Description  Reads a CSV (comma, semicolon or tab separated) or XLSX file (first sheet) of up to 10 MiB and 10000 rows. The first row is the header. Nothing is imported yet: the response proposes a column mapping to review with preview and confirm with commit.
+// @Tags         Imports
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id    path      string  true  "Clinic ID"
+// @Param        file  formData  file    true  "CSV or XLSX file"
+// @Success      201   {object}  ImportResponse
+// @Failure      400   {object}  response.Problem "Missing or unreadable file"
+// @Failure      404   {object}  response.Problem "Clinic not found"
+// @Failure      413   {object}  response.Problem "File too large or too many rows"
+// @Failure      415   {object}  response.Problem "Not a CSV or XLSX file"
+// @Failure      500   {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{id}/imports [post]
+func (h *Handler) Upload(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxUploadSize)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.Error(w, r, response.CodePayloadTooLarge, "File must be at most 10 MiB")
+			return
+		}
+		response.ValidationErrorRes(w, r, "Request body has invalid fields", []response.ValidationError{
+			{Field: "file", Message: i18n.Translate(i18n.FromContext(r.Context()), "This field is required")},
+		})
+		return
+	}
+	defer file.Close()
+
+	job, err := h.service.Upload(r.Context(), services.UploadImportParams{
+		ClinicID: r.PathValue("id"),
+		FileName: header.Filename,
+		File:     file,
+	})
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error uploading import", "clinic_id", r.PathValue("id"))
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, toImportResponse(job))
+}
+
+// GetImport devuelve una importación con su progreso
+// @Summary      Get an import
+// @Description  Returns the import with its mapping, status and progress.
+// @Tags         Imports
+// @Produce      json
+// @Param        id        path      string  true  "Clinic ID"
+// @Param        importId  path      string  true  "Import ID"
+// @Success      200       {object}  ImportResponse
+// @Failure      400       {object}  response.Problem "Invalid ID"
+// @Failure      404       {object}  response.Problem "Import not found"
+// @Failure      500       {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{id}/imports/{importId} [get]
+func (h *Handler) GetImport(w http.ResponseWriter, r *http.Request) {
+	job, err := h.service.GetByID(r.Context(), r.PathValue("id"), r.PathValue("importId"))
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error getting import", "import_id", r.PathValue("importId"))
+		return
+	}
+	response.JSON(w, http.StatusOK, toImportResponse(job))
+}
+
+// Preview valida las filas sin importar nada (dry-run)
+// @Summary      Preview an import (dry-run)
+// @Description  Validates every row with the given mapping (or the saved one) and returns the errors per row, without importing anything. Species must be one of the allowed species (Spanish and Portuguese names are accepted) and dates must be ISO 8601.
+// @Tags         Imports
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string          true   "Clinic ID"
+// @Param        importId  path      string          true   "Import ID"
+// @Param        body      body      MappingRequest  false  "Column mapping"
+// @Success      200       {object}  PreviewResponse
+// @Failure      400       {object}  response.Problem "Invalid mapping"
+// @Failure      404       {object}  response.Problem "Import not found"
+// @Failure      500       {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{id}/imports/{importId}/preview [post]
+func (h *Handler) Preview(w http.ResponseWriter, r *http.Request) {
+	mapping, ok := decodeMapping(w, r)
+	if !ok {
+		return
+	}
+
+	preview, err := h.service.Preview(r.Context(), r.PathValue("id"), r.PathValue("importId"), services.PreviewImportParams{
+		Mapping: mapping,
+		Locale:  i18n.FromContext(r.Context()),
+	})
+	if err != nil {
+		h.writeError(w, r, err, "Error previewing import")
+		return
+	}
+	response.JSON(w, http.StatusOK, toPreviewResponse(preview))
+}
+
+// Commit confirma la importación y la pone en cola
+// @Summary      Commit an import
+// @Description  Saves the mapping and imports the rows in the background. Owners are deduplicated against existing ones by email or phone. Poll the import to follow its progress; invalid rows are skipped and listed in /errors.
+// @Tags         Imports
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string          true   "Clinic ID"
+// @Param        importId  path      string          true   "Import ID"
+// @Param        body      body      MappingRequest  false  "Column mapping"
+// @Success      202       {object}  ImportResponse
+// @Failure      400       {object}  response.Problem "Invalid mapping"
+// @Failure      404       {object}  response.Problem "Import not found"
+// @Failure      409       {object}  response.Problem "Import already committed"
+// @Failure      500       {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{id}/imports/{importId}/commit [post]
+func (h *Handler) Commit(w http.ResponseWriter, r *http.Request) {
+	mapping, ok := decodeMapping(w, r)
+	if !ok {
+		return
+	}
+
+	job, err := h.service.Commit(r.Context(), r.PathValue("id"), r.PathValue("importId"), mapping, i18n.FromContext(r.Context()))
+	if err != nil {
+		h.writeError(w, r, err, "Error committing import")
+		return
+	}
+	response.JSON(w, http.StatusAccepted, toImportResponse(job))
+}
+
+// Resume reanuda una importación fallida
+// @Summary      Resume a failed import
+// @Description  Queues a failed import again. Rows already processed are not imported twice. Imports interrupted by a restart resume on their own.
+// @Tags         Imports
+// @Produce      json
+// @Param        id        path      string  true  "Clinic ID"
+// @Param        importId  path      string  true  "Import ID"
+// @Success      202       {object}  ImportResponse
+// @Failure      404       {object}  response.Problem "Import not found"
+// @Failure      409       {object}  response.Problem "Import has not failed"
+// @Failure      500       {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{id}/imports/{importId}/resume [post]
+func (h *Handler) Resume(w http.ResponseWriter, r *http.Request) {
+	job, err := h.service.Resume(r.Context(), r.PathValue("id"), r.PathValue("importId"))
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error resuming import", "import_id", r.PathValue("importId"))
+		return
+	}
+	response.JSON(w, http.StatusAccepted, toImportResponse(job))
+}
+
+// InvalidRows lista las filas que no se importaron
+// @Summary      List rows that were not imported
+// @Description  Rows that failed validation during the import, with their errors, in file order.
+// @Tags         Imports
+// @Produce      json
+// @Param        id        path      string  true   "Clinic ID"
+// @Param        importId  path      string  true   "Import ID"
+// @Param        after     query     int     false  "nextAfter from the previous page"
+// @Param        limit     query     int     false  "Rows per page (default: 50, max: 500)"
+// @Success      200       {object}  InvalidRowsResponse
+// @Failure      400       {object}  response.Problem "Invalid parameters"
+// @Failure      404       {object}  response.Problem "Import not found"
+// @Failure      500       {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{id}/imports/{importId}/errors [get]
+func (h *Handler) InvalidRows(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	locale := i18n.FromContext(r.Context())
+
+	var errs []response.ValidationError
+	after, limit := -1, defaultRowsLimit
+	if v := query.Get("after"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			errs = append(errs, response.ValidationError{Field: "after", Message: i18n.Translate(locale, "Must be greater than or equal to %s", "0"), Value: v})
+		}
+		after = n
+	}
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxRowsLimit {
+			errs = append(errs, response.ValidationError{Field: "limit", Message: i18n.Translate(locale, "limit must be an integer between 1 and %s", strconv.Itoa(maxRowsLimit)), Value: v})
+		}
+		limit = n
+	}
+	if len(errs) > 0 {
+		response.ValidationErrorRes(w, r, "Invalid query parameters", errs)
+		return
+	}
+
+	rows, err := h.service.InvalidRows(r.Context(), r.PathValue("id"), r.PathValue("importId"), after, limit)
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error listing invalid import rows", "import_id", r.PathValue("importId"))
+		return
+	}
+
+	res := InvalidRowsResponse{Data: make([]RowErrorsResponse, len(rows))}
+	for i, row := range rows {
+		res.Data[i] = toRowErrorsResponse(row)
+	}
+	if len(rows) == limit {
+		next := rows[len(rows)-1].Index
+		res.NextAfter = &next
+	}
+	response.JSON(w, http.StatusOK, res)
+}
+
+// decodeMapping lee el cuerpo opcional con el mapeo de columnas.
+func decodeMapping(w http.ResponseWriter, r *http.Request) (map[string]string, bool) {
+	var req MappingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(w, r, response.CodeMalformedJSON, "Request body is not valid JSON")
+		return nil, false
+	}
+	return req.Mapping, true
+}
+
+// writeError responde los errores de mapeo con el error de cada campo y el
+// resto con el registro de apierror.
+func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, err error, msg string) {
+	var mappingErr *services.MappingError
+	if errors.As(err, &mappingErr) {
+		response.ValidationErrorRes(w, r, "Invalid column mapping", toValidationErrors(mappingErr.Errors))
+		return
+	}
+	apierror.Write(w, r, h.logger, err, msg, "import_id", r.PathValue("importId"))
+}
@@ -0,0 +1,39 @@
+package imports
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/features"
+)
+
+// RegisterRoutes registra la importación de pacientes y tutores de una clínica.
+// Los trabajos confirmados los procesa services.ImportRunner. Todas las rutas
+// responden 404 si la clínica no tiene el módulo de importación.
+func RegisterRoutes(mux *http.ServeMux, stores *storage.Stores, logger *slog.Logger) {
+	handler := NewHandler(services.NewImportService(stores, logger), logger)
+	guard := features.NewGuard(services.NewFeatureService(stores, logger), logger)
+	group := guard.Group(mux, models.ModuleImports, features.PathClinic)
+
+	group.Handle("POST /api/v1/clinics/{id}/imports", clinicLocale(handler.Upload))
+	group.Handle("GET /api/v1/clinics/{id}/imports/{importId}", clinicLocale(handler.GetImport))
+	group.Handle("POST /api/v1/clinics/{id}/imports/{importId}/preview", clinicLocale(handler.Preview))
+	group.Handle("POST /api/v1/clinics/{id}/imports/{importId}/commit", clinicLocale(handler.Commit))
+	group.Handle("POST /api/v1/clinics/{id}/imports/{importId}/resume", clinicLocale(handler.Resume))
+	group.Handle("GET /api/v1/clinics/{id}/imports/{importId}/errors", clinicLocale(handler.InvalidRows))
+
+	logger.Info("Import routes registered successfully")
+}
+
+// clinicLocale hace que los mensajes (y los errores por fila) salgan en el
+// idioma de la clínica si el cliente no pide uno.
+func clinicLocale(next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i18n.UseClinic(r.Context(), r.PathValue("id"))
+		next(w, r)
+	})
+}
@@ -0,0 +1,81 @@
+// Package logging da a cada petición su logger y escribe el log de acceso.
+// Los registros llevan request_id y trace_id si se hacen con el ctx de la
+// petición (ver requestid.LogHandler y tracing.LogHandler).
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/recorder"
+)
+
+type contextKey struct{}
+
+// FromContext devuelve el logger de la petición, o slog.Default() si ctx no
+// pasó por Middleware.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// NewContext devuelve una copia de ctx con el logger.
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// Middleware guarda en el ctx de cada petición un logger derivado de logger
+// con el método y la ruta pedida.
+func Middleware(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reqLogger := logger.With("method", r.Method, "path", r.URL.Path)
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), reqLogger)))
+		})
+	}
+}
+
+// AccessLog escribe una línea por petición con el status, los bytes, la
+// duración, el patrón de ruta y la clínica. Como metrics.Middleware, debe ir
+// pegado al mux (sin middlewares que copien la petición en medio) para leer
+// r.Pattern y los valores de la ruta.
+func AccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := recorder.Wrap(w)
+		next.ServeHTTP(rec, r)
+
+		status := rec.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		attrs := []slog.Attr{
+			slog.String("route", r.Pattern),
+			slog.Int("status", status),
+			slog.Int64("bytes", rec.Bytes()),
+			slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+		}
+		if clinicID := clinic(r); clinicID != "" {
+			attrs = append(attrs, slog.String("clinic_id", clinicID))
+		}
+
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		FromContext(r.Context()).LogAttrs(r.Context(), level, "HTTP request", attrs...)
+	})
+}
+
+// clinic es el ID de clínica de las rutas /api/v1/clinics/{id}/...
+func clinic(r *http.Request) string {
+	if strings.Contains(r.Pattern, "/clinics/{id}") {
+		return r.PathValue("id")
+	}
+	return ""
+}
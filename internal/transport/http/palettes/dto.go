@@ -0,0 +1,85 @@
+package palettes
+
+import (
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/palette"
+)
+
+// GenerateRequest es el color de marca del que se parte.
+type GenerateRequest struct {
+	Color string `json:"color" validate:"required,hex_color" example:"#0EA5E9"`
+}
+
+// GenerateResponse es la paleta generada y su variante oscura.
+type GenerateResponse struct {
+	Level    string           `json:"level" example:"AA-large"` // Nivel de WCAG 2.1 configurado (PALETTE_CONTRAST_LEVEL)
+	Required float64          `json:"required" example:"3"`     // Contraste mínimo de primary y secondary con el fondo
+	Light    GeneratedPalette `json:"light"`
+	Dark     GeneratedPalette `json:"dark"`
+	Dominant []string         `json:"dominant,omitempty"` // Colores dominantes del logo, de más a menos frecuente
+}
+
+// GeneratedPalette son los cinco colores, el texto legible sobre cada uno y
+// el contraste de primary y secondary con el fondo.
+type GeneratedPalette struct {
+	Primary    string           `json:"primary" example:"#0B84BA"`
+	Secondary  string           `json:"secondary" example:"#1769D4"`
+	Tertiary   string           `json:"tertiary" example:"#242E33"`
+	Quaternary string           `json:"quaternary" example:"#D65A2B"`
+	Background string           `json:"background" example:"#F9FBFC"`
+	Text       TextColors       `json:"text"`
+	Contrast   []ContrastResult `json:"contrast"`
+}
+
+// TextColors es el color de texto (#111827 o #FFFFFF) con más contraste sobre cada color.
+type TextColors struct {
+	OnPrimary    string `json:"onPrimary" example:"#FFFFFF"`
+	OnSecondary  string `json:"onSecondary" example:"#FFFFFF"`
+	OnTertiary   string `json:"onTertiary" example:"#FFFFFF"`
+	OnQuaternary string `json:"onQuaternary" example:"#FFFFFF"`
+	OnBackground string `json:"onBackground" example:"#111827"`
+}
+
+// ContrastResult es el contraste de un color con el fondo.
+type ContrastResult struct {
+	Color  string  `json:"color" example:"primary"`
+	Ratio  float64 `json:"ratio" example:"4.01"` // Redondeado hacia abajo a dos decimales
+	Passes bool    `json:"passes"`
+}
+
+func toGenerateResponse(generated *palette.Generated) GenerateResponse {
+	return GenerateResponse{
+		Level:    palette.Level(),
+		Required: palette.Required(),
+		Light:    toGeneratedPalette(generated.Light),
+		Dark:     toGeneratedPalette(generated.Dark),
+		Dominant: generated.Dominant,
+	}
+}
+
+func toGeneratedPalette(p models.ColorPalette) GeneratedPalette {
+	checks := palette.CheckPalette(p)
+	contrast := make([]ContrastResult, len(checks))
+	for i, check := range checks {
+		contrast[i] = ContrastResult{
+			Color:  check.Color,
+			Ratio:  float64(int(check.Ratio*100)) / 100,
+			Passes: check.Passes,
+		}
+	}
+	return GeneratedPalette{
+		Primary:    p.Primary,
+		Secondary:  p.Secondary,
+		Tertiary:   p.Tertiary,
+		Quaternary: p.Quaternary,
+		Background: p.Background,
+		Text: TextColors{
+			OnPrimary:    models.TextColorOn(p.Primary),
+			OnSecondary:  models.TextColorOn(p.Secondary),
+			OnTertiary:   models.TextColorOn(p.Tertiary),
+			OnQuaternary: models.TextColorOn(p.Quaternary),
+			OnBackground: models.TextColorOn(p.Background),
+		},
+		Contrast: contrast,
+	}
+}
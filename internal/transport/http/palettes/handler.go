@@ -0,0 +1,107 @@
+package palettes
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	"github.com/zabaletac3/go-vet-api/internal/branding"
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/middleware"
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/palette"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/apierror"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+)
+
+// MaxUploadSize limita el cuerpo multipart: el logo admite hasta 2 MiB.
+const MaxUploadSize = 2<<20 + 64<<10
+
+// Handler genera paletas; no guarda nada.
+type Handler struct {
+	logger *slog.Logger
+}
+
+// NewHandler es el constructor del handler de paletas.
+func NewHandler(logger *slog.Logger) *Handler {
+	return &Handler{logger: logger.With("handler", "palettes")}
+}
+
+// Generate genera una paleta a partir de un color o de un logo
+// @Summary      Generate a palette
+// @Description  Derives a harmonious five-colour palette from a brand colour (JSON body) or from the dominant colours of a logo (multipart, field logo: PNG, JPEG, GIF or WebP up to 2 MiB). Primary is the brand colour, secondary an analogous colour (or the logo's second colour), tertiary a dark neutral tinted with the brand hue, quaternary the complementary accent and background a tinted white. Primary, secondary and quaternary are darkened just enough to reach the configured WCAG 2.1 contrast with the background; the dark variant lightens them against a near-black background. Nothing is saved: send the colours to PATCH /api/v1/clinics/{id}.
+// @Tags         Palettes
+// @Accept       json
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        request  body      GenerateRequest  false  "Brand colour (JSON)"
+// @Param        logo     formData  file             false  "Logo (multipart)"
+// @Success      200      {object}  GenerateResponse
+// @Failure      400      {object}  response.Problem "Invalid colour, missing or unreadable logo"
+// @Failure      413      {object}  response.Problem "Logo too large"
+// @Failure      415      {object}  response.Problem "Logo is not a PNG, JPEG, GIF or WebP image"
+// @Failure      500      {object}  response.Problem "Internal server error"
+// @Router       /api/v1/palettes/generate [post]
+func (h *Handler) Generate(w http.ResponseWriter, r *http.Request) {
+	var (
+		generated *palette.Generated
+		err       error
+	)
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		generated, err = h.fromLogo(w, r)
+	} else {
+		generated, err = h.fromColor(w, r)
+	}
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error generating palette")
+		return
+	}
+	if generated == nil {
+		return // Ya se respondió con el error de la petición
+	}
+	response.JSON(w, http.StatusOK, toGenerateResponse(generated))
+}
+
+// fromColor lee el color del cuerpo JSON; devuelve nil, nil si ya respondió
+func (h *Handler) fromColor(w http.ResponseWriter, r *http.Request) (*palette.Generated, error) {
+	var req GenerateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, r, response.CodeMalformedJSON, "Request body is not valid JSON")
+		return nil, nil
+	}
+	if errs := middleware.ValidationErrors(r, &req); len(errs) > 0 {
+		response.ValidationErrorRes(w, r, "Request body has invalid fields", errs)
+		return nil, nil
+	}
+	return palette.FromColor(req.Color)
+}
+
+// fromLogo lee el logo del formulario; devuelve nil, nil si ya respondió
+func (h *Handler) fromLogo(w http.ResponseWriter, r *http.Request) (*palette.Generated, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxUploadSize)
+	file, _, err := r.FormFile("logo")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.Error(w, r, response.CodePayloadTooLarge, "Images must be at most 2 MiB (favicons 1 MiB)")
+			return nil, nil
+		}
+		response.ValidationErrorRes(w, r, "Request body has invalid fields", []response.ValidationError{
+			{Field: "logo", Message: i18n.Translate(i18n.FromContext(r.Context()), "This field is required")},
+		})
+		return nil, nil
+	}
+	defer file.Close()
+
+	data, err := branding.Read(models.AssetLogo, file)
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := branding.Decode(models.AssetLogo, data)
+	if err != nil {
+		return nil, err
+	}
+	return palette.FromImage(img)
+}
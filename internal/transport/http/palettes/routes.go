@@ -0,0 +1,15 @@
+package palettes
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// RegisterRoutes registra la generación de paletas.
+func RegisterRoutes(mux *http.ServeMux, logger *slog.Logger) {
+	handler := NewHandler(logger)
+
+	mux.HandleFunc("POST /api/v1/palettes/generate", handler.Generate)
+
+	logger.Info("Palette routes registered successfully")
+}
@@ -0,0 +1,70 @@
+package plans
+
+import (
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+)
+
+// PlanResponse es un plan de suscripción con sus límites.
+type PlanResponse struct {
+	ID          string   `json:"id" example:"free"`
+	Name        string   `json:"name" example:"Free"`
+	MaxUsers    *int64   `json:"maxUsers" example:"3"`      // null: ilimitado
+	MaxPatients *int64   `json:"maxPatients" example:"200"` // null: ilimitado
+	StorageMB   *int64   `json:"storageMB" example:"100"`   // null: ilimitado
+	Modules     []string `json:"modules" example:"search,transfers"`
+}
+
+// ResourceUsageResponse es el uso de un recurso frente al límite del plan.
+type ResourceUsageResponse struct {
+	Used      int64  `json:"used" example:"2"`
+	Limit     *int64 `json:"limit" example:"3"`     // null: ilimitado
+	Remaining *int64 `json:"remaining" example:"1"` // null: ilimitado
+}
+
+// UsageResponse es el uso de una clínica frente a los límites de su plan.
+type UsageResponse struct {
+	ClinicID string                `json:"clinicId"`
+	Plan     PlanResponse          `json:"plan"`
+	Users    ResourceUsageResponse `json:"users"`
+	Patients ResourceUsageResponse `json:"patients"`
+	Storage  ResourceUsageResponse `json:"storage"` // En bytes
+}
+
+func toPlanResponse(plan models.Plan) PlanResponse {
+	return PlanResponse{
+		ID:          plan.ID,
+		Name:        plan.Name,
+		MaxUsers:    limit(plan.MaxUsers),
+		MaxPatients: limit(plan.MaxPatients),
+		StorageMB:   limit(plan.StorageMB),
+		Modules:     plan.Modules,
+	}
+}
+
+func toResourceUsageResponse(u services.ResourceUsage) ResourceUsageResponse {
+	res := ResourceUsageResponse{Used: u.Used, Limit: limit(u.Limit)}
+	if res.Limit != nil {
+		remaining := u.Remaining()
+		res.Remaining = &remaining
+	}
+	return res
+}
+
+func toUsageResponse(usage *services.ClinicPlanUsage) UsageResponse {
+	return UsageResponse{
+		ClinicID: usage.ClinicID,
+		Plan:     toPlanResponse(usage.Plan),
+		Users:    toResourceUsageResponse(usage.Users),
+		Patients: toResourceUsageResponse(usage.Patients),
+		Storage:  toResourceUsageResponse(usage.Storage),
+	}
+}
+
+// limit convierte un límite del plan a JSON: 0 (ilimitado) es null
+func limit(n int64) *int64 {
+	if n <= 0 {
+		return nil
+	}
+	return &n
+}
@@ -0,0 +1,60 @@
+package plans
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/apierror"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+)
+
+// Handler contiene las dependencias de los planes.
+type Handler struct {
+	service services.PlanService
+	logger  *slog.Logger
+}
+
+// NewHandler es el constructor del handler de planes.
+func NewHandler(svc services.PlanService, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: svc,
+		logger:  logger.With("handler", "plans"),
+	}
+}
+
+// List devuelve los planes a la venta
+// @Summary      List subscription plans
+// @Description  Returns the plans a clinic can be on, from smallest to largest, with their limits and enabled modules. A null limit means unlimited.
+// @Tags         Plans
+// @Produce      json
+// @Success      200  {array}   PlanResponse
+// @Router       /api/v1/plans [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	plans := make([]PlanResponse, len(models.Plans))
+	for i, plan := range models.Plans {
+		plans[i] = toPlanResponse(plan)
+	}
+	response.JSON(w, http.StatusOK, plans)
+}
+
+// Usage devuelve el uso de una clínica frente a los límites de su plan
+// @Summary      Get clinic usage against its plan
+// @Description  Returns the clinic's plan and, for users, patients and storage (in bytes), how much is used, the plan limit and what remains. A null limit means unlimited. Counters are kept up to date on every write; a clinic that moved to a smaller plan may be above its limits until it frees up resources.
+// @Tags         Plans
+// @Produce      json
+// @Param        id   path      string  true  "Clinic ID"
+// @Success      200  {object}  UsageResponse
+// @Failure      400  {object}  response.Problem "Invalid ID"
+// @Failure      404  {object}  response.Problem "Clinic not found"
+// @Failure      500  {object}  response.Problem "Internal server error"
+// @Router       /api/v1/admin/clinics/{id}/usage [get]
+func (h *Handler) Usage(w http.ResponseWriter, r *http.Request) {
+	usage, err := h.service.Usage(r.Context(), r.PathValue("id"))
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error getting clinic usage", "clinic_id", r.PathValue("id"))
+		return
+	}
+	response.JSON(w, http.StatusOK, toUsageResponse(usage))
+}
@@ -0,0 +1,19 @@
+package plans
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+// RegisterRoutes registra el catálogo de planes y el uso de cada clínica.
+func RegisterRoutes(mux *http.ServeMux, stores *storage.Stores, logger *slog.Logger) {
+	handler := NewHandler(services.NewPlanService(stores, logger), logger)
+
+	mux.HandleFunc("GET /api/v1/plans", handler.List)
+	mux.HandleFunc("GET /api/v1/admin/clinics/{id}/usage", handler.Usage)
+
+	logger.Info("Plan routes registered successfully")
+}
@@ -0,0 +1,64 @@
+// Package recorder guarda el status y los bytes de una respuesta para los
+// middlewares que los necesitan después de servirla (métricas, trazas, logs).
+package recorder
+
+import "net/http"
+
+// Recorder envuelve un http.ResponseWriter y anota lo que se responde.
+type Recorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+// Wrap devuelve el Recorder de w, o uno nuevo si w no lo es: varios
+// middlewares encadenados comparten así el mismo.
+func Wrap(w http.ResponseWriter) *Recorder {
+	if rec, ok := w.(*Recorder); ok {
+		return rec
+	}
+	return &Recorder{ResponseWriter: w}
+}
+
+// Status es el código enviado; 200 si el handler escribió sin llamar a
+// WriteHeader, 0 si aún no respondió nada.
+func (r *Recorder) Status() int {
+	return r.status
+}
+
+// Bytes es el tamaño del cuerpo escrito
+func (r *Recorder) Bytes() int64 {
+	return r.bytes
+}
+
+func (r *Recorder) WriteHeader(status int) {
+	// Los 1xx (p. ej. 103 Early Hints) no son la respuesta final
+	if r.status == 0 && status >= 200 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *Recorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Flush mantiene el streaming de las exportaciones
+func (r *Recorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		if r.status == 0 {
+			r.status = http.StatusOK
+		}
+		f.Flush()
+	}
+}
+
+// Unwrap permite a http.ResponseController llegar al writer original
+func (r *Recorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
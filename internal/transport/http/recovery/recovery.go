@@ -0,0 +1,45 @@
+// Package recovery convierte el pánico de un handler en una respuesta 500 en
+// vez de cortar la conexión.
+package recovery
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/logging"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/recorder"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+)
+
+// Middleware recupera los pánicos, los registra con su traza de pila y
+// responde un problema 500 con el ID de la petición. Va por dentro de
+// metrics.Middleware y tracing.Middleware para que cuenten el 500. Si el
+// handler ya había empezado a responder no se puede enviar otra respuesta y se
+// aborta la conexión.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := recorder.Wrap(w)
+		defer func() {
+			p := recover()
+			if p == nil {
+				return
+			}
+			// http.ErrAbortHandler es la forma prevista de cortar una respuesta
+			if p == http.ErrAbortHandler {
+				panic(p)
+			}
+
+			logging.FromContext(r.Context()).ErrorContext(r.Context(), "Panic serving request",
+				"panic", fmt.Sprint(p),
+				"stack", string(debug.Stack()),
+			)
+			if rec.Status() != 0 {
+				panic(http.ErrAbortHandler)
+			}
+			response.Error(rec, r, response.CodeInternal, "An unexpected error occurred")
+		}()
+
+		next.ServeHTTP(rec, r)
+	})
+}
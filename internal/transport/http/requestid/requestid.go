@@ -0,0 +1,64 @@
+// Package requestid identifica cada petición con un ID que se devuelve en la
+// cabecera X-Request-ID y en las respuestas de error, para poder cruzar un
+// error reportado por un cliente con los logs.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// Header es la cabecera de la que se toma (si es válido) y en la que se devuelve el ID.
+const Header = "X-Request-ID"
+
+// maxLength limita los IDs que se aceptan del cliente
+const maxLength = 128
+
+type contextKey struct{}
+
+// FromContext devuelve el ID de la petición, o "" si no pasó por Middleware.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// NewContext devuelve una copia de ctx con el ID de la petición.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// Middleware asigna el ID a cada petición: el de X-Request-ID si el cliente
+// (o un proxy) envía uno válido, o uno nuevo.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(Header)
+		if !valid(id) {
+			id = generate()
+		}
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
+
+// valid acepta solo IDs cortos de caracteres visibles sin comillas (van a logs y JSON)
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if c := id[i]; c <= ' ' || c > '~' || c == '"' || c == '\\' {
+			return false
+		}
+	}
+	return true
+}
+
+func generate() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,97 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/requestid"
+)
+
+// ProblemContentType es el tipo de contenido de las respuestas de error (RFC 9457, antes RFC 7807).
+const ProblemContentType = "application/problem+json"
+
+// Code es un código de error estable. El contrato con los clientes es Name:
+// el título y el detalle pueden cambiar sin romperlos, y se traducen al idioma
+// de la petición (el texto en inglés es la clave del catálogo de i18n).
+type Code struct {
+	Name   string // p. ej. CLINIC_NAME_TAKEN
+	Status int
+	Title  string
+}
+
+// Type es la URI del tipo de problema, derivada del código
+func (c Code) Type() string {
+	return "urn:go-vet-api:problem:" + strings.ToLower(strings.ReplaceAll(c.Name, "_", "-"))
+}
+
+// Códigos genéricos de la capa HTTP. Los de dominio (errores de los servicios)
+// están en el registro del paquete apierror.
+var (
+	CodeBadRequest           = Code{"BAD_REQUEST", http.StatusBadRequest, "Bad Request"}
+	CodeMalformedJSON        = Code{"MALFORMED_JSON", http.StatusBadRequest, "Malformed JSON"}
+	CodeValidationFailed     = Code{"VALIDATION_FAILED", http.StatusBadRequest, "Validation Failed"}
+	CodeUnauthorized         = Code{"UNAUTHORIZED", http.StatusUnauthorized, "Unauthorized"}
+	CodeForbidden            = Code{"FORBIDDEN", http.StatusForbidden, "Forbidden"}
+	CodeNotFound             = Code{"NOT_FOUND", http.StatusNotFound, "Not Found"}
+	CodeConflict             = Code{"CONFLICT", http.StatusConflict, "Conflict"}
+	CodePreconditionRequired = Code{"PRECONDITION_REQUIRED", http.StatusPreconditionRequired, "Precondition Required"}
+	CodeUnprocessableEntity  = Code{"UNPROCESSABLE_ENTITY", http.StatusUnprocessableEntity, "Unprocessable Entity"}
+	CodePayloadTooLarge      = Code{"PAYLOAD_TOO_LARGE", http.StatusRequestEntityTooLarge, "Payload Too Large"}
+	CodeTooManyRequests      = Code{"TOO_MANY_REQUESTS", http.StatusTooManyRequests, "Too Many Requests"}
+	CodeInternal             = Code{"INTERNAL_ERROR", http.StatusInternalServerError, "Internal Server Error"}
+
+	// Idempotency-Key (ver middleware.Idempotency)
+	CodeInvalidIdempotencyKey = Code{"INVALID_IDEMPOTENCY_KEY", http.StatusBadRequest, "Invalid Idempotency Key"}
+	CodeIdempotencyKeyReused  = Code{"IDEMPOTENCY_KEY_REUSED", http.StatusConflict, "Idempotency Key Reused"}
+	CodeIdempotencyInProgress = Code{"IDEMPOTENCY_REQUEST_IN_PROGRESS", http.StatusConflict, "Request In Progress"}
+)
+
+// Problem es el cuerpo de todas las respuestas de error de la API
+type Problem struct {
+	Type      string            `json:"type" example:"urn:go-vet-api:problem:clinic-name-taken"`
+	Title     string            `json:"title" example:"Clinic Name Taken"`
+	Status    int               `json:"status" example:"409"`
+	Detail    string            `json:"detail,omitempty" example:"A clinic with that name already exists"`
+	Instance  string            `json:"instance,omitempty" example:"/api/v1/clinics"` // Ruta de la petición
+	Code      string            `json:"code" example:"CLINIC_NAME_TAKEN"`             // Estable: usar este para decidir en el cliente
+	RequestID string            `json:"requestId,omitempty" example:"4f7d1c2e9a0b4c3d8e6f5a4b3c2d1e0f"`
+	Errors    []ValidationError `json:"errors,omitempty"` // Errores por campo (VALIDATION_FAILED)
+}
+
+// NewProblem construye el problema de una petición con el código y el detalle
+// indicados, con el título y el detalle traducidos al idioma de la petición
+func NewProblem(r *http.Request, code Code, detail string) Problem {
+	locale := i18n.FromContext(r.Context())
+	return Problem{
+		Type:      code.Type(),
+		Title:     i18n.Translate(locale, code.Title),
+		Status:    code.Status,
+		Detail:    i18n.Translate(locale, detail),
+		Instance:  r.URL.Path,
+		Code:      code.Name,
+		RequestID: requestid.FromContext(r.Context()),
+	}
+}
+
+// WriteProblem envía un problema como application/problem+json
+func WriteProblem(w http.ResponseWriter, r *http.Request, p Problem) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("Content-Language", string(i18n.FromContext(r.Context())))
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// Error envía un error con un código genérico (o uno de dominio ya resuelto)
+func Error(w http.ResponseWriter, r *http.Request, code Code, detail string) {
+	WriteProblem(w, r, NewProblem(r, code, detail))
+}
+
+// ValidationErrorRes envía los errores de validación por campo (VALIDATION_FAILED).
+// Los mensajes de fields ya deben venir traducidos.
+func ValidationErrorRes(w http.ResponseWriter, r *http.Request, detail string, fields []ValidationError) {
+	p := NewProblem(r, CodeValidationFailed, detail)
+	p.Errors = fields
+	WriteProblem(w, r, p)
+}
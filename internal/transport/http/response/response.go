@@ -4,13 +4,8 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
-)
 
-// ErrorResponse representa una respuesta de error básica
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-}
+)
 
 // ValidationError representa un error de validación específico
 type ValidationError struct {
@@ -19,13 +14,6 @@ type ValidationError struct {
 	Value   string `json:"value,omitempty"`
 }
 
-// ValidationErrorResponse representa una respuesta con errores de validación
-type ValidationErrorResponse struct {
-	Error   string            `json:"error"`
-	Message string            `json:"message"`
-	Fields  []ValidationError `json:"fields"`
-}
-
 // SuccessResponse representa una respuesta exitosa genérica
 type SuccessResponse struct {
 	Success bool        `json:"success"`
@@ -59,23 +47,6 @@ func JSON(w http.ResponseWriter, statusCode int, data interface{}) {
 	}
 }
 
-// Error envía una respuesta de error básica
-func Error(w http.ResponseWriter, statusCode int, error string, message string) {
-	JSON(w, statusCode, ErrorResponse{
-		Error:   error,
-		Message: message,
-	})
-}
-
-// ValidationError envía una respuesta con errores de validación
-func ValidationErrorRes(w http.ResponseWriter, error string, message string, fields []ValidationError) {
-	JSON(w, http.StatusBadRequest, ValidationErrorResponse{
-		Error:   error,
-		Message: message,
-		Fields:  fields,
-	})
-}
-
 // Success envía una respuesta exitosa
 func Success(w http.ResponseWriter, message string, data interface{}) {
 	JSON(w, http.StatusOK, SuccessResponse{
@@ -99,6 +70,11 @@ func NoContent(w http.ResponseWriter) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// NotModified envía una respuesta 304 (GET condicional) sin cuerpo
+func NotModified(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNotModified)
+}
+
 // Paginated envía una respuesta paginada
 func Paginated(w http.ResponseWriter, data interface{}, currentPage, perPage, totalPages int, total int64) {
 	JSON(w, http.StatusOK, PaginatedResponse{
@@ -114,44 +90,44 @@ func Paginated(w http.ResponseWriter, data interface{}, currentPage, perPage, to
 }
 
 // BadRequest envía una respuesta de solicitud incorrecta
-func BadRequest(w http.ResponseWriter, message string) {
-	Error(w, http.StatusBadRequest, "Solicitud incorrecta", message)
+func BadRequest(w http.ResponseWriter, r *http.Request, message string) {
+	Error(w, r, CodeBadRequest, message)
 }
 
 // Unauthorized envía una respuesta de no autorizado
-func Unauthorized(w http.ResponseWriter, message string) {
-	Error(w, http.StatusUnauthorized, "No autorizado", message)
+func Unauthorized(w http.ResponseWriter, r *http.Request, message string) {
+	Error(w, r, CodeUnauthorized, message)
 }
 
 // Forbidden envía una respuesta de prohibido
-func Forbidden(w http.ResponseWriter, message string) {
-	Error(w, http.StatusForbidden, "Prohibido", message)
+func Forbidden(w http.ResponseWriter, r *http.Request, message string) {
+	Error(w, r, CodeForbidden, message)
 }
 
 // NotFound envía una respuesta de no encontrado
-func NotFound(w http.ResponseWriter, message string) {
-	Error(w, http.StatusNotFound, "No encontrado", message)
+func NotFound(w http.ResponseWriter, r *http.Request, message string) {
+	Error(w, r, CodeNotFound, message)
 }
 
 // Conflict envía una respuesta de conflicto
-func Conflict(w http.ResponseWriter, message string) {
-	Error(w, http.StatusConflict, "Conflicto", message)
+func Conflict(w http.ResponseWriter, r *http.Request, message string) {
+	Error(w, r, CodeConflict, message)
 }
 
 // InternalServerError envía una respuesta de error interno del servidor
-func InternalServerError(w http.ResponseWriter, message string, logger *slog.Logger, err error) {
+func InternalServerError(w http.ResponseWriter, r *http.Request, message string, logger *slog.Logger, err error) {
 	if logger != nil && err != nil {
-		logger.Error("Error interno del servidor", "error", err, "message", message)
+		logger.ErrorContext(r.Context(), "Error interno del servidor", "error", err, "message", message)
 	}
-	Error(w, http.StatusInternalServerError, "Error interno del servidor", message)
+	Error(w, r, CodeInternal, message)
 }
 
 // UnprocessableEntity envía una respuesta de entidad no procesable
-func UnprocessableEntity(w http.ResponseWriter, message string) {
-	Error(w, http.StatusUnprocessableEntity, "Entidad no procesable", message)
+func UnprocessableEntity(w http.ResponseWriter, r *http.Request, message string) {
+	Error(w, r, CodeUnprocessableEntity, message)
 }
 
 // TooManyRequests envía una respuesta de demasiadas solicitudes
-func TooManyRequests(w http.ResponseWriter, message string) {
-	Error(w, http.StatusTooManyRequests, "Demasiadas solicitudes", message)
-}
\ No newline at end of file
+func TooManyRequests(w http.ResponseWriter, r *http.Request, message string) {
+	Error(w, r, CodeTooManyRequests, message)
+}
@@ -1,12 +1,22 @@
 package http
 
 import (
-	"encoding/json"
 	"log/slog"
 	"net/http"
-	"time"
 
+	"github.com/zabaletac3/go-vet-api/internal/health"
+	"github.com/zabaletac3/go-vet-api/internal/metrics"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/attachments"
 	"github.com/zabaletac3/go-vet-api/internal/transport/http/clinics"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/features"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/healthz"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/imports"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/palettes"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/plans"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/search"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/themes"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/transfers"
 	"github.com/zabaletac3/go-vet-api/internal/transport/http/users"
 	"go.mongodb.org/mongo-driver/mongo"
 
@@ -14,40 +24,48 @@ import (
 )
 
 // SetupAllRoutes recibe las dependencias globales y las distribuye.
-func SetupAllRoutes(mux *http.ServeMux, db *mongo.Database, logger *slog.Logger) {
+// db es nil cuando la API corre con STORAGE_DRIVER=memory; checks son los de
+// la readiness.
+func SetupAllRoutes(mux *http.ServeMux, stores *storage.Stores, db *mongo.Database, checks *health.Registry, logger *slog.Logger) {
 
 
 	// Módulo de Usuarios
-	users.RegisterRoutes(mux, db, logger)
+	users.RegisterRoutes(mux, stores, logger)
 
 	// Módulo de Clínicas
-	clinics.RegisterRoutes(mux, db, logger)
-
-	// @Summary     Obtener información de salud
-	// @Description Endpoint para verificar el estado del servidor
-	// @Tags        health
-	// @Accept      json
-	// @Produce     json
-	// @Success     200 {object} map[string]string
-	// @Router      /health [get]
-	mux.HandleFunc("GET /health", handleHealthCheck(logger))
+	clinics.RegisterRoutes(mux, stores, db, logger)
+
+	// Importación de pacientes y tutores (CSV/XLSX)
+	imports.RegisterRoutes(mux, stores, logger)
+
+	// Exportación e importación de clínicas completas
+	transfers.RegisterRoutes(mux, stores, logger)
+
+	// Planes de suscripción y uso de cada clínica
+	plans.RegisterRoutes(mux, stores, logger)
+
+	// Flags y módulos de cada clínica
+	features.RegisterRoutes(mux, stores, logger)
+
+	// Imágenes de marca y tema público de cada clínica
+	themes.RegisterRoutes(mux, stores, logger)
+
+	// Generación de paletas accesibles (desde un color o un logo)
+	palettes.RegisterRoutes(mux, logger)
+
+	// Ficheros adjuntos de las clínicas (historias, analíticas, imágenes...)
+	attachments.RegisterRoutes(mux, stores, logger)
+
+	// Búsqueda global (clínicas, tutores y mascotas)
+	search.RegisterRoutes(mux, stores, logger)
+
+	// Sondas de liveness y readiness
+	healthz.RegisterRoutes(mux, checks, logger)
+
+	// Métricas para Prometheus (fuera de la API: sin /api/v1 ni documentación)
+	mux.Handle("GET /metrics", metrics.Handler())
 	mux.HandleFunc("GET /test/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("La ruta de prueba funciona!"))
 	})
 	mux.HandleFunc("/swagger/", httpSwagger.WrapHandler) 
 }
-
-// handleHealthCheck ahora es una función privada dentro del paquete http.
-func handleHealthCheck(logger *slog.Logger) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		response := map[string]string{
-			"status":    "ok, go!",
-			"timestamp": time.Now().UTC().Format(time.RFC3339),
-		}
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(response); err != nil {
-			logger.Error("Error escribiendo respuesta de health check", "error", err)
-		}
-	}
-}
\ No newline at end of file
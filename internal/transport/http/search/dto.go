@@ -0,0 +1,40 @@
+package search
+
+import (
+	"math"
+
+	"github.com/zabaletac3/go-vet-api/internal/services"
+)
+
+// HitResponse es un resultado de la búsqueda global.
+type HitResponse struct {
+	Type     string  `json:"type" example:"pets"` // clinics, owners o pets
+	ID       string  `json:"id"`
+	ClinicID string  `json:"clinicId,omitempty"`
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle,omitempty"`
+	Score    float64 `json:"score"` // Entre 0 y 1
+}
+
+// SearchResponse es la respuesta de GET /api/v1/search.
+type SearchResponse struct {
+	Data []HitResponse `json:"data"`
+}
+
+// fromHits convierte los resultados del servicio en DTOs de respuesta.
+func fromHits(hits []services.SearchHit) []HitResponse {
+	data := make([]HitResponse, len(hits))
+	for i, hit := range hits {
+		data[i] = HitResponse{
+			Type:     hit.Type,
+			ID:       hit.ID.Hex(),
+			Title:    hit.Title,
+			Subtitle: hit.Subtitle,
+			Score:    math.Round(hit.Score*100) / 100,
+		}
+		if !hit.ClinicID.IsZero() {
+			data[i].ClinicID = hit.ClinicID.Hex()
+		}
+	}
+	return data
+}
@@ -0,0 +1,83 @@
+package search
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/apierror"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+)
+
+// maxQueryLength limita el largo de q (el coste crece con los términos y trigramas)
+const maxQueryLength = 200
+
+// Handler contiene las dependencias de la búsqueda global.
+type Handler struct {
+	service services.SearchService
+	logger  *slog.Logger
+}
+
+// NewHandler es el constructor del handler de búsqueda.
+func NewHandler(svc services.SearchService, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: svc,
+		logger:  logger.With("handler", "search"),
+	}
+}
+
+// Search busca clínicas, tutores y mascotas tolerando errores tipográficos
+// @Summary      Search clinics, owners and pets
+// @Description  Typo-tolerant search ranked by similarity. Matches whole words, prefixes and words with up to 1 typo (4-7 letters) or 2 typos (8+ letters), ignoring case and accents. Owners and pets are searched within clinicId.
+// @Tags         Search
+// @Produce      json
+// @Param        q         query     string  true   "Search text (max 200 characters)"
+// @Param        clinicId  query     string  false  "Clinic whose owners and pets are searched"
+// @Param        types     query     string  false  "Comma-separated: clinics, owners, pets (default: clinics, plus owners and pets when clinicId is given)"
+// @Param        limit     query     int     false  "Max results (default: 20, max: 50)"
+// @Success      200       {object}  SearchResponse
+// @Failure      400       {object}  response.Problem "Invalid parameters"
+// @Failure      404       {object}  response.Problem "Clinic not found"
+// @Failure      500       {object}  response.Problem "Internal server error"
+// @Router       /api/v1/search [get]
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	params := services.SearchParams{
+		Query:    query.Get("q"),
+		ClinicID: query.Get("clinicId"),
+	}
+	// Los mensajes salen en el idioma de la clínica si el cliente no pide uno
+	i18n.UseClinic(r.Context(), params.ClinicID)
+
+	var errs []response.ValidationError
+	if len(params.Query) > maxQueryLength {
+		errs = append(errs, response.ValidationError{Field: "q", Message: i18n.Translate(i18n.FromContext(r.Context()), "q must be at most 200 characters")})
+	}
+	if types := query.Get("types"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			params.Types = append(params.Types, strings.TrimSpace(t))
+		}
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 50 {
+			errs = append(errs, response.ValidationError{Field: "limit", Message: i18n.Translate(i18n.FromContext(r.Context()), "limit must be an integer between 1 and 50"), Value: limitStr})
+		}
+		params.Limit = limit
+	}
+	if len(errs) > 0 {
+		response.ValidationErrorRes(w, r, "Invalid query parameters", errs)
+		return
+	}
+
+	hits, err := h.service.Search(r.Context(), params)
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error searching", "q", params.Query)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, SearchResponse{Data: fromHits(hits)})
+}
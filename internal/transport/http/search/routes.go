@@ -0,0 +1,22 @@
+package search
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/features"
+)
+
+// RegisterRoutes registra la búsqueda global. Con clinicId, la clínica debe
+// tener el módulo de búsqueda; sin él solo se buscan clínicas y no se comprueba.
+func RegisterRoutes(mux *http.ServeMux, stores *storage.Stores, logger *slog.Logger) {
+	handler := NewHandler(services.NewSearchService(stores, logger), logger)
+	guard := features.NewGuard(services.NewFeatureService(stores, logger), logger)
+
+	guard.Group(mux, models.ModuleSearch, features.QueryClinic).HandleFunc("GET /api/v1/search", handler.Search)
+
+	logger.Info("Search routes registered successfully")
+}
@@ -11,6 +11,13 @@ import (
 	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/zabaletac3/go-vet-api/internal/health"
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/metrics"
+	"github.com/zabaletac3/go-vet-api/internal/tracing"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/logging"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/recovery"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/requestid"
 )
 
 // Server ahora tiene su propia instancia de logger.
@@ -19,20 +26,37 @@ type Server struct {
 	Mux    *http.ServeMux 
 	logger *slog.Logger 
 	validate *validator.Validate
+	checks     *health.Registry
+	drainDelay time.Duration
 }
 
 // NewServer es el constructor que ahora recibe el logger como dependencia.
-func NewServer(port int, logger *slog.Logger) *Server { 
+// Al apagarse, la readiness de checks falla durante drainDelay antes de dejar
+// de aceptar conexiones, para que los balanceadores retiren la instancia.
+// middlewares van al final de la cadena, justo antes del mux.
+func NewServer(port int, checks *health.Registry, drainDelay time.Duration, logger *slog.Logger, middlewares ...Middleware) *Server { 
 	mux := http.NewServeMux()
 
 	server := &Server{
 		server: &http.Server{
 			Addr:    fmt.Sprintf(":%d", port),
-			Handler: mux,
+			Handler: Chain(mux, append([]Middleware{
+				requestid.Middleware,
+				logging.Middleware(logger),
+				i18n.Middleware,
+				// De aquí hacia dentro ningún middleware copia la petición: leen
+				// el patrón de ruta que el mux deja en ella
+				tracing.Middleware,
+				logging.AccessLog,
+				metrics.Middleware,
+				recovery.Middleware,
+			}, middlewares...)...),
 		},
 		Mux:    mux,
 		logger: logger, 
 		validate: validator.New(),
+		checks:     checks,
+		drainDelay: drainDelay,
 	}
 
 	
@@ -62,6 +86,13 @@ func (s *Server) gracefulShutdown() {
 
 	s.logger.Info("Servidor apagándose...")
 
+	// Las peticiones siguen atendiéndose mientras los balanceadores ven la readiness en fallo
+	s.checks.Drain()
+	if s.drainDelay > 0 {
+		s.logger.Info("Retirando la instancia de los balanceadores", "drain_delay", s.drainDelay.String())
+		time.Sleep(s.drainDelay)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -0,0 +1,130 @@
+package themes
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+)
+
+// ThemeResponse es el tema de marca blanca de una clínica.
+type ThemeResponse struct {
+	Name        string                   `json:"name" example:"vet-central"`
+	DisplayName string                   `json:"displayName" example:"Veterinaria Central"`
+	Palette     PaletteResponse          `json:"palette"`
+	Text        TextColorsResponse       `json:"text"`             // Color de texto legible sobre cada color de la paleta
+	Dark        DarkThemeResponse        `json:"dark"`             // Variante para modo oscuro
+	Assets      map[string]AssetResponse `json:"assets,omitempty"` // Por tipo: logo, favicon, emailHeader (solo los subidos)
+}
+
+// PaletteResponse son los colores de la clínica; los que no tiene toman los de la paleta por defecto.
+type PaletteResponse struct {
+	Primary    string `json:"primary" example:"#3B82F6"`
+	Secondary  string `json:"secondary" example:"#8B5CF6"`
+	Tertiary   string `json:"tertiary" example:"#1F2937"`
+	Quaternary string `json:"quaternary" example:"#1F2937"`
+	Background string `json:"background" example:"#FFFFFF"`
+}
+
+// TextColorsResponse es el color de texto (#111827 o #FFFFFF) con más contraste sobre cada color.
+type TextColorsResponse struct {
+	OnPrimary    string `json:"onPrimary" example:"#FFFFFF"`
+	OnSecondary  string `json:"onSecondary" example:"#FFFFFF"`
+	OnTertiary   string `json:"onTertiary" example:"#FFFFFF"`
+	OnQuaternary string `json:"onQuaternary" example:"#FFFFFF"`
+	OnBackground string `json:"onBackground" example:"#111827"`
+}
+
+// DarkThemeResponse es la paleta para modo oscuro: fondo casi negro con el
+// tono de primary y colores aclarados lo justo para el contraste exigido.
+type DarkThemeResponse struct {
+	Palette PaletteResponse    `json:"palette"`
+	Text    TextColorsResponse `json:"text"`
+}
+
+// AssetResponse es una imagen de marca con la URL de cada variante.
+type AssetResponse struct {
+	ContentType string            `json:"contentType" example:"image/png"`
+	Size        int64             `json:"size"` // Bytes de todas las variantes
+	Variants    []VariantResponse `json:"variants"`
+	UploadedAt  time.Time         `json:"uploadedAt"`
+}
+
+// VariantResponse es una copia redimensionada de una imagen de marca.
+type VariantResponse struct {
+	Name   string `json:"name" example:"md"`
+	Width  int    `json:"width" example:"256"`
+	Height int    `json:"height" example:"256"`
+	URL    string `json:"url" example:"/api/v1/clinics/vet-central/branding/logo/md?v=9f86d081884c7d65"` // Relativa a la API; cambia con cada subida
+}
+
+func toThemeResponse(theme *services.Theme) ThemeResponse {
+	res := ThemeResponse{
+		Name:        theme.Name,
+		DisplayName: theme.DisplayName,
+		Palette:     toPaletteResponse(theme.Palette),
+		Text:        toTextColorsResponse(theme.Text),
+		Dark: DarkThemeResponse{
+			Palette: toPaletteResponse(theme.Dark),
+			Text:    toTextColorsResponse(theme.DarkText),
+		},
+	}
+	if len(theme.Assets) > 0 {
+		res.Assets = make(map[string]AssetResponse, len(theme.Assets))
+		for kind, asset := range theme.Assets {
+			res.Assets[kind] = toAssetResponse(theme.Name, kind, &asset)
+		}
+	}
+	return res
+}
+
+func toPaletteResponse(p models.ColorPalette) PaletteResponse {
+	return PaletteResponse{
+		Primary:    p.Primary,
+		Secondary:  p.Secondary,
+		Tertiary:   p.Tertiary,
+		Quaternary: p.Quaternary,
+		Background: p.Background,
+	}
+}
+
+func toTextColorsResponse(t services.ThemeTextColors) TextColorsResponse {
+	return TextColorsResponse{
+		OnPrimary:    t.OnPrimary,
+		OnSecondary:  t.OnSecondary,
+		OnTertiary:   t.OnTertiary,
+		OnQuaternary: t.OnQuaternary,
+		OnBackground: t.OnBackground,
+	}
+}
+
+func toAssetResponse(clinicName, kind string, asset *models.BrandAsset) AssetResponse {
+	res := AssetResponse{
+		ContentType: asset.ContentType,
+		Size:        asset.Size,
+		Variants:    make([]VariantResponse, len(asset.Variants)),
+		UploadedAt:  asset.UploadedAt,
+	}
+	for i, v := range asset.Variants {
+		res.Variants[i] = VariantResponse{
+			Name:   v.Name,
+			Width:  v.Width,
+			Height: v.Height,
+			URL:    assetURL(clinicName, kind, v.Name, asset.Hash),
+		}
+	}
+	return res
+}
+
+// assetURL lleva el hash de la imagen para que una subida nueva no choque con
+// la caché de la anterior
+func assetURL(clinicName, kind, variant, hash string) string {
+	return fmt.Sprintf("/api/v1/clinics/%s/branding/%s/%s?v=%s", url.PathEscape(clinicName), kind, variant, assetVersion(hash))
+}
+
+// assetVersion es la parte del hash que va en las URLs
+func assetVersion(hash string) string {
+	return hash[:min(16, len(hash))]
+}
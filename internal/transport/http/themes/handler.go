@@ -0,0 +1,190 @@
+package themes
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/apierror"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+)
+
+// MaxUploadSize limita el cuerpo multipart; el límite de cada tipo de imagen
+// (2 MiB, 1 MiB el favicon) lo aplica el servicio.
+const MaxUploadSize = 2<<20 + 64<<10
+
+// imageTooLarge es el detalle de las imágenes que superan su límite
+const imageTooLarge = "Images must be at most 2 MiB (favicons 1 MiB)"
+
+// Handler contiene las dependencias de las imágenes de marca y los temas.
+type Handler struct {
+	service services.BrandingService
+	logger  *slog.Logger
+}
+
+// NewHandler es el constructor del handler de temas.
+func NewHandler(svc services.BrandingService, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: svc,
+		logger:  logger.With("handler", "themes"),
+	}
+}
+
+// Upload sube una imagen de marca
+// @Summary      Upload a branding image
+// @Description  Uploads the clinic's logo, favicon or email header and resizes it into variants: logo sm/md/lg (fit in 128, 256 and 512 px), favicon 16/32/180/192/512 (square, center-cropped) and emailHeader 1x/2x (fit in 600x300 and 1200x600). The type is detected from the content: PNG, JPEG, GIF or WebP (SVG is not accepted), at most 4096 px per side and 2 MiB (favicons 1 MiB). Variants count towards the plan's storage; uploading the same image again changes nothing.
+// @Tags         Branding
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id    path      string  true  "Clinic ID"
+// @Param        kind  path      string  true  "Image kind"  Enums(logo, favicon, emailHeader)
+// @Param        file  formData  file    true  "Image file"
+// @Success      200   {object}  AssetResponse
+// @Failure      400   {object}  response.Problem "Invalid ID or kind, missing file, unreadable image or dimensions out of range"
+// @Failure      402   {object}  response.Problem "Plan storage limit reached"
+// @Failure      404   {object}  response.Problem "Clinic not found"
+// @Failure      413   {object}  response.Problem "Image too large"
+// @Failure      415   {object}  response.Problem "Not a PNG, JPEG, GIF or WebP image"
+// @Failure      500   {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{id}/branding/{kind} [put]
+func (h *Handler) Upload(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxUploadSize)
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.Error(w, r, response.CodePayloadTooLarge, imageTooLarge)
+			return
+		}
+		response.ValidationErrorRes(w, r, "Request body has invalid fields", []response.ValidationError{
+			{Field: "file", Message: i18n.Translate(i18n.FromContext(r.Context()), "This field is required")},
+		})
+		return
+	}
+	defer file.Close()
+
+	clinicID, kind := r.PathValue("id"), r.PathValue("kind")
+	clinic, err := h.service.Upload(r.Context(), clinicID, kind, file)
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error uploading branding image", "clinic_id", clinicID, "kind", kind)
+		return
+	}
+	response.JSON(w, http.StatusOK, toAssetResponse(clinic.Name, kind, clinic.Asset(kind)))
+}
+
+// Delete borra una imagen de marca
+// @Summary      Delete a branding image
+// @Description  Deletes all variants of the clinic's logo, favicon or email header and frees their storage.
+// @Tags         Branding
+// @Produce      json
+// @Param        id    path  string  true  "Clinic ID"
+// @Param        kind  path  string  true  "Image kind"  Enums(logo, favicon, emailHeader)
+// @Success      204   "Deleted"
+// @Failure      400   {object}  response.Problem "Invalid ID or kind"
+// @Failure      404   {object}  response.Problem "Clinic or image not found"
+// @Failure      500   {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{id}/branding/{kind} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	clinicID, kind := r.PathValue("id"), r.PathValue("kind")
+	if err := h.service.Delete(r.Context(), clinicID, kind); err != nil {
+		apierror.Write(w, r, h.logger, err, "Error deleting branding image", "clinic_id", clinicID, "kind", kind)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Theme devuelve el tema público de una clínica
+// @Summary      Get a clinic's theme
+// @Description  Public endpoint for the white-label frontend: the clinic's palette (missing colours take the defaults), the most readable text colour on each palette colour (#111827 or #FFFFFF, by WCAG 2.1 contrast) a dark-mode variant of the palette and the URLs of its branding images. Inactive clinics are not found.
+// @Tags         Branding
+// @Produce      json
+// @Param        name           path      string  true   "Clinic name"
+// @Param        If-None-Match  header    string  false  "ETag from a previous response; 304 if unchanged"
+// @Success      200  {object}  ThemeResponse
+// @Header       200  {string}  ETag  "Theme version"
+// @Success      304  "Not modified"
+// @Failure      404  {object}  response.Problem "Clinic not found"
+// @Failure      500  {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{name}/theme [get]
+func (h *Handler) Theme(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	theme, err := h.service.Theme(r.Context(), name)
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error getting clinic theme", "name", name)
+		return
+	}
+
+	etag := `"` + strconv.FormatInt(theme.Version, 10) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		response.NotModified(w)
+		return
+	}
+	response.JSON(w, http.StatusOK, toThemeResponse(theme))
+}
+
+// Asset sirve una variante de una imagen de marca
+// @Summary      Get a branding image
+// @Description  Public endpoint serving one variant of a clinic's branding image. With the v parameter of the theme URLs the response is cacheable forever. Supports conditional requests, and Range requests with local storage.
+// @Tags         Branding
+// @Produce      png
+// @Produce      jpeg
+// @Param        name     path   string  true   "Clinic name"
+// @Param        kind     path   string  true   "Image kind"  Enums(logo, favicon, emailHeader)
+// @Param        variant  path   string  true   "Variant name, e.g. md"
+// @Param        v        query  string  false  "Image version from the theme URLs"
+// @Success      200  {file}    file
+// @Failure      404  {object}  response.Problem "Clinic, image or variant not found"
+// @Failure      500  {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{name}/branding/{kind}/{variant} [get]
+func (h *Handler) Asset(w http.ResponseWriter, r *http.Request) {
+	name, kind, variant := r.PathValue("name"), r.PathValue("kind"), r.PathValue("variant")
+	asset, content, err := h.service.OpenAsset(r.Context(), name, kind, variant)
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error opening branding image", "name", name, "kind", kind, "variant", variant)
+		return
+	}
+	defer content.Close()
+
+	// La URL versionada nunca cambia de contenido; sin versión se revalida pronto
+	version := assetVersion(asset.Hash)
+	if r.URL.Query().Get("v") == version {
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		w.Header().Set("Cache-Control", "public, max-age=300")
+	}
+	w.Header().Set("Content-Type", asset.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	etag := `"` + version + "-" + variant + `"`
+	w.Header().Set("ETag", etag)
+	// El almacén local da un fichero (con Range); S3, solo un lector
+	if seeker, ok := content.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, "", asset.UploadedAt, seeker)
+		return
+	}
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		response.NotModified(w)
+		return
+	}
+	if v, ok := asset.Variant(variant); ok {
+		w.Header().Set("Content-Length", strconv.FormatInt(v.Size, 10))
+	}
+	io.Copy(w, content)
+}
+
+// etagMatches evalúa If-None-Match con la comparación débil
+func etagMatches(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
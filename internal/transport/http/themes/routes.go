@@ -0,0 +1,22 @@
+package themes
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+)
+
+// RegisterRoutes registra las imágenes de marca de cada clínica y su tema.
+// El tema y las imágenes son públicos y se piden por el nombre de la clínica.
+func RegisterRoutes(mux *http.ServeMux, stores *storage.Stores, logger *slog.Logger) {
+	handler := NewHandler(services.NewBrandingService(stores, logger), logger)
+
+	mux.HandleFunc("PUT /api/v1/clinics/{id}/branding/{kind}", handler.Upload)
+	mux.HandleFunc("DELETE /api/v1/clinics/{id}/branding/{kind}", handler.Delete)
+	mux.HandleFunc("GET /api/v1/clinics/{name}/theme", handler.Theme)
+	mux.HandleFunc("GET /api/v1/clinics/{name}/branding/{kind}/{variant}", handler.Asset)
+
+	logger.Info("Theme routes registered successfully")
+}
@@ -0,0 +1,46 @@
+package transfers
+
+import (
+	"time"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+)
+
+// TransferResponse es un traspaso (exportación o importación) con su progreso.
+type TransferResponse struct {
+	ID       string `json:"id"`
+	Kind     string `json:"kind" example:"export"` // export o import
+	ClinicID string `json:"clinicId"`              // En una importación, el ID de la clínica nueva
+	// SourceClinicID es el ID de la clínica en el entorno de origen (solo importaciones)
+	SourceClinicID string                `json:"sourceClinicId,omitempty"`
+	Status         string                `json:"status" example:"running"` // queued, running, completed o failed
+	Counts         models.TransferCounts `json:"counts"`
+	Size           int64                 `json:"size,omitempty"` // Bytes del archivo exportado
+	Error          string                `json:"error,omitempty"`
+	CreatedAt      time.Time             `json:"createdAt"`
+	UpdatedAt      time.Time             `json:"updatedAt"`
+	StartedAt      *time.Time            `json:"startedAt,omitempty"`
+	FinishedAt     *time.Time            `json:"finishedAt,omitempty"`
+	ExpiresAt      *time.Time            `json:"expiresAt,omitempty"` // Cuándo se borran el traspaso y su archivo
+}
+
+func toTransferResponse(job *models.TransferJob) TransferResponse {
+	res := TransferResponse{
+		ID:         job.ID.Hex(),
+		Kind:       job.Kind,
+		ClinicID:   job.ClinicID.Hex(),
+		Status:     job.Status,
+		Counts:     job.Counts,
+		Size:       job.Size,
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		UpdatedAt:  job.UpdatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+		ExpiresAt:  job.ExpiresAt,
+	}
+	if job.SourceClinicID != nil {
+		res.SourceClinicID = job.SourceClinicID.Hex()
+	}
+	return res
+}
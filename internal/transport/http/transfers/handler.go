@@ -0,0 +1,163 @@
+package transfers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/apierror"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
+)
+
+// MaxArchiveSize limita el tamaño del archivo subido para importar.
+const MaxArchiveSize = 512 << 20
+
+// Handler contiene las dependencias de los traspasos.
+type Handler struct {
+	service services.TransferService
+	logger  *slog.Logger
+}
+
+// NewHandler es el constructor del handler de traspasos.
+func NewHandler(svc services.TransferService, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: svc,
+		logger:  logger.With("handler", "transfers"),
+	}
+}
+
+// Export pone en cola la exportación de una clínica
+// @Summary      Export a whole clinic
+// @Description  Queues an export of the clinic with its users, owners and patients. The result is a versioned zip archive (one NDJSON file per collection and a manifest.json with the format version and a SHA-256 checksum per file). Poll the transfer and download the archive when it is completed. The archive includes password hashes: store it safely.
+// @Tags         Transfers
+// @Produce      json
+// @Param        id   path      string  true  "Clinic ID"
+// @Success      202  {object}  TransferResponse
+// @Failure      400  {object}  response.Problem "Invalid ID"
+// @Failure      404  {object}  response.Problem "Clinic not found"
+// @Failure      500  {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics/{id}/export [post]
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	job, err := h.service.Export(r.Context(), r.PathValue("id"))
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error exporting clinic", "clinic_id", r.PathValue("id"))
+		return
+	}
+	response.JSON(w, http.StatusAccepted, toTransferResponse(job))
+}
+
+// Import sube un archivo exportado y pone en cola la creación de su clínica
+// @Summary      Import a whole clinic
+// @Description  Verifies an archive produced by the export (manifest, checksums and references) and recreates the clinic in the background with new IDs; references between records are kept. Use name and displayName when the original clinic still exists here. Poll the transfer to follow its progress.
+// @Tags         Transfers
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file         formData  file    true   "Archive (.zip) of up to 512 MiB"
+// @Param        name         formData  string  false  "Name of the new clinic (default: the archived one)"
+// @Param        displayName  formData  string  false  "Display name of the new clinic (default: the archived one)"
+// @Success      202          {object}  TransferResponse
+// @Failure      400          {object}  response.Problem "Missing file or invalid archive"
+// @Failure      409          {object}  response.Problem "Clinic name or display name taken"
+// @Failure      413          {object}  response.Problem "Archive too large"
+// @Failure      422          {object}  response.Problem "Unsupported format version or checksum mismatch"
+// @Failure      500          {object}  response.Problem "Internal server error"
+// @Router       /api/v1/clinics:import [post]
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxArchiveSize)
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.Error(w, r, response.CodePayloadTooLarge, "Archive must be at most 512 MiB")
+			return
+		}
+		response.ValidationErrorRes(w, r, "Request body has invalid fields", []response.ValidationError{
+			{Field: "file", Message: i18n.Translate(i18n.FromContext(r.Context()), "This field is required")},
+		})
+		return
+	}
+	defer file.Close()
+
+	job, err := h.service.Import(r.Context(), services.ImportTenantParams{
+		Archive:     file,
+		Name:        r.FormValue("name"),
+		DisplayName: r.FormValue("displayName"),
+	})
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error importing clinic")
+		return
+	}
+	response.JSON(w, http.StatusAccepted, toTransferResponse(job))
+}
+
+// GetTransfer devuelve un traspaso con su progreso
+// @Summary      Get a transfer
+// @Description  Returns the export or import with its status and the number of records processed.
+// @Tags         Transfers
+// @Produce      json
+// @Param        id   path      string  true  "Transfer ID"
+// @Success      200  {object}  TransferResponse
+// @Failure      400  {object}  response.Problem "Invalid ID"
+// @Failure      404  {object}  response.Problem "Transfer not found"
+// @Failure      500  {object}  response.Problem "Internal server error"
+// @Router       /api/v1/transfers/{id} [get]
+func (h *Handler) GetTransfer(w http.ResponseWriter, r *http.Request) {
+	job, err := h.service.GetByID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error getting transfer", "transfer_id", r.PathValue("id"))
+		return
+	}
+	response.JSON(w, http.StatusOK, toTransferResponse(job))
+}
+
+// Retry vuelve a poner en cola un traspaso fallido
+// @Summary      Retry a failed transfer
+// @Description  Queues a failed export or import again. An import does not create twice what it already created. Transfers interrupted by a restart resume on their own.
+// @Tags         Transfers
+// @Produce      json
+// @Param        id   path      string  true  "Transfer ID"
+// @Success      202  {object}  TransferResponse
+// @Failure      404  {object}  response.Problem "Transfer not found"
+// @Failure      409  {object}  response.Problem "Transfer has not failed"
+// @Failure      500  {object}  response.Problem "Internal server error"
+// @Router       /api/v1/transfers/{id}/retry [post]
+func (h *Handler) Retry(w http.ResponseWriter, r *http.Request) {
+	job, err := h.service.Retry(r.Context(), r.PathValue("id"))
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error retrying transfer", "transfer_id", r.PathValue("id"))
+		return
+	}
+	response.JSON(w, http.StatusAccepted, toTransferResponse(job))
+}
+
+// Download descarga el archivo de una exportación terminada
+// @Summary      Download an exported archive
+// @Description  Returns the zip archive of a completed export until its expiresAt. Supports Range requests.
+// @Tags         Transfers
+// @Produce      application/zip
+// @Param        id   path      string  true  "Transfer ID"
+// @Success      200  {file}    file
+// @Failure      404  {object}  response.Problem "Transfer not found"
+// @Failure      409  {object}  response.Problem "Not a completed export"
+// @Failure      500  {object}  response.Problem "Internal server error"
+// @Router       /api/v1/transfers/{id}/archive [get]
+func (h *Handler) Download(w http.ResponseWriter, r *http.Request) {
+	job, file, err := h.service.OpenArchive(r.Context(), r.PathValue("id"))
+	if err != nil {
+		apierror.Write(w, r, h.logger, err, "Error opening transfer archive", "transfer_id", r.PathValue("id"))
+		return
+	}
+	defer file.Close()
+
+	name := fmt.Sprintf("clinic-%s-%s.zip", job.ClinicID.Hex(), job.ID.Hex())
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	modified := job.UpdatedAt
+	if job.FinishedAt != nil {
+		modified = *job.FinishedAt
+	}
+	http.ServeContent(w, r, name, modified, file)
+}
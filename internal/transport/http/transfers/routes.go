@@ -0,0 +1,29 @@
+package transfers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/zabaletac3/go-vet-api/internal/models"
+	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/features"
+)
+
+// RegisterRoutes registra la exportación e importación de clínicas completas.
+// Los trabajos los procesa services.TransferRunner. Exportar requiere el
+// módulo de transferencias, que todos los planes incluyen por defecto (una
+// clínica solo lo pierde si se le desactiva expresamente); importar crea una
+// clínica nueva y no depende de él.
+func RegisterRoutes(mux *http.ServeMux, stores *storage.Stores, logger *slog.Logger) {
+	handler := NewHandler(services.NewTransferService(stores, logger), logger)
+	guard := features.NewGuard(services.NewFeatureService(stores, logger), logger)
+
+	guard.Group(mux, models.ModuleTransfers, features.PathClinic).HandleFunc("POST /api/v1/clinics/{id}/export", handler.Export)
+	mux.HandleFunc("POST /api/v1/clinics:import", handler.Import)
+	mux.HandleFunc("GET /api/v1/transfers/{id}", handler.GetTransfer)
+	mux.HandleFunc("POST /api/v1/transfers/{id}/retry", handler.Retry)
+	mux.HandleFunc("GET /api/v1/transfers/{id}/archive", handler.Download)
+
+	logger.Info("Transfer routes registered successfully")
+}
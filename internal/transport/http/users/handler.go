@@ -2,10 +2,16 @@ package users
 
 import (
 	"encoding/json"
-	"errors"
+	"log/slog"
 	"net/http"
 
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+	"github.com/zabaletac3/go-vet-api/internal/models"
 	"github.com/zabaletac3/go-vet-api/internal/services"
+	"github.com/zabaletac3/go-vet-api/internal/storage"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/apierror"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/batch"
+	"github.com/zabaletac3/go-vet-api/internal/transport/http/response"
 )
 
 // registerUserRequest define la estructura del cuerpo de la petición para el registro.
@@ -18,6 +24,23 @@ type registerUserRequest struct {
 	Role     string `json:"role" validate:"required"`
 }
 
+// toParams mapea un elemento del lote a los parámetros del servicio.
+func (req registerUserRequest) toParams() services.CreateUserParams {
+	return services.CreateUserParams{
+		ClinicID: req.ClinicID,
+		FullName: req.FullName,
+		Email:    req.Email,
+		Password: req.Password,
+		Role:     req.Role,
+	}
+}
+
+// registerUsersBatchRequest es el cuerpo de users:batch (para Swagger; ver batch.Request).
+type registerUsersBatchRequest struct {
+	Mode  services.BatchMode    `json:"mode" enums:"atomic,best_effort" example:"atomic"` // Por defecto atomic
+	Items []registerUserRequest `json:"items"`
+}
+
 // Handler contiene las dependencias para los handlers de usuario, en este caso, el servicio.
 type Handler struct {
 	service services.UserService
@@ -30,15 +53,18 @@ func NewHandler(svc services.UserService) *Handler {
 
 // register es el método del handler para registrar un nuevo usuario.
 // @Summary      Registra un nuevo usuario
-// @Description  Crea un nuevo usuario (empleado) asociado a una clínica.
+// @Description  Crea un nuevo usuario (empleado) asociado a una clínica. Cada usuario ocupa un puesto del plan de la clínica.
 // @Tags         Users
 // @Accept       json
 // @Produce      json
 // @Param        user  body      registerUserRequest  true  "Datos para el registro del usuario"
+// @Param        Idempotency-Key  header  string  false  "Clave única por intento; los reintentos con la misma clave repiten la primera respuesta (24h)"
 // @Success      201   {object}  models.User
-// @Failure      400   {object}  map[string]string "Error: Petición inválida"
-// @Failure      409   {object}  map[string]string "Error: El email ya existe"
-// @Failure      500   {object}  map[string]string "Error: Error interno del servidor"
+// @Failure      400   {object}  response.Problem "Petición inválida"
+// @Failure      402   {object}  response.Problem "La clínica alcanzó el límite de usuarios de su plan (PLAN_LIMIT_EXCEEDED)"
+// @Failure      404   {object}  response.Problem "Clínica no encontrada"
+// @Failure      409   {object}  response.Problem "El email ya existe, o Idempotency-Key reutilizada o en curso"
+// @Failure      500   {object}  response.Problem "Error interno del servidor"
 // @Router       /api/v1/users/register [post]
 func (h *Handler) register(w http.ResponseWriter, r *http.Request) {
 	// DTO: Este struct representa el cuerpo JSON que esperamos en la petición.
@@ -52,11 +78,14 @@ func (h *Handler) register(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
-		http.Error(w, "cuerpo de la petición inválido", http.StatusBadRequest)
+		response.Error(w, r, response.CodeMalformedJSON, "Request body is not valid JSON")
 		return
 	}
 	// Aquí iría la validación del requestBody con la librería 'validator'.
 
+	// Los errores salen en el idioma de la clínica si el cliente no pide uno
+	i18n.UseClinic(r.Context(), requestBody.ClinicID)
+
 	// Mapeamos el DTO de la petición a los parámetros que espera el servicio.
 	// Esto desacopla la capa de servicio de la estructura de la API.
 	params := services.CreateUserParams{
@@ -70,17 +99,9 @@ func (h *Handler) register(w http.ResponseWriter, r *http.Request) {
 	// Llamamos a la lógica de negocio en el servicio.
 	user, err := h.service.Register(r.Context(), params)
 	if err != nil {
-		// Verificamos si es un error de negocio específico para dar una mejor respuesta HTTP.
-		if errors.Is(err, services.ErrUserAlreadyExists) {
-			http.Error(w, err.Error(), http.StatusConflict) // 409 Conflict
-			return
-		}
-		if errors.Is(err, services.ErrPasswordTooShort) {
-			http.Error(w, err.Error(), http.StatusBadRequest) // 400 Bad Request
-			return
-		}
-		// Si es otro tipo de error, devolvemos un error de servidor genérico.
-		http.Error(w, "error interno del servidor", http.StatusInternalServerError)
+		// Los errores de negocio (email repetido, contraseña corta) se resuelven en
+		// el registro central; cualquier otro es un error interno.
+		apierror.Write(w, r, nil, err, "Error registrando usuario", "clinicId", params.ClinicID)
 		return
 	}
 
@@ -88,4 +109,25 @@ func (h *Handler) register(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(user)
+}
+
+// registerBatch registra varios usuarios en un solo lote.
+// @Summary      Registra usuarios en lote
+// @Description  Registra hasta 200 usuarios, validando cada uno con las reglas de registro. En modo atomic (por defecto) el lote va en una transacción y no se crea ninguno si alguno falla; en best_effort cada usuario se crea por separado. La respuesta trae un resultado por usuario, en el orden de la petición.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        batch            body    registerUsersBatchRequest  true   "Usuarios a registrar"
+// @Param        Idempotency-Key  header  string                     false  "Clave única por intento; los reintentos con la misma clave repiten la primera respuesta (24h)"
+// @Success      201  {object}  batch.Response  "Todos los usuarios creados"
+// @Success      207  {object}  batch.Response  "Algunos fallaron (ver items[].status)"
+// @Failure      400  {object}  response.Problem "Cuerpo malformado, modo o número de elementos inválido"
+// @Failure      409  {object}  response.Problem "Idempotency-Key reutilizada o en curso"
+// @Failure      501  {object}  response.Problem "El modo atomic requiere un replica set de MongoDB"
+// @Failure      500  {object}  response.Problem "Error interno del servidor"
+// @Router       /api/v1/users:batch [post]
+func (h *Handler) registerBatch(tx storage.Transactor, logger *slog.Logger) http.HandlerFunc {
+	return batch.Handler(tx, logger, registerUserRequest.toParams, h.service.Register, func(user *models.User) any {
+		return user
+	})
 }
\ No newline at end of file
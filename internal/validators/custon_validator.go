@@ -89,16 +89,17 @@ func validateStrongPassword(fl validator.FieldLevel) bool {
 	return true
 }
 
+// Species son las especies permitidas por valid_species
+var Species = []string{
+	"dog", "cat", "bird", "fish", "rabbit", "hamster", "guinea_pig", 
+	"ferret", "reptile", "horse", "cow", "pig", "goat", "sheep",
+}
+
 // validateSpecies valida que la especie sea una de las permitidas
 func validateSpecies(fl validator.FieldLevel) bool {
-	validSpecies := []string{
-		"dog", "cat", "bird", "fish", "rabbit", "hamster", "guinea_pig", 
-		"ferret", "reptile", "horse", "cow", "pig", "goat", "sheep",
-	}
-	
 	species := strings.ToLower(fl.Field().String())
 	
-	for _, valid := range validSpecies {
+	for _, valid := range Species {
 		if species == valid {
 			return true
 		}
@@ -0,0 +1,117 @@
+package validators
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/zabaletac3/go-vet-api/internal/i18n"
+)
+
+// FieldError es el error de validación de un campo con el mensaje ya traducido.
+type FieldError struct {
+	Field   string
+	Message string
+	Value   string
+}
+
+// Check valida v con las reglas registradas y devuelve sus errores por campo en
+// el idioma indicado (nil si es válido).
+func Check(v any, locale i18n.Locale) []FieldError {
+	err := GetValidator().Struct(v)
+	if err == nil {
+		return nil
+	}
+	if errs := Describe(err, locale); len(errs) > 0 {
+		return errs
+	}
+	return []FieldError{{Message: i18n.Translate(locale, "Invalid value")}}
+}
+
+// Describe traduce los errores de validator a errores por campo legibles.
+// Devuelve nil si err no es un validator.ValidationErrors.
+func Describe(err error, locale i18n.Locale) []FieldError {
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return nil
+	}
+
+	errs := make([]FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		// Solo los valores de texto se devuelven al cliente
+		value, _ := fieldError.Value().(string)
+		errs = append(errs, FieldError{
+			Field:   strings.ToLower(fieldError.Field()),
+			Message: i18n.Translate(locale, message(fieldError), messageArgs(fieldError)...),
+			Value:   value,
+		})
+	}
+	return errs
+}
+
+// message devuelve el mensaje (clave del catálogo de i18n) de la regla que
+// falló, incluidas las personalizadas de este paquete
+func message(fieldError validator.FieldError) string {
+	isString := fieldError.Kind().String() == "string"
+	switch fieldError.Tag() {
+	case "required":
+		return "This field is required"
+	case "email":
+		return "Must be a valid email address"
+	case "url":
+		return "Must be a valid URL"
+	case "min":
+		if isString {
+			return "Must be at least %s characters"
+		}
+		return "Minimum value is %s"
+	case "max":
+		if isString {
+			return "Must be at most %s characters"
+		}
+		return "Maximum value is %s"
+	case "len":
+		return "Must be exactly %s characters"
+	case "oneof":
+		return "Must be one of: %s"
+	case "gt":
+		return "Must be greater than %s"
+	case "gte":
+		return "Must be greater than or equal to %s"
+	case "lt":
+		return "Must be less than %s"
+	case "lte":
+		return "Must be less than or equal to %s"
+	case "strong_password":
+		return "Password must be at least 8 characters long and include uppercase and lowercase letters, numbers and special characters"
+	case "valid_species":
+		return "Invalid species. Allowed species: %s"
+	case "mongodb_id":
+		return "Must be a valid ID"
+	case "numeric":
+		return "Must be a number"
+	case "datetime":
+		return "Must be a valid ISO 8601 date"
+	case "hex_color":
+		return "Must be a hex color like #1A2B3C"
+	case "clinic_name":
+		return "Must be 2 to 100 characters and start with a letter, digit, hyphen, underscore or dot"
+	case "display_name":
+		return "Must be 2 to 150 characters and contain only letters, digits, spaces and - _ . ( ) & ' , :"
+	default:
+		return "Invalid value"
+	}
+}
+
+// messageArgs son los argumentos del mensaje de message
+func messageArgs(fieldError validator.FieldError) []any {
+	switch fieldError.Tag() {
+	case "min", "max", "len", "gt", "gte", "lt", "lte":
+		return []any{fieldError.Param()}
+	case "oneof":
+		return []any{strings.ReplaceAll(fieldError.Param(), " ", ", ")}
+	case "valid_species":
+		return []any{strings.Join(Species, ", ")}
+	default:
+		return nil
+	}
+}